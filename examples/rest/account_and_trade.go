@@ -16,6 +16,7 @@ import (
 
 	"github.com/weex-api/openapi-contract-go-sdk/weex"
 	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
 )
 
 func main() {
@@ -237,12 +238,20 @@ func main() {
 	fmt.Println("=== 9. Adjust Leverage (COMMENTED OUT) ===")
 
 	// Example 9: Adjust Leverage (UNCOMMENT TO TEST)
-	err = client.Account().AdjustLeverage(ctx, &account.AdjustLeverageRequest{
+	leverageReq := &account.AdjustLeverageRequest{
 		Symbol:        "cmt_btcusdt",
 		MarginMode:    1, // 1 = Cross Mode, 3 = Isolated Mode
 		LongLeverage:  "10",
 		ShortLeverage: "10", // Must equal LongLeverage in Cross mode
-	})
+	}
+	// Check the leverage against the contract's limits before sending
+	var contract *market.ContractInfo
+	if contracts, err := client.Market().GetContracts(ctx, &market.GetContractsRequest{Symbol: leverageReq.Symbol}); err == nil && len(contracts) > 0 {
+		contract = &contracts[0]
+	}
+	if err = leverageReq.Normalize(contract); err == nil {
+		err = client.Account().AdjustLeverage(ctx, leverageReq)
+	}
 	if err != nil {
 		log.Printf("❌ Failed to adjust leverage: %v\n", err)
 	} else {
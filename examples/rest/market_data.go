@@ -144,7 +144,7 @@ func main() {
 				side = "BUY"
 			}
 			fmt.Printf("  [%s] Price: %s, Qty: %s, Time: %v\n",
-				side, trade.Price, trade.Size, time.UnixMilli(trade.Time).Format("15:04:05"))
+				side, trade.Price, trade.Size, trade.Time.Time().Format("15:04:05"))
 		}
 	}
 
@@ -23,25 +23,15 @@ import (
 )
 
 func main() {
-	// ⚠️ IMPORTANT: Replace with your actual API credentials
-	// Or use environment variables for security
-	apiKey := os.Getenv("WEEX_API_KEY")
-	secretKey := os.Getenv("WEEX_SECRET_KEY")
-	passphrase := os.Getenv("WEEX_PASSPHRASE")
-
-	if apiKey == "" || secretKey == "" || passphrase == "" {
-		log.Fatal("❌ Please set WEEX_API_KEY, WEEX_SECRET_KEY, and WEEX_PASSPHRASE environment variables")
+	// Load API credentials from WEEX_API_KEY, WEEX_SECRET_KEY and WEEX_PASSPHRASE
+	config, err := weex.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
 	}
-
-	// Create configuration with API credentials
-	config := weex.NewDefaultConfig().
-		WithAPIKey(apiKey).
-		WithSecretKey(secretKey).
-		WithPassphrase(passphrase).
-		WithLogLevel(weex.LogLevelInfo)
+	config.WithLogLevel(weex.LogLevelInfo)
 
 	// Create authenticator
-	auth := weex.NewAuthenticator(apiKey, secretKey, passphrase)
+	auth := weex.NewAuthenticator(config.APIKey, config.SecretKey, config.Passphrase)
 
 	// Create private WebSocket client
 	client := private.NewClient(config, auth)
@@ -72,7 +62,7 @@ func main() {
 
 	// ===== Example 1: Subscribe to Account Balance Updates =====
 	fmt.Println("\n=== Example 1: Subscribe to Account Balance Updates ===")
-	err := client.SubscribeAccount(func(account *websocket.AccountData) error {
+	err = client.SubscribeAccount(func(account *websocket.AccountData) error {
 		fmt.Printf("\n💰 [ACCOUNT UPDATE]\n")
 		for _, asset := range account.Data {
 			fmt.Printf("  %s: Available=%s, Frozen=%s, Equity=%s, UnrealizedPnL=%s\n",
@@ -105,11 +95,10 @@ func main() {
 	err = client.SubscribeOrders(func(order *websocket.OrderData) error {
 		fmt.Printf("\n📋 [ORDER UPDATE]\n")
 		for _, o := range order.Data {
-			stateStr := getOrderStateString(o.State)
 			fmt.Printf("  Order: %s (ClientOID: %s)\n", o.OrderId, o.ClientOid)
 			fmt.Printf("    Symbol: %s, Type: %d, Side: %s\n", o.Symbol, o.Type, o.Side)
 			fmt.Printf("    Price: %s, Size: %s, Filled: %s, State: %s\n",
-				o.Price, o.Size, o.FilledSize, stateStr)
+				o.Price, o.Size, o.FilledSize, o.StatusEnum())
 			if !o.RealizedPnl.IsZero() {
 				fmt.Printf("    RealizedPnL: %s\n", o.RealizedPnl)
 			}
@@ -163,23 +152,3 @@ func main() {
 
 	fmt.Println("👋 Goodbye!")
 }
-
-// getOrderStateString returns a human-readable order state string
-func getOrderStateString(state int) string {
-	switch state {
-	case 0:
-		return "PENDING"
-	case 1:
-		return "OPEN"
-	case 2:
-		return "PARTIALLY_FILLED"
-	case 3:
-		return "FILLED"
-	case 4:
-		return "CANCELED"
-	case 5:
-		return "FAILED"
-	default:
-		return fmt.Sprintf("UNKNOWN(%d)", state)
-	}
-}
@@ -2,4 +2,4 @@ module github.com/weex-api/openapi-contract-go-sdk
 
 go 1.25.4
 
-require github.com/gorilla/websocket v1.5.3 // indirect
+require github.com/gorilla/websocket v1.5.3
@@ -6,13 +6,20 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
 )
 
 // Authenticator handles API authentication and signature generation
+// Credentials can be replaced at runtime with SetCredentials; it is safe for concurrent use.
 type Authenticator struct {
+	creds atomic.Pointer[credentials]
+}
+
+// credentials is an immutable set of API credentials
+type credentials struct {
 	apiKey     string
 	secretKey  string
 	passphrase string
@@ -20,11 +27,21 @@ type Authenticator struct {
 
 // NewAuthenticator creates a new Authenticator instance
 func NewAuthenticator(apiKey, secretKey, passphrase string) *Authenticator {
-	return &Authenticator{
+	a := &Authenticator{}
+	a.SetCredentials(apiKey, secretKey, passphrase)
+	return a
+}
+
+// SetCredentials atomically replaces the credentials used for subsequent signatures
+// Requests already signed keep the old credentials. WebSocket clients sharing
+// this Authenticator log in with the new credentials on their next connect or
+// reconnect.
+func (a *Authenticator) SetCredentials(apiKey, secretKey, passphrase string) {
+	a.creds.Store(&credentials{
 		apiKey:     apiKey,
 		secretKey:  secretKey,
 		passphrase: passphrase,
-	}
+	})
 }
 
 // SignRequest generates the HMAC SHA256 signature for a REST API request
@@ -43,7 +60,7 @@ func NewAuthenticator(apiKey, secretKey, passphrase string) *Authenticator {
 // Returns the base64-encoded signature string
 func (a *Authenticator) SignRequest(timestamp int64, method, path, body string) string {
 	message := fmt.Sprintf("%d%s%s%s", timestamp, method, path, body)
-	return a.sign(message)
+	return a.creds.Load().sign(message)
 }
 
 // SignWebSocket generates the HMAC SHA256 signature for WebSocket authentication
@@ -62,7 +79,7 @@ func (a *Authenticator) SignRequest(timestamp int64, method, path, body string)
 // Returns the base64-encoded signature string
 func (a *Authenticator) SignWebSocket(timestamp int64, method, path, body string) string {
 	message := fmt.Sprintf("%d%s%s%s", timestamp, method, path, body)
-	return a.sign(message)
+	return a.creds.Load().sign(message)
 }
 
 // SignWebSocketAuth generates the HMAC SHA256 signature for WebSocket authentication
@@ -79,12 +96,24 @@ func (a *Authenticator) SignWebSocket(timestamp int64, method, path, body string
 // Returns the base64-encoded signature string
 func (a *Authenticator) SignWebSocketAuth(timestamp int64, path string) string {
 	message := fmt.Sprintf("%d%s", timestamp, path)
-	return a.sign(message)
+	return a.creds.Load().sign(message)
+}
+
+// WebSocketLoginArgs returns the args of a WebSocket login request: API key, passphrase, timestamp and signature
+// All four come from the same credentials, even if SetCredentials is called concurrently.
+//
+// Parameters:
+//   - timestamp: Unix timestamp in seconds
+//   - path: Signed path (e.g., "/users/self/verify")
+func (a *Authenticator) WebSocketLoginArgs(timestamp int64, path string) []string {
+	creds := a.creds.Load()
+	sign := creds.sign(fmt.Sprintf("%d%s%s%s", timestamp, "GET", path, ""))
+	return []string{creds.apiKey, creds.passphrase, fmt.Sprintf("%d", timestamp), sign}
 }
 
 // sign generates the HMAC SHA256 signature
-func (a *Authenticator) sign(message string) string {
-	h := hmac.New(sha256.New, []byte(a.secretKey))
+func (c *credentials) sign(message string) string {
+	h := hmac.New(sha256.New, []byte(c.secretKey))
 	h.Write([]byte(message))
 	return base64.StdEncoding.EncodeToString(h.Sum(nil))
 }
@@ -103,12 +132,13 @@ func (a *Authenticator) GetRESTHeaders(timestamp int64, method, path, body strin
 		timestamp = time.Now().UnixMilli()
 	}
 
-	signature := a.SignRequest(timestamp, method, path, body)
+	creds := a.creds.Load()
+	signature := creds.sign(fmt.Sprintf("%d%s%s%s", timestamp, method, path, body))
 
 	return map[string]string{
-		types.HeaderAccessKey:        a.apiKey,
+		types.HeaderAccessKey:        creds.apiKey,
 		types.HeaderAccessSign:       signature,
-		types.HeaderAccessPassphrase: a.passphrase,
+		types.HeaderAccessPassphrase: creds.passphrase,
 		types.HeaderAccessTimestamp:  fmt.Sprintf("%d", timestamp),
 		types.HeaderContentType:      types.ContentTypeJSON,
 		types.HeaderUserAgent:        types.DefaultUserAgent,
@@ -131,12 +161,13 @@ func (a *Authenticator) GetWebSocketHeaders(timestamp int64, path string) map[st
 		path = "/v2/ws/private"
 	}
 
-	signature := a.SignWebSocketAuth(timestamp, path)
+	creds := a.creds.Load()
+	signature := creds.sign(fmt.Sprintf("%d%s", timestamp, path))
 
 	return map[string]string{
-		types.HeaderAccessKey:        a.apiKey,
+		types.HeaderAccessKey:        creds.apiKey,
 		types.HeaderAccessSign:       signature,
-		types.HeaderAccessPassphrase: a.passphrase,
+		types.HeaderAccessPassphrase: creds.passphrase,
 		types.HeaderAccessTimestamp:  fmt.Sprintf("%d", timestamp),
 		types.HeaderUserAgent:        types.DefaultUserAgent,
 	}
@@ -144,12 +175,12 @@ func (a *Authenticator) GetWebSocketHeaders(timestamp int64, path string) map[st
 
 // GetAPIKey returns the API key
 func (a *Authenticator) GetAPIKey() string {
-	return a.apiKey
+	return a.creds.Load().apiKey
 }
 
 // GetPassphrase returns the passphrase
 func (a *Authenticator) GetPassphrase() string {
-	return a.passphrase
+	return a.creds.Load().passphrase
 }
 
 // ValidateTimestamp checks if a timestamp is within acceptable range
@@ -0,0 +1,66 @@
+package weex
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestAuthenticatorSetCredentials(t *testing.T) {
+	tests := []struct {
+		name                    string
+		key, secret, passphrase string
+	}{
+		{"same credentials", "key", "secret", "passphrase"},
+		{"rotated credentials", "key2", "secret2", "passphrase2"},
+		{"empty credentials", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := NewAuthenticator("key", "secret", "passphrase")
+			auth.SetCredentials(tt.key, tt.secret, tt.passphrase)
+			want := NewAuthenticator(tt.key, tt.secret, tt.passphrase)
+
+			if got := auth.GetAPIKey(); got != tt.key {
+				t.Errorf("GetAPIKey() = %q, want %q", got, tt.key)
+			}
+			if got := auth.GetPassphrase(); got != tt.passphrase {
+				t.Errorf("GetPassphrase() = %q, want %q", got, tt.passphrase)
+			}
+			if got, w := auth.SignRequest(1, "GET", "/p", ""), want.SignRequest(1, "GET", "/p", ""); got != w {
+				t.Errorf("SignRequest() = %q, want %q", got, w)
+			}
+			args := auth.WebSocketLoginArgs(1700000000, "/users/self/verify")
+			wantArgs := []string{tt.key, tt.passphrase, "1700000000", want.SignWebSocket(1700000000, "GET", "/users/self/verify", "")}
+			if fmt.Sprint(args) != fmt.Sprint(wantArgs) {
+				t.Errorf("WebSocketLoginArgs() = %v, want %v", args, wantArgs)
+			}
+		})
+	}
+}
+
+func TestAuthenticatorSetCredentialsConcurrent(t *testing.T) {
+	auth := NewAuthenticator("key0", "secret0", "passphrase0")
+	signs := make(map[string]string)
+	for i := 0; i < 4; i++ {
+		a := NewAuthenticator(fmt.Sprintf("key%d", i), fmt.Sprintf("secret%d", i), "")
+		signs[a.GetAPIKey()] = a.SignWebSocket(1, "GET", "/users/self/verify", "")
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			n := i % 4
+			auth.SetCredentials(fmt.Sprintf("key%d", n), fmt.Sprintf("secret%d", n), "")
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		args := auth.WebSocketLoginArgs(1, "/users/self/verify")
+		if args[3] != signs[args[0]] {
+			t.Fatalf("WebSocketLoginArgs() key %q signed with other credentials", args[0])
+		}
+	}
+	wg.Wait()
+}
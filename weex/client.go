@@ -1,14 +1,17 @@
 package weex
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/weex-api/openapi-contract-go-sdk/weex/rest"
 	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
 	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
 	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
 )
 
 // Client is the main SDK client for WEEX Contract API
@@ -17,11 +20,20 @@ type Client struct {
 	auth   *Authenticator
 	rest   *rest.Client
 	logger Logger
+	limit  *RateLimiter
 
-	// Service accessors (lazy initialization)
+	// Service accessors (lazy initialization, safe for concurrent use)
+	marketOnce     sync.Once
 	marketService  *market.Service
+	accountOnce    sync.Once
 	accountService *account.Service
+	tradeOnce      sync.Once
 	tradeService   *trade.Service
+
+	// Order rate limiter (fetched once from account config)
+	orderLimiterMu      sync.Mutex
+	orderRateLimiter    *OrderRateLimiter
+	orderLimiterRetryAt time.Time // After a failed fetch, orders go unpaced until then
 }
 
 // NewClient creates a new WEEX Contract API client
@@ -62,14 +74,18 @@ func NewClient(config *Config) (*Client, error) {
 		config.BackoffFactor,
 		config.Logger,
 	)
+	retrier.SetPolicy(config.RetryPolicy)
+	retrier.SetMaxElapsed(config.MaxRetryElapsed)
 
 	// Create rate limiter
-	rateLimiter := NewRateLimiter(
+	rateLimiter := NewRateLimiterWithWindow(
 		config.EnableRateLimit,
 		config.IPWeight,
 		config.UIDWeight,
+		config.RateLimitWindow,
 		config.Logger,
 	)
+	rateLimiter.SetObserveOnly(config.RateLimitObserveOnly)
 
 	// Create REST client
 	restClient := rest.NewClient(
@@ -81,12 +97,23 @@ func NewClient(config *Config) (*Client, error) {
 		rateLimiter,
 		config.Logger,
 	)
+	restClient.SetDecodeMode(config.ResponseDecodeMode)
+	restClient.SetExtraHeaders(config.ExtraHeaders)
+	restClient.SetSymbolLocking(config.SymbolLocking)
+	restClient.SetLogSecrets(config.LogSecrets)
+	for _, hook := range config.RequestHooks {
+		restClient.AddRequestHook(hook)
+	}
+	for _, hook := range config.ResponseHooks {
+		restClient.AddResponseHook(hook)
+	}
 
 	return &Client{
 		config: config,
 		auth:   auth,
 		rest:   restClient,
 		logger: config.Logger,
+		limit:  rateLimiter,
 	}, nil
 }
 
@@ -125,14 +152,18 @@ func NewPublicClient(config *Config) (*Client, error) {
 		config.BackoffFactor,
 		config.Logger,
 	)
+	retrier.SetPolicy(config.RetryPolicy)
+	retrier.SetMaxElapsed(config.MaxRetryElapsed)
 
 	// Create rate limiter
-	rateLimiter := NewRateLimiter(
+	rateLimiter := NewRateLimiterWithWindow(
 		config.EnableRateLimit,
 		config.IPWeight,
 		config.UIDWeight,
+		config.RateLimitWindow,
 		config.Logger,
 	)
+	rateLimiter.SetObserveOnly(config.RateLimitObserveOnly)
 
 	// Create REST client
 	restClient := rest.NewClient(
@@ -144,42 +175,208 @@ func NewPublicClient(config *Config) (*Client, error) {
 		rateLimiter,
 		config.Logger,
 	)
+	restClient.SetDecodeMode(config.ResponseDecodeMode)
+	restClient.SetExtraHeaders(config.ExtraHeaders)
+	restClient.SetSymbolLocking(config.SymbolLocking)
+	restClient.SetLogSecrets(config.LogSecrets)
+	for _, hook := range config.RequestHooks {
+		restClient.AddRequestHook(hook)
+	}
+	for _, hook := range config.ResponseHooks {
+		restClient.AddResponseHook(hook)
+	}
 
 	return &Client{
 		config: config,
 		auth:   auth,
 		rest:   restClient,
 		logger: config.Logger,
+		limit:  rateLimiter,
 	}, nil
 }
 
+// NewClientChecked creates a new client like NewClient and verifies the credentials
+//
+// After structural validation a lightweight authenticated request is sent so
+// rejected keys, signatures or passphrases are reported at setup time instead
+// of on the first authenticated call. Rejections wrap ErrInvalidCredentials.
+//
+// Example:
+//
+//	client, err := weex.NewClientChecked(ctx, config)
+//	if errors.Is(err, weex.ErrInvalidCredentials) {
+//	    log.Fatalf("check API key settings: %v", err)
+//	}
+func NewClientChecked(ctx context.Context, config *Config) (*Client, error) {
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.CheckCredentials(ctx); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// CheckCredentials verifies the configured credentials with a lightweight authenticated request
+// The probe fetches the USDT asset with Account().GetSingleAsset. Returns an
+// error wrapping ErrInvalidCredentials if the API rejects the credentials.
+// GET /account/getAccount?coin=USDT
+// Weight(IP): 1, Weight(UID): 1
+func (c *Client) CheckCredentials(ctx context.Context) error {
+	_, err := c.Account().GetSingleAsset(ctx, "USDT")
+	if err == nil {
+		return nil
+	}
+
+	if code := apiErrorCode(err); types.IsAuthError(code) {
+		return fmt.Errorf("%w: %s (code %s): %v", ErrInvalidCredentials, authErrorDescription(code), code, err)
+	}
+	return fmt.Errorf("credential check failed: %w", err)
+}
+
 // Market returns the market data service
 // Provides access to public market data endpoints
+// Its contract cache, shared with the Trade service, uses ContractCacheTTL.
 func (c *Client) Market() *market.Service {
-	if c.marketService == nil {
+	c.marketOnce.Do(func() {
 		c.marketService = market.NewService(c.rest)
-	}
+		c.marketService.SetContractCacheTTL(c.config.ContractCacheTTL)
+	})
 	return c.marketService
 }
 
 // Account returns the account management service
 // Provides access to account and position endpoints (requires authentication)
 func (c *Client) Account() *account.Service {
-	if c.accountService == nil {
+	c.accountOnce.Do(func() {
 		c.accountService = account.NewService(c.rest)
-	}
+	})
 	return c.accountService
 }
 
 // Trade returns the trading service
 // Provides access to order and trading endpoints (requires authentication)
+// With EnableRateLimit, order placement is paced by GetOrderRateLimiter. Whether
+// orders are paced is fixed by the config when Trade is first called;
+// SetRateLimitEnabled only affects the weight-based rate limiter.
 func (c *Client) Trade() *trade.Service {
-	if c.tradeService == nil {
+	c.tradeOnce.Do(func() {
 		c.tradeService = trade.NewService(c.rest)
-	}
+		c.tradeService.SetMarketService(c.Market())
+		if c.config.EnableRateLimit {
+			c.tradeService.SetOrderLimiter(accountOrderLimiter{client: c})
+		}
+	})
 	return c.tradeService
 }
 
+// orderLimiterRetryDelay is how long orders go unpaced after the account order rate limit could not be fetched
+const orderLimiterRetryDelay = time.Minute
+
+// GetOrderRateLimiter returns the order rate limiter for the account
+//
+// On first call the account configuration is fetched and the limiter is seeded
+// from CreateOrderRateLimitPerMinute and CreateOrderDelayMilliseconds. The
+// limiter is cached. With EnableRateLimit the Trade service paces every order
+// placement through it, fetching it on the first order if needed.
+func (c *Client) GetOrderRateLimiter(ctx context.Context) (*OrderRateLimiter, error) {
+	c.orderLimiterMu.Lock()
+	limiter := c.orderRateLimiter
+	c.orderLimiterMu.Unlock()
+	if limiter != nil {
+		return limiter, nil
+	}
+
+	// Fetched without holding the lock so a slow account endpoint does not
+	// queue every caller; concurrent first calls may fetch more than once
+	accounts, err := c.Account().GetAccountList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account order rate limit: %w", err)
+	}
+
+	c.orderLimiterMu.Lock()
+	defer c.orderLimiterMu.Unlock()
+	if c.orderRateLimiter != nil {
+		return c.orderRateLimiter, nil
+	}
+	c.orderRateLimiter = NewOrderRateLimiter(
+		accounts.Account.CreateOrderRateLimitPerMinute,
+		time.Duration(accounts.Account.CreateOrderDelayMilliseconds)*time.Millisecond,
+	)
+	c.logger.Debug("Order rate limit: %d/min, delay %dms",
+		accounts.Account.CreateOrderRateLimitPerMinute, accounts.Account.CreateOrderDelayMilliseconds)
+	return c.orderRateLimiter, nil
+}
+
+// accountOrderLimiter paces orders with the client's account order rate limiter
+type accountOrderLimiter struct {
+	client *Client
+}
+
+// WaitForOrders waits on the limiter from GetOrderRateLimiter, fetching it on first use
+// If the account configuration cannot be fetched (e.g. the API key lacks read
+// permission or the endpoint is down) orders are placed unpaced and the fetch
+// is not retried for orderLimiterRetryDelay.
+func (l accountOrderLimiter) WaitForOrders(ctx context.Context, n int) error {
+	c := l.client
+	c.orderLimiterMu.Lock()
+	skip := c.orderRateLimiter == nil && time.Now().Before(c.orderLimiterRetryAt)
+	c.orderLimiterMu.Unlock()
+	if skip {
+		return nil
+	}
+
+	limiter, err := c.GetOrderRateLimiter(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		c.logger.Warn("Placing orders without order rate limit pacing: %v", err)
+		c.orderLimiterMu.Lock()
+		c.orderLimiterRetryAt = time.Now().Add(orderLimiterRetryDelay)
+		c.orderLimiterMu.Unlock()
+		return nil
+	}
+	return limiter.WaitForOrders(ctx, n)
+}
+
+// AddRequestHook registers a hook called before every REST request attempt
+func (c *Client) AddRequestHook(hook rest.RequestHook) {
+	c.rest.AddRequestHook(hook)
+}
+
+// AddResponseHook registers a hook called after every REST response body is read
+func (c *Client) AddResponseHook(hook rest.ResponseHook) {
+	c.rest.AddResponseHook(hook)
+}
+
+// GetRateLimiter returns the weight-based rate limiter
+func (c *Client) GetRateLimiter() *RateLimiter {
+	return c.limit
+}
+
+// SetRateLimitEnabled pauses or resumes local weight-based rate limiting
+func (c *Client) SetRateLimitEnabled(enabled bool) {
+	c.limit.SetEnabled(enabled)
+}
+
+// UpdateCredentials swaps the API credentials used to sign subsequent requests
+// In-flight requests finish with the old credentials. WebSocket clients created
+// with GetAuthenticator pick up the new credentials when they next (re)connect.
+// The credentials in GetConfig are not changed.
+func (c *Client) UpdateCredentials(apiKey, secretKey, passphrase string) {
+	c.auth.SetCredentials(apiKey, secretKey, passphrase)
+	c.logger.Info("API credentials updated")
+}
+
+// GetAuthenticator returns the authenticator used to sign requests
+// Pass it to private WebSocket clients so UpdateCredentials also applies to them.
+func (c *Client) GetAuthenticator() *Authenticator {
+	return c.auth
+}
+
 // GetConfig returns a copy of the client configuration
 func (c *Client) GetConfig() *Config {
 	return c.config.Clone()
@@ -0,0 +1,449 @@
+package weex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestCheckCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantInvalid bool
+		wantErr     bool
+	}{
+		{"accepted", http.StatusOK, `{"code":"0","msg":"success","requestTime":1,"data":{}}`, false, false},
+		{"invalid key", http.StatusBadRequest, `{"code":"40006","msg":"Invalid ACCESS_KEY","requestTime":1}`, true, true},
+		{"server error", http.StatusInternalServerError, `{"code":"50001","msg":"internal error","requestTime":1}`, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requested = r.Method + " " + r.URL.RequestURI()
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			config := NewDefaultConfig().WithBaseURL(server.URL).
+				WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase")
+			config.MaxRetries = 0
+			config.Logger = NewNoOpLogger()
+
+			_, err := NewClientChecked(context.Background(), config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewClientChecked() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrInvalidCredentials); got != tt.wantInvalid {
+				t.Errorf("errors.Is(ErrInvalidCredentials) = %v, want %v (error: %v)", got, tt.wantInvalid, err)
+			}
+			if want := "GET /capi/v2/account/getAccount?coin=USDT"; requested != want {
+				t.Errorf("probe = %q, want %q", requested, want)
+			}
+		})
+	}
+}
+
+func TestResponseDecodeModeWired(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    rest.DecodeMode
+		wantErr bool
+	}{
+		{"default lenient", rest.DecodeModeLenient, false},
+		{"strict", rest.DecodeModeStrict, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":{"epoch":"1700000000.000","iso":"2023-11-14T22:13:20.000Z","timestamp":1700000000000,"zone":"UTC"}}`))
+			}))
+			defer server.Close()
+
+			config := NewDefaultConfig().WithBaseURL(server.URL).WithResponseDecodeMode(tt.mode)
+			config.MaxRetries = 0
+			config.Logger = NewNoOpLogger()
+			client, err := NewPublicClient(config)
+			if err != nil {
+				t.Fatalf("NewPublicClient() error = %v", err)
+			}
+
+			_, err = client.Market().GetServerTime(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetServerTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNetworkErrorRetried(t *testing.T) {
+	tests := []struct {
+		name        string
+		resets      int32         // Connections reset before answering
+		delay       time.Duration // Time taken to answer
+		ctxTimeout  time.Duration
+		wantCalls   int32
+		wantNetwork bool
+		wantCtxErr  bool
+	}{
+		{"reset once then succeed", 1, 0, 0, 2, false, false},
+		{"reset every attempt", 100, 0, 0, 2, true, false},
+		{"caller deadline not retried", 0, 200 * time.Millisecond, 50 * time.Millisecond, 1, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= tt.resets {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					if err == nil {
+						conn.Close()
+					}
+					return
+				}
+				select {
+				case <-time.After(tt.delay):
+				case <-r.Context().Done():
+					return
+				}
+				w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":{"timestamp":1700000000000}}`))
+			}))
+			defer server.Close()
+
+			config := NewDefaultConfig().WithBaseURL(server.URL)
+			config.MaxRetries = 1
+			config.InitialBackoff = time.Millisecond
+			config.Logger = NewNoOpLogger()
+			client, err := NewPublicClient(config)
+			if err != nil {
+				t.Fatalf("NewPublicClient() error = %v", err)
+			}
+
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+			_, err = client.Market().GetServerTime(ctx)
+
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("requests = %d, want %d", got, tt.wantCalls)
+			}
+			var netErr *NetworkError
+			if got := errors.As(err, &netErr); got != tt.wantNetwork {
+				t.Errorf("errors.As(*NetworkError) = %v, want %v (error: %v)", got, tt.wantNetwork, err)
+			}
+			if got := errors.Is(err, context.DeadlineExceeded); got != tt.wantCtxErr {
+				t.Errorf("errors.Is(DeadlineExceeded) = %v, want %v (error: %v)", got, tt.wantCtxErr, err)
+			}
+			if !tt.wantNetwork && !tt.wantCtxErr && err != nil {
+				t.Errorf("GetServerTime() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestAPIErrorOnHTTP200Retried(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		wantCalls int32
+	}{
+		{"retriable system error", "50001", 2},
+		{"non-retriable auth error", "40007", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.Write([]byte(`{"code":"` + tt.code + `","msg":"error","requestTime":1}`))
+			}))
+			defer server.Close()
+
+			config := NewDefaultConfig().WithBaseURL(server.URL)
+			config.MaxRetries = 1
+			config.InitialBackoff = time.Millisecond
+			config.Logger = NewNoOpLogger()
+			client, err := NewPublicClient(config)
+			if err != nil {
+				t.Fatalf("NewPublicClient() error = %v", err)
+			}
+
+			_, err = client.Market().GetServerTime(context.Background())
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != tt.code {
+				t.Fatalf("GetServerTime() error = %v, want APIError %s", err, tt.code)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("requests = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestExtraHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]string
+		ctx    map[string]string
+		want   map[string]string
+	}{
+		{"config headers", map[string]string{"X-Correlation-Id": "abc", "X-Tenant": "t1"}, nil,
+			map[string]string{"X-Correlation-Id": "abc", "X-Tenant": "t1"}},
+		{"context overrides config", map[string]string{"X-Correlation-Id": "abc"}, map[string]string{"x-correlation-id": "def", "X-Trace": "1"},
+			map[string]string{"X-Correlation-Id": "def", "X-Trace": "1"}},
+		{"reserved headers ignored", map[string]string{"ACCESS-KEY": "other", "Content-Type": "text/plain", "locale": "fr-FR"}, map[string]string{"access-sign": "forged", "ACCESS-FOO": "x"},
+			map[string]string{"ACCESS-KEY": "key", "ACCESS-PASSPHRASE": "passphrase", "Content-Type": "application/json", "Locale": types.DefaultLocale, "ACCESS-FOO": ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Clone()
+				w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":{}}`))
+			}))
+			defer server.Close()
+
+			config := NewDefaultConfig().WithBaseURL(server.URL).
+				WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase")
+			config.MaxRetries = 0
+			config.Logger = NewNoOpLogger()
+			for k, v := range tt.config {
+				config.WithExtraHeader(k, v)
+			}
+			client, err := NewClient(config)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = rest.WithHeaders(ctx, tt.ctx)
+			}
+			if _, err := client.Account().GetAccountList(ctx); err != nil {
+				t.Fatalf("GetAccountList() error = %v", err)
+			}
+
+			for k, want := range tt.want {
+				if v := got.Get(k); v != want {
+					t.Errorf("header %s = %q, want %q", k, v, want)
+				}
+			}
+			if got.Get("ACCESS-SIGN") == "" || got.Get("ACCESS-SIGN") == "forged" || got.Get("ACCESS-TIMESTAMP") == "" {
+				t.Errorf("auth headers = %v, want a signature and timestamp set by the SDK", got)
+			}
+		})
+	}
+}
+
+func TestHooksRunPerAttempt(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int32 // Rate limited responses before success
+		retries  int
+		attempts int32
+		wantErr  bool
+	}{
+		{"no retry", 0, 3, 1, false},
+		{"retried twice", 2, 3, 3, false},
+		{"retries exhausted", 5, 1, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= tt.failures {
+					w.WriteHeader(http.StatusTooManyRequests)
+					w.Write([]byte(`{"code":"429","msg":"too many requests","requestTime":1}`))
+					return
+				}
+				w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":{"timestamp":1700000000000}}`))
+			}))
+			defer server.Close()
+
+			var configRequests, clientRequests, responses atomic.Int32
+			var statuses []int
+			var mu sync.Mutex
+			config := NewDefaultConfig().WithBaseURL(server.URL).
+				WithRequestHook(func(req *http.Request) {
+					if req.Header.Get(types.HeaderLocale) != "" {
+						configRequests.Add(1)
+					}
+				}).
+				WithResponseHook(func(resp *http.Response, body []byte) {
+					mu.Lock()
+					defer mu.Unlock()
+					responses.Add(1)
+					statuses = append(statuses, resp.StatusCode)
+				})
+			config.MaxRetries = tt.retries
+			config.InitialBackoff = time.Millisecond
+			config.Logger = NewNoOpLogger()
+			client, err := NewPublicClient(config)
+			if err != nil {
+				t.Fatalf("NewPublicClient() error = %v", err)
+			}
+			client.AddRequestHook(func(*http.Request) { clientRequests.Add(1) })
+
+			_, err = client.Market().GetServerTime(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetServerTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			attempts := calls.Load()
+			if attempts != tt.attempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.attempts)
+			}
+			if configRequests.Load() != attempts || clientRequests.Load() != attempts || responses.Load() != attempts {
+				t.Errorf("hook calls (config request, client request, response) = %d, %d, %d, want %d each",
+					configRequests.Load(), clientRequests.Load(), responses.Load(), attempts)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if last := statuses[len(statuses)-1]; (last == http.StatusOK) == tt.wantErr {
+				t.Errorf("last response status = %d", last)
+			}
+		})
+	}
+}
+
+func TestSymbolLocking(t *testing.T) {
+	tests := []struct {
+		name        string
+		locking     bool
+		symbols     [2]string
+		wantOverlap bool
+	}{
+		{"same symbol serialized", true, [2]string{"cmt_btcusdt", "cmt_btcusdt"}, false},
+		{"different symbols parallel", true, [2]string{"cmt_btcusdt", "cmt_ethusdt"}, true},
+		{"locking disabled", false, [2]string{"cmt_btcusdt", "cmt_btcusdt"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Each request is held long enough for a parallel one to overlap it
+			var inFlight, maxInFlight atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := inFlight.Add(1)
+				for m := maxInFlight.Load(); n > m && !maxInFlight.CompareAndSwap(m, n); m = maxInFlight.Load() {
+				}
+				time.Sleep(50 * time.Millisecond)
+				inFlight.Add(-1)
+				w.Write([]byte(`{"code":"0","msg":"success","requestTime":1}`))
+			}))
+			defer server.Close()
+
+			config := NewDefaultConfig().WithBaseURL(server.URL).
+				WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase").
+				WithSymbolLocking(tt.locking)
+			config.MaxRetries = 0
+			config.EnableRateLimit = false
+			config.Logger = NewNoOpLogger()
+			client, err := NewClient(config)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			var wg sync.WaitGroup
+			errs := make(chan error, 2)
+			for _, symbol := range tt.symbols {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs <- client.Account().ModifyAccountMode(context.Background(), &account.ModifyAccountModeRequest{
+						Symbol: symbol, MarginMode: int(types.MarginModeIsolated),
+					})
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				if err != nil {
+					t.Fatalf("ModifyAccountMode() error = %v", err)
+				}
+			}
+
+			if got := maxInFlight.Load() > 1; got != tt.wantOverlap {
+				t.Errorf("requests overlapped = %v, want %v", got, tt.wantOverlap)
+			}
+		})
+	}
+}
+
+func TestUpdateCredentials(t *testing.T) {
+	type creds struct{ key, secret, passphrase string }
+	tests := []struct {
+		name    string
+		updates []creds
+	}{
+		{"no update", nil},
+		{"single rotation", []creds{{"key2", "secret2", "passphrase2"}}},
+		{"repeated rotation", []creds{{"key2", "secret2", "passphrase2"}, {"key3", "secret3", "passphrase3"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			var uri string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, uri = r.Header.Clone(), r.URL.RequestURI()
+				w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":{}}`))
+			}))
+			defer server.Close()
+
+			config := NewDefaultConfig().WithBaseURL(server.URL).
+				WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase")
+			config.MaxRetries = 0
+			config.Logger = NewNoOpLogger()
+			client, err := NewClient(config)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			want := creds{"key", "secret", "passphrase"}
+			for i := 0; i <= len(tt.updates); i++ {
+				if i > 0 {
+					want = tt.updates[i-1]
+					client.UpdateCredentials(want.key, want.secret, want.passphrase)
+				}
+				if _, err := client.Account().GetAccountList(context.Background()); err != nil {
+					t.Fatalf("request %d: GetAccountList() error = %v", i, err)
+				}
+
+				if v := got.Get(types.HeaderAccessKey); v != want.key {
+					t.Errorf("request %d: ACCESS-KEY = %q, want %q", i, v, want.key)
+				}
+				if v := got.Get(types.HeaderAccessPassphrase); v != want.passphrase {
+					t.Errorf("request %d: ACCESS-PASSPHRASE = %q, want %q", i, v, want.passphrase)
+				}
+				ts, err := strconv.ParseInt(got.Get(types.HeaderAccessTimestamp), 10, 64)
+				if err != nil {
+					t.Fatalf("request %d: ACCESS-TIMESTAMP = %q", i, got.Get(types.HeaderAccessTimestamp))
+				}
+				sign := NewAuthenticator(want.key, want.secret, want.passphrase).SignRequest(ts, http.MethodGet, uri, "")
+				if v := got.Get(types.HeaderAccessSign); v != sign {
+					t.Errorf("request %d: ACCESS-SIGN = %q, want signature with secret %q", i, v, want.secret)
+				}
+			}
+			if key := client.GetAuthenticator().GetAPIKey(); key != want.key {
+				t.Errorf("GetAuthenticator().GetAPIKey() = %q, want %q", key, want.key)
+			}
+			if key := client.GetConfig().APIKey; key != "key" {
+				t.Errorf("GetConfig().APIKey = %q, want the original %q", key, "key")
+			}
+		})
+	}
+}
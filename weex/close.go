@@ -0,0 +1,90 @@
+package weex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// ErrNoPositionToClose is returned by CloseReduceOnly when there is no open position on the closed side
+var ErrNoPositionToClose = errors.New("no open position to close")
+
+// CloseReduceOnly places a close order whose size never exceeds the open position
+//
+// The contract API has no reduce-only flag, so the current positions are
+// fetched first and req.Size is clamped to the size held on the side being
+// closed (an empty Size closes the whole position). req.Type must be a close
+// type (3 or 4). req itself is not modified.
+func (c *Client) CloseReduceOnly(ctx context.Context, req *trade.PlaceOrderRequest) (*trade.PlaceOrderResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("order request cannot be nil")
+	}
+
+	positions, err := c.Account().GetAllPositions(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch positions: %w", err)
+	}
+
+	clamped, err := ClampCloseSize(req, positions)
+	if err != nil {
+		return nil, err
+	}
+	if clamped.Size != req.Size {
+		c.logger.Debug("Close size for %s clamped from %q to %s", req.Symbol, req.Size, clamped.Size)
+	}
+
+	return c.Trade().PlaceOrder(ctx, clamped)
+}
+
+// ClampCloseSize returns a copy of req with Size limited to the position held on the side it closes
+// An empty Size is set to the full position size. Returns ErrNoPositionToClose if
+// positions hold nothing for req.Symbol on that side.
+func ClampCloseSize(req *trade.PlaceOrderRequest, positions []account.Position) (*trade.PlaceOrderRequest, error) {
+	code, err := strconv.Atoi(req.Type)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order type %q: %w", req.Type, err)
+	}
+	orderType := types.OrderType(code)
+	if orderType.IsOpen() {
+		return nil, fmt.Errorf("order type %s does not close a position", orderType)
+	}
+	side, err := orderType.PositionSide()
+	if err != nil {
+		return nil, err
+	}
+
+	held := types.Decimal("0")
+	for _, p := range positions {
+		if p.Symbol != req.Symbol {
+			continue
+		}
+		if ps, err := types.ParsePositionSide(p.Side); err != nil || ps != side {
+			continue
+		}
+		if held, err = held.AddErr(types.Decimal(p.Size)); err != nil {
+			return nil, fmt.Errorf("invalid position size %q: %w", p.Size, err)
+		}
+	}
+	if cmp, err := held.CmpErr("0"); err != nil || cmp <= 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoPositionToClose, req.Symbol, side)
+	}
+
+	clamped := *req
+	if req.Size == "" {
+		clamped.Size = string(held)
+		return &clamped, nil
+	}
+	cmp, err := types.Decimal(req.Size).CmpErr(held)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order size %q: %w", req.Size, err)
+	}
+	if cmp > 0 {
+		clamped.Size = string(held)
+	}
+	return &clamped, nil
+}
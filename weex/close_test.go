@@ -0,0 +1,118 @@
+package weex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+)
+
+// testPositions holds a long and a short cmt_btcusdt position and an ETH long
+var testPositions = []account.Position{
+	{Symbol: "cmt_btcusdt", Side: "LONG", Size: "0.5"},
+	{Symbol: "cmt_btcusdt", Side: "SHORT", Size: "0.2"},
+	{Symbol: "cmt_ethusdt", Side: "LONG", Size: "3"},
+}
+
+func TestClampCloseSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       trade.PlaceOrderRequest
+		positions []account.Position
+		wantSize  string
+		wantErr   error // nil only checks that an error is returned when wantSize is ""
+	}{
+		{"within position", trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "3", Size: "0.3"}, testPositions, "0.3", nil},
+		{"equal to position", trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "3", Size: "0.50"}, testPositions, "0.50", nil},
+		{"exceeds long", trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "3", Size: "1"}, testPositions, "0.5", nil},
+		{"exceeds short", trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "4", Size: "0.21"}, testPositions, "0.2", nil},
+		{"empty closes all", trade.PlaceOrderRequest{Symbol: "cmt_ethusdt", Type: "3"}, testPositions, "3", nil},
+		{"split positions summed", trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "3", Size: "1"},
+			append(testPositions, account.Position{Symbol: "cmt_btcusdt", Side: "long", Size: "0.25"}), "0.75", nil},
+		{"no position on side", trade.PlaceOrderRequest{Symbol: "cmt_ethusdt", Type: "4", Size: "1"}, testPositions, "", ErrNoPositionToClose},
+		{"no positions", trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "3", Size: "1"}, nil, "", ErrNoPositionToClose},
+		{"open order rejected", trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "1", Size: "1"}, testPositions, "", nil},
+		{"invalid type", trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "close", Size: "1"}, testPositions, "", nil},
+		{"invalid size", trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "3", Size: "abc"}, testPositions, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			got, err := ClampCloseSize(&req, tt.positions)
+			if tt.wantSize == "" {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Fatalf("ClampCloseSize() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ClampCloseSize() error = %v", err)
+			}
+			if got.Size != tt.wantSize {
+				t.Errorf("Size = %q, want %q", got.Size, tt.wantSize)
+			}
+			if req != tt.req {
+				t.Errorf("request modified: %+v", req)
+			}
+		})
+	}
+}
+
+func TestCloseReduceOnly(t *testing.T) {
+	var sentSize string
+	var placed int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := `{}`
+		switch strings.TrimPrefix(r.URL.Path, "/capi/v2") {
+		case "/account/position/allPosition":
+			positions, _ := json.Marshal(testPositions)
+			data = string(positions)
+		case "/order/placeOrder":
+			var body trade.PlaceOrderRequest
+			raw, _ := io.ReadAll(r.Body)
+			json.Unmarshal(raw, &body)
+			sentSize = body.Size
+			placed++
+			data = `{"order_id":"1","client_oid":"c1"}`
+		}
+		w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":` + data + `}`))
+	}))
+	defer server.Close()
+
+	config := NewDefaultConfig().WithBaseURL(server.URL).
+		WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase")
+	config.MaxRetries = 0
+	config.Logger = NewNoOpLogger()
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	req := &trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "3", Size: "2", OrderType: "0", MatchPrice: "1"}
+	resp, err := client.CloseReduceOnly(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CloseReduceOnly() error = %v", err)
+	}
+	if sentSize != "0.5" {
+		t.Errorf("sent size = %q, want the position size 0.5", sentSize)
+	}
+	if resp.OrderId != "1" || req.Size != "2" {
+		t.Errorf("OrderId = %q, request size = %q; want 1 and the request left unchanged", resp.OrderId, req.Size)
+	}
+
+	// Nothing is placed without a position to close
+	_, err = client.CloseReduceOnly(context.Background(), &trade.PlaceOrderRequest{Symbol: "cmt_ethusdt", Type: "4", Size: "1"})
+	if !errors.Is(err, ErrNoPositionToClose) {
+		t.Errorf("CloseReduceOnly() error = %v, want ErrNoPositionToClose", err)
+	}
+	if placed != 1 {
+		t.Errorf("orders placed = %d, want 1", placed)
+	}
+}
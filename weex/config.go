@@ -1,9 +1,16 @@
 package weex
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
 	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
 )
 
@@ -24,14 +31,18 @@ type Config struct {
 	MaxRetries  int           // Maximum number of retries for failed requests (default: 3)
 
 	// Rate limiting
-	EnableRateLimit bool // Enable rate limiting (default: true)
-	IPWeight        int  // Max IP weight per 5 minutes (default: 300)
-	UIDWeight       int  // Max UID weight per 5 minutes (default: 100)
+	EnableRateLimit      bool          // Enable rate limiting (default: true)
+	RateLimitObserveOnly bool          // Track weight without blocking when EnableRateLimit is false (default: false)
+	IPWeight             int           // Max IP weight per 5 minutes (default: 300)
+	UIDWeight            int           // Max UID weight per 5 minutes (default: 100)
+	RateLimitWindow      time.Duration // Weight budget refill window (default: 5 minutes)
 
 	// Retry settings
-	InitialBackoff time.Duration // Initial backoff duration for retries (default: 1 second)
-	MaxBackoff     time.Duration // Maximum backoff duration for retries (default: 30 seconds)
-	BackoffFactor  float64       // Backoff multiplier (default: 2.0)
+	InitialBackoff  time.Duration // Initial backoff duration for retries (default: 1 second)
+	MaxBackoff      time.Duration // Maximum backoff duration for retries (default: 30 seconds)
+	BackoffFactor   float64       // Backoff multiplier (default: 2.0)
+	RetryPolicy     *RetryPolicy  // Per-error retry count overrides (default: nil, MaxRetries for all)
+	MaxRetryElapsed time.Duration // Maximum total time across all retries including backoffs (default: 0, unlimited)
 
 	// WebSocket settings
 	WSReadBufferSize  int           // WebSocket read buffer size (default: 4096)
@@ -41,6 +52,18 @@ type Config struct {
 	WSReconnect       bool          // Enable automatic reconnection (default: true)
 	WSMaxReconnect    int           // Maximum reconnection attempts (default: 10)
 	WSReconnectDelay  time.Duration // Initial reconnection delay (default: 1 second)
+	WSDialTimeout     time.Duration // WebSocket dial timeout for connects and reconnects (default: 30 seconds)
+	WSWriteQueueSize  int           // WebSocket outbound write queue capacity (default: 256)
+	WSMaxArgsPerFrame int           // Maximum channels per subscribe/unsubscribe frame (default: 20)
+
+	// WebSocket subscribe ack timeouts
+	WSAckTimeout          time.Duration            // Subscribe ack timeout for public channels (default: 5 seconds)
+	WSPrivateAckTimeout   time.Duration            // Subscribe ack timeout for private channels (default: 15 seconds)
+	WSAckTimeoutByChannel map[string]time.Duration // Per channel type overrides, keyed by channel prefix (e.g. "ticker", "orders")
+	WSResubscribeTimeout  time.Duration            // Ack timeout per channel when resubscribing after reconnect (default: 0, use the subscribe ack timeout)
+
+	// Contract metadata
+	ContractCacheTTL time.Duration // How long fetched contract metadata is cached; order placement checks sizes only against cached contracts (default: 5 minutes, 0 disables)
 
 	// Logging
 	Logger   Logger   // Custom logger (default: DefaultLogger with Info level)
@@ -48,6 +71,19 @@ type Config struct {
 
 	// Locale
 	Locale string // API locale (default: "en")
+
+	// Extra headers added to every REST request, e.g. correlation or tenant ids
+	// They are not part of the request signature and cannot replace auth headers
+	ExtraHeaders map[string]string
+
+	// Concurrency
+	SymbolLocking bool // Serialize order placement and leverage/margin mode changes per symbol (default: false)
+
+	// Debugging
+	LogSecrets         bool                // Log API key, signature and passphrase verbatim in debug logs (default: false, masked)
+	ResponseDecodeMode rest.DecodeMode     // Handling of unmodeled response fields (default: rest.DecodeModeLenient)
+	RequestHooks       []rest.RequestHook  // Called before every REST request attempt
+	ResponseHooks      []rest.ResponseHook // Called after every REST response body is read
 }
 
 // NewDefaultConfig creates a new Config with default values
@@ -63,6 +99,7 @@ func NewDefaultConfig() *Config {
 		EnableRateLimit: true,
 		IPWeight:        300,
 		UIDWeight:       100,
+		RateLimitWindow: DefaultRateLimitWindow,
 
 		InitialBackoff: 1 * time.Second,
 		MaxBackoff:     30 * time.Second,
@@ -75,6 +112,14 @@ func NewDefaultConfig() *Config {
 		WSReconnect:       true,
 		WSMaxReconnect:    10,
 		WSReconnectDelay:  1 * time.Second,
+		WSDialTimeout:     30 * time.Second,
+		WSWriteQueueSize:  256,
+		WSMaxArgsPerFrame: 20,
+
+		WSAckTimeout:        5 * time.Second,
+		WSPrivateAckTimeout: 15 * time.Second,
+
+		ContractCacheTTL: market.DefaultContractCacheTTL,
 
 		Logger:   NewDefaultLogger(LogLevelInfo),
 		LogLevel: LogLevelInfo,
@@ -100,11 +145,29 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("%w: HTTPTimeout must be greater than 0", ErrInvalidConfig)
 	}
 
+	// Rate limit validation
+	if c.RateLimitWindow < 0 {
+		return fmt.Errorf("%w: RateLimitWindow cannot be negative", ErrInvalidConfig)
+	}
+
 	// Retry validation
 	if c.MaxRetries < 0 {
 		return fmt.Errorf("%w: MaxRetries cannot be negative", ErrInvalidConfig)
 	}
 
+	if c.MaxRetryElapsed < 0 {
+		return fmt.Errorf("%w: MaxRetryElapsed cannot be negative", ErrInvalidConfig)
+	}
+
+	if c.ContractCacheTTL < 0 {
+		return fmt.Errorf("%w: ContractCacheTTL cannot be negative", ErrInvalidConfig)
+	}
+
+	// Retry policy validation
+	if err := c.RetryPolicy.validate(); err != nil {
+		return err
+	}
+
 	// Backoff validation
 	if c.InitialBackoff <= 0 {
 		return fmt.Errorf("%w: InitialBackoff must be greater than 0", ErrInvalidConfig)
@@ -146,11 +209,131 @@ func (c *Config) ValidatePublic() error {
 }
 
 // Clone creates a copy of the configuration
+// ExtraHeaders, RequestHooks, ResponseHooks, WSAckTimeoutByChannel and
+// RetryPolicy are copied, so changing them on the clone does not change c.
 func (c *Config) Clone() *Config {
 	clone := *c
+	clone.RetryPolicy = c.RetryPolicy.Clone()
+	clone.WSAckTimeoutByChannel = maps.Clone(c.WSAckTimeoutByChannel)
+	clone.ExtraHeaders = maps.Clone(c.ExtraHeaders)
+	clone.RequestHooks = slices.Clone(c.RequestHooks)
+	clone.ResponseHooks = slices.Clone(c.ResponseHooks)
 	return &clone
 }
 
+// RedactedJSON serializes the configuration with credentials masked
+//
+// Intended for bug reports and debugging. Fields whose names indicate a
+// credential (key, secret, passphrase, password, token) are masked so only a
+// short prefix of the API key and the length of other secrets are shown.
+// ExtraHeaders values are masked too, since they may carry tokens.
+// Durations and enums are rendered as strings and the Logger as its type name.
+func (c *Config) RedactedJSON() ([]byte, error) {
+	return json.MarshalIndent(redactValue(reflect.ValueOf(*c)), "", "  ")
+}
+
+// redactValue converts a config value into a JSON-friendly form with credentials masked
+func redactValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Struct:
+		out := make(map[string]interface{}, v.NumField())
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fv := v.Field(i)
+			if fv.Kind() == reflect.String && isCredentialField(field.Name) {
+				out[field.Name] = maskCredential(field.Name, fv.String())
+				continue
+			}
+			if headers, ok := fv.Interface().(map[string]string); ok && field.Name == "ExtraHeaders" {
+				out[field.Name] = maskHeaders(headers)
+				continue
+			}
+			out[field.Name] = redactValue(fv)
+		}
+		return out
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type() == reflect.TypeOf((*Logger)(nil)).Elem() {
+			return fmt.Sprintf("%T", v.Interface())
+		}
+		return redactValue(v.Elem())
+	case reflect.Map:
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = redactValue(iter.Value())
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			out[i] = redactValue(v.Index(i))
+		}
+		return out
+	case reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return nil
+		}
+		return v.Type().String()
+	}
+
+	switch val := v.Interface().(type) {
+	case time.Duration:
+		return val.String()
+	case fmt.Stringer:
+		return val.String()
+	}
+	return v.Interface()
+}
+
+// isCredentialField returns true if a field name indicates a credential
+func isCredentialField(name string) bool {
+	lower := strings.ToLower(name)
+	for _, marker := range []string{"key", "secret", "passphrase", "password", "token"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskCredential masks a credential, keeping a short prefix of the API key only
+func maskCredential(name, value string) string {
+	if value == "" {
+		return ""
+	}
+	if name == "APIKey" && len(value) > 8 {
+		return fmt.Sprintf("%s****(len %d)", value[:4], len(value))
+	}
+	return fmt.Sprintf("****(len %d)", len(value))
+}
+
+// maskHeaders returns headers with every value masked
+func maskHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(headers))
+	for name, value := range headers {
+		masked[name] = maskCredential(name, value)
+	}
+	return masked
+}
+
 // WithAPIKey sets the API key and returns the config for chaining
 func (c *Config) WithAPIKey(apiKey string) *Config {
 	c.APIKey = apiKey
@@ -175,18 +358,96 @@ func (c *Config) WithBaseURL(baseURL string) *Config {
 	return c
 }
 
+// Environment is a set of REST and WebSocket endpoints to connect to
+type Environment struct {
+	BaseURL      string // REST API base URL
+	WSPublicURL  string // Public WebSocket URL
+	WSPrivateURL string // Private WebSocket URL
+}
+
+// EnvironmentProduction is the production environment (the default)
+var EnvironmentProduction = Environment{
+	BaseURL:      types.DefaultBaseURL,
+	WSPublicURL:  types.DefaultWSPublicURL,
+	WSPrivateURL: types.DefaultWSPrivateURL,
+}
+
+// WithEnvironment sets all endpoint URLs from env and returns the config for chaining
+// WEEX publishes no contract testnet hosts, so to target another deployment
+// (e.g. a local mock server) define an Environment with its URLs. Setters
+// chained afterwards, such as WithBaseURL, still override individual URLs.
+func (c *Config) WithEnvironment(env Environment) *Config {
+	c.BaseURL = env.BaseURL
+	c.WSPublicURL = env.WSPublicURL
+	c.WSPrivateURL = env.WSPrivateURL
+	return c
+}
+
 // WithHTTPTimeout sets the HTTP timeout and returns the config for chaining
 func (c *Config) WithHTTPTimeout(timeout time.Duration) *Config {
 	c.HTTPTimeout = timeout
 	return c
 }
 
+// WithRateLimitWindow sets the weight budget refill window and returns the config for chaining
+func (c *Config) WithRateLimitWindow(window time.Duration) *Config {
+	c.RateLimitWindow = window
+	return c
+}
+
 // WithMaxRetries sets the maximum retries and returns the config for chaining
 func (c *Config) WithMaxRetries(maxRetries int) *Config {
 	c.MaxRetries = maxRetries
 	return c
 }
 
+// WithRetryPolicy sets the per-error retry count overrides and returns the config for chaining
+func (c *Config) WithRetryPolicy(policy *RetryPolicy) *Config {
+	c.RetryPolicy = policy
+	return c
+}
+
+// WithMaxRetryElapsed sets the maximum total retry duration and returns the config for chaining
+func (c *Config) WithMaxRetryElapsed(maxElapsed time.Duration) *Config {
+	c.MaxRetryElapsed = maxElapsed
+	return c
+}
+
+// WithWSDialTimeout sets the WebSocket dial timeout and returns the config for chaining
+func (c *Config) WithWSDialTimeout(timeout time.Duration) *Config {
+	c.WSDialTimeout = timeout
+	return c
+}
+
+// WithWSWriteQueueSize sets the WebSocket write queue capacity and returns the config for chaining
+func (c *Config) WithWSWriteQueueSize(size int) *Config {
+	c.WSWriteQueueSize = size
+	return c
+}
+
+// WithWSMaxArgsPerFrame sets the maximum channels per subscribe/unsubscribe frame and returns the config for chaining
+func (c *Config) WithWSMaxArgsPerFrame(n int) *Config {
+	c.WSMaxArgsPerFrame = n
+	return c
+}
+
+// WithWSAckTimeout sets the subscribe ack timeout for a channel type and returns the config for chaining
+// channelType is the channel prefix, e.g. "ticker" or "orders"
+func (c *Config) WithWSAckTimeout(channelType string, timeout time.Duration) *Config {
+	if c.WSAckTimeoutByChannel == nil {
+		c.WSAckTimeoutByChannel = make(map[string]time.Duration)
+	}
+	c.WSAckTimeoutByChannel[channelType] = timeout
+	return c
+}
+
+// WithWSResubscribeTimeout sets the per-channel ack timeout used when resubscribing after reconnect
+// and returns the config for chaining
+func (c *Config) WithWSResubscribeTimeout(timeout time.Duration) *Config {
+	c.WSResubscribeTimeout = timeout
+	return c
+}
+
 // WithLogger sets the logger and returns the config for chaining
 func (c *Config) WithLogger(logger Logger) *Config {
 	c.Logger = logger
@@ -202,6 +463,52 @@ func (c *Config) WithLogLevel(level LogLevel) *Config {
 	return c
 }
 
+// WithSymbolLocking enables or disables per-symbol serialization of mutating requests and returns the config for chaining
+func (c *Config) WithSymbolLocking(enabled bool) *Config {
+	c.SymbolLocking = enabled
+	return c
+}
+
+// WithContractCacheTTL sets how long contract metadata is cached and returns the config for chaining
+func (c *Config) WithContractCacheTTL(ttl time.Duration) *Config {
+	c.ContractCacheTTL = ttl
+	return c
+}
+
+// WithLogSecrets enables or disables logging credentials verbatim in debug logs and returns the config for chaining
+func (c *Config) WithLogSecrets(enabled bool) *Config {
+	c.LogSecrets = enabled
+	return c
+}
+
+// WithResponseDecodeMode sets how unmodeled response fields are handled and returns the config for chaining
+func (c *Config) WithResponseDecodeMode(mode rest.DecodeMode) *Config {
+	c.ResponseDecodeMode = mode
+	return c
+}
+
+// WithRequestHook adds a hook called before every REST request attempt and returns the config for chaining
+func (c *Config) WithRequestHook(hook rest.RequestHook) *Config {
+	c.RequestHooks = append(c.RequestHooks, hook)
+	return c
+}
+
+// WithResponseHook adds a hook called after every REST response and returns the config for chaining
+func (c *Config) WithResponseHook(hook rest.ResponseHook) *Config {
+	c.ResponseHooks = append(c.ResponseHooks, hook)
+	return c
+}
+
+// WithExtraHeader adds a header sent with every REST request and returns the config for chaining
+// Extra headers are not signed and cannot replace the auth, content type or locale headers
+func (c *Config) WithExtraHeader(key, value string) *Config {
+	if c.ExtraHeaders == nil {
+		c.ExtraHeaders = make(map[string]string)
+	}
+	c.ExtraHeaders[key] = value
+	return c
+}
+
 // WithLocale sets the locale and returns the config for chaining
 func (c *Config) WithLocale(locale string) *Config {
 	c.Locale = locale
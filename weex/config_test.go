@@ -0,0 +1,191 @@
+package weex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestRedactedJSON(t *testing.T) {
+	cfg := NewDefaultConfig().
+		WithAPIKey("bg_0123456789abcdef").
+		WithSecretKey("supersecretvalue").
+		WithPassphrase("passphrase")
+	cfg.ExtraHeaders = map[string]string{
+		"Authorization": "Bearer tokenvalue",
+		"X-Tenant-Id":   "tenant-42",
+	}
+
+	data, err := cfg.RedactedJSON()
+	if err != nil {
+		t.Fatalf("RedactedJSON: %v", err)
+	}
+	for _, secret := range []string{"0123456789abcdef", "supersecretvalue", "passphrase", "tokenvalue", "tenant-42"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("output contains %q:\n%s", secret, data)
+		}
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	tests := []struct {
+		field string
+		want  interface{}
+	}{
+		{"APIKey", "bg_0****(len 19)"},
+		{"SecretKey", "****(len 16)"},
+		{"Passphrase", "****(len 10)"},
+		{"ExtraHeaders", map[string]interface{}{"Authorization": "****(len 17)", "X-Tenant-Id": "****(len 9)"}},
+		{"BaseURL", cfg.BaseURL},
+		{"HTTPTimeout", "10s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got, _ := json.Marshal(out[tt.field])
+			want, _ := json.Marshal(tt.want)
+			if string(got) != string(want) {
+				t.Errorf("%s = %s, want %s", tt.field, got, want)
+			}
+		})
+	}
+}
+
+// mockEnvironment is a caller-defined Environment for a local mock server
+var mockEnvironment = Environment{
+	BaseURL:      "http://localhost:8080",
+	WSPublicURL:  "ws://localhost:8080/v2/ws/public",
+	WSPrivateURL: "ws://localhost:8080/v2/ws/private",
+}
+
+func TestEnvironmentURLs(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+		want   Environment
+	}{
+		{"default", NewDefaultConfig(), EnvironmentProduction},
+		{"custom", NewDefaultConfig().WithEnvironment(mockEnvironment), mockEnvironment},
+		{"custom with base URL override", NewDefaultConfig().WithEnvironment(mockEnvironment).WithBaseURL("http://localhost:9090"), Environment{
+			BaseURL:      "http://localhost:9090",
+			WSPublicURL:  "ws://localhost:8080/v2/ws/public",
+			WSPrivateURL: "ws://localhost:8080/v2/ws/private",
+		}},
+		{"production over custom", NewDefaultConfig().WithEnvironment(mockEnvironment).WithEnvironment(EnvironmentProduction), EnvironmentProduction},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Environment{BaseURL: tt.config.BaseURL, WSPublicURL: tt.config.WSPublicURL, WSPrivateURL: tt.config.WSPrivateURL}
+			if got != tt.want {
+				t.Errorf("URLs = %+v, want %+v", got, tt.want)
+			}
+			if err := tt.config.ValidatePublic(); err != nil {
+				t.Errorf("ValidatePublic() error = %v", err)
+			}
+			tt.config.WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase")
+			if err := tt.config.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestNewClientUsesEnvironmentBaseURL(t *testing.T) {
+	// The hook cancels the request's context, so it fails before anything is sent
+	ctx, cancel := context.WithCancel(context.Background())
+	var requested string
+	config := NewDefaultConfig().WithEnvironment(mockEnvironment).WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase")
+	config.Logger = NewNoOpLogger()
+	config.MaxRetries = 0
+	config.RequestHooks = []rest.RequestHook{func(req *http.Request) {
+		requested = req.URL.String()
+		cancel()
+	}}
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.Market().GetServerTime(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetServerTime() error = %v, want context.Canceled", err)
+	}
+
+	if want := "http://localhost:8080/capi/v2/market/time"; requested != want {
+		t.Errorf("requested %q, want %q", requested, want)
+	}
+}
+
+func TestConfigCloneIsIndependent(t *testing.T) {
+	noopRequest := func(*http.Request) {}
+	noopResponse := func(*http.Response, []byte) {}
+	newConfig := func() *Config {
+		return NewDefaultConfig().
+			WithExtraHeader("X-Tenant", "a").
+			WithRequestHook(noopRequest).
+			WithResponseHook(noopResponse).
+			WithWSAckTimeout("orders", time.Second).
+			WithRetryPolicy(&RetryPolicy{ByCode: map[string]int{"429": 0}})
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(clone *Config)
+	}{
+		{"extra header added", func(clone *Config) { clone.WithExtraHeader("X-Trace", "b") }},
+		{"extra header replaced", func(clone *Config) { clone.ExtraHeaders["X-Tenant"] = "b" }},
+		{"request hook added", func(clone *Config) { clone.WithRequestHook(noopRequest) }},
+		{"request hook replaced", func(clone *Config) { clone.RequestHooks[0] = nil }},
+		{"response hook added", func(clone *Config) { clone.WithResponseHook(noopResponse) }},
+		{"response hook replaced", func(clone *Config) { clone.ResponseHooks[0] = nil }},
+		{"ack timeout added", func(clone *Config) { clone.WithWSAckTimeout("ticker", time.Minute) }},
+		{"ack timeout replaced", func(clone *Config) { clone.WSAckTimeoutByChannel["orders"] = time.Minute }},
+		{"retry policy code changed", func(clone *Config) { clone.RetryPolicy.ByCode["429"] = 5 }},
+		{"retry policy type added", func(clone *Config) {
+			clone.RetryPolicy.ByType = map[types.ErrorType]int{types.ErrTypeNetwork: 5}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := newConfig()
+			tt.mutate(original.Clone())
+			checkConfigUnchanged(t, original)
+		})
+		t.Run(tt.name+" through GetConfig", func(t *testing.T) {
+			client, err := NewPublicClient(newConfig())
+			if err != nil {
+				t.Fatalf("NewPublicClient() error = %v", err)
+			}
+			tt.mutate(client.GetConfig())
+			checkConfigUnchanged(t, client.GetConfig())
+		})
+	}
+}
+
+// checkConfigUnchanged checks config still has the values set in TestConfigCloneIsIndependent
+func checkConfigUnchanged(t *testing.T, config *Config) {
+	t.Helper()
+	if want := map[string]string{"X-Tenant": "a"}; !reflect.DeepEqual(config.ExtraHeaders, want) {
+		t.Errorf("ExtraHeaders = %v, want %v", config.ExtraHeaders, want)
+	}
+	if len(config.RequestHooks) != 1 || config.RequestHooks[0] == nil {
+		t.Errorf("RequestHooks = %v, want the one original hook", config.RequestHooks)
+	}
+	if len(config.ResponseHooks) != 1 || config.ResponseHooks[0] == nil {
+		t.Errorf("ResponseHooks = %v, want the one original hook", config.ResponseHooks)
+	}
+	if want := map[string]time.Duration{"orders": time.Second}; !reflect.DeepEqual(config.WSAckTimeoutByChannel, want) {
+		t.Errorf("WSAckTimeoutByChannel = %v, want %v", config.WSAckTimeoutByChannel, want)
+	}
+	if want := (&RetryPolicy{ByCode: map[string]int{"429": 0}}); !reflect.DeepEqual(config.RetryPolicy, want) {
+		t.Errorf("RetryPolicy = %+v, want %+v", config.RetryPolicy, want)
+	}
+}
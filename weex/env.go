@@ -0,0 +1,61 @@
+package weex
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variables read by ConfigFromEnv and ConfigFromEnvPublic
+const (
+	EnvAPIKey     = "WEEX_API_KEY"
+	EnvSecretKey  = "WEEX_SECRET_KEY"
+	EnvPassphrase = "WEEX_PASSPHRASE"
+	EnvBaseURL    = "WEEX_BASE_URL"  // Optional: overrides DefaultBaseURL
+	EnvLogLevel   = "WEEX_LOG_LEVEL" // Optional: debug, info, warn, error or none
+)
+
+// ConfigFromEnv creates a default config with credentials and settings from environment variables
+// Returns an error wrapping ErrInvalidConfig naming any missing credential variables.
+func ConfigFromEnv() (*Config, error) {
+	config, err := ConfigFromEnvPublic()
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, v := range []struct {
+		name  string
+		value *string
+	}{
+		{EnvAPIKey, &config.APIKey},
+		{EnvSecretKey, &config.SecretKey},
+		{EnvPassphrase, &config.Passphrase},
+	} {
+		*v.value = os.Getenv(v.name)
+		if *v.value == "" {
+			missing = append(missing, v.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing environment variables %s", ErrInvalidConfig, strings.Join(missing, ", "))
+	}
+	return config, nil
+}
+
+// ConfigFromEnvPublic creates a default config with the optional settings from environment variables
+// No credentials are required, so the config suits NewPublicClient.
+func ConfigFromEnvPublic() (*Config, error) {
+	config := NewDefaultConfig()
+	if baseURL := os.Getenv(EnvBaseURL); baseURL != "" {
+		config.BaseURL = baseURL
+	}
+	if value := os.Getenv(EnvLogLevel); value != "" {
+		level, err := ParseLogLevel(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidConfig, EnvLogLevel, err)
+		}
+		config.WithLogLevel(level)
+	}
+	return config, nil
+}
@@ -0,0 +1,120 @@
+package weex
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantErr     bool
+		wantMissing []string // variables named in the error
+		wantBaseURL string
+		wantLevel   LogLevel
+	}{
+		{"all set", map[string]string{
+			EnvAPIKey: "key", EnvSecretKey: "secret", EnvPassphrase: "pass",
+			EnvBaseURL: "https://example.test", EnvLogLevel: "debug",
+		}, false, nil, "https://example.test", LogLevelDebug},
+		{"credentials only", map[string]string{
+			EnvAPIKey: "key", EnvSecretKey: "secret", EnvPassphrase: "pass",
+		}, false, nil, EnvironmentProduction.BaseURL, NewDefaultConfig().LogLevel},
+		{"missing passphrase", map[string]string{
+			EnvAPIKey: "key", EnvSecretKey: "secret",
+		}, true, []string{EnvPassphrase}, "", 0},
+		{"missing all", nil, true, []string{EnvAPIKey, EnvSecretKey, EnvPassphrase}, "", 0},
+		{"invalid log level", map[string]string{
+			EnvAPIKey: "key", EnvSecretKey: "secret", EnvPassphrase: "pass", EnvLogLevel: "loud",
+		}, true, []string{EnvLogLevel}, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{EnvAPIKey, EnvSecretKey, EnvPassphrase, EnvBaseURL, EnvLogLevel} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			config, err := ConfigFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConfigFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidConfig) {
+					t.Errorf("ConfigFromEnv() error = %v, want ErrInvalidConfig", err)
+				}
+				for _, name := range tt.wantMissing {
+					if !strings.Contains(err.Error(), name) {
+						t.Errorf("ConfigFromEnv() error = %v, want it to name %s", err, name)
+					}
+				}
+				return
+			}
+			if config.APIKey != "key" || config.SecretKey != "secret" || config.Passphrase != "pass" {
+				t.Errorf("credentials = %q, %q, %q", config.APIKey, config.SecretKey, config.Passphrase)
+			}
+			if config.BaseURL != tt.wantBaseURL || config.LogLevel != tt.wantLevel {
+				t.Errorf("BaseURL, LogLevel = %s, %v, want %s, %v", config.BaseURL, config.LogLevel, tt.wantBaseURL, tt.wantLevel)
+			}
+			if err := config.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigFromEnvPublic(t *testing.T) {
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(EnvSecretKey, "")
+	t.Setenv(EnvPassphrase, "")
+	t.Setenv(EnvBaseURL, "https://example.test")
+	t.Setenv(EnvLogLevel, "ERROR")
+
+	config, err := ConfigFromEnvPublic()
+	if err != nil {
+		t.Fatalf("ConfigFromEnvPublic() error = %v", err)
+	}
+	if config.APIKey != "" || config.BaseURL != "https://example.test" || config.LogLevel != LogLevelError {
+		t.Errorf("config = %q, %s, %v", config.APIKey, config.BaseURL, config.LogLevel)
+	}
+	if err := config.ValidatePublic(); err != nil {
+		t.Errorf("ValidatePublic() error = %v", err)
+	}
+
+	t.Setenv(EnvLogLevel, "verbose")
+	if _, err := ConfigFromEnvPublic(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("ConfigFromEnvPublic() with invalid level error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    LogLevel
+		wantErr bool
+	}{
+		{"debug", LogLevelDebug, false},
+		{"INFO", LogLevelInfo, false},
+		{"warn", LogLevelWarn, false},
+		{"Warning", LogLevelWarn, false},
+		{" error ", LogLevelError, false},
+		{"none", LogLevelNone, false},
+		{"off", LogLevelNone, false},
+		{"", 0, true},
+		{"trace", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseLogLevel(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLogLevel(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLogLevel(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package weex
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -8,57 +9,12 @@ import (
 )
 
 // APIError represents an error returned by the WEEX Contract API
-type APIError struct {
-	Code        string               // Error code from API
-	Message     string               // Error message from API
-	HTTPStatus  int                  // HTTP status code
-	RequestTime int64                // Request timestamp from API response
-	Category    *types.ErrorCategory // Error category
-	Underlying  error                // Underlying error if any
-}
-
-// Error implements the error interface
-func (e *APIError) Error() string {
-	if e.Underlying != nil {
-		return fmt.Sprintf("API error [%s]: %s (HTTP %d) - %v", e.Code, e.Message, e.HTTPStatus, e.Underlying)
-	}
-	return fmt.Sprintf("API error [%s]: %s (HTTP %d)", e.Code, e.Message, e.HTTPStatus)
-}
-
-// IsRetriable returns true if the error is retriable
-func (e *APIError) IsRetriable() bool {
-	return e.Category != nil && e.Category.Retriable
-}
-
-// IsAuthError returns true if the error is an authentication error
-func (e *APIError) IsAuthError() bool {
-	return e.Category != nil && e.Category.Type == types.ErrTypeAuth
-}
-
-// IsRateLimitError returns true if the error is a rate limiting error
-func (e *APIError) IsRateLimitError() bool {
-	return e.Category != nil && e.Category.Type == types.ErrTypeRateLimit
-}
-
-// IsValidationError returns true if the error is a validation error
-func (e *APIError) IsValidationError() bool {
-	return e.Category != nil && e.Category.Type == types.ErrTypeValidation
-}
-
-// IsSystemError returns true if the error is a system error
-func (e *APIError) IsSystemError() bool {
-	return e.Category != nil && e.Category.Type == types.ErrTypeSystem
-}
+// It is defined in the types package so the REST client can return it
+type APIError = types.APIError
 
 // NewAPIError creates a new APIError from API response
 func NewAPIError(code, message string, httpStatus int, requestTime int64) *APIError {
-	return &APIError{
-		Code:        code,
-		Message:     message,
-		HTTPStatus:  httpStatus,
-		RequestTime: requestTime,
-		Category:    types.GetErrorCategory(code),
-	}
+	return types.NewAPIError(code, message, httpStatus, requestTime)
 }
 
 // WrapError wraps an underlying error with API error information
@@ -68,35 +24,49 @@ func WrapError(code, message string, httpStatus int, requestTime int64, underlyi
 	return err
 }
 
-// NetworkError represents a network-related error
-type NetworkError struct {
-	Operation string // Operation being performed (e.g., "dial", "read", "write")
-	URL       string // URL being accessed
-	Err       error  // Underlying error
+// apiErrorCode extracts the API error code from err, or "" if err is not an API error
+func apiErrorCode(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
 }
 
-// Error implements the error interface
-func (e *NetworkError) Error() string {
-	return fmt.Sprintf("network error during %s to %s: %v", e.Operation, e.URL, e.Err)
+// authErrorDescriptions describes authentication error codes
+var authErrorDescriptions = map[string]string{
+	"40001": "ACCESS-KEY header is empty",
+	"40002": "ACCESS-SIGN header is empty",
+	"40003": "ACCESS-PASSPHRASE header is empty",
+	"40004": "ACCESS-TIMESTAMP header is empty",
+	"40005": "invalid ACCESS-TIMESTAMP",
+	"40006": "invalid API key",
+	"40007": "invalid signature, check the secret key",
+	"40008": "timestamp expired, check the system clock",
+	"40009": "API key does not exist",
+	"40010": "incorrect passphrase",
+	"40011": "API key expired",
+	"40012": "API key frozen",
+	"40013": "IP address not in the API key whitelist",
+	"40014": "API key not bound to subaccount",
+	"40753": "invalid locale parameter",
 }
 
-// Unwrap returns the underlying error
-func (e *NetworkError) Unwrap() error {
-	return e.Err
+// authErrorDescription returns a human-readable description of an authentication error code
+func authErrorDescription(code string) string {
+	if desc, ok := authErrorDescriptions[code]; ok {
+		return desc
+	}
+	return "credentials rejected"
 }
 
-// IsRetriable returns true for network errors
-func (e *NetworkError) IsRetriable() bool {
-	return true
-}
+// NetworkError represents a network-related error
+// It is defined in the types package so the REST client can return it
+type NetworkError = types.NetworkError
 
 // NewNetworkError creates a new NetworkError
 func NewNetworkError(operation, url string, err error) *NetworkError {
-	return &NetworkError{
-		Operation: operation,
-		URL:       url,
-		Err:       err,
-	}
+	return types.NewNetworkError(operation, url, err)
 }
 
 // IsRetriableHTTPStatus checks if an HTTP status code indicates a retriable error
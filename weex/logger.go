@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 )
 
 // LogLevel represents the logging level
@@ -35,6 +36,24 @@ func (l LogLevel) String() string {
 	}
 }
 
+// ParseLogLevel parses a log level name ("debug", "info", "warn", "error", "none"), case-insensitively
+func ParseLogLevel(s string) (LogLevel, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "DEBUG":
+		return LogLevelDebug, nil
+	case "INFO":
+		return LogLevelInfo, nil
+	case "WARN", "WARNING":
+		return LogLevelWarn, nil
+	case "ERROR":
+		return LogLevelError, nil
+	case "NONE", "OFF":
+		return LogLevelNone, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q", s)
+	}
+}
+
 // Logger is the interface for logging in the SDK
 type Logger interface {
 	// Debug logs a debug message
@@ -0,0 +1,95 @@
+package weex
+
+import "time"
+
+// Option configures a client created by NewClientWithOptions or NewPublicClientWithOptions
+type Option func(*Config)
+
+// NewClientWithOptions creates a new authenticated client from options
+// The options are applied to a fresh NewDefaultConfig, so clients built from
+// the same options never share configuration state.
+func NewClientWithOptions(opts ...Option) (*Client, error) {
+	return NewClient(configFromOptions(opts))
+}
+
+// NewPublicClientWithOptions creates a new client for public endpoints from options
+func NewPublicClientWithOptions(opts ...Option) (*Client, error) {
+	return NewPublicClient(configFromOptions(opts))
+}
+
+// configFromOptions applies opts to a new default config
+func configFromOptions(opts []Option) *Config {
+	config := NewDefaultConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// WithAPIKey sets the API key
+func WithAPIKey(apiKey string) Option {
+	return func(c *Config) { c.APIKey = apiKey }
+}
+
+// WithSecretKey sets the secret key
+func WithSecretKey(secretKey string) Option {
+	return func(c *Config) { c.SecretKey = secretKey }
+}
+
+// WithPassphrase sets the passphrase
+func WithPassphrase(passphrase string) Option {
+	return func(c *Config) { c.Passphrase = passphrase }
+}
+
+// WithCredentials sets the API key, secret key and passphrase
+func WithCredentials(apiKey, secretKey, passphrase string) Option {
+	return func(c *Config) {
+		c.APIKey = apiKey
+		c.SecretKey = secretKey
+		c.Passphrase = passphrase
+	}
+}
+
+// WithEnvironment sets all endpoint URLs from env
+func WithEnvironment(env Environment) Option {
+	return func(c *Config) { c.WithEnvironment(env) }
+}
+
+// WithTimeout sets the HTTP timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Config) { c.HTTPTimeout = timeout }
+}
+
+// WithRateLimit enables rate limiting with the given IP and UID weight budgets
+func WithRateLimit(ipWeight, uidWeight int) Option {
+	return func(c *Config) {
+		c.EnableRateLimit = true
+		c.IPWeight = ipWeight
+		c.UIDWeight = uidWeight
+	}
+}
+
+// WithoutRateLimit disables local rate limiting
+func WithoutRateLimit() Option {
+	return func(c *Config) { c.EnableRateLimit = false }
+}
+
+// WithMaxRetries sets the maximum number of retries
+func WithMaxRetries(maxRetries int) Option {
+	return func(c *Config) { c.MaxRetries = maxRetries }
+}
+
+// WithLogger sets the logger
+func WithLogger(logger Logger) Option {
+	return func(c *Config) { c.Logger = logger }
+}
+
+// WithLogLevel sets the log level
+func WithLogLevel(level LogLevel) Option {
+	return func(c *Config) { c.WithLogLevel(level) }
+}
+
+// WithConfigFunc applies fn to the config, for settings without a dedicated option
+func WithConfigFunc(fn func(*Config)) Option {
+	return Option(fn)
+}
@@ -0,0 +1,118 @@
+package weex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfigFromOptions(t *testing.T) {
+	logger := NewNoOpLogger()
+
+	tests := []struct {
+		name  string
+		opts  []Option
+		check func(t *testing.T, c *Config)
+	}{
+		{"defaults", nil, func(t *testing.T, c *Config) {
+			if c.BaseURL != EnvironmentProduction.BaseURL || c.HTTPTimeout != 10*time.Second || !c.EnableRateLimit {
+				t.Errorf("config = %+v, want NewDefaultConfig values", c)
+			}
+		}},
+		{"credentials", []Option{WithCredentials("key", "secret", "pass")}, func(t *testing.T, c *Config) {
+			if c.APIKey != "key" || c.SecretKey != "secret" || c.Passphrase != "pass" {
+				t.Errorf("credentials = %q, %q, %q", c.APIKey, c.SecretKey, c.Passphrase)
+			}
+		}},
+		{"separate credentials", []Option{WithAPIKey("key"), WithSecretKey("secret"), WithPassphrase("pass")}, func(t *testing.T, c *Config) {
+			if c.APIKey != "key" || c.SecretKey != "secret" || c.Passphrase != "pass" {
+				t.Errorf("credentials = %q, %q, %q", c.APIKey, c.SecretKey, c.Passphrase)
+			}
+		}},
+		{"environment", []Option{WithEnvironment(mockEnvironment)}, func(t *testing.T, c *Config) {
+			if c.BaseURL != mockEnvironment.BaseURL || c.WSPublicURL != mockEnvironment.WSPublicURL || c.WSPrivateURL != mockEnvironment.WSPrivateURL {
+				t.Errorf("URLs = %s, %s, %s, want mock environment", c.BaseURL, c.WSPublicURL, c.WSPrivateURL)
+			}
+		}},
+		{"timeout and retries", []Option{WithTimeout(3 * time.Second), WithMaxRetries(0)}, func(t *testing.T, c *Config) {
+			if c.HTTPTimeout != 3*time.Second || c.MaxRetries != 0 {
+				t.Errorf("HTTPTimeout, MaxRetries = %v, %d", c.HTTPTimeout, c.MaxRetries)
+			}
+		}},
+		{"rate limit", []Option{WithoutRateLimit(), WithRateLimit(50, 20)}, func(t *testing.T, c *Config) {
+			if !c.EnableRateLimit || c.IPWeight != 50 || c.UIDWeight != 20 {
+				t.Errorf("rate limit = %v, %d, %d", c.EnableRateLimit, c.IPWeight, c.UIDWeight)
+			}
+		}},
+		{"without rate limit", []Option{WithoutRateLimit()}, func(t *testing.T, c *Config) {
+			if c.EnableRateLimit {
+				t.Error("EnableRateLimit = true")
+			}
+		}},
+		{"logger and level", []Option{WithLogger(logger), WithLogLevel(LogLevelError)}, func(t *testing.T, c *Config) {
+			if c.Logger != logger || c.LogLevel != LogLevelError {
+				t.Errorf("Logger, LogLevel = %v, %v", c.Logger, c.LogLevel)
+			}
+		}},
+		{"config func", []Option{WithConfigFunc(func(c *Config) { c.SymbolLocking = true })}, func(t *testing.T, c *Config) {
+			if !c.SymbolLocking {
+				t.Error("SymbolLocking = false")
+			}
+		}},
+		{"later options win", []Option{WithTimeout(time.Second), WithTimeout(2 * time.Second)}, func(t *testing.T, c *Config) {
+			if c.HTTPTimeout != 2*time.Second {
+				t.Errorf("HTTPTimeout = %v, want 2s", c.HTTPTimeout)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, configFromOptions(tt.opts))
+		})
+	}
+}
+
+func TestClientsFromSameOptionsIndependent(t *testing.T) {
+	base := []Option{WithCredentials("key", "secret", "pass"), WithLogger(NewNoOpLogger()), WithRateLimit(50, 20)}
+
+	first, err := NewClientWithOptions(base...)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	second, err := NewClientWithOptions(append(base[:len(base):len(base)], WithTimeout(time.Second), WithRateLimit(80, 40))...)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	if first.config == second.config {
+		t.Fatal("clients share a config")
+	}
+	if got := first.GetConfig(); got.HTTPTimeout != 10*time.Second || got.IPWeight != 50 || got.UIDWeight != 20 {
+		t.Errorf("first config = %v, %d, %d; want the base options only", got.HTTPTimeout, got.IPWeight, got.UIDWeight)
+	}
+	if got := second.GetConfig(); got.HTTPTimeout != time.Second || got.IPWeight != 80 || got.UIDWeight != 40 {
+		t.Errorf("second config = %v, %d, %d; want its own options", got.HTTPTimeout, got.IPWeight, got.UIDWeight)
+	}
+
+	// Changing one client's credentials or limiter leaves the other alone
+	first.UpdateCredentials("other", "other-secret", "other-pass")
+	first.SetRateLimitEnabled(false)
+	if got := second.GetConfig(); got.APIKey != "key" {
+		t.Errorf("second APIKey = %q after updating the first client, want key", got.APIKey)
+	}
+	if !second.GetRateLimiter().Enabled() {
+		t.Error("second client's rate limiting disabled by the first")
+	}
+	if ip, uid := second.GetRateLimiter().GetStatus(); ip != 80 || uid != 40 {
+		t.Errorf("second limiter budgets = %d, %d, want 80, 40", ip, uid)
+	}
+}
+
+func TestNewClientWithOptionsValidates(t *testing.T) {
+	if _, err := NewClientWithOptions(WithLogger(NewNoOpLogger())); err == nil {
+		t.Error("NewClientWithOptions() without credentials expected error")
+	}
+	if _, err := NewPublicClientWithOptions(WithLogger(NewNoOpLogger())); err != nil {
+		t.Errorf("NewPublicClientWithOptions() without credentials error = %v", err)
+	}
+}
@@ -4,17 +4,19 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // TokenBucket implements a token bucket rate limiter
 type TokenBucket struct {
-	capacity       int           // Maximum number of tokens
-	tokens         int           // Current number of tokens
-	refillRate     int           // Tokens to add per refill interval
-	refillInterval time.Duration // How often to refill tokens
-	lastRefill     time.Time     // Last refill time
-	mu             sync.Mutex    // Mutex for thread safety
+	capacity       int              // Maximum number of tokens
+	tokens         float64          // Current number of tokens (fractional between refills)
+	refillRate     int              // Tokens to add per refill interval
+	refillInterval time.Duration    // Time for an empty bucket to refill completely
+	lastRefill     time.Time        // Last refill time
+	now            func() time.Time // Clock (time.Now unless injected)
+	mu             sync.Mutex       // Mutex for thread safety
 }
 
 // NewTokenBucket creates a new TokenBucket
@@ -23,14 +25,24 @@ type TokenBucket struct {
 //   - capacity: Maximum tokens (e.g., 300 for IP weight, 100 for UID weight)
 //   - refillInterval: Time window for refill (e.g., 5 minutes)
 //
-// The bucket refills to full capacity after each interval
+// Tokens accrue linearly so an empty bucket is full again after one interval
 func NewTokenBucket(capacity int, refillInterval time.Duration) *TokenBucket {
+	return NewTokenBucketWithClock(capacity, refillInterval, time.Now)
+}
+
+// NewTokenBucketWithClock creates a new TokenBucket that reads the time from now
+// Useful for deterministic tests; a nil now uses time.Now
+func NewTokenBucketWithClock(capacity int, refillInterval time.Duration, now func() time.Time) *TokenBucket {
+	if now == nil {
+		now = time.Now
+	}
 	return &TokenBucket{
 		capacity:       capacity,
-		tokens:         capacity,
+		tokens:         float64(capacity),
 		refillRate:     capacity,
 		refillInterval: refillInterval,
-		lastRefill:     time.Now(),
+		lastRefill:     now(),
+		now:            now,
 	}
 }
 
@@ -42,8 +54,8 @@ func (tb *TokenBucket) Take(n int) bool {
 
 	tb.refill()
 
-	if tb.tokens >= n {
-		tb.tokens -= n
+	if tb.tokens >= float64(n) {
+		tb.tokens -= float64(n)
 		return true
 	}
 
@@ -74,16 +86,24 @@ func (tb *TokenBucket) Wait(ctx context.Context, n int) error {
 	}
 }
 
-// refill adds tokens based on elapsed time since last refill
+// refill adds tokens in proportion to the time elapsed since the last refill, capped at capacity
 // Must be called with mutex held
 func (tb *TokenBucket) refill() {
-	now := time.Now()
+	now := tb.now()
 	elapsed := now.Sub(tb.lastRefill)
+	if elapsed <= 0 {
+		return
+	}
+	tb.lastRefill = now
+
+	if tb.refillInterval <= 0 {
+		tb.tokens = float64(tb.capacity)
+		return
+	}
 
-	if elapsed >= tb.refillInterval {
-		// Full refill
-		tb.tokens = tb.capacity
-		tb.lastRefill = now
+	tb.tokens += float64(tb.refillRate) * float64(elapsed) / float64(tb.refillInterval)
+	if tb.tokens > float64(tb.capacity) {
+		tb.tokens = float64(tb.capacity)
 	}
 }
 
@@ -93,15 +113,35 @@ func (tb *TokenBucket) Available() int {
 	defer tb.mu.Unlock()
 
 	tb.refill()
-	return tb.tokens
+	return int(tb.tokens)
 }
 
+// DefaultRateLimitWindow is the window over which IP and UID weight budgets refill
+const DefaultRateLimitWindow = 5 * time.Minute
+
 // RateLimiter manages rate limiting using token buckets
 type RateLimiter struct {
-	ipBucket  *TokenBucket // IP weight limiter
-	uidBucket *TokenBucket // UID weight limiter
-	enabled   bool         // Whether rate limiting is enabled
-	logger    Logger
+	ipBucket    *TokenBucket  // IP weight limiter
+	uidBucket   *TokenBucket  // UID weight limiter
+	window      time.Duration // Weight budget refill window
+	enabled     atomic.Bool   // Whether rate limiting is enabled
+	observeOnly bool          // Track weight without blocking when disabled
+	logger      Logger
+
+	// Observe-only tallies
+	usageMu       sync.Mutex
+	ipConsumed    int64 // Total IP weight consumed
+	uidConsumed   int64 // Total UID weight consumed
+	overBudgetIP  int64 // Requests that would have waited for IP weight
+	overBudgetUID int64 // Requests that would have waited for UID weight
+}
+
+// ObservedUsage reports the weight tracked by a RateLimiter in observe-only mode
+type ObservedUsage struct {
+	IPConsumed    int64 // Total IP weight consumed
+	UIDConsumed   int64 // Total UID weight consumed
+	OverBudgetIP  int64 // Requests that would have been delayed for IP weight
+	OverBudgetUID int64 // Requests that would have been delayed for UID weight
 }
 
 // NewRateLimiter creates a new RateLimiter
@@ -112,12 +152,50 @@ type RateLimiter struct {
 //   - uidWeight: Maximum UID weight per 5 minutes (default: 100)
 //   - logger: Logger instance
 func NewRateLimiter(enabled bool, ipWeight, uidWeight int, logger Logger) *RateLimiter {
-	return &RateLimiter{
-		ipBucket:  NewTokenBucket(ipWeight, 5*time.Second),
-		uidBucket: NewTokenBucket(uidWeight, 5*time.Second),
-		enabled:   enabled,
+	return NewRateLimiterWithWindow(enabled, ipWeight, uidWeight, DefaultRateLimitWindow, logger)
+}
+
+// NewRateLimiterWithWindow creates a new RateLimiter whose weight budgets refill every window
+// A non-positive window uses DefaultRateLimitWindow
+func NewRateLimiterWithWindow(enabled bool, ipWeight, uidWeight int, window time.Duration, logger Logger) *RateLimiter {
+	if !enabled {
+		logger.Warn("Rate limiting is disabled; requests may be throttled by the exchange")
+	}
+	if window <= 0 {
+		window = DefaultRateLimitWindow
+	}
+	rl := &RateLimiter{
+		ipBucket:  NewTokenBucket(ipWeight, window),
+		uidBucket: NewTokenBucket(uidWeight, window),
+		window:    window,
 		logger:    logger,
 	}
+	rl.enabled.Store(enabled)
+	return rl
+}
+
+// SetEnabled turns rate limiting on or off at runtime
+// Requests already waiting for capacity keep waiting; the new setting applies
+// to requests made after the call.
+func (rl *RateLimiter) SetEnabled(enabled bool) {
+	if rl.enabled.Swap(enabled) == enabled {
+		return
+	}
+	if enabled {
+		rl.logger.Info("Rate limiting enabled")
+	} else {
+		rl.logger.Warn("Rate limiting is disabled; requests may be throttled by the exchange")
+	}
+}
+
+// Enabled reports whether rate limiting is currently enabled
+func (rl *RateLimiter) Enabled() bool {
+	return rl.enabled.Load()
+}
+
+// Window returns the weight budget refill window
+func (rl *RateLimiter) Window() time.Duration {
+	return rl.window
 }
 
 // WaitForCapacity waits until the specified weight is available
@@ -129,7 +207,8 @@ func NewRateLimiter(enabled bool, ipWeight, uidWeight int, logger Logger) *RateL
 //
 // Returns error if rate limit cannot be satisfied or context is canceled
 func (rl *RateLimiter) WaitForCapacity(ctx context.Context, ipWeight, uidWeight int) error {
-	if !rl.enabled {
+	if !rl.enabled.Load() {
+		rl.observe(ipWeight, uidWeight)
 		return nil
 	}
 
@@ -155,7 +234,8 @@ func (rl *RateLimiter) WaitForCapacity(ctx context.Context, ipWeight, uidWeight
 // TryAcquire attempts to acquire the specified weight without waiting
 // Returns true if successful, false otherwise
 func (rl *RateLimiter) TryAcquire(ipWeight, uidWeight int) bool {
-	if !rl.enabled {
+	if !rl.enabled.Load() {
+		rl.observe(ipWeight, uidWeight)
 		return true
 	}
 
@@ -173,7 +253,180 @@ func (rl *RateLimiter) TryAcquire(ipWeight, uidWeight int) bool {
 	return ipOk && uidOk
 }
 
+// SetObserveOnly enables tracking of consumed weight while rate limiting is disabled
+//
+// In observe-only mode requests are never blocked, but the weight they consume
+// is tallied and a warning is logged whenever the budget would have been exceeded.
+// Has no effect while rate limiting is enabled.
+func (rl *RateLimiter) SetObserveOnly(observeOnly bool) {
+	rl.usageMu.Lock()
+	defer rl.usageMu.Unlock()
+	rl.observeOnly = observeOnly
+}
+
+// ObservedUsage returns the weight tallied in observe-only mode
+func (rl *RateLimiter) ObservedUsage() ObservedUsage {
+	rl.usageMu.Lock()
+	defer rl.usageMu.Unlock()
+	return ObservedUsage{
+		IPConsumed:    rl.ipConsumed,
+		UIDConsumed:   rl.uidConsumed,
+		OverBudgetIP:  rl.overBudgetIP,
+		OverBudgetUID: rl.overBudgetUID,
+	}
+}
+
+// observe tallies weight without blocking (observe-only mode)
+func (rl *RateLimiter) observe(ipWeight, uidWeight int) {
+	rl.usageMu.Lock()
+	defer rl.usageMu.Unlock()
+
+	if !rl.observeOnly {
+		return
+	}
+
+	rl.ipConsumed += int64(ipWeight)
+	rl.uidConsumed += int64(uidWeight)
+
+	if ipWeight > 0 && !rl.ipBucket.Take(ipWeight) {
+		rl.overBudgetIP++
+		rl.logger.Warn("IP weight budget would have been exceeded (requested %d, available %d)", ipWeight, rl.ipBucket.Available())
+	}
+	if uidWeight > 0 && !rl.uidBucket.Take(uidWeight) {
+		rl.overBudgetUID++
+		rl.logger.Warn("UID weight budget would have been exceeded (requested %d, available %d)", uidWeight, rl.uidBucket.Available())
+	}
+}
+
 // GetStatus returns the current status of the rate limiter
 func (rl *RateLimiter) GetStatus() (ipAvailable, uidAvailable int) {
 	return rl.ipBucket.Available(), rl.uidBucket.Available()
 }
+
+// OrderRateLimiter paces order placement according to the account's order rate limit
+//
+// The limit is separate from the weight-based RateLimiter and is described by
+// Account.CreateOrderRateLimitPerMinute and Account.CreateOrderDelayMilliseconds
+type OrderRateLimiter struct {
+	limitPerMinute int              // Maximum orders per rolling minute (0 = unlimited)
+	minDelay       time.Duration    // Minimum delay between order placements
+	placed         []time.Time      // Placement times within the last minute
+	lastOrder      time.Time        // Time of the most recent placement
+	now            func() time.Time // Clock (time.Now unless injected)
+	mu             sync.Mutex       // Mutex for thread safety
+}
+
+// NewOrderRateLimiter creates a new OrderRateLimiter
+//
+// Parameters:
+//   - limitPerMinute: Maximum number of orders per minute (0 disables the count limit)
+//   - minDelay: Minimum delay between consecutive order placements (0 disables the delay)
+func NewOrderRateLimiter(limitPerMinute int, minDelay time.Duration) *OrderRateLimiter {
+	return NewOrderRateLimiterWithClock(limitPerMinute, minDelay, time.Now)
+}
+
+// NewOrderRateLimiterWithClock creates a new OrderRateLimiter that reads the time from now
+// Useful for deterministic tests; a nil now uses time.Now
+func NewOrderRateLimiterWithClock(limitPerMinute int, minDelay time.Duration, now func() time.Time) *OrderRateLimiter {
+	if now == nil {
+		now = time.Now
+	}
+	return &OrderRateLimiter{
+		limitPerMinute: limitPerMinute,
+		minDelay:       minDelay,
+		now:            now,
+	}
+}
+
+// orderPollInterval caps each sleep in WaitForOrders so the clock is re-read regularly
+const orderPollInterval = 100 * time.Millisecond
+
+// WaitForOrders waits until n orders can be placed without exceeding the limit
+// Returns error if context is canceled or deadline exceeded
+func (ol *OrderRateLimiter) WaitForOrders(ctx context.Context, n int) error {
+	for {
+		wait := ol.reserve(n)
+		if wait <= 0 {
+			return nil
+		}
+		if wait > orderPollInterval {
+			wait = orderPollInterval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve records n placements if allowed, otherwise returns how long to wait
+func (ol *OrderRateLimiter) reserve(n int) time.Duration {
+	ol.mu.Lock()
+	defer ol.mu.Unlock()
+
+	now := ol.now()
+
+	// Drop placements older than one minute
+	cutoff := now.Add(-time.Minute)
+	i := 0
+	for i < len(ol.placed) && !ol.placed[i].After(cutoff) {
+		i++
+	}
+	ol.placed = ol.placed[i:]
+
+	var wait time.Duration
+
+	// Enforce the inter-order delay
+	if ol.minDelay > 0 && !ol.lastOrder.IsZero() {
+		if d := ol.minDelay - now.Sub(ol.lastOrder); d > wait {
+			wait = d
+		}
+	}
+
+	// Enforce the per-minute count; a batch larger than the limit waits for an empty window
+	if ol.limitPerMinute > 0 {
+		need := n
+		if need > ol.limitPerMinute {
+			need = ol.limitPerMinute
+		}
+		if excess := len(ol.placed) + need - ol.limitPerMinute; excess > 0 {
+			if d := ol.placed[excess-1].Add(time.Minute).Sub(now); d > wait {
+				wait = d
+			}
+		}
+	}
+
+	if wait > 0 {
+		return wait
+	}
+
+	for j := 0; j < n; j++ {
+		ol.placed = append(ol.placed, now)
+	}
+	ol.lastOrder = now
+	return 0
+}
+
+// Limits returns the configured per-minute order limit and inter-order delay
+func (ol *OrderRateLimiter) Limits() (limitPerMinute int, minDelay time.Duration) {
+	return ol.limitPerMinute, ol.minDelay
+}
+
+// Placed returns the number of orders placed within the last minute
+func (ol *OrderRateLimiter) Placed() int {
+	ol.mu.Lock()
+	defer ol.mu.Unlock()
+
+	cutoff := ol.now().Add(-time.Minute)
+	count := 0
+	for _, t := range ol.placed {
+		if t.After(cutoff) {
+			count++
+		}
+	}
+	return count
+}
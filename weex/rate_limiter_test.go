@@ -0,0 +1,452 @@
+package weex
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// warnCounter is a Logger counting Warn calls
+type warnCounter struct {
+	mu    sync.Mutex
+	warns int
+}
+
+func (l *warnCounter) Debug(string, ...interface{}) {}
+func (l *warnCounter) Info(string, ...interface{})  {}
+func (l *warnCounter) Error(string, ...interface{}) {}
+func (l *warnCounter) SetLevel(LogLevel)            {}
+func (l *warnCounter) Warn(string, ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warns++
+}
+
+func (l *warnCounter) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.warns
+}
+
+// fakeClock is a manually advanced clock for token buckets and order limiters
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(1700000000, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestRateLimitWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		window time.Duration
+		want   time.Duration
+	}{
+		{"default config", DefaultRateLimitWindow, 5 * time.Minute},
+		{"configured", 10 * time.Minute, 10 * time.Minute},
+		{"zero uses default", 0, DefaultRateLimitWindow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := NewRateLimiterWithWindow(true, 300, 100, tt.window, NewNoOpLogger())
+			if rl.Window() != tt.want || rl.ipBucket.refillInterval != tt.want || rl.uidBucket.refillInterval != tt.want {
+				t.Errorf("window = %v (ip %v, uid %v), want %v", rl.Window(), rl.ipBucket.refillInterval, rl.uidBucket.refillInterval, tt.want)
+			}
+
+			config := NewDefaultConfig().WithRateLimitWindow(tt.window)
+			config.Logger = NewNoOpLogger()
+			public, err := NewPublicClient(config)
+			if err != nil {
+				t.Fatalf("NewPublicClient() error = %v", err)
+			}
+			private, err := NewClient(config.WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase"))
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			if public.limit.Window() != tt.want || private.limit.Window() != tt.want {
+				t.Errorf("client windows = %v, %v, want %v", public.limit.Window(), private.limit.Window(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenBucketNoRefillBeforeWindow(t *testing.T) {
+	clock := newFakeClock()
+	bucket := NewTokenBucketWithClock(300, DefaultRateLimitWindow, clock.Now)
+	if !bucket.Take(300) {
+		t.Fatal("Take(300) on a full bucket failed")
+	}
+
+	// Elapsed: 5s, 1m, 4m59s
+	var elapsed time.Duration
+	for _, step := range []time.Duration{5 * time.Second, 55 * time.Second, 3*time.Minute + 59*time.Second} {
+		clock.Advance(step)
+		elapsed += step
+		if bucket.Take(300) {
+			t.Fatalf("bucket refilled after %v, want a full refill only after %v", elapsed, DefaultRateLimitWindow)
+		}
+	}
+
+	clock.Advance(time.Second)
+	if !bucket.Take(300) {
+		t.Errorf("bucket not refilled after %v", DefaultRateLimitWindow)
+	}
+}
+
+func TestTokenBucketProportionalRefill(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		take     int
+		advance  time.Duration
+		want     int
+	}{
+		{"no time passed", 5 * time.Minute, 300, 0, 0},
+		{"one fifth of the window", 5 * time.Minute, 300, time.Minute, 60},
+		{"half the window", 5 * time.Minute, 200, 150 * time.Second, 250},
+		{"capped at capacity", 5 * time.Minute, 300, 10 * time.Minute, 300},
+		{"fractional tokens accrue", 5 * time.Minute, 300, 500 * time.Millisecond, 0},
+		{"zero interval refills fully", 0, 300, time.Nanosecond, 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			bucket := NewTokenBucketWithClock(300, tt.interval, clock.Now)
+			if !bucket.Take(tt.take) {
+				t.Fatalf("Take(%d) on a full bucket failed", tt.take)
+			}
+			clock.Advance(tt.advance)
+			if got := bucket.Available(); got != tt.want {
+				t.Errorf("Available() = %d, want %d", got, tt.want)
+			}
+			if bucket.Take(tt.want + 1) {
+				t.Errorf("Take(%d) succeeded with %d available", tt.want+1, tt.want)
+			}
+		})
+	}
+
+	// Sub-token accruals add up across calls
+	clock := newFakeClock()
+	bucket := NewTokenBucketWithClock(300, 5*time.Minute, clock.Now)
+	bucket.Take(300)
+	for i := 0; i < 4; i++ {
+		clock.Advance(250 * time.Millisecond)
+		bucket.Available()
+	}
+	if got := bucket.Available(); got != 1 {
+		t.Errorf("Available() after 4 x 250ms = %d, want 1", got)
+	}
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	clock := newFakeClock()
+	bucket := NewTokenBucketWithClock(300, 5*time.Minute, clock.Now)
+	bucket.Take(300)
+
+	// Canceled while no tokens accrue
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	if err := bucket.Wait(ctx, 60); err != context.DeadlineExceeded {
+		t.Fatalf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// Satisfied once the clock moves far enough
+	done := make(chan error, 1)
+	go func() { done <- bucket.Wait(context.Background(), 60) }()
+	clock.Advance(59 * time.Second)
+	select {
+	case err := <-done:
+		t.Fatalf("Wait() returned %v before 60 tokens accrued", err)
+	case <-time.After(250 * time.Millisecond):
+	}
+	clock.Advance(time.Second)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait() did not return after 60 tokens accrued")
+	}
+	if got := bucket.Available(); got != 0 {
+		t.Errorf("Available() after Wait = %d, want 0", got)
+	}
+}
+
+func TestNewTokenBucketWithNilClock(t *testing.T) {
+	bucket := NewTokenBucketWithClock(10, time.Minute, nil)
+	if !bucket.Take(10) || bucket.Available() != 0 {
+		t.Error("bucket with a nil clock did not behave as a time.Now bucket")
+	}
+}
+
+func TestOrderRateLimiterPerMinute(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		batch int // Orders in the request that exceeds the limit
+	}{
+		{"single order", 5, 1},
+		{"batch", 5, 3},
+		{"batch larger than limit", 2, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			limiter := NewOrderRateLimiterWithClock(tt.limit, 0, clock.Now)
+
+			// Fill the window one order per second
+			for i := 0; i < tt.limit; i++ {
+				if err := limiter.WaitForOrders(context.Background(), 1); err != nil {
+					t.Fatalf("WaitForOrders() order %d error = %v", i+1, err)
+				}
+				clock.Advance(time.Second)
+			}
+			if got := limiter.Placed(); got != tt.limit {
+				t.Fatalf("Placed() = %d, want %d", got, tt.limit)
+			}
+
+			// The next order blocks until the oldest placements leave the window
+			done := make(chan error, 1)
+			go func() { done <- limiter.WaitForOrders(context.Background(), tt.batch) }()
+			select {
+			case err := <-done:
+				t.Fatalf("WaitForOrders() returned %v within the window", err)
+			case <-time.After(250 * time.Millisecond):
+			}
+
+			// Still inside the minute of the first placement
+			clock.Advance(time.Minute - time.Duration(tt.limit)*time.Second - time.Millisecond)
+			select {
+			case err := <-done:
+				t.Fatalf("WaitForOrders() returned %v before the window rolled", err)
+			case <-time.After(250 * time.Millisecond):
+			}
+
+			// Past the minute of every placement the order has to wait for
+			need := tt.batch
+			if need > tt.limit {
+				need = tt.limit
+			}
+			clock.Advance(time.Duration(need) * time.Second)
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("WaitForOrders() error = %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("WaitForOrders() did not return after the window rolled")
+			}
+		})
+	}
+}
+
+func TestOrderRateLimiterMinDelay(t *testing.T) {
+	clock := newFakeClock()
+	limiter := NewOrderRateLimiterWithClock(0, 200*time.Millisecond, clock.Now)
+	if err := limiter.WaitForOrders(context.Background(), 1); err != nil {
+		t.Fatalf("WaitForOrders() error = %v", err)
+	}
+
+	// Canceled while the clock stands still
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	if err := limiter.WaitForOrders(ctx, 1); err != context.DeadlineExceeded {
+		t.Fatalf("WaitForOrders() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	clock.Advance(200 * time.Millisecond)
+	if err := limiter.WaitForOrders(context.Background(), 1); err != nil {
+		t.Fatalf("WaitForOrders() after the delay error = %v", err)
+	}
+}
+
+func TestNewOrderRateLimiterWithNilClock(t *testing.T) {
+	limiter := NewOrderRateLimiterWithClock(10, 0, nil)
+	if err := limiter.WaitForOrders(context.Background(), 3); err != nil || limiter.Placed() != 3 {
+		t.Errorf("limiter with a nil clock did not behave as a time.Now limiter: err = %v, Placed() = %d", err, limiter.Placed())
+	}
+}
+
+func TestRateLimiterObserveOnly(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		observeOnly bool
+		want        ObservedUsage
+		wantWarns   int // Warnings after construction
+	}{
+		// 5 requests of IP weight 4 and UID weight 3 against budgets of 10 and 6:
+		// the 3rd, 4th and 5th exceed IP; the 3rd, 4th and 5th exceed UID
+		{"observe only", false, true, ObservedUsage{IPConsumed: 20, UIDConsumed: 15, OverBudgetIP: 3, OverBudgetUID: 3}, 6},
+		{"disabled without observing", false, false, ObservedUsage{}, 0},
+		{"enabled ignores observe only", true, true, ObservedUsage{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &warnCounter{}
+			rl := NewRateLimiter(tt.enabled, 10, 6, logger)
+			rl.SetObserveOnly(tt.observeOnly)
+			if !tt.enabled && logger.count() != 1 {
+				t.Errorf("construction warnings = %d, want 1 when rate limiting is disabled", logger.count())
+			}
+			constructed := logger.count()
+
+			start := time.Now()
+			for i := 0; i < 5; i++ {
+				if tt.enabled {
+					rl.TryAcquire(4, 3)
+					continue
+				}
+				if i%2 == 0 {
+					if err := rl.WaitForCapacity(context.Background(), 4, 3); err != nil {
+						t.Fatalf("WaitForCapacity() error = %v", err)
+					}
+				} else if !rl.TryAcquire(4, 3) {
+					t.Fatal("TryAcquire() = false while rate limiting is disabled")
+				}
+			}
+			if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+				t.Errorf("took %v, want no blocking", elapsed)
+			}
+
+			if got := rl.ObservedUsage(); got != tt.want {
+				t.Errorf("ObservedUsage() = %+v, want %+v", got, tt.want)
+			}
+			if got := logger.count() - constructed; got != tt.wantWarns {
+				t.Errorf("over-budget warnings = %d, want %d", got, tt.wantWarns)
+			}
+		})
+	}
+}
+
+func TestClientRateLimitObserveOnlyWired(t *testing.T) {
+	config := NewDefaultConfig()
+	config.EnableRateLimit = false
+	config.RateLimitObserveOnly = true
+	config.Logger = NewNoOpLogger()
+	client, err := NewPublicClient(config)
+	if err != nil {
+		t.Fatalf("NewPublicClient() error = %v", err)
+	}
+
+	limiter := client.GetRateLimiter()
+	if err := limiter.WaitForCapacity(context.Background(), 7, 2); err != nil {
+		t.Fatalf("WaitForCapacity() error = %v", err)
+	}
+	if got := limiter.ObservedUsage(); got.IPConsumed != 7 || got.UIDConsumed != 2 {
+		t.Errorf("ObservedUsage() = %+v, want 7 IP and 2 UID weight", got)
+	}
+}
+
+func TestRateLimiterSetEnabled(t *testing.T) {
+	// Each step sets the enabled flag and then requests 1 UID weight against
+	// an exhausted budget of 2
+	tests := []struct {
+		name      string
+		enabled   bool
+		toggles   []bool
+		wantPass  []bool // whether each step's request passes without waiting
+		wantWarns int    // warnings after construction
+	}{
+		{"pause and resume", true, []bool{false, true}, []bool{true, false}, 1},
+		{"resume from disabled", false, []bool{true, false}, []bool{false, true}, 1},
+		{"repeated setting is a no-op", true, []bool{true, true}, []bool{false, false}, 0},
+		{"pause twice warns once", true, []bool{false, false}, []bool{true, true}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &warnCounter{}
+			rl := NewRateLimiter(true, 100, 2, logger)
+			if !rl.TryAcquire(0, 2) {
+				t.Fatal("TryAcquire() = false on a fresh limiter")
+			}
+			rl.SetEnabled(tt.enabled)
+			constructed := logger.count()
+
+			for i, enabled := range tt.toggles {
+				rl.SetEnabled(enabled)
+				if rl.Enabled() != enabled {
+					t.Fatalf("step %d: Enabled() = %v, want %v", i, rl.Enabled(), enabled)
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+				err := rl.WaitForCapacity(ctx, 0, 1)
+				cancel()
+				if passed := err == nil; passed != tt.wantPass[i] {
+					t.Errorf("step %d: WaitForCapacity() error = %v, want pass %v", i, err, tt.wantPass[i])
+				}
+			}
+			if got := logger.count() - constructed; got != tt.wantWarns {
+				t.Errorf("warnings = %d, want %d", got, tt.wantWarns)
+			}
+		})
+	}
+}
+
+func TestRateLimiterSetEnabledMidFlight(t *testing.T) {
+	rl := NewRateLimiter(true, 100, 1, NewNoOpLogger())
+	if !rl.TryAcquire(0, 1) {
+		t.Fatal("TryAcquire() = false on a fresh limiter")
+	}
+
+	// A request already waiting keeps waiting after rate limiting is paused
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	waiting := make(chan error, 1)
+	go func() { waiting <- rl.WaitForCapacity(ctx, 0, 1) }()
+	time.Sleep(20 * time.Millisecond)
+
+	rl.SetEnabled(false)
+
+	// while new requests go straight through
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := rl.WaitForCapacity(context.Background(), 0, 1); err != nil {
+				t.Errorf("WaitForCapacity() while paused error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := <-waiting; err == nil {
+		t.Error("request waiting before the pause was let through")
+	}
+}
+
+func TestClientSetRateLimitEnabled(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Logger = NewNoOpLogger()
+	client, err := NewPublicClient(config)
+	if err != nil {
+		t.Fatalf("NewPublicClient() error = %v", err)
+	}
+
+	limiter := client.GetRateLimiter()
+	client.SetRateLimitEnabled(false)
+	if limiter.Enabled() {
+		t.Error("Enabled() = true after SetRateLimitEnabled(false)")
+	}
+	client.SetRateLimitEnabled(true)
+	if !limiter.Enabled() {
+		t.Error("Enabled() = false after SetRateLimitEnabled(true)")
+	}
+}
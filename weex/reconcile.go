@@ -0,0 +1,142 @@
+package weex
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// PositionKey identifies a position by symbol and side
+type PositionKey struct {
+	Symbol string
+	Side   types.PositionSide
+}
+
+// DesiredState is the state a strategy intends the account to be in
+type DesiredState struct {
+	Positions map[PositionKey]types.Decimal // Target size per position; positions not listed should be flat
+	Orders    map[string]bool               // Client order IDs the strategy expects to be resting
+}
+
+// PositionAdjustment is a position whose size differs from its target
+type PositionAdjustment struct {
+	Symbol  string
+	Side    types.PositionSide
+	Current types.Decimal // Size currently held
+	Target  types.Decimal // Desired size
+	Delta   types.Decimal // Target - Current (positive = open more, negative = close)
+}
+
+// ReconcilePlan lists the actions needed to bring the account to a DesiredState
+type ReconcilePlan struct {
+	Cancel []trade.Order        // Orphaned orders to cancel
+	Adjust []PositionAdjustment // Positions to resize, sorted by symbol and side
+}
+
+// InSync returns true if no action is needed
+func (p *ReconcilePlan) InSync() bool {
+	return len(p.Cancel) == 0 && len(p.Adjust) == 0
+}
+
+// Reconcile fetches open orders and positions and compares them against desired
+// The returned plan is not executed; see ComputeReconcilePlan for the rules.
+func (c *Client) Reconcile(ctx context.Context, desired DesiredState) (*ReconcilePlan, error) {
+	orders, err := c.Trade().GetOpenOrders(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch open orders: %w", err)
+	}
+	positions, err := c.Account().GetAllPositions(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch positions: %w", err)
+	}
+	return ComputeReconcilePlan(desired, orders, positions)
+}
+
+// ComputeReconcilePlan compares a snapshot of open orders and positions against desired
+//
+// An order is orphaned, and listed for cancellation, unless its client order ID
+// is in desired.Orders or it trades a position with a non-zero target in
+// desired.Positions. Every position whose held size differs from its target
+// (zero if not listed) is listed for adjustment.
+func ComputeReconcilePlan(desired DesiredState, orders []trade.Order, positions []account.Position) (*ReconcilePlan, error) {
+	held := make(map[PositionKey]types.Decimal)
+	for _, p := range positions {
+		side, err := types.ParsePositionSide(p.Side)
+		if err != nil {
+			return nil, fmt.Errorf("position %d: %w", p.ID, err)
+		}
+		key := PositionKey{Symbol: p.Symbol, Side: side}
+		size := held[key]
+		if size == "" {
+			size = "0"
+		}
+		if held[key], err = size.AddErr(types.Decimal(p.Size)); err != nil {
+			return nil, fmt.Errorf("invalid position size %q for %s %s: %w", p.Size, p.Symbol, side, err)
+		}
+	}
+
+	plan := &ReconcilePlan{}
+	for _, order := range orders {
+		if desired.Orders[order.ClientOid] {
+			continue
+		}
+		code, err := strconv.Atoi(order.Type)
+		if err != nil {
+			return nil, fmt.Errorf("order %s: invalid order type %q: %w", order.OrderId, order.Type, err)
+		}
+		side, err := types.OrderType(code).PositionSide()
+		if err != nil {
+			return nil, fmt.Errorf("order %s: %w", order.OrderId, err)
+		}
+		if target, ok := desired.Positions[PositionKey{Symbol: order.Symbol, Side: side}]; ok && !target.IsZero() {
+			continue
+		}
+		plan.Cancel = append(plan.Cancel, order)
+	}
+
+	keys := make(map[PositionKey]bool, len(held)+len(desired.Positions))
+	for key := range held {
+		keys[key] = true
+	}
+	for key := range desired.Positions {
+		keys[key] = true
+	}
+	for key := range keys {
+		current, ok := held[key]
+		if !ok {
+			current = "0"
+		}
+		target, ok := desired.Positions[key]
+		if !ok || target == "" {
+			target = "0"
+		}
+		delta, err := target.SubErr(current)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target size %q for %s %s: %w", target, key.Symbol, key.Side, err)
+		}
+		if delta.IsZero() {
+			continue
+		}
+		plan.Adjust = append(plan.Adjust, PositionAdjustment{
+			Symbol:  key.Symbol,
+			Side:    key.Side,
+			Current: current,
+			Target:  target,
+			Delta:   delta,
+		})
+	}
+	sort.Slice(plan.Adjust, func(i, j int) bool {
+		a, b := plan.Adjust[i], plan.Adjust[j]
+		if a.Symbol != b.Symbol {
+			return a.Symbol < b.Symbol
+		}
+		return a.Side < b.Side
+	})
+
+	return plan, nil
+}
@@ -0,0 +1,164 @@
+package weex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// testOpenOrders are resting orders for the reconciliation tests
+var testOpenOrders = []trade.Order{
+	{OrderId: "1", ClientOid: "keep", Symbol: "cmt_btcusdt", Type: "1"},  // Expected by client order ID
+	{OrderId: "2", ClientOid: "stray", Symbol: "cmt_btcusdt", Type: "2"}, // BTC short is to be flat
+	{OrderId: "3", ClientOid: "tp", Symbol: "cmt_ethusdt", Type: "3"},    // ETH long has a target
+	{OrderId: "4", ClientOid: "old", Symbol: "cmt_xrpusdt", Type: "1"},   // No XRP target
+	{OrderId: "5", ClientOid: "entry", Symbol: "cmt_solusdt", Type: "2"}, // SOL short has a target
+}
+
+func TestComputeReconcilePlan(t *testing.T) {
+	tests := []struct {
+		name       string
+		desired    DesiredState
+		orders     []trade.Order
+		positions  []account.Position
+		wantCancel []string // order IDs
+		wantAdjust []PositionAdjustment
+	}{
+		{"in sync", DesiredState{
+			Positions: map[PositionKey]types.Decimal{
+				{"cmt_btcusdt", types.PositionSideLong}:  "0.50",
+				{"cmt_btcusdt", types.PositionSideShort}: "0.2",
+				{"cmt_ethusdt", types.PositionSideLong}:  "3",
+			},
+		}, nil, testPositions, nil, nil},
+		{"drifted", DesiredState{
+			Positions: map[PositionKey]types.Decimal{
+				{"cmt_btcusdt", types.PositionSideLong}:  "0.5",
+				{"cmt_ethusdt", types.PositionSideLong}:  "5",
+				{"cmt_solusdt", types.PositionSideShort}: "1",
+			},
+			Orders: map[string]bool{"keep": true},
+		}, testOpenOrders, testPositions, []string{"2", "4"}, []PositionAdjustment{
+			{Symbol: "cmt_btcusdt", Side: types.PositionSideShort, Current: "0.2", Target: "0", Delta: "-0.2"},
+			{Symbol: "cmt_ethusdt", Side: types.PositionSideLong, Current: "3", Target: "5", Delta: "2"},
+			{Symbol: "cmt_solusdt", Side: types.PositionSideShort, Current: "0", Target: "1", Delta: "1"},
+		}},
+		{"flatten everything", DesiredState{}, testOpenOrders, testPositions, []string{"1", "2", "3", "4", "5"}, []PositionAdjustment{
+			{Symbol: "cmt_btcusdt", Side: types.PositionSideLong, Current: "0.5", Target: "0", Delta: "-0.5"},
+			{Symbol: "cmt_btcusdt", Side: types.PositionSideShort, Current: "0.2", Target: "0", Delta: "-0.2"},
+			{Symbol: "cmt_ethusdt", Side: types.PositionSideLong, Current: "3", Target: "0", Delta: "-3"},
+		}},
+		{"zero target does not protect orders", DesiredState{
+			Positions: map[PositionKey]types.Decimal{{"cmt_xrpusdt", types.PositionSideLong}: "0"},
+		}, testOpenOrders[3:4], nil, []string{"4"}, nil},
+		{"split positions summed", DesiredState{
+			Positions: map[PositionKey]types.Decimal{{"cmt_btcusdt", types.PositionSideLong}: "1"},
+		}, nil, []account.Position{
+			{Symbol: "cmt_btcusdt", Side: "LONG", Size: "0.25"},
+			{Symbol: "cmt_btcusdt", Side: "long", Size: "0.5"},
+		}, nil, []PositionAdjustment{
+			{Symbol: "cmt_btcusdt", Side: types.PositionSideLong, Current: "0.75", Target: "1", Delta: "0.25"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := ComputeReconcilePlan(tt.desired, tt.orders, tt.positions)
+			if err != nil {
+				t.Fatalf("ComputeReconcilePlan() error = %v", err)
+			}
+			var cancel []string
+			for _, order := range plan.Cancel {
+				cancel = append(cancel, order.OrderId)
+			}
+			if !reflect.DeepEqual(cancel, tt.wantCancel) {
+				t.Errorf("Cancel = %v, want %v", cancel, tt.wantCancel)
+			}
+			if len(plan.Adjust) != len(tt.wantAdjust) {
+				t.Fatalf("Adjust = %+v, want %+v", plan.Adjust, tt.wantAdjust)
+			}
+			for i, want := range tt.wantAdjust {
+				got := plan.Adjust[i]
+				if got.Symbol != want.Symbol || got.Side != want.Side ||
+					got.Current.Cmp(want.Current) != 0 || got.Target.Cmp(want.Target) != 0 || got.Delta.Cmp(want.Delta) != 0 {
+					t.Errorf("Adjust[%d] = %+v, want %+v", i, got, want)
+				}
+			}
+			if plan.InSync() != (tt.wantCancel == nil && tt.wantAdjust == nil) {
+				t.Errorf("InSync() = %v", plan.InSync())
+			}
+		})
+	}
+}
+
+func TestComputeReconcilePlanErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		desired   DesiredState
+		orders    []trade.Order
+		positions []account.Position
+	}{
+		{"unknown position side", DesiredState{}, nil, []account.Position{{Symbol: "cmt_btcusdt", Side: "BOTH", Size: "1"}}},
+		{"invalid position size", DesiredState{}, nil, []account.Position{{Symbol: "cmt_btcusdt", Side: "LONG", Size: "lots"}}},
+		{"invalid order type", DesiredState{}, []trade.Order{{OrderId: "1", Symbol: "cmt_btcusdt", Type: "open_long"}}, nil},
+		{"unknown order type", DesiredState{}, []trade.Order{{OrderId: "1", Symbol: "cmt_btcusdt", Type: "9"}}, nil},
+		{"invalid target", DesiredState{Positions: map[PositionKey]types.Decimal{{"cmt_btcusdt", types.PositionSideLong}: "half"}}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if plan, err := ComputeReconcilePlan(tt.desired, tt.orders, tt.positions); err == nil {
+				t.Errorf("ComputeReconcilePlan() = %+v, want error", plan)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := `[]`
+		switch strings.TrimPrefix(r.URL.Path, "/capi/v2") {
+		case "/account/position/allPosition":
+			positions, _ := json.Marshal(testPositions)
+			data = string(positions)
+		case "/order/current":
+			orders, _ := json.Marshal(testOpenOrders[:2])
+			data = string(orders)
+		}
+		w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":` + data + `}`))
+	}))
+	defer server.Close()
+
+	config := NewDefaultConfig().WithBaseURL(server.URL).
+		WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase")
+	config.MaxRetries = 0
+	config.Logger = NewNoOpLogger()
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	plan, err := client.Reconcile(context.Background(), DesiredState{
+		Positions: map[PositionKey]types.Decimal{
+			{"cmt_btcusdt", types.PositionSideLong}: "0.5",
+			{"cmt_ethusdt", types.PositionSideLong}: "3",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(plan.Cancel) != 1 || plan.Cancel[0].OrderId != "2" {
+		t.Errorf("Cancel = %+v, want order 2", plan.Cancel)
+	}
+	if len(plan.Adjust) != 1 || plan.Adjust[0].Side != types.PositionSideShort || plan.Adjust[0].Delta.Cmp("-0.2") != 0 {
+		t.Errorf("Adjust = %+v, want closing the 0.2 BTC short", plan.Adjust)
+	}
+}
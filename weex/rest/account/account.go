@@ -3,12 +3,19 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/weex-api/openapi-contract-go-sdk/weex/rest"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
 )
 
+// ErrInvalidCollateralAmount is returned when a margin adjustment amount is zero or malformed
+var ErrInvalidCollateralAmount = errors.New("invalid collateral amount")
+
 // Service provides access to account management API endpoints
 type Service struct {
 	client *rest.Client
@@ -74,6 +81,35 @@ func (s *Service) GetAllPositions(ctx context.Context, req *GetAllPositionsReque
 	return positions, err
 }
 
+// GetActiveSymbols returns the distinct symbols that have a non-zero position
+// Symbols are returned in sorted order, suitable for driving per-symbol queries
+func (s *Service) GetActiveSymbols(ctx context.Context) ([]string, error) {
+	positions, err := s.GetAllPositions(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ActiveSymbols(positions), nil
+}
+
+// ActiveSymbols returns the sorted distinct symbols of positions with a non-zero size
+func ActiveSymbols(positions []Position) []string {
+	seen := make(map[string]struct{})
+	for _, p := range positions {
+		size, err := types.Decimal(p.Size).Rat()
+		if err != nil || size.Sign() == 0 || p.Symbol == "" {
+			continue
+		}
+		seen[p.Symbol] = struct{}{}
+	}
+
+	symbols := make([]string, 0, len(seen))
+	for symbol := range seen {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
 // GetSinglePosition gets a single position
 // GET /account/position/singlePosition
 // Weight(IP): 2, Weight(UID): 3
@@ -138,9 +174,15 @@ func (s *Service) GetUserConfig(ctx context.Context, req *GetUserConfigRequest)
 func (s *Service) AdjustLeverage(ctx context.Context, req *AdjustLeverageRequest) error {
 	path := "/account/leverage"
 
+	unlock, err := s.client.LockSymbol(ctx, req.Symbol)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// API returns standard response (code, msg, requestTime), not data
 	var response rest.APIResponse
-	err := s.client.PostRaw(ctx, path, req, &response, 10, 20)
+	err = s.client.PostRaw(ctx, path, req, &response, 10, 20)
 	if err != nil {
 		return err
 	}
@@ -155,6 +197,13 @@ func (s *Service) AdjustLeverage(ctx context.Context, req *AdjustLeverageRequest
 func (s *Service) AdjustMargin(ctx context.Context, req *AdjustMarginRequest) error {
 	path := "/account/adjustMargin"
 
+	if req.IsolatedPositionId <= 0 {
+		return fmt.Errorf("isolatedPositionId must be greater than 0")
+	}
+	if _, err := req.Direction(); err != nil {
+		return err
+	}
+
 	// API returns standard response (code, msg, requestTime), not data
 	var response rest.APIResponse
 	err := s.client.PostRaw(ctx, path, req, &response, 15, 30)
@@ -164,6 +213,30 @@ func (s *Service) AdjustMargin(ctx context.Context, req *AdjustMarginRequest) er
 	return nil
 }
 
+// AddMargin adds margin to an isolated position
+// amount must be a positive decimal; it is sent as a positive collateral amount
+func (s *Service) AddMargin(ctx context.Context, positionId int64, amount types.Decimal) error {
+	if err := validateMarginMagnitude(amount); err != nil {
+		return err
+	}
+	return s.AdjustMargin(ctx, &AdjustMarginRequest{
+		IsolatedPositionId: positionId,
+		CollateralAmount:   string(amount),
+	})
+}
+
+// ReduceMargin removes margin from an isolated position
+// amount must be a positive decimal; it is sent as a negative collateral amount
+func (s *Service) ReduceMargin(ctx context.Context, positionId int64, amount types.Decimal) error {
+	if err := validateMarginMagnitude(amount); err != nil {
+		return err
+	}
+	return s.AdjustMargin(ctx, &AdjustMarginRequest{
+		IsolatedPositionId: positionId,
+		CollateralAmount:   "-" + strings.TrimPrefix(string(amount), "+"),
+	})
+}
+
 // AutoAddMargin enables/disables auto add margin for an isolated position
 // POST /account/autoAddMargin
 // Weight(IP): 10, Weight(UID): 5
@@ -190,9 +263,15 @@ func (s *Service) AutoAddMargin(ctx context.Context, req *AutoAddMarginRequest)
 func (s *Service) ModifyAccountMode(ctx context.Context, req *ModifyAccountModeRequest) error {
 	path := "/account/position/changeHoldModel"
 
+	unlock, err := s.client.LockSymbol(ctx, req.Symbol)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// API returns standard response (code, msg, requestTime), not data
 	var response rest.APIResponse
-	err := s.client.PostRaw(ctx, path, req, &response, 20, 50)
+	err = s.client.PostRaw(ctx, path, req, &response, 20, 50)
 	if err != nil {
 		return err
 	}
@@ -204,7 +283,7 @@ func (s *Service) ModifyAccountMode(ctx context.Context, req *ModifyAccountModeR
 // ValidateCoinId checks if a coin ID is valid
 func ValidateCoinId(coinId int) error {
 	if coinId <= 0 {
-		return fmt.Errorf("coinId must be greater than 0")
+		return types.NewValidationError("coinId", "coinId must be greater than 0")
 	}
 	return nil
 }
@@ -212,7 +291,33 @@ func ValidateCoinId(coinId int) error {
 // ValidatePositionSide checks if a position side is valid
 func ValidatePositionSide(side string) error {
 	if side != "LONG" && side != "SHORT" {
-		return fmt.Errorf("positionSide must be LONG or SHORT")
+		return types.NewValidationError("positionSide", "positionSide must be LONG or SHORT")
+	}
+	return nil
+}
+
+// ValidateCollateralAmount checks if a collateral amount is a valid non-zero signed decimal
+func ValidateCollateralAmount(amount string) error {
+	if strings.TrimSpace(amount) != amount || amount == "" {
+		return types.NewValidationError("amount", "%w: %q", ErrInvalidCollateralAmount, amount)
+	}
+	r, err := types.Decimal(amount).Rat()
+	if err != nil {
+		return types.NewValidationError("amount", "%w: %q is not a number", ErrInvalidCollateralAmount, amount)
+	}
+	if r.Sign() == 0 {
+		return types.NewValidationError("amount", "%w: amount cannot be zero", ErrInvalidCollateralAmount)
+	}
+	return nil
+}
+
+// validateMarginMagnitude checks that an AddMargin/ReduceMargin amount is a positive decimal
+func validateMarginMagnitude(amount types.Decimal) error {
+	if err := ValidateCollateralAmount(string(amount)); err != nil {
+		return err
+	}
+	if strings.HasPrefix(string(amount), "-") {
+		return types.NewValidationError("amount", "%w: amount must be positive, sign is set by the helper", ErrInvalidCollateralAmount)
 	}
 	return nil
 }
@@ -220,7 +325,7 @@ func ValidatePositionSide(side string) error {
 // ValidateMarginMode checks if a margin mode is valid
 func ValidateMarginMode(mode int) error {
 	if mode != 1 && mode != 3 {
-		return fmt.Errorf("marginMode must be 1 (SHARED) or 3 (ISOLATED)")
+		return types.NewValidationError("marginMode", "marginMode must be 1 (SHARED) or 3 (ISOLATED)")
 	}
 	return nil
 }
@@ -0,0 +1,105 @@
+package account_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestActiveSymbols(t *testing.T) {
+	tests := []struct {
+		name      string
+		positions []account.Position
+		want      []string
+	}{
+		{"none", nil, []string{}},
+		{"distinct sorted", []account.Position{
+			{Symbol: "cmt_ethusdt", Side: "LONG", Size: "2"},
+			{Symbol: "cmt_btcusdt", Side: "LONG", Size: "0.5"},
+			{Symbol: "cmt_btcusdt", Side: "SHORT", Size: "0.1"},
+		}, []string{"cmt_btcusdt", "cmt_ethusdt"}},
+		{"zero sizes skipped", []account.Position{
+			{Symbol: "cmt_btcusdt", Size: "0"},
+			{Symbol: "cmt_ethusdt", Size: "0.000"},
+			{Symbol: "cmt_solusdt", Size: "0.0000000000000000000001"},
+		}, []string{"cmt_solusdt"}},
+		{"malformed and empty skipped", []account.Position{
+			{Symbol: "cmt_btcusdt", Size: "abc"},
+			{Symbol: "", Size: "1"},
+			{Symbol: "cmt_xrpusdt", Size: ""},
+		}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := account.ActiveSymbols(tt.positions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActiveSymbols() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetActiveSymbols(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/account/position/allPosition": `[
+			{"symbol":"cmt_btcusdt","side":"LONG","size":"0.5"},
+			{"symbol":"cmt_ethusdt","side":"SHORT","size":"0"},
+			{"symbol":"cmt_btcusdt","side":"SHORT","size":"0.2"},
+			{"symbol":"cmt_dogeusdt","side":"LONG","size":"100"}
+		]`,
+	})
+	client := newTestClient(t, server)
+
+	got, err := client.Account().GetActiveSymbols(context.Background())
+	if err != nil {
+		t.Fatalf("GetActiveSymbols() error = %v", err)
+	}
+	if want := []string{"cmt_btcusdt", "cmt_dogeusdt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetActiveSymbols() = %v, want %v", got, want)
+	}
+	if requests := server.Requests(); len(requests) != 1 || requests[0].Path != "/account/position/allPosition" {
+		t.Errorf("requests = %+v, want one GET /account/position/allPosition", requests)
+	}
+}
+
+func TestValidatorFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		field string // empty when the value is valid
+	}{
+		{"coin id valid", account.ValidateCoinId(2), ""},
+		{"coin id zero", account.ValidateCoinId(0), "coinId"},
+		{"position side valid", account.ValidatePositionSide("SHORT"), ""},
+		{"position side invalid", account.ValidatePositionSide("long"), "positionSide"},
+		{"margin mode valid", account.ValidateMarginMode(3), ""},
+		{"margin mode invalid", account.ValidateMarginMode(2), "marginMode"},
+		{"collateral valid", account.ValidateCollateralAmount("-1.5"), ""},
+		{"collateral empty", account.ValidateCollateralAmount(""), "amount"},
+		{"collateral not a number", account.ValidateCollateralAmount("abc"), "amount"},
+		{"collateral zero", account.ValidateCollateralAmount("0.00"), "amount"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.field == "" {
+				if tt.err != nil {
+					t.Errorf("err = %v, want nil", tt.err)
+				}
+				return
+			}
+			var v *types.ValidationError
+			if !errors.As(tt.err, &v) {
+				t.Fatalf("errors.As(%v) = false, want a ValidationError", tt.err)
+			}
+			if v.Field != tt.field {
+				t.Errorf("Field = %q, want %q", v.Field, tt.field)
+			}
+			if tt.field == "amount" && !errors.Is(tt.err, account.ErrInvalidCollateralAmount) {
+				t.Errorf("errors.Is(%v, ErrInvalidCollateralAmount) = false", tt.err)
+			}
+		})
+	}
+}
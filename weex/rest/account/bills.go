@@ -0,0 +1,87 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxBillPages caps the number of GetBills requests made by IterateBills
+const DefaultMaxBillPages = 100
+
+var (
+	// ErrStopIteration can be returned by an IterateBills callback to stop early without an error
+	ErrStopIteration = errors.New("stop iteration")
+
+	// ErrBillPageLimit is returned when IterateBills reaches DefaultMaxBillPages with more pages left
+	ErrBillPageLimit = errors.New("bill page limit reached")
+
+	// ErrBillPageStalled is returned when IterateBills gets a full page of bills at a single timestamp
+	// The bills endpoint pages by time window only, so the rest of that timestamp cannot be fetched.
+	ErrBillPageStalled = errors.New("bill page cannot advance past a single timestamp")
+)
+
+// IterateBills calls fn for every bill matching req, newest first, following HasNextPage
+//
+// Pages are advanced with GetBillsRequest.NextPage; bills repeated at a page
+// boundary are skipped by BillId. Iteration stops when there are no more
+// pages, when fn returns an error (ErrStopIteration stops without error), when
+// ctx is done, after DefaultMaxBillPages requests (ErrBillPageLimit), or when
+// a whole page is at one timestamp and paging cannot advance (ErrBillPageStalled).
+//
+// Example:
+//
+//	err := svc.IterateBills(ctx, &account.GetBillsRequest{Coin: "USDT", Limit: 100}, func(bill account.Bill) error {
+//	    fmt.Println(bill.BillId, bill.Amount)
+//	    return nil
+//	})
+func (s *Service) IterateBills(ctx context.Context, req *GetBillsRequest, fn func(Bill) error) error {
+	current := &GetBillsRequest{}
+	if req != nil {
+		*current = *req
+	}
+
+	var boundary map[int64]bool
+	for page := 0; ; page++ {
+		if page >= DefaultMaxBillPages {
+			return fmt.Errorf("%w: %d pages", ErrBillPageLimit, page)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		resp, err := s.GetBills(ctx, current)
+		if err != nil {
+			return fmt.Errorf("failed to get bills page %d: %w", page+1, err)
+		}
+
+		for _, bill := range resp.Items {
+			if boundary[bill.BillId] {
+				continue
+			}
+			if err := fn(bill); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if current.PageStalled(resp) {
+			return fmt.Errorf("%w: %d bills at %d, try a larger Limit", ErrBillPageStalled, len(resp.Items), current.EndTime)
+		}
+		next := current.NextPage(resp)
+		if next == nil {
+			return nil
+		}
+
+		// Bills at the new EndTime are returned again by the next page
+		boundary = make(map[int64]bool)
+		for _, bill := range resp.Items {
+			if bill.CTime.Int64() == next.EndTime {
+				boundary[bill.BillId] = true
+			}
+		}
+		current = next
+	}
+}
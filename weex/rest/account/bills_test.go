@@ -0,0 +1,393 @@
+package account_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestGetBillsSendsRequestFields(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *account.GetBillsRequest
+		want map[string]interface{}
+	}{
+		{
+			"fully populated",
+			&account.GetBillsRequest{
+				Coin:         "USDT",
+				Symbol:       "cmt_btcusdt",
+				BusinessType: "open_long",
+				StartTime:    1716000000000,
+				EndTime:      1716604853286,
+				Limit:        50,
+			},
+			map[string]interface{}{
+				"coin":         "USDT",
+				"symbol":       "cmt_btcusdt",
+				"businessType": "open_long",
+				"startTime":    float64(1716000000000),
+				"endTime":      float64(1716604853286),
+				"limit":        float64(50),
+			},
+		},
+		{
+			"next page only moves endTime",
+			(&account.GetBillsRequest{Coin: "USDT", Limit: 2}).NextPage(&account.BillsResponse{
+				HasNextPage: true, Items: []account.Bill{{BillId: 2, CTime: 4000}, {BillId: 1, CTime: 3000}},
+			}),
+			map[string]interface{}{"coin": "USDT", "endTime": float64(3000), "limit": float64(2)},
+		},
+		{"empty", &account.GetBillsRequest{}, map[string]interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, map[string]string{"/account/bills": `{"hasNextPage":false,"items":[]}`})
+			client := newTestClient(t, server)
+
+			if _, err := client.Account().GetBills(context.Background(), tt.req); err != nil {
+				t.Fatalf("GetBills() error = %v", err)
+			}
+			requests := server.Requests()
+			if len(requests) != 1 {
+				t.Fatalf("requests = %d, want 1", len(requests))
+			}
+			got := requests[0]
+			if got.Method != http.MethodPost || got.Path != "/account/bills" {
+				t.Errorf("request = %s %s, want POST /account/bills", got.Method, got.Path)
+			}
+			if !reflect.DeepEqual(got.Body, tt.want) {
+				t.Errorf("body = %v, want %v", got.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetBillsRequestNextPage(t *testing.T) {
+	req := &account.GetBillsRequest{Coin: "USDT", Symbol: "cmt_btcusdt", BusinessType: "open_long", StartTime: 1000, EndTime: 5000, Limit: 2}
+	tests := []struct {
+		name string
+		resp *account.BillsResponse
+		want *account.GetBillsRequest
+	}{
+		{"last page", &account.BillsResponse{HasNextPage: false, Items: []account.Bill{{CTime: 3000}}}, nil},
+		{"next page", &account.BillsResponse{HasNextPage: true, Items: []account.Bill{{CTime: 4000}, {CTime: 3000}}},
+			&account.GetBillsRequest{Coin: "USDT", Symbol: "cmt_btcusdt", BusinessType: "open_long", StartTime: 1000, EndTime: 3000, Limit: 2}},
+		{"page at end time", &account.BillsResponse{HasNextPage: true, Items: []account.Bill{{CTime: 5000}, {CTime: 5000}}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := req.NextPage(tt.resp); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NextPage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetBillsRequestPageStalled(t *testing.T) {
+	atEnd := &account.BillsResponse{HasNextPage: true, Items: []account.Bill{{CTime: 5000}, {CTime: 5000}}}
+	tests := []struct {
+		name string
+		req  *account.GetBillsRequest
+		resp *account.BillsResponse
+		want bool
+	}{
+		{"page at end time", &account.GetBillsRequest{EndTime: 5000}, atEnd, true},
+		{"last page at end time", &account.GetBillsRequest{EndTime: 5000}, &account.BillsResponse{Items: atEnd.Items}, false},
+		{"no end time", &account.GetBillsRequest{}, atEnd, false},
+		{"older bill in page", &account.GetBillsRequest{EndTime: 5000},
+			&account.BillsResponse{HasNextPage: true, Items: []account.Bill{{CTime: 5000}, {CTime: 4000}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.PageStalled(tt.resp); got != tt.want {
+				t.Errorf("PageStalled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBillsResponseOldestTime(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []account.Bill
+		want  types.Millis
+	}{
+		{"empty", nil, 0},
+		{"newest first", []account.Bill{{CTime: 4000}, {CTime: 3000}}, 3000},
+		{"unordered", []account.Bill{{CTime: 3000}, {CTime: 5000}, {CTime: 2000}}, 2000},
+		{"missing times ignored", []account.Bill{{CTime: 0}, {CTime: 4000}, {CTime: 0}}, 4000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &account.BillsResponse{Items: tt.items}
+			if got := resp.OldestTime(); got != tt.want {
+				t.Errorf("OldestTime() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetBillsTwoPages(t *testing.T) {
+	// Pages keyed by the endTime of the request that returns them
+	pages := map[float64]string{
+		0:    `{"hasNextPage":true,"items":[{"billId":3,"cTime":5000},{"billId":2,"cTime":4000}]}`,
+		4000: `{"hasNextPage":false,"items":[{"billId":2,"cTime":4000},{"billId":1,"cTime":3000}]}`,
+	}
+	var endTimes []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		endTime, _ := body["endTime"].(float64)
+		endTimes = append(endTimes, endTime)
+		w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":` + pages[endTime] + `}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, &testServer{Server: server})
+	req := &account.GetBillsRequest{Symbol: "cmt_btcusdt", Limit: 2}
+
+	var ids []int64
+	for req != nil {
+		resp, err := client.Account().GetBills(context.Background(), req)
+		if err != nil {
+			t.Fatalf("GetBills() error = %v", err)
+		}
+		for _, bill := range resp.Items {
+			ids = append(ids, bill.BillId)
+		}
+		req = req.NextPage(resp)
+	}
+
+	if want := []float64{0, 4000}; !reflect.DeepEqual(endTimes, want) {
+		t.Errorf("endTimes = %v, want %v", endTimes, want)
+	}
+	// The bill at the page boundary is returned by both pages
+	if want := []int64{3, 2, 2, 1}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("bill IDs = %v, want %v", ids, want)
+	}
+}
+
+// billsServer serves bills pages keyed by the endTime of the request and
+// returns a client for it with rate limiting disabled, plus the endTimes requested.
+// Requests for an endTime without a page get an empty last page.
+func billsServer(t *testing.T, pages map[float64]string) (*weex.Client, func() []float64) {
+	t.Helper()
+	var mu sync.Mutex
+	var endTimes []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		endTime, _ := body["endTime"].(float64)
+		mu.Lock()
+		endTimes = append(endTimes, endTime)
+		mu.Unlock()
+		page, ok := pages[endTime]
+		if !ok {
+			page = `{"hasNextPage":false,"items":[]}`
+		}
+		w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":` + page + `}`))
+	}))
+	t.Cleanup(server.Close)
+
+	config := weex.NewDefaultConfig().WithBaseURL(server.URL).
+		WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase")
+	config.MaxRetries = 0
+	config.EnableRateLimit = false
+	config.Logger = weex.NewNoOpLogger()
+	client, err := weex.NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, func() []float64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]float64(nil), endTimes...)
+	}
+}
+
+func TestIterateBills(t *testing.T) {
+	twoPages := map[float64]string{
+		0:    `{"hasNextPage":true,"items":[{"billId":4,"cTime":5000},{"billId":3,"cTime":4000},{"billId":2,"cTime":4000}]}`,
+		4000: `{"hasNextPage":false,"items":[{"billId":3,"cTime":4000},{"billId":2,"cTime":4000},{"billId":1,"cTime":3000}]}`,
+	}
+	errCallback := errors.New("callback failed")
+
+	tests := []struct {
+		name         string
+		pages        map[float64]string
+		stopAt       int64 // Bill ID at which the callback stops
+		stopErr      error
+		wantIDs      []int64
+		wantEndTimes []float64
+		wantErr      error
+	}{
+		{"two pages with boundary bills skipped", twoPages, 0, nil, []int64{4, 3, 2, 1}, []float64{0, 4000}, nil},
+		{"single page", map[float64]string{0: `{"hasNextPage":false,"items":[{"billId":1,"cTime":3000}]}`}, 0, nil, []int64{1}, []float64{0}, nil},
+		{"stopped early", twoPages, 3, account.ErrStopIteration, []int64{4, 3}, []float64{0}, nil},
+		{"callback error", twoPages, 1, errCallback, []int64{4, 3, 2, 1}, []float64{0, 4000}, errCallback},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, endTimes := billsServer(t, tt.pages)
+
+			var ids []int64
+			err := client.Account().IterateBills(context.Background(), &account.GetBillsRequest{Limit: 3}, func(bill account.Bill) error {
+				ids = append(ids, bill.BillId)
+				if bill.BillId == tt.stopAt {
+					return tt.stopErr
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("IterateBills() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("bill IDs = %v, want %v", ids, tt.wantIDs)
+			}
+			if got := endTimes(); !reflect.DeepEqual(got, tt.wantEndTimes) {
+				t.Errorf("endTimes = %v, want %v", got, tt.wantEndTimes)
+			}
+		})
+	}
+}
+
+// ledgerServer serves bills from a newest-first ledger, applying endTime and
+// limit the way the bills endpoint does; offset is ignored. It returns a client
+// for it with rate limiting disabled and a function reporting the request count.
+func ledgerServer(t *testing.T, ledger []account.Bill) (*weex.Client, func() int) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req account.GetBillsRequest
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &req)
+		mu.Lock()
+		requests++
+		mu.Unlock()
+
+		var window []account.Bill
+		for _, bill := range ledger {
+			if req.EndTime == 0 || bill.CTime.Int64() <= req.EndTime {
+				window = append(window, bill)
+			}
+		}
+		end := req.Limit
+		if end > len(window) {
+			end = len(window)
+		}
+		page, _ := json.Marshal(account.BillsResponse{HasNextPage: end < len(window), Items: window[:end]})
+		w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":` + string(page) + `}`))
+	}))
+	t.Cleanup(server.Close)
+
+	config := weex.NewDefaultConfig().WithBaseURL(server.URL).
+		WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase")
+	config.MaxRetries = 0
+	config.EnableRateLimit = false
+	config.Logger = weex.NewNoOpLogger()
+	client, err := weex.NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestIterateBillsSingleTimestamp(t *testing.T) {
+	tests := []struct {
+		name         string
+		ledger       []account.Bill
+		limit        int
+		wantIDs      []int64
+		wantRequests int
+		wantErr      error
+	}{
+		{"more than a page at one timestamp", []account.Bill{
+			{BillId: 5, CTime: 5000}, {BillId: 4, CTime: 5000}, {BillId: 3, CTime: 5000}, {BillId: 2, CTime: 4000}, {BillId: 1, CTime: 3000},
+		}, 2, []int64{5, 4}, 2, account.ErrBillPageStalled},
+		{"timestamp spanning pages after older ones", []account.Bill{
+			{BillId: 5, CTime: 6000}, {BillId: 4, CTime: 5000}, {BillId: 3, CTime: 5000}, {BillId: 2, CTime: 5000}, {BillId: 1, CTime: 4000},
+		}, 2, []int64{5, 4, 3}, 2, account.ErrBillPageStalled},
+		{"timestamp fits in a page", []account.Bill{
+			{BillId: 4, CTime: 5000}, {BillId: 3, CTime: 5000}, {BillId: 2, CTime: 4000}, {BillId: 1, CTime: 3000},
+		}, 3, []int64{4, 3, 2, 1}, 2, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := ledgerServer(t, tt.ledger)
+
+			var ids []int64
+			err := client.Account().IterateBills(context.Background(), &account.GetBillsRequest{Limit: tt.limit}, func(bill account.Bill) error {
+				ids = append(ids, bill.BillId)
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("IterateBills() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("bill IDs = %v, want %v", ids, tt.wantIDs)
+			}
+			if got := requests(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestIterateBillsPageLimit(t *testing.T) {
+	// Every page claims another follows
+	pages := make(map[float64]string)
+	for i := 0; i <= account.DefaultMaxBillPages+1; i++ {
+		end := float64(1_000_000 - i*1000)
+		if i == 0 {
+			end = 0
+		}
+		pages[end] = fmt.Sprintf(`{"hasNextPage":true,"items":[{"billId":%d,"cTime":%d}]}`, i+1, 1_000_000-(i+1)*1000)
+	}
+	client, endTimes := billsServer(t, pages)
+
+	err := client.Account().IterateBills(context.Background(), nil, func(account.Bill) error { return nil })
+	if !errors.Is(err, account.ErrBillPageLimit) {
+		t.Fatalf("IterateBills() error = %v, want ErrBillPageLimit", err)
+	}
+	if got := len(endTimes()); got != account.DefaultMaxBillPages {
+		t.Errorf("requests = %d, want %d", got, account.DefaultMaxBillPages)
+	}
+}
+
+func TestIterateBillsContextCancelled(t *testing.T) {
+	client, endTimes := billsServer(t, map[float64]string{
+		0:    `{"hasNextPage":true,"items":[{"billId":2,"cTime":4000}]}`,
+		4000: `{"hasNextPage":false,"items":[{"billId":1,"cTime":3000}]}`,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := client.Account().IterateBills(ctx, nil, func(account.Bill) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("IterateBills() error = %v, want context.Canceled", err)
+	}
+	if got := endTimes(); len(got) != 1 {
+		t.Errorf("requests = %v, want only the first page", got)
+	}
+}
@@ -0,0 +1,133 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// EquityPoint is one sample of account equity
+type EquityPoint struct {
+	Time   time.Time                // Time the sample was taken
+	Equity types.Decimal            // Sum of equity across the sampled coins
+	ByCoin map[string]types.Decimal // Equity per coin
+}
+
+// EquitySampler periodically samples account equity, e.g. to chart an equity curve
+//
+// Equity is summed across coins as reported by GetAccountBalance. Coins are
+// added at face value, so restrict the sampler to coins of the same unit with
+// SetCoins if the account holds several margin currencies.
+type EquitySampler struct {
+	service  *Service
+	interval time.Duration
+
+	mu      sync.Mutex
+	coins   map[string]bool // nil = all coins
+	onError func(error)
+}
+
+// NewEquitySampler creates a new EquitySampler polling every interval
+func NewEquitySampler(service *Service, interval time.Duration) *EquitySampler {
+	return &EquitySampler{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// SetCoins restricts sampling to the given coins (none = all coins)
+func (s *EquitySampler) SetCoins(coins ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(coins) == 0 {
+		s.coins = nil
+		return
+	}
+	s.coins = make(map[string]bool, len(coins))
+	for _, coin := range coins {
+		s.coins[coin] = true
+	}
+}
+
+// SetOnError sets a callback for failed samples after the first
+// Failed samples are skipped and the sampler keeps running.
+func (s *EquitySampler) SetOnError(callback func(error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onError = callback
+}
+
+// Run samples equity immediately and then every interval until ctx is done
+// Only an error on the first sample is returned; later failures are skipped
+// and reported through the SetOnError callback.
+func (s *EquitySampler) Run(ctx context.Context, handler func(EquityPoint)) error {
+	if err := s.poll(ctx, handler); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := s.poll(ctx, handler); err != nil && ctx.Err() == nil {
+				s.mu.Lock()
+				onError := s.onError
+				s.mu.Unlock()
+				if onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}
+}
+
+// poll fetches balances and dispatches the resulting point
+func (s *EquitySampler) poll(ctx context.Context, handler func(EquityPoint)) error {
+	balances, err := s.service.GetAccountBalance(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get account balance: %w", err)
+	}
+	point, err := s.Sample(balances, time.Now())
+	if err != nil {
+		return err
+	}
+	handler(point)
+	return nil
+}
+
+// Sample builds an EquityPoint from balances taken at now
+func (s *EquitySampler) Sample(balances []AssetBalance, now time.Time) (EquityPoint, error) {
+	s.mu.Lock()
+	coins := s.coins
+	s.mu.Unlock()
+
+	point := EquityPoint{
+		Time:   now,
+		Equity: "0",
+		ByCoin: make(map[string]types.Decimal),
+	}
+	for _, balance := range balances {
+		if coins != nil && !coins[balance.CoinName] {
+			continue
+		}
+		equity := types.Decimal(balance.Equity)
+		total, err := point.Equity.AddErr(equity)
+		if err != nil {
+			return EquityPoint{}, fmt.Errorf("invalid equity %q for %s: %w", balance.Equity, balance.CoinName, err)
+		}
+		point.Equity = total
+		if current, ok := point.ByCoin[balance.CoinName]; ok {
+			equity, _ = current.AddErr(equity)
+		}
+		point.ByCoin[balance.CoinName] = equity
+	}
+	return point, nil
+}
@@ -0,0 +1,167 @@
+package account_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// balanceServer replies to /account/assets with each of samples in turn, then
+// repeats the last one; an empty sample is answered with an API error.
+func balanceServer(t *testing.T, samples []string) *weex.Client {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(calls.Add(1)) - 1
+		if i >= len(samples) {
+			i = len(samples) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if samples[i] == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"40001","msg":"temporary failure","requestTime":1700000000000,"data":null}`))
+			return
+		}
+		w.Write([]byte(`{"code":"0","msg":"success","requestTime":1700000000000,"data":` + samples[i] + `}`))
+	}))
+	t.Cleanup(server.Close)
+
+	config := weex.NewDefaultConfig().
+		WithBaseURL(server.URL).
+		WithAPIKey("test-api-key").
+		WithSecretKey("test-secret-key").
+		WithPassphrase("test-passphrase")
+	config.MaxRetries = 0
+	config.EnableRateLimit = false
+	config.Logger = weex.NewNoOpLogger()
+	client, err := weex.NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestEquitySamplerSample(t *testing.T) {
+	balances := []account.AssetBalance{
+		{CoinName: "USDT", Equity: "100.1"},
+		{CoinName: "USDC", Equity: "50.2"},
+		{CoinName: "USDT", Equity: "0.2"},
+	}
+	now := time.UnixMilli(1700000000000)
+
+	tests := []struct {
+		name     string
+		coins    []string
+		balances []account.AssetBalance
+		expected types.Decimal
+		byCoin   map[string]types.Decimal
+		wantErr  bool
+	}{
+		{"all coins", nil, balances, "150.5", map[string]types.Decimal{"USDT": "100.3", "USDC": "50.2"}, false},
+		{"restricted", []string{"USDT"}, balances, "100.3", map[string]types.Decimal{"USDT": "100.3"}, false},
+		{"no balances", nil, nil, "0", map[string]types.Decimal{}, false},
+		{"invalid equity", nil, []account.AssetBalance{{CoinName: "USDT", Equity: "abc"}}, "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler := account.NewEquitySampler(nil, time.Second)
+			sampler.SetCoins(tt.coins...)
+			point, err := sampler.Sample(tt.balances, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sample() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !point.Time.Equal(now) {
+				t.Errorf("Time = %v, want %v", point.Time, now)
+			}
+			if point.Equity.Cmp(tt.expected) != 0 {
+				t.Errorf("Equity = %s, want %s", point.Equity, tt.expected)
+			}
+			if len(point.ByCoin) != len(tt.byCoin) {
+				t.Fatalf("ByCoin = %v, want %v", point.ByCoin, tt.byCoin)
+			}
+			for coin, want := range tt.byCoin {
+				if point.ByCoin[coin].Cmp(want) != 0 {
+					t.Errorf("ByCoin[%s] = %s, want %s", coin, point.ByCoin[coin], want)
+				}
+			}
+		})
+	}
+}
+
+func TestEquitySamplerRun(t *testing.T) {
+	client := balanceServer(t, []string{
+		`[{"coinName":"USDT","equity":"1000"}]`,
+		`[{"coinName":"USDT","equity":"1000.5"},{"coinName":"BTC","equity":"0.1"}]`,
+		"",
+		`[{"coinName":"USDT","equity":"999.25"}]`,
+	})
+	sampler := account.NewEquitySampler(client.Account(), 10*time.Millisecond)
+	sampler.SetCoins("USDT")
+
+	var mu sync.Mutex
+	var errs []error
+	sampler.SetOnError(func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var points []account.EquityPoint
+	err := sampler.Run(ctx, func(point account.EquityPoint) {
+		points = append(points, point)
+		if len(points) == 3 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want context.Canceled", err)
+	}
+
+	expected := []types.Decimal{"1000", "1000.5", "999.25"}
+	if len(points) != len(expected) {
+		t.Fatalf("got %d points, want %d", len(points), len(expected))
+	}
+	for i, want := range expected {
+		if points[i].Equity.Cmp(want) != 0 {
+			t.Errorf("point %d equity = %s, want %s", i, points[i].Equity, want)
+		}
+		if i > 0 && points[i].Time.Before(points[i-1].Time) {
+			t.Errorf("point %d time %v before previous %v", i, points[i].Time, points[i-1].Time)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) != 1 {
+		t.Errorf("got %d sample errors, want 1: %v", len(errs), errs)
+	}
+}
+
+func TestEquitySamplerRunFirstSampleFails(t *testing.T) {
+	client := balanceServer(t, []string{""})
+	sampler := account.NewEquitySampler(client.Account(), 10*time.Millisecond)
+
+	called := false
+	err := sampler.Run(context.Background(), func(account.EquityPoint) { called = true })
+	if err == nil {
+		t.Fatal("Run() expected error when the first sample fails")
+	}
+	if called {
+		t.Error("handler called despite failed first sample")
+	}
+}
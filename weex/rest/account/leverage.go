@@ -0,0 +1,43 @@
+package account
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// Normalize validates the leverages against contract and rewrites them in canonical form
+//
+// Both leverages must be positive and within the contract's MinLeverage and
+// MaxLeverage, and must be equal in cross margin mode. contract may be nil, in
+// which case only the format is checked. Call it before AdjustLeverage to
+// catch values the exchange would reject (code 50007).
+func (r *AdjustLeverageRequest) Normalize(contract *market.ContractInfo) error {
+	if contract == nil {
+		contract = &market.ContractInfo{Symbol: r.Symbol}
+	} else if contract.Symbol != "" && r.Symbol != contract.Symbol {
+		return fmt.Errorf("symbol %s does not match contract %s", r.Symbol, contract.Symbol)
+	}
+
+	long, longErr := contract.NormalizeLeverage(types.Decimal(r.LongLeverage))
+	if longErr != nil {
+		longErr = fmt.Errorf("longLeverage: %w", longErr)
+	}
+	short, shortErr := contract.NormalizeLeverage(types.Decimal(r.ShortLeverage))
+	if shortErr != nil {
+		shortErr = fmt.Errorf("shortLeverage: %w", shortErr)
+	}
+	if err := errors.Join(longErr, shortErr); err != nil {
+		return err
+	}
+
+	if r.MarginMode == int(types.MarginModeShared) && long != short {
+		return fmt.Errorf("longLeverage %s and shortLeverage %s must be equal in cross margin mode", long, short)
+	}
+
+	r.LongLeverage = string(long)
+	r.ShortLeverage = string(short)
+	return nil
+}
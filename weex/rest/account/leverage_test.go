@@ -0,0 +1,50 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestAdjustLeverageRequestNormalize(t *testing.T) {
+	contract := &market.ContractInfo{Symbol: "cmt_btcusdt", MinLeverage: 1, MaxLeverage: 125}
+
+	tests := []struct {
+		name      string
+		req       account.AdjustLeverageRequest
+		contract  *market.ContractInfo
+		wantLong  string
+		wantShort string
+		wantErr   bool
+	}{
+		{"valid cross", account.AdjustLeverageRequest{Symbol: "cmt_btcusdt", MarginMode: int(types.MarginModeShared), LongLeverage: "10", ShortLeverage: "10.0"}, contract, "10", "10", false},
+		{"valid isolated", account.AdjustLeverageRequest{Symbol: "cmt_btcusdt", MarginMode: int(types.MarginModeIsolated), LongLeverage: "20", ShortLeverage: " 5"}, contract, "20", "5", false},
+		{"over max", account.AdjustLeverageRequest{Symbol: "cmt_btcusdt", MarginMode: int(types.MarginModeIsolated), LongLeverage: "200", ShortLeverage: "5"}, contract, "", "", true},
+		{"below min", account.AdjustLeverageRequest{Symbol: "cmt_btcusdt", MarginMode: int(types.MarginModeIsolated), LongLeverage: "5", ShortLeverage: "0.5"}, contract, "", "", true},
+		{"cross mismatch", account.AdjustLeverageRequest{Symbol: "cmt_btcusdt", MarginMode: int(types.MarginModeShared), LongLeverage: "10", ShortLeverage: "20"}, contract, "", "", true},
+		{"symbol mismatch", account.AdjustLeverageRequest{Symbol: "cmt_ethusdt", MarginMode: int(types.MarginModeShared), LongLeverage: "10", ShortLeverage: "10"}, contract, "", "", true},
+		{"nil contract format only", account.AdjustLeverageRequest{Symbol: "cmt_btcusdt", MarginMode: int(types.MarginModeShared), LongLeverage: "500", ShortLeverage: "500"}, nil, "500", "500", false},
+		{"nil contract malformed", account.AdjustLeverageRequest{Symbol: "cmt_btcusdt", MarginMode: int(types.MarginModeShared), LongLeverage: "ten", ShortLeverage: "10"}, nil, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			err := req.Normalize(tt.contract)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if req != tt.req {
+					t.Errorf("Normalize() modified request on error: %+v", req)
+				}
+				return
+			}
+			if req.LongLeverage != tt.wantLong || req.ShortLeverage != tt.wantShort {
+				t.Errorf("Normalize() leverages = %s/%s, want %s/%s", req.LongLeverage, req.ShortLeverage, tt.wantLong, tt.wantShort)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package account_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestAddReduceMargin(t *testing.T) {
+	tests := []struct {
+		name    string
+		adjust  func(ctx context.Context, s *account.Service) error
+		want    string // collateralAmount sent, empty if no request is expected
+		wantErr error
+	}{
+		{"add", func(ctx context.Context, s *account.Service) error {
+			return s.AddMargin(ctx, 7, "10.5")
+		}, "10.5", nil},
+		{"reduce", func(ctx context.Context, s *account.Service) error {
+			return s.ReduceMargin(ctx, 7, "10.5")
+		}, "-10.5", nil},
+		{"reduce explicit plus", func(ctx context.Context, s *account.Service) error {
+			return s.ReduceMargin(ctx, 7, "+3")
+		}, "-3", nil},
+		{"add zero", func(ctx context.Context, s *account.Service) error {
+			return s.AddMargin(ctx, 7, "0.000")
+		}, "", account.ErrInvalidCollateralAmount},
+		{"reduce zero", func(ctx context.Context, s *account.Service) error {
+			return s.ReduceMargin(ctx, 7, "0")
+		}, "", account.ErrInvalidCollateralAmount},
+		{"add negative", func(ctx context.Context, s *account.Service) error {
+			return s.AddMargin(ctx, 7, "-1")
+		}, "", account.ErrInvalidCollateralAmount},
+		{"add malformed", func(ctx context.Context, s *account.Service) error {
+			return s.AddMargin(ctx, 7, "ten")
+		}, "", account.ErrInvalidCollateralAmount},
+		{"reduce padded", func(ctx context.Context, s *account.Service) error {
+			return s.ReduceMargin(ctx, 7, " 1")
+		}, "", account.ErrInvalidCollateralAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, nil)
+			client := newTestClient(t, server)
+
+			err := tt.adjust(context.Background(), client.Account())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			requests := server.Requests()
+			if tt.want == "" {
+				if len(requests) != 0 {
+					t.Fatalf("sent %d requests, want none", len(requests))
+				}
+				return
+			}
+			if len(requests) != 1 || requests[0].Path != "/account/adjustMargin" {
+				t.Fatalf("requests = %+v, want one POST /account/adjustMargin", requests)
+			}
+			body := requests[0].Body
+			if body["collateralAmount"] != tt.want || body["isolatedPositionId"] != float64(7) {
+				t.Errorf("body = %v, want collateralAmount %s for position 7", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjustMarginRequestDirection(t *testing.T) {
+	tests := []struct {
+		amount  string
+		want    account.MarginDirection
+		wantErr bool
+	}{
+		{"10", account.MarginDirectionIncrease, false},
+		{"+0.5", account.MarginDirectionIncrease, false},
+		{"-2.25", account.MarginDirectionDecrease, false},
+		{"0.000000000000000000000000000001", account.MarginDirectionIncrease, false},
+		{"1e-30", 0, true},
+		{"1/2", 0, true},
+		{"0", 0, true},
+		{"-0.0", 0, true},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"1 ", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			req := &account.AdjustMarginRequest{IsolatedPositionId: 1, CollateralAmount: tt.amount}
+			got, err := req.Direction()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Direction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, account.ErrInvalidCollateralAmount) || len(types.ValidationErrors(err)) != 1 {
+					t.Errorf("error = %v, want a ValidationError wrapping ErrInvalidCollateralAmount", err)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Direction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjustMarginRequiresPosition(t *testing.T) {
+	server := newTestServer(t, nil)
+	client := newTestClient(t, server)
+
+	err := client.Account().AdjustMargin(context.Background(), &account.AdjustMarginRequest{CollateralAmount: "1"})
+	if err == nil {
+		t.Fatal("expected an error for a missing isolatedPositionId")
+	}
+	if n := len(server.Requests()); n != 0 {
+		t.Errorf("sent %d requests, want none", n)
+	}
+}
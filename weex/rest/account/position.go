@@ -0,0 +1,117 @@
+package account
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// Notional returns the current notional value of the position (|size| * markPrice)
+// The value is unsigned for both LONG and SHORT positions
+func (p *Position) Notional(markPrice types.Decimal) (types.Decimal, error) {
+	notional, err := p.notional(markPrice)
+	if err != nil {
+		return "", err
+	}
+	return types.NewDecimalFromRat(notional), nil
+}
+
+// notional returns |size| * markPrice exactly
+func (p *Position) notional(markPrice types.Decimal) (*big.Rat, error) {
+	size, err := types.Decimal(p.Size).Rat()
+	if err != nil {
+		return nil, fmt.Errorf("invalid position size %q: %w", p.Size, err)
+	}
+	price, err := markPrice.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("invalid mark price %q: %w", markPrice, err)
+	}
+	if price.Sign() < 0 {
+		return nil, fmt.Errorf("mark price cannot be negative, got %s", markPrice)
+	}
+	return new(big.Rat).Mul(size.Abs(size), price), nil
+}
+
+// LeverageUtilization returns notional / equity at the given mark price
+// A value of 3 means the position is 3x the account equity
+func (p *Position) LeverageUtilization(markPrice, equity types.Decimal) (types.Decimal, error) {
+	notional, err := p.notional(markPrice)
+	if err != nil {
+		return "", err
+	}
+	eq, err := equity.Rat()
+	if err != nil {
+		return "", fmt.Errorf("invalid equity %q: %w", equity, err)
+	}
+	if eq.Sign() <= 0 {
+		return "", fmt.Errorf("equity must be greater than 0, got %s", equity)
+	}
+	return types.NewDecimalFromRat(notional.Quo(notional, eq)), nil
+}
+
+// BreakEvenPrice returns the price at which closing the position breaks even after fees and funding
+//
+// The cumulative open fee and funding from CumOpenFee and CumFundingFee are
+// added to the entry cost (OpenValue), and the exit is assumed to be a market
+// close paying takerFee. makerFee estimates the open fee when the position
+// carries no recorded CumOpenFee. Fees are treated as costs regardless of sign;
+// a negative CumFundingFee is funding paid and a positive one funding received.
+func (p *Position) BreakEvenPrice(takerFee, makerFee types.Decimal) (types.Decimal, error) {
+	size, err := types.Decimal(p.Size).Rat()
+	if err != nil {
+		return "", fmt.Errorf("invalid position size %q: %w", p.Size, err)
+	}
+	size.Abs(size)
+	if size.Sign() == 0 {
+		return "", fmt.Errorf("position size must be non-zero")
+	}
+	openValue, err := types.Decimal(p.OpenValue).Rat()
+	if err != nil {
+		return "", fmt.Errorf("invalid open value %q: %w", p.OpenValue, err)
+	}
+	openValue.Abs(openValue)
+
+	one := big.NewRat(1, 1)
+	taker, err := takerFee.Rat()
+	if err != nil || taker.Sign() < 0 || taker.Cmp(one) >= 0 {
+		return "", fmt.Errorf("invalid taker fee %q", takerFee)
+	}
+	maker, err := makerFee.Rat()
+	if err != nil || maker.Sign() < 0 || maker.Cmp(one) >= 0 {
+		return "", fmt.Errorf("invalid maker fee %q", makerFee)
+	}
+
+	// An empty Cum* field parses as zero
+	openFee, err := types.Decimal(p.CumOpenFee).Rat()
+	if err != nil {
+		return "", fmt.Errorf("invalid cumulative open fee %q: %w", p.CumOpenFee, err)
+	}
+	openFee.Abs(openFee)
+	if openFee.Sign() == 0 {
+		openFee.Mul(openValue, maker)
+	}
+	funding, err := types.Decimal(p.CumFundingFee).Rat()
+	if err != nil {
+		return "", fmt.Errorf("invalid cumulative funding fee %q: %w", p.CumFundingFee, err)
+	}
+
+	// Costs already paid: open fee plus funding paid (negative funding is a cost)
+	costs := new(big.Rat).Sub(openFee, funding)
+
+	price := new(big.Rat)
+	switch strings.ToUpper(p.Side) {
+	case "LONG":
+		// size*price*(1-taker) = openValue + costs
+		price.Add(openValue, costs)
+		price.Quo(price, new(big.Rat).Mul(size, new(big.Rat).Sub(one, taker)))
+	case "SHORT":
+		// openValue - size*price*(1+taker) = costs
+		price.Sub(openValue, costs)
+		price.Quo(price, new(big.Rat).Mul(size, new(big.Rat).Add(one, taker)))
+	default:
+		return "", fmt.Errorf("unknown position side %q", p.Side)
+	}
+	return types.NewDecimalFromRat(price), nil
+}
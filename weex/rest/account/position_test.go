@@ -0,0 +1,105 @@
+package account_test
+
+import (
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestPositionNotional(t *testing.T) {
+	tests := []struct {
+		name      string
+		position  account.Position
+		markPrice types.Decimal
+		want      types.Decimal
+		wantErr   bool
+	}{
+		{"long", account.Position{Side: "LONG", Size: "0.5"}, "60000", "30000", false},
+		{"short", account.Position{Side: "SHORT", Size: "2"}, "3000.5", "6001", false},
+		{"signed short size", account.Position{Side: "SHORT", Size: "-2"}, "3000.5", "6001", false},
+		{"exact decimals", account.Position{Side: "LONG", Size: "0.1"}, "0.3", "0.03", false},
+		{"zero size", account.Position{Side: "LONG", Size: "0"}, "60000", "0", false},
+		{"negative price", account.Position{Side: "LONG", Size: "1"}, "-1", "", true},
+		{"bad size", account.Position{Side: "LONG", Size: "x"}, "1", "", true},
+		{"bad price", account.Position{Side: "LONG", Size: "1"}, "x", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.position.Notional(tt.markPrice)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Notional() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Notional() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositionLeverageUtilization(t *testing.T) {
+	tests := []struct {
+		name      string
+		position  account.Position
+		markPrice types.Decimal
+		equity    types.Decimal
+		want      types.Decimal
+		wantErr   bool
+	}{
+		{"long", account.Position{Side: "LONG", Size: "0.5"}, "60000", "10000", "3", false},
+		{"short", account.Position{Side: "SHORT", Size: "2"}, "3000", "24000", "0.25", false},
+		{"non-terminating", account.Position{Side: "LONG", Size: "1"}, "100", "300", "0.333333333333333333", false},
+		{"zero equity", account.Position{Side: "LONG", Size: "1"}, "100", "0", "", true},
+		{"negative equity", account.Position{Side: "LONG", Size: "1"}, "100", "-5", "", true},
+		{"bad equity", account.Position{Side: "LONG", Size: "1"}, "100", "x", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.position.LeverageUtilization(tt.markPrice, tt.equity)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LeverageUtilization() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LeverageUtilization() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositionBreakEvenPrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		position account.Position
+		taker    types.Decimal
+		maker    types.Decimal
+		want     types.Decimal
+		wantErr  bool
+	}{
+		// (99.85 + 0.06 + 0.04) / (1 - 0.0005) = 100, above the 99.85 entry
+		{"long pays fees and funding", account.Position{Side: "LONG", Size: "1", OpenValue: "99.85", CumOpenFee: "-0.06", CumFundingFee: "-0.04"}, "0.0005", "0.0002", "100", false},
+		// (99.9499 - 0.06 - 0.04) / (1 + 0.0005) = 99.8, below the 99.9499 entry
+		{"short pays fees and funding", account.Position{Side: "SHORT", Size: "-1", OpenValue: "99.9499", CumOpenFee: "0.06", CumFundingFee: "-0.04"}, "0.0005", "0.0002", "99.8", false},
+		// Funding received outweighs the open fee: (99.7499 - 0.1 + 0.2) / 1.0005 = 99.8, above the entry
+		{"short receives funding", account.Position{Side: "SHORT", Size: "1", OpenValue: "99.7499", CumOpenFee: "0.1", CumFundingFee: "0.2"}, "0.0005", "0", "99.8", false},
+		// No recorded open fee: estimated as 100 * 0.0002
+		{"maker fee estimates open fee", account.Position{Side: "LONG", Size: "1", OpenValue: "100"}, "0", "0.0002", "100.02", false},
+		{"exact decimals", account.Position{Side: "long", Size: "3", OpenValue: "0.3"}, "0", "0", "0.1", false},
+		{"zero size", account.Position{Side: "LONG", Size: "0", OpenValue: "100"}, "0", "0", "", true},
+		{"unknown side", account.Position{Side: "BOTH", Size: "1", OpenValue: "100"}, "0", "0", "", true},
+		{"taker fee of one", account.Position{Side: "LONG", Size: "1", OpenValue: "100"}, "1", "0", "", true},
+		{"negative maker fee", account.Position{Side: "LONG", Size: "1", OpenValue: "100"}, "0", "-0.1", "", true},
+		{"bad open fee", account.Position{Side: "LONG", Size: "1", OpenValue: "100", CumOpenFee: "x"}, "0", "0", "", true},
+		{"bad funding", account.Position{Side: "LONG", Size: "1", OpenValue: "100", CumFundingFee: "x"}, "0", "0", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.position.BreakEvenPrice(tt.taker, tt.maker)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BreakEvenPrice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BreakEvenPrice() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package account_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+)
+
+// request is a request received by testServer
+type request struct {
+	Method string
+	Path   string // Path without the /capi/v2 prefix
+	Query  string
+	Body   map[string]interface{}
+}
+
+// testServer is a local REST server recording every request
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []request
+}
+
+// newTestServer starts a server replying to each path with the wrapped data from
+// responses (keyed by path without the /capi/v2 prefix), or with empty data.
+func newTestServer(t *testing.T, responses map[string]string) *testServer {
+	t.Helper()
+	s := &testServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := request{
+			Method: r.Method,
+			Path:   strings.TrimPrefix(r.URL.Path, "/capi/v2"),
+			Query:  r.URL.RawQuery,
+		}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			json.Unmarshal(data, &req.Body)
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, req)
+		s.mu.Unlock()
+
+		data, ok := responses[req.Path]
+		if !ok {
+			data = "{}"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code":"0","msg":"success","requestTime":1700000000000,"data":` + data + `}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Requests returns the requests received so far
+func (s *testServer) Requests() []request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]request(nil), s.requests...)
+}
+
+// newTestClient returns an authenticated client pointed at s with retries disabled
+func newTestClient(t *testing.T, s *testServer) *weex.Client {
+	t.Helper()
+	config := weex.NewDefaultConfig().
+		WithBaseURL(s.URL).
+		WithAPIKey("test-api-key").
+		WithSecretKey("test-secret-key").
+		WithPassphrase("test-passphrase")
+	config.MaxRetries = 0
+	config.Logger = weex.NewNoOpLogger()
+	client, err := weex.NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
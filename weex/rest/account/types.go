@@ -1,6 +1,8 @@
 package account
 
 import (
+	"strings"
+
 	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
 )
 
@@ -162,23 +164,38 @@ type Position struct {
 
 // Bill represents an account bill/transaction
 type Bill struct {
-	BillId         int64  `json:"billId"`         // Bill ID
-	Coin           string `json:"coin"`           // Currency name
-	Symbol         string `json:"symbol"`         // Contract symbol
-	Amount         string `json:"amount"`         // Amount
-	BusinessType   string `json:"businessType"`   // Business type
-	Balance        string `json:"balance"`        // Balance after transaction
-	FillFee        string `json:"fillFee"`        // Transaction fee
-	TransferReason string `json:"transferReason"` // Transfer reason
-	CTime          int64  `json:"cTime"`          // Creation time (Unix millisecond timestamp)
+	BillId         int64        `json:"billId"`         // Bill ID
+	Coin           string       `json:"coin"`           // Currency name
+	Symbol         string       `json:"symbol"`         // Contract symbol
+	Amount         string       `json:"amount"`         // Amount
+	BusinessType   string       `json:"businessType"`   // Business type
+	Balance        string       `json:"balance"`        // Balance after transaction
+	FillFee        string       `json:"fillFee"`        // Transaction fee
+	TransferReason string       `json:"transferReason"` // Transfer reason
+	CTime          types.Millis `json:"cTime"`          // Creation time (Unix millisecond timestamp)
 }
 
 // BillsResponse represents the paginated bills response
+//
+// The API pages by time window rather than offset or cursor and does not
+// return a total count; use GetBillsRequest.NextPage to build the request for
+// the following (older) page.
 type BillsResponse struct {
 	HasNextPage bool   `json:"hasNextPage"` // Has next page
 	Items       []Bill `json:"items"`       // Bills list
 }
 
+// OldestTime returns the smallest CTime in the page (0 if the page is empty)
+func (r *BillsResponse) OldestTime() types.Millis {
+	var oldest types.Millis
+	for _, bill := range r.Items {
+		if oldest == 0 || (bill.CTime > 0 && bill.CTime < oldest) {
+			oldest = bill.CTime
+		}
+	}
+	return oldest
+}
+
 // UserConfigData represents user configuration data for a single contract
 type UserConfigData struct {
 	IsolatedLongLeverage  string `json:"isolated_long_leverage"`  // Isolated long leverage
@@ -228,6 +245,45 @@ type GetBillsRequest struct {
 	Limit        int    `json:"limit,omitempty"`        // Optional: page size (default 20, max 100)
 }
 
+// NextPage returns the request for the page after resp, or nil if there is none
+//
+// Bills are returned newest first, so the next page ends at the oldest bill of
+// resp. EndTime is inclusive, so bills sharing that timestamp are returned
+// again and should be skipped by BillId. Returns nil when HasNextPage is false.
+//
+// If every bill of resp is at the request's EndTime the window cannot move
+// without skipping bills, so nil is returned as well; check PageStalled to
+// tell this apart from the last page. IterateBills reports it as
+// ErrBillPageStalled.
+func (r *GetBillsRequest) NextPage(resp *BillsResponse) *GetBillsRequest {
+	if resp == nil || !resp.HasNextPage {
+		return nil
+	}
+	oldest := resp.OldestTime()
+	if oldest == 0 {
+		return nil
+	}
+
+	if r.PageStalled(resp) {
+		return nil
+	}
+
+	next := *r
+	next.EndTime = oldest.Int64()
+	return &next
+}
+
+// PageStalled reports whether resp has more pages but all its bills are at the request's EndTime
+// The time window cannot advance past such a page without skipping the
+// remaining bills at that timestamp; a larger Limit may fit them in one page.
+func (r *GetBillsRequest) PageStalled(resp *BillsResponse) bool {
+	if resp == nil || !resp.HasNextPage || r.EndTime == 0 {
+		return false
+	}
+	oldest := resp.OldestTime()
+	return oldest != 0 && oldest.Int64() >= r.EndTime
+}
+
 // GetUserConfigRequest is the request for GetUserConfig
 type GetUserConfigRequest struct {
 	Symbol string // Optional: contract symbol (if not specified, returns all)
@@ -257,6 +313,38 @@ type AdjustMarginRequest struct {
 	CollateralAmount   string `json:"collateralAmount"`   // Required: collateral amount (positive=increase, negative=decrease)
 }
 
+// MarginDirection represents whether a margin adjustment adds or removes margin
+type MarginDirection int
+
+const (
+	MarginDirectionIncrease MarginDirection = 1  // Add margin to the position
+	MarginDirectionDecrease MarginDirection = -1 // Remove margin from the position
+)
+
+// String returns the string representation of MarginDirection
+func (d MarginDirection) String() string {
+	switch d {
+	case MarginDirectionIncrease:
+		return "INCREASE"
+	case MarginDirectionDecrease:
+		return "DECREASE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Direction parses CollateralAmount and returns whether it adds or removes margin
+// Returns ErrInvalidCollateralAmount if the amount is zero or malformed
+func (r *AdjustMarginRequest) Direction() (MarginDirection, error) {
+	if err := ValidateCollateralAmount(r.CollateralAmount); err != nil {
+		return 0, err
+	}
+	if strings.HasPrefix(r.CollateralAmount, "-") {
+		return MarginDirectionDecrease, nil
+	}
+	return MarginDirectionIncrease, nil
+}
+
 // AdjustMarginResponse is the response for AdjustMargin
 type AdjustMarginResponse struct {
 	Symbol        string        `json:"symbol"`        // Contract symbol
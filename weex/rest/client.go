@@ -5,9 +5,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
@@ -28,7 +32,7 @@ type Authenticator interface {
 
 // Retrier interface (to avoid importing weex package)
 type Retrier interface {
-	DoWithRetry(ctx context.Context, fn func() error) error
+	DoWithRetryContext(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // RateLimiter interface (to avoid importing weex package)
@@ -36,6 +40,29 @@ type RateLimiter interface {
 	WaitForCapacity(ctx context.Context, ipWeight, uidWeight int) error
 }
 
+// DecodeMode controls how response fields not modeled by the SDK are handled
+type DecodeMode int
+
+const (
+	DecodeModeLenient DecodeMode = iota // Unknown fields are ignored (default)
+	DecodeModeWarn                      // Unknown fields are logged at Warn level
+	DecodeModeStrict                    // Unknown fields fail the request
+)
+
+// String returns the string representation of DecodeMode
+func (m DecodeMode) String() string {
+	switch m {
+	case DecodeModeLenient:
+		return "LENIENT"
+	case DecodeModeWarn:
+		return "WARN"
+	case DecodeModeStrict:
+		return "STRICT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // Client is the REST API client
 type Client struct {
 	baseURL     string
@@ -45,6 +72,20 @@ type Client struct {
 	retrier     Retrier
 	rateLimiter RateLimiter
 	logger      Logger
+	decodeMode  DecodeMode
+	logSecrets  bool // Log credentials in debug output verbatim (default: masked)
+
+	// Extra headers added to every request (not signed)
+	extraHeaders map[string]string
+
+	// Request/response middleware hooks
+	hooksMu       sync.RWMutex
+	requestHooks  []RequestHook
+	responseHooks []ResponseHook
+
+	// Per-symbol serialization of mutating requests (nil = disabled)
+	symbolLocksMu sync.RWMutex
+	symbolLocks   *SymbolLocks
 }
 
 // NewClient creates a new REST API client
@@ -60,15 +101,93 @@ func NewClient(baseURL, locale string, httpClient *http.Client, auth Authenticat
 	}
 }
 
+// SetDecodeMode sets how response fields not modeled by the SDK are handled
+// Use DecodeModeWarn or DecodeModeStrict in debug builds to catch API drift early
+func (c *Client) SetDecodeMode(mode DecodeMode) {
+	c.decodeMode = mode
+}
+
+// SetLogSecrets sets whether credentials are logged verbatim in debug logs (default: masked)
+func (c *Client) SetLogSecrets(enabled bool) {
+	c.logSecrets = enabled
+}
+
+// credentialHeaders are masked in debug logs unless secrets logging is enabled
+var credentialHeaders = []string{types.HeaderAccessKey, types.HeaderAccessSign, types.HeaderAccessPassphrase}
+
+// loggableHeaders returns the request headers for debug logging with credentials masked
+func (c *Client) loggableHeaders(header http.Header) http.Header {
+	logged := header.Clone()
+	if c.logSecrets {
+		return logged
+	}
+	for _, key := range credentialHeaders {
+		if value := logged.Get(key); value != "" {
+			logged.Set(key, types.MaskSecret(value))
+		}
+	}
+	return logged
+}
+
+// ErrRequestNotSent is matched by errors from requests that failed before any
+// attempt was handed to the HTTP transport (e.g. canceled while waiting for
+// rate limit capacity), so the server cannot have seen them
+var ErrRequestNotSent = errors.New("request not sent")
+
+// notSentError marks an error as ErrRequestNotSent while keeping its message
+type notSentError struct {
+	err error
+}
+
+func (e *notSentError) Error() string        { return e.err.Error() }
+func (e *notSentError) Unwrap() error        { return e.err }
+func (e *notSentError) Is(target error) bool { return target == ErrRequestNotSent }
+
+// markNotSent wraps err as ErrRequestNotSent unless an attempt was sent
+func markNotSent(err error, sent bool) error {
+	if err == nil || sent {
+		return err
+	}
+	return &notSentError{err: err}
+}
+
 // DoRequest performs an HTTP request with authentication, retry, and rate limiting
+// Errors match ErrRequestNotSent if no attempt reached the HTTP transport.
 func (c *Client) DoRequest(ctx context.Context, method, path string, body interface{}, result interface{}, ipWeight, uidWeight int) error {
-	return c.retrier.DoWithRetry(ctx, func() error {
-		return c.doRequestOnce(ctx, method, path, body, result, ipWeight, uidWeight)
+	var sent bool
+	err := c.retrier.DoWithRetryContext(ctx, func(ctx context.Context) error {
+		return c.doRequestOnce(ctx, method, path, body, result, ipWeight, uidWeight, nil, &sent)
+	})
+	return markNotSent(err, sent)
+}
+
+// ResponseMeta holds HTTP response details for a request
+type ResponseMeta struct {
+	StatusCode  int         // HTTP status code
+	Header      http.Header // Response headers
+	RequestTime int64       // requestTime from the API response wrapper (0 if absent)
+}
+
+// DoRequestWithMeta performs a request like DoRequest and also returns the response metadata
+// The metadata is from the last attempt and is returned on error when a response was received
+func (c *Client) DoRequestWithMeta(ctx context.Context, method, path string, body interface{}, result interface{}, ipWeight, uidWeight int) (*ResponseMeta, error) {
+	var meta *ResponseMeta
+	var sent bool
+	err := c.retrier.DoWithRetryContext(ctx, func(ctx context.Context) error {
+		attempt := &ResponseMeta{}
+		err := c.doRequestOnce(ctx, method, path, body, result, ipWeight, uidWeight, attempt, &sent)
+		if attempt.StatusCode != 0 {
+			meta = attempt
+		}
+		return err
 	})
+	return meta, markNotSent(err, sent)
 }
 
 // doRequestOnce performs a single HTTP request attempt
-func (c *Client) doRequestOnce(ctx context.Context, method, path string, body interface{}, result interface{}, ipWeight, uidWeight int) error {
+// If meta is non-nil it is filled from the response. *sent is set once the
+// request is handed to the HTTP transport.
+func (c *Client) doRequestOnce(ctx context.Context, method, path string, body interface{}, result interface{}, ipWeight, uidWeight int, meta *ResponseMeta, sent *bool) error {
 	// Wait for rate limit capacity
 	if err := c.rateLimiter.WaitForCapacity(ctx, ipWeight, uidWeight); err != nil {
 		return fmt.Errorf("rate limit wait failed: %w", err)
@@ -95,6 +214,9 @@ func (c *Client) doRequestOnce(ctx context.Context, method, path string, body in
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
+	// Add extra headers first so SDK-managed headers always win
+	c.applyExtraHeaders(ctx, req)
+
 	// Add authentication headers
 	timestamp := time.Now().UnixMilli()
 	headers := c.auth.GetRESTHeaders(timestamp, method, types.DefaultAPIPathPrefix+path, bodyStr)
@@ -106,48 +228,108 @@ func (c *Client) doRequestOnce(ctx context.Context, method, path string, body in
 	req.Header.Set(types.HeaderLocale, c.locale)
 
 	// Log request
-	c.logger.Debug("REST request: %s %s (IP weight: %d, UID weight: %d)", method, path, ipWeight, uidWeight)
+	c.logger.Debug("REST request: %s %s (IP weight: %d, UID weight: %d) Headers: %v Body: %s", method, path, ipWeight, uidWeight, c.loggableHeaders(req.Header), bodyStr)
+
+	// Run request hooks
+	c.runRequestHooks(req)
 
 	// Execute request
+	*sent = true
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		// Cancellation by the caller is not a network failure and must not be retried
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("failed to execute request: %w", ctxErr)
+		}
+		return types.NewNetworkError("request", url, err)
 	}
 	defer resp.Body.Close()
 
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("failed to read response: %w", ctxErr)
+		}
+		return types.NewNetworkError("read", url, err)
 	}
 
+	// Run response hooks
+	c.runResponseHooks(resp, respBody)
+
 	// Log response
 	c.logger.Debug("REST response: %s %s - Status: %d, Body: %s", method, path, resp.StatusCode, string(respBody))
 
 	// Parse response
-	return c.parseResponse(resp.StatusCode, respBody, result)
+	if meta != nil {
+		meta.StatusCode = resp.StatusCode
+		meta.Header = resp.Header
+	}
+	err = c.parseResponse(resp.StatusCode, respBody, result, meta)
+
+	// Surface the server-requested delay on rate limit errors
+	var apiErr *types.APIError
+	if errors.As(err, &apiErr) && (resp.StatusCode == http.StatusTooManyRequests || apiErr.IsRateLimitError()) {
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	}
+	return err
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP-date
+// Returns 0 if the header is missing, malformed or in the past
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
 }
 
 // parseResponse parses the API response and handles errors
-func (c *Client) parseResponse(statusCode int, body []byte, result interface{}) error {
+// If meta is non-nil its RequestTime is set from the response wrapper
+func (c *Client) parseResponse(statusCode int, body []byte, result interface{}, meta *ResponseMeta) error {
 	// Try parsing as API response wrapper first
 	var apiResp APIResponse
 	if err := json.Unmarshal(body, &apiResp); err == nil {
 		// Successfully parsed as APIResponse, check if it has the wrapper structure
 		if apiResp.Code != "" || apiResp.Msg != "" || apiResp.RequestTime != 0 {
 			// This is a wrapped response
+			if meta != nil {
+				meta.RequestTime = apiResp.RequestTime
+			}
+
 			// Check for API errors
-			// Success codes: "0" or "200" (some endpoints return "200" for success)
-			// HTTP 2xx status codes also indicate success
-			isSuccess := apiResp.Code == "0" || apiResp.Code == "200" || (statusCode >= 200 && statusCode < 300)
-			if apiResp.Code != "" && !isSuccess {
-				return fmt.Errorf("API error [%s]: %s (status: %d, time: %d)", apiResp.Code, apiResp.Msg, statusCode, apiResp.RequestTime)
+			// When a code is present it alone decides success: "0" or "200"
+			// (some endpoints return "200" for success). The gateway also reports
+			// errors such as 50001 with HTTP 200, so the status code is not trusted.
+			// Without a code, the HTTP status decides.
+			if apiResp.Code != "" {
+				if apiResp.Code != "0" && apiResp.Code != "200" {
+					return types.NewAPIError(apiResp.Code, apiResp.Msg, statusCode, apiResp.RequestTime)
+				}
+			} else if statusCode >= 400 {
+				return types.NewHTTPStatusError(statusCode)
 			}
 
 			// Parse data if result is provided
 			if result != nil && len(apiResp.Data) > 0 {
-				if err := json.Unmarshal(apiResp.Data, result); err != nil {
-					return fmt.Errorf("failed to unmarshal response data: %w", err)
+				if err := c.unmarshalData(apiResp.Data, result); err != nil {
+					// Scalar data (e.g. "data":"someId") cannot fill an object result;
+					// results that model the whole wrapper are decoded from the body
+					if _, ok := result.(WrapperResult); !ok || !isScalarJSON(apiResp.Data) || c.decode(body, result) != nil {
+						return fmt.Errorf("failed to unmarshal response data: %w", err)
+					}
 				}
 			}
 			return nil
@@ -155,19 +337,85 @@ func (c *Client) parseResponse(statusCode int, body []byte, result interface{})
 	}
 
 	// Not a wrapped response or failed to parse as wrapper
+	// Check HTTP status code for errors before decoding the body
+	if statusCode >= 400 {
+		return types.NewHTTPStatusError(statusCode)
+	}
+
 	// Try parsing directly into result
 	if result != nil {
-		if err := json.Unmarshal(body, result); err != nil {
+		if err := c.decode(body, result); err != nil {
 			return fmt.Errorf("failed to unmarshal direct response: %w", err)
 		}
 	}
 
-	// Check HTTP status code for errors
-	if statusCode >= 400 {
-		return fmt.Errorf("HTTP error: %d", statusCode)
+	return nil
+}
+
+// unmarshalData unmarshals the data field into result
+// Some gateways double-encode data as a JSON string (e.g. "data":"{\"symbol\":...}");
+// if direct unmarshalling fails and data is a quoted JSON document, it is unquoted and re-parsed
+func (c *Client) unmarshalData(data json.RawMessage, result interface{}) error {
+	// A scalar (string, number, bool) is accepted as-is into a string result
+	if s, ok := result.(*string); ok && isScalarJSON(data) {
+		trimmed := bytes.TrimSpace(data)
+		if trimmed[0] == '"' {
+			return json.Unmarshal(trimmed, s)
+		}
+		*s = string(trimmed)
+		return nil
 	}
 
-	return nil
+	err := c.decode(data, result)
+	if err == nil {
+		return nil
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '"' {
+		return err
+	}
+
+	var inner string
+	if jsonErr := json.Unmarshal(trimmed, &inner); jsonErr != nil {
+		return err
+	}
+	innerBytes := bytes.TrimSpace([]byte(inner))
+	if len(innerBytes) == 0 || (innerBytes[0] != '{' && innerBytes[0] != '[') {
+		return err
+	}
+
+	return c.decode(innerBytes, result)
+}
+
+// isScalarJSON returns true if data is a JSON string, number or boolean
+func isScalarJSON(data json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return false
+	}
+	return trimmed[0] != '{' && trimmed[0] != '['
+}
+
+// decode unmarshals data into result according to the client's DecodeMode
+func (c *Client) decode(data []byte, result interface{}) error {
+	if c.decodeMode == DecodeModeLenient {
+		return json.Unmarshal(data, result)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(result)
+	if err == nil || !strings.HasPrefix(err.Error(), "json: unknown field") {
+		return err
+	}
+
+	if c.decodeMode == DecodeModeStrict {
+		return fmt.Errorf("response contains field not modeled by the SDK: %w", err)
+	}
+
+	c.logger.Warn("Response contains field not modeled by the SDK (%T): %v", result, err)
+	return json.Unmarshal(data, result)
 }
 
 // Get performs a GET request
@@ -175,6 +423,11 @@ func (c *Client) Get(ctx context.Context, path string, result interface{}, ipWei
 	return c.DoRequest(ctx, http.MethodGet, path, nil, result, ipWeight, uidWeight)
 }
 
+// GetWithMeta performs a GET request and returns the response metadata
+func (c *Client) GetWithMeta(ctx context.Context, path string, result interface{}, ipWeight, uidWeight int) (*ResponseMeta, error) {
+	return c.DoRequestWithMeta(ctx, http.MethodGet, path, nil, result, ipWeight, uidWeight)
+}
+
 // Post performs a POST request
 func (c *Client) Post(ctx context.Context, path string, body interface{}, result interface{}, ipWeight, uidWeight int) error {
 	return c.DoRequest(ctx, http.MethodPost, path, body, result, ipWeight, uidWeight)
@@ -195,6 +448,13 @@ func (c *Client) Delete(ctx context.Context, path string, body interface{}, resu
 	return c.DoRequest(ctx, http.MethodDelete, path, body, result, ipWeight, uidWeight)
 }
 
+// WrapperResult is implemented by result types that model the whole response
+// wrapper (code, msg, requestTime, data) rather than only its data field.
+// Only such results are decoded from the full body when data is a scalar.
+type WrapperResult interface {
+	ResponseWrapper()
+}
+
 // APIResponse represents the standard API response wrapper
 type APIResponse struct {
 	Code        string          `json:"code"`        // Error code ("0" means success)
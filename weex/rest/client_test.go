@@ -0,0 +1,396 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+type testTicker struct {
+	Symbol string `json:"symbol"`
+	Last   string `json:"last"`
+}
+
+type testWrapper struct {
+	Code        string `json:"code"`
+	Msg         string `json:"msg"`
+	RequestTime int64  `json:"requestTime"`
+	Data        string `json:"data"`
+}
+
+func (r *testWrapper) ResponseWrapper() {}
+
+func TestParseResponseString(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"quoted", `{"code":"0","msg":"success","requestTime":1,"data":"12345"}`, "12345"},
+		{"number", `{"code":"0","msg":"success","requestTime":1,"data":12345}`, "12345"},
+		{"bool", `{"code":"0","msg":"success","requestTime":1,"data":true}`, "true"},
+	}
+	c := NewClient("", "", nil, nil, nil, nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			if err := c.parseResponse(http.StatusOK, []byte(tt.body), &got, nil); err != nil {
+				t.Fatalf("parseResponse: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseResponseScalarIntoStruct(t *testing.T) {
+	body := []byte(`{"code":"0","msg":"success","requestTime":1700000000000,"data":"error"}`)
+	for _, mode := range []DecodeMode{DecodeModeLenient, DecodeModeWarn, DecodeModeStrict} {
+		t.Run(mode.String(), func(t *testing.T) {
+			c := NewClient("", "", nil, nil, nil, nil, nil)
+			c.SetDecodeMode(mode)
+
+			var ticker testTicker
+			if err := c.parseResponse(http.StatusOK, body, &ticker, nil); err == nil {
+				t.Fatalf("expected decode error, got %+v", ticker)
+			}
+
+			var wrapper testWrapper
+			if err := c.parseResponse(http.StatusOK, body, &wrapper, nil); err != nil {
+				t.Fatalf("wrapper result: %v", err)
+			}
+			want := testWrapper{Code: "0", Msg: "success", RequestTime: 1700000000000, Data: "error"}
+			if wrapper != want {
+				t.Errorf("got %+v, want %+v", wrapper, want)
+			}
+		})
+	}
+}
+
+func TestParseResponseObject(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"object", `{"code":"0","msg":"success","requestTime":1,"data":{"symbol":"cmt_btcusdt","last":"100"}}`},
+		{"double encoded", `{"code":"0","msg":"success","requestTime":1,"data":"{\"symbol\":\"cmt_btcusdt\",\"last\":\"100\"}"}`},
+		{"unwrapped", `{"symbol":"cmt_btcusdt","last":"100"}`},
+	}
+	c := NewClient("", "", nil, nil, nil, nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testTicker
+			if err := c.parseResponse(http.StatusOK, []byte(tt.body), &got, nil); err != nil {
+				t.Fatalf("parseResponse: %v", err)
+			}
+			if want := (testTicker{Symbol: "cmt_btcusdt", Last: "100"}); got != want {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseResponseAPIError(t *testing.T) {
+	body := []byte(`{"code":"40001","msg":"invalid symbol","requestTime":1,"data":null}`)
+	c := NewClient("", "", nil, nil, nil, nil, nil)
+
+	var got testTicker
+	err := c.parseResponse(http.StatusBadRequest, body, &got, nil)
+	var apiErr *types.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *types.APIError, got %v", err)
+	}
+	if apiErr.Code != "40001" {
+		t.Errorf("code = %q, want 40001", apiErr.Code)
+	}
+}
+
+func TestParseResponseCodeDecidesSuccess(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantCode string // Expected APIError code, "" for success
+	}{
+		{"code 0", http.StatusOK, `{"code":"0","msg":"success","requestTime":1,"data":{}}`, ""},
+		{"code 200", http.StatusOK, `{"code":"200","msg":"success","requestTime":1,"data":{}}`, ""},
+		{"system error on HTTP 200", http.StatusOK, `{"code":"50001","msg":"service unavailable","requestTime":1}`, "50001"},
+		{"auth error on HTTP 200", http.StatusOK, `{"code":"40007","msg":"invalid signature","requestTime":1}`, "40007"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient("", "", nil, nil, nil, nil, nil)
+			err := c.parseResponse(tt.status, []byte(tt.body), nil, nil)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("parseResponse() error = %v, want nil", err)
+				}
+				return
+			}
+			var apiErr *types.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("parseResponse() error = %v, want *types.APIError", err)
+			}
+			if apiErr.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", apiErr.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestLoggableHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set(types.HeaderAccessKey, "bg_0123456789abcdef")
+	header.Set(types.HeaderAccessSign, "c2lnbmF0dXJlLXZhbHVl")
+	header.Set(types.HeaderAccessPassphrase, "passphrase")
+	header.Set(types.HeaderAccessTimestamp, "1700000000000")
+
+	tests := []struct {
+		name       string
+		logSecrets bool
+		want       map[string]string
+	}{
+		{"zero value masks", false, map[string]string{
+			types.HeaderAccessKey:        "bg_0***",
+			types.HeaderAccessSign:       "c2ln***",
+			types.HeaderAccessPassphrase: "pass***",
+			types.HeaderAccessTimestamp:  "1700000000000",
+		}},
+		{"log secrets", true, map[string]string{
+			types.HeaderAccessKey:        "bg_0123456789abcdef",
+			types.HeaderAccessSign:       "c2lnbmF0dXJlLXZhbHVl",
+			types.HeaderAccessPassphrase: "passphrase",
+			types.HeaderAccessTimestamp:  "1700000000000",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{}
+			c.SetLogSecrets(tt.logSecrets)
+			got := c.loggableHeaders(header)
+			for key, want := range tt.want {
+				if got.Get(key) != want {
+					t.Errorf("%s = %q, want %q", key, got.Get(key), want)
+				}
+			}
+		})
+	}
+	if header.Get(types.HeaderAccessKey) != "bg_0123456789abcdef" {
+		t.Error("loggableHeaders modified the request headers")
+	}
+}
+
+func TestUnmarshalDataDoubleEncoded(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []testTicker
+		wantErr bool
+	}{
+		{"array", `[{"symbol":"cmt_btcusdt","last":"100"}]`, []testTicker{{"cmt_btcusdt", "100"}}, false},
+		{"double encoded array", `"[{\"symbol\":\"cmt_btcusdt\",\"last\":\"100\"}]"`, []testTicker{{"cmt_btcusdt", "100"}}, false},
+		{"double encoded with whitespace", `" [{\"symbol\":\"cmt_ethusdt\",\"last\":\"5\"}] "`, []testTicker{{"cmt_ethusdt", "5"}}, false},
+		{"quoted plain string", `"not json"`, nil, true},
+		{"double encoded invalid", `"[{\"symbol\":"`, nil, true},
+		{"number", `42`, nil, true},
+	}
+	c := NewClient("", "", nil, nil, nil, nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []testTicker
+			err := c.unmarshalData([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unmarshalData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// warnRecorder is a Logger counting Warn calls
+type warnRecorder struct {
+	warns int
+}
+
+func (l *warnRecorder) Debug(string, ...interface{}) {}
+func (l *warnRecorder) Info(string, ...interface{})  {}
+func (l *warnRecorder) Warn(string, ...interface{})  { l.warns++ }
+func (l *warnRecorder) Error(string, ...interface{}) {}
+
+func TestDecodeModeUnknownField(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      DecodeMode
+		body      string
+		wantErr   bool
+		wantWarns int
+	}{
+		{"lenient extra field", DecodeModeLenient, `{"code":"0","msg":"success","requestTime":1,"data":{"symbol":"cmt_btcusdt","last":"100","newField":1}}`, false, 0},
+		{"warn extra field", DecodeModeWarn, `{"code":"0","msg":"success","requestTime":1,"data":{"symbol":"cmt_btcusdt","last":"100","newField":1}}`, false, 1},
+		{"strict extra field", DecodeModeStrict, `{"code":"0","msg":"success","requestTime":1,"data":{"symbol":"cmt_btcusdt","last":"100","newField":1}}`, true, 0},
+		{"strict unwrapped extra field", DecodeModeStrict, `{"symbol":"cmt_btcusdt","last":"100","newField":1}`, true, 0},
+		{"strict modeled fields", DecodeModeStrict, `{"code":"0","msg":"success","requestTime":1,"data":{"symbol":"cmt_btcusdt","last":"100"}}`, false, 0},
+		{"warn modeled fields", DecodeModeWarn, `{"code":"0","msg":"success","requestTime":1,"data":{"symbol":"cmt_btcusdt","last":"100"}}`, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &warnRecorder{}
+			c := NewClient("", "", nil, nil, nil, nil, logger)
+			c.SetDecodeMode(tt.mode)
+
+			var got testTicker
+			err := c.parseResponse(http.StatusOK, []byte(tt.body), &got, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if logger.warns != tt.wantWarns {
+				t.Errorf("warns = %d, want %d", logger.warns, tt.wantWarns)
+			}
+			if !tt.wantErr && got != (testTicker{Symbol: "cmt_btcusdt", Last: "100"}) {
+				t.Errorf("got %+v", got)
+			}
+		})
+	}
+}
+
+func TestDecodeModeString(t *testing.T) {
+	tests := []struct {
+		mode DecodeMode
+		want string
+	}{
+		{DecodeModeLenient, "LENIENT"},
+		{DecodeModeWarn, "WARN"},
+		{DecodeModeStrict, "STRICT"},
+		{DecodeMode(9), "UNKNOWN"},
+	}
+	for _, tt := range tests {
+		if got := tt.mode.String(); got != tt.want {
+			t.Errorf("DecodeMode(%d).String() = %q, want %q", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"missing", "", 0},
+		{"seconds", "5", 5 * time.Second},
+		{"seconds with spaces", " 120 ", 2 * time.Minute},
+		{"zero seconds", "0", 0},
+		{"negative seconds", "-3", 0},
+		{"http date", now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second},
+		{"http date in the past", now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"rfc850 date", now.Add(time.Minute).Format(time.RFC850), time.Minute},
+		{"malformed", "soon", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+type stubAuth struct{}
+
+func (stubAuth) GetRESTHeaders(int64, string, string, string) map[string]string { return nil }
+
+// attemptRetrier runs fn up to attempts times while it fails
+type attemptRetrier struct {
+	attempts int
+}
+
+func (r attemptRetrier) DoWithRetryContext(ctx context.Context, fn func(ctx context.Context) error) error {
+	var err error
+	for i := 0; i < r.attempts; i++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// failingLimiter fails WaitForCapacity from the given call on (1-based, 0 = never)
+type failingLimiter struct {
+	failFrom int
+	calls    int
+}
+
+func (l *failingLimiter) WaitForCapacity(context.Context, int, int) error {
+	l.calls++
+	if l.failFrom > 0 && l.calls >= l.failFrom {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestDoRequestNotSent(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		attempts    int
+		failFrom    int // Rate limit wait call that starts failing
+		wantErr     bool
+		wantNotSent bool
+	}{
+		{"success", http.StatusOK, 1, 0, false, false},
+		{"canceled before the first attempt", http.StatusOK, 1, 1, true, true},
+		{"canceled before every attempt", http.StatusOK, 3, 1, true, true},
+		{"sent and failed", http.StatusInternalServerError, 1, 0, true, false},
+		{"canceled before a retry of a sent attempt", http.StatusInternalServerError, 2, 2, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(tt.status)
+				if tt.status != http.StatusOK {
+					w.Write([]byte(`{"code":"50000","msg":"internal error","requestTime":1,"data":null}`))
+					return
+				}
+				w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":"ok"}`))
+			}))
+			defer server.Close()
+
+			limiter := &failingLimiter{failFrom: tt.failFrom}
+			c := NewClient(server.URL, "en-US", server.Client(), stubAuth{}, attemptRetrier{attempts: tt.attempts}, limiter, &warnRecorder{})
+
+			var got string
+			err := c.Post(context.Background(), "/order/placeOrder", map[string]string{"symbol": "cmt_btcusdt"}, &got, 2, 5)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Post() error = %v, want error %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrRequestNotSent) != tt.wantNotSent {
+				t.Errorf("Post() error = %v, want ErrRequestNotSent %v", err, tt.wantNotSent)
+			}
+			if tt.wantNotSent {
+				if requests != 0 {
+					t.Errorf("requests = %d, want 0", requests)
+				}
+				if !errors.Is(err, context.Canceled) || err.Error() != "rate limit wait failed: context canceled" {
+					t.Errorf("Post() error = %q, want the rate limit error unchanged", err)
+				}
+			}
+
+			_, err = c.DoRequestWithMeta(context.Background(), http.MethodGet, "/market/time", nil, &got, 1, 1)
+			if tt.failFrom > 0 && !errors.Is(err, ErrRequestNotSent) {
+				t.Errorf("DoRequestWithMeta() after the limiter failed = %v, want ErrRequestNotSent", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// headersContextKey is the context key for per-request extra headers
+type headersContextKey struct{}
+
+// WithHeaders returns a context that adds headers to REST requests made with it
+//
+// Headers are merged over the client-wide extra headers. Like those, they are
+// not part of the request signature and cannot replace the auth, content type
+// or locale headers set by the SDK.
+//
+// Example:
+//
+//	ctx = rest.WithHeaders(ctx, map[string]string{"X-Correlation-Id": id})
+//	order, err := client.Trade().PlaceOrder(ctx, req)
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	merged := make(map[string]string, len(headers))
+	if existing, ok := ctx.Value(headersContextKey{}).(map[string]string); ok {
+		for k, v := range existing {
+			merged[k] = v
+		}
+	}
+	for k, v := range headers {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, headersContextKey{}, merged)
+}
+
+// SetExtraHeaders sets headers added to every REST request
+// They are not part of the request signature and cannot replace SDK-managed headers
+func (c *Client) SetExtraHeaders(headers map[string]string) {
+	extra := make(map[string]string, len(headers))
+	for k, v := range headers {
+		extra[k] = v
+	}
+	c.extraHeaders = extra
+}
+
+// applyExtraHeaders adds client-wide and context headers to req, skipping reserved headers
+func (c *Client) applyExtraHeaders(ctx context.Context, req *http.Request) {
+	set := func(headers map[string]string) {
+		for key, value := range headers {
+			if isReservedHeader(key) {
+				c.logger.Warn("Ignoring extra header %q: reserved by the SDK", key)
+				continue
+			}
+			req.Header.Set(key, value)
+		}
+	}
+
+	set(c.extraHeaders)
+	if headers, ok := ctx.Value(headersContextKey{}).(map[string]string); ok {
+		set(headers)
+	}
+}
+
+// isReservedHeader returns true for headers managed by the SDK (auth, content type, locale)
+func isReservedHeader(key string) bool {
+	switch http.CanonicalHeaderKey(key) {
+	case http.CanonicalHeaderKey(types.HeaderAccessKey),
+		http.CanonicalHeaderKey(types.HeaderAccessSign),
+		http.CanonicalHeaderKey(types.HeaderAccessPassphrase),
+		http.CanonicalHeaderKey(types.HeaderAccessTimestamp),
+		http.CanonicalHeaderKey(types.HeaderContentType),
+		http.CanonicalHeaderKey(types.HeaderLocale):
+		return true
+	}
+	return strings.HasPrefix(strings.ToUpper(key), "ACCESS-")
+}
@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"net/http"
+)
+
+// RequestHook is called with each outgoing request just before it is sent
+// Hooks run on every attempt, including retries, after all headers are set
+type RequestHook func(req *http.Request)
+
+// ResponseHook is called with each response and its body after the body is read
+// Hooks run on every attempt, including retries, before the response is parsed
+type ResponseHook func(resp *http.Response, body []byte)
+
+// AddRequestHook registers a hook called before every HTTP request
+func (c *Client) AddRequestHook(hook RequestHook) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.requestHooks = append(c.requestHooks, hook)
+}
+
+// AddResponseHook registers a hook called after every HTTP response body is read
+func (c *Client) AddResponseHook(hook ResponseHook) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.responseHooks = append(c.responseHooks, hook)
+}
+
+// runRequestHooks calls the registered request hooks in order
+func (c *Client) runRequestHooks(req *http.Request) {
+	c.hooksMu.RLock()
+	hooks := c.requestHooks
+	c.hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook(req)
+	}
+}
+
+// runResponseHooks calls the registered response hooks in order
+func (c *Client) runResponseHooks(resp *http.Response, body []byte) {
+	c.hooksMu.RLock()
+	hooks := c.responseHooks
+	c.hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook(resp, body)
+	}
+}
@@ -0,0 +1,198 @@
+package market
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// MinimumOrderSize returns the smallest order size accepted for the contract
+// This is the larger of MinOrderSize and the lot size (SizeIncrement)
+func (c *ContractInfo) MinimumOrderSize() (types.Decimal, error) {
+	minSize, err := types.Decimal(c.MinOrderSize).Rat()
+	if err != nil {
+		return "", fmt.Errorf("invalid minOrderSize %q: %w", c.MinOrderSize, err)
+	}
+	lot, err := types.Decimal(c.SizeIncrement).Rat()
+	if err != nil {
+		return "", fmt.Errorf("invalid size_increment %q: %w", c.SizeIncrement, err)
+	}
+	if lot.Cmp(minSize) > 0 {
+		return types.NewDecimalFromRat(lot), nil
+	}
+	return types.NewDecimalFromRat(minSize), nil
+}
+
+// MinimumOrderValue returns the notional value of the minimum order size at price
+// The contract API publishes no separate minimum notional, so this is only the
+// value of the smallest order ValidateOrderSize accepts.
+func (c *ContractInfo) MinimumOrderValue(price types.Decimal) (types.Decimal, error) {
+	minSize, err := c.MinimumOrderSize()
+	if err != nil {
+		return "", err
+	}
+	value, err := minSize.MulErr(price)
+	if err != nil {
+		return "", fmt.Errorf("invalid price %q: %w", price, err)
+	}
+	return value, nil
+}
+
+// ValidateOrderSize checks size against the contract's minimum, maximum and lot size
+// All comparisons are exact decimal arithmetic.
+func (c *ContractInfo) ValidateOrderSize(size types.Decimal) error {
+	s, err := size.Rat()
+	if err != nil || size == "" {
+		return types.NewValidationError("size", "invalid size %q", size)
+	}
+	if s.Sign() <= 0 {
+		return types.NewValidationError("size", "size must be greater than 0, got %s", size)
+	}
+
+	minSize, err := c.MinimumOrderSize()
+	if err != nil {
+		return err
+	}
+	minRat, _ := minSize.Rat()
+	if s.Cmp(minRat) < 0 {
+		return types.NewValidationError("size", "size %s is below the minimum order size %s for %s", size, minSize, c.Symbol)
+	}
+
+	maxSize, err := types.Decimal(c.MaxOrderSize).Rat()
+	if err != nil {
+		return fmt.Errorf("invalid maxOrderSize %q: %w", c.MaxOrderSize, err)
+	}
+	if maxSize.Sign() > 0 && s.Cmp(maxSize) > 0 {
+		return types.NewValidationError("size", "size %s exceeds the maximum order size %s for %s", size, c.MaxOrderSize, c.Symbol)
+	}
+
+	lot, err := types.Decimal(c.SizeIncrement).Rat()
+	if err != nil {
+		return fmt.Errorf("invalid size_increment %q: %w", c.SizeIncrement, err)
+	}
+	if !isMultiple(s, lot) {
+		return types.NewValidationError("size", "size %s is not a multiple of the lot size %s for %s", size, c.SizeIncrement, c.Symbol)
+	}
+
+	return nil
+}
+
+// isMultiple returns true if value is a whole multiple of step; a step of 0 or less matches anything
+func isMultiple(value, step *big.Rat) bool {
+	if step.Sign() <= 0 {
+		return true
+	}
+	return new(big.Rat).Quo(value, step).IsInt()
+}
+
+// ValidatePrice checks that price is positive and an exact multiple of the contract's tick size
+func (c *ContractInfo) ValidatePrice(price types.Decimal) error {
+	p, err := price.Rat()
+	if err != nil || price == "" {
+		return types.NewValidationError("price", "invalid price %q", price)
+	}
+	if p.Sign() <= 0 {
+		return types.NewValidationError("price", "price must be greater than 0, got %s", price)
+	}
+
+	tick, err := types.Decimal(c.TickSize).Rat()
+	if err != nil {
+		return fmt.Errorf("invalid tick_size %q: %w", c.TickSize, err)
+	}
+	if !isMultiple(p, tick) {
+		return types.NewValidationError("price", "price %s is not a multiple of the tick size %s for %s", price, c.TickSize, c.Symbol)
+	}
+	return nil
+}
+
+// ValidateLeverage checks that leverage is a positive number within the contract's
+// MinLeverage and MaxLeverage (limits of 0 are not enforced)
+func (c *ContractInfo) ValidateLeverage(leverage types.Decimal) error {
+	lev, err := leverage.Rat()
+	if err != nil || leverage == "" {
+		return types.NewValidationError("leverage", "invalid leverage %q", leverage)
+	}
+	if lev.Sign() <= 0 {
+		return types.NewValidationError("leverage", "leverage must be greater than 0, got %s", leverage)
+	}
+	if c.MinLeverage > 0 && lev.Cmp(new(big.Rat).SetInt64(int64(c.MinLeverage))) < 0 {
+		return types.NewValidationError("leverage", "leverage %s is below the minimum leverage %d for %s", leverage, c.MinLeverage, c.Symbol)
+	}
+	if c.MaxLeverage > 0 && lev.Cmp(new(big.Rat).SetInt64(int64(c.MaxLeverage))) > 0 {
+		return types.NewValidationError("leverage", "leverage %s exceeds the maximum leverage %d for %s", leverage, c.MaxLeverage, c.Symbol)
+	}
+	return nil
+}
+
+// NormalizeLeverage validates leverage and returns it in canonical form (e.g. " 10.0" -> "10")
+func (c *ContractInfo) NormalizeLeverage(leverage types.Decimal) (types.Decimal, error) {
+	leverage = types.Decimal(strings.TrimSpace(string(leverage)))
+	if err := c.ValidateLeverage(leverage); err != nil {
+		return "", err
+	}
+	lev, _ := leverage.Rat()
+	return types.NewDecimalFromRat(lev), nil
+}
+
+// RoundPrice snaps price to the nearest multiple of the contract's tick size
+// Halves round away from zero. price is returned unchanged if it or the tick size
+// cannot be parsed, or if the contract has no tick size.
+func (c *ContractInfo) RoundPrice(price types.Decimal) types.Decimal {
+	return roundToIncrement(price, c.TickSize)
+}
+
+// RoundSize snaps size to the nearest multiple of the contract's lot size (SizeIncrement)
+// Halves round away from zero. size is returned unchanged if it or the lot size
+// cannot be parsed, or if the contract has no lot size.
+func (c *ContractInfo) RoundSize(size types.Decimal) types.Decimal {
+	return roundToIncrement(size, c.SizeIncrement)
+}
+
+// ValidateOrder checks an order's price, size and leverage against the contract
+// price may be empty for market orders and leverage empty to skip the leverage
+// check; size is checked against the minimum, maximum and lot size, price
+// against the tick size and leverage against the contract's limits. All
+// problems are reported together.
+func (c *ContractInfo) ValidateOrder(price, size, leverage types.Decimal) error {
+	var errs []error
+	if price != "" {
+		if err := c.ValidatePrice(price); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := c.ValidateOrderSize(size); err != nil {
+		errs = append(errs, err)
+	}
+	if leverage != "" {
+		if err := c.ValidateLeverage(leverage); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// roundToIncrement rounds value to the nearest multiple of increment
+func roundToIncrement(value types.Decimal, increment string) types.Decimal {
+	v, err := value.Rat()
+	if err != nil || value == "" {
+		return value
+	}
+	step, err := types.Decimal(increment).Rat()
+	if err != nil || step.Sign() <= 0 {
+		return value
+	}
+
+	// Round |value/step| half up to a whole number of steps, then restore the sign
+	quo := new(big.Rat).Quo(v, step)
+	n, rem := new(big.Int).QuoRem(new(big.Int).Abs(quo.Num()), quo.Denom(), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(quo.Denom()) >= 0 {
+		n.Add(n, big.NewInt(1))
+	}
+	if quo.Sign() < 0 {
+		n.Neg(n)
+	}
+	return types.NewDecimalFromRat(new(big.Rat).Mul(new(big.Rat).SetInt(n), step))
+}
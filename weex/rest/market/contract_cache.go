@@ -0,0 +1,82 @@
+package market
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultContractCacheTTL is the contract cache TTL used by weex.Client and trade.NewService
+const DefaultContractCacheTTL = 5 * time.Minute
+
+// contractCache holds the contract map served by GetContractsMap
+type contractCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	contracts map[string]ContractInfo
+	fetchedAt time.Time
+}
+
+// SetContractCacheTTL caches the GetContractsMap result for ttl (0 disables caching)
+// A service from NewService does not cache; weex.Client sets Config.ContractCacheTTL.
+func (s *Service) SetContractCacheTTL(ttl time.Duration) {
+	s.contracts.mu.Lock()
+	defer s.contracts.mu.Unlock()
+	s.contracts.ttl = ttl
+	if ttl <= 0 {
+		s.contracts.contracts = nil
+	}
+}
+
+// InvalidateContractCache drops the cached contract map so the next GetContractsMap refetches it
+func (s *Service) InvalidateContractCache() {
+	s.contracts.mu.Lock()
+	defer s.contracts.mu.Unlock()
+	s.contracts.contracts = nil
+}
+
+// GetContractsMap gets all contracts keyed by symbol
+// With SetContractCacheTTL the map is reused until it expires, so repeated
+// lookups (e.g. for RoundPrice or order validation) don't hit the network.
+// The returned map is a copy and may be modified by the caller.
+func (s *Service) GetContractsMap(ctx context.Context) (map[string]ContractInfo, error) {
+	s.contracts.mu.Lock()
+	defer s.contracts.mu.Unlock()
+
+	cache := &s.contracts
+	if cache.contracts == nil || cache.ttl <= 0 || time.Since(cache.fetchedAt) >= cache.ttl {
+		list, err := s.GetContracts(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		contracts := make(map[string]ContractInfo, len(list))
+		for _, contract := range list {
+			contracts[contract.Symbol] = contract
+		}
+		if cache.ttl <= 0 {
+			return contracts, nil
+		}
+		cache.contracts = contracts
+		cache.fetchedAt = time.Now()
+	}
+
+	contracts := make(map[string]ContractInfo, len(cache.contracts))
+	for symbol, contract := range cache.contracts {
+		contracts[symbol] = contract
+	}
+	return contracts, nil
+}
+
+// CachedContract returns the contract for symbol from the GetContractsMap cache without fetching
+// ok is false if caching is disabled, the cache is empty or expired, or symbol is not in it.
+func (s *Service) CachedContract(symbol string) (ContractInfo, bool) {
+	s.contracts.mu.Lock()
+	defer s.contracts.mu.Unlock()
+
+	cache := &s.contracts
+	if cache.contracts == nil || cache.ttl <= 0 || time.Since(cache.fetchedAt) >= cache.ttl {
+		return ContractInfo{}, false
+	}
+	contract, ok := cache.contracts[symbol]
+	return contract, ok
+}
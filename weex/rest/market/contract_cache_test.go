@@ -0,0 +1,107 @@
+package market_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+)
+
+// contractServer returns a market service serving two contracts and the count of contract requests
+func contractServer(t *testing.T) (*market.Service, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"symbol":"cmt_btcusdt","tick_size":"0.1"},{"symbol":"cmt_ethusdt","tick_size":"0.01"}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	config := weex.NewDefaultConfig().WithBaseURL(server.URL)
+	config.MaxRetries = 0
+	config.Logger = weex.NewNoOpLogger()
+	client, err := weex.NewPublicClient(config)
+	if err != nil {
+		t.Fatalf("NewPublicClient() error = %v", err)
+	}
+	return client.Market(), &calls
+}
+
+func TestGetContractsMapCache(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		between   func(s *market.Service) // run between the two GetContractsMap calls
+		wantCalls int32
+	}{
+		{"no cache", 0, func(*market.Service) {}, 2},
+		{"second call within ttl", time.Minute, func(*market.Service) {}, 1},
+		{"invalidated", time.Minute, func(s *market.Service) { s.InvalidateContractCache() }, 2},
+		{"expired", 20 * time.Millisecond, func(*market.Service) { time.Sleep(40 * time.Millisecond) }, 2},
+		{"caching disabled", time.Minute, func(s *market.Service) { s.SetContractCacheTTL(0) }, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, calls := contractServer(t)
+			service.SetContractCacheTTL(tt.ttl)
+
+			for i := 0; i < 2; i++ {
+				if i == 1 {
+					tt.between(service)
+				}
+				contracts, err := service.GetContractsMap(context.Background())
+				if err != nil {
+					t.Fatalf("GetContractsMap() error = %v", err)
+				}
+				if len(contracts) != 2 || contracts["cmt_ethusdt"].TickSize != "0.01" {
+					t.Errorf("GetContractsMap() = %+v, want both contracts by symbol", contracts)
+				}
+				// Callers get a copy they may modify
+				delete(contracts, "cmt_btcusdt")
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("HTTP requests = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCachedContract(t *testing.T) {
+	service, calls := contractServer(t)
+	if _, ok := service.CachedContract("cmt_btcusdt"); ok {
+		t.Error("CachedContract() ok before any fetch")
+	}
+
+	service.SetContractCacheTTL(time.Minute)
+	if _, err := service.GetContractsMap(context.Background()); err != nil {
+		t.Fatalf("GetContractsMap() error = %v", err)
+	}
+	tests := []struct {
+		symbol string
+		ok     bool
+	}{
+		{"cmt_btcusdt", true},
+		{"cmt_ethusdt", true},
+		{"cmt_xrpusdt", false},
+	}
+	for _, tt := range tests {
+		contract, ok := service.CachedContract(tt.symbol)
+		if ok != tt.ok || ok && contract.Symbol != tt.symbol {
+			t.Errorf("CachedContract(%s) = %+v, %v, want ok %v", tt.symbol, contract, ok, tt.ok)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("HTTP requests = %d, want 1", got)
+	}
+
+	service.InvalidateContractCache()
+	if _, ok := service.CachedContract("cmt_btcusdt"); ok {
+		t.Error("CachedContract() ok after InvalidateContractCache")
+	}
+}
@@ -0,0 +1,260 @@
+package market
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func testContract() *ContractInfo {
+	return &ContractInfo{
+		Symbol:        "cmt_btcusdt",
+		TickSize:      "0.1",
+		SizeIncrement: "0.001",
+		MinOrderSize:  "0.001",
+		MaxOrderSize:  "100",
+		MinLeverage:   1,
+		MaxLeverage:   125,
+	}
+}
+
+func TestMinimumOrderSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		minSize  string
+		lot      string
+		expected types.Decimal
+		wantErr  bool
+	}{
+		{"min above lot", "0.01", "0.001", "0.01", false},
+		{"lot above min", "0.0001", "0.001", "0.001", false},
+		{"no min", "", "0.001", "0.001", false},
+		{"invalid min", "abc", "0.001", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ContractInfo{MinOrderSize: tt.minSize, SizeIncrement: tt.lot}
+			got, err := c.MinimumOrderSize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MinimumOrderSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("MinimumOrderSize() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMinimumOrderValue(t *testing.T) {
+	c := &ContractInfo{MinOrderSize: "0.001", SizeIncrement: "0.001"}
+	got, err := c.MinimumOrderValue("65432.1")
+	if err != nil {
+		t.Fatalf("MinimumOrderValue() error = %v", err)
+	}
+	if got != "65.4321" {
+		t.Errorf("MinimumOrderValue() = %s, want 65.4321", got)
+	}
+	if _, err := c.MinimumOrderValue("abc"); err == nil {
+		t.Error("MinimumOrderValue(abc) expected error")
+	}
+}
+
+func TestValidateOrderSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    types.Decimal
+		wantErr bool
+	}{
+		{"valid", "0.5", false},
+		{"minimum", "0.001", false},
+		{"maximum", "100", false},
+		{"empty", "", true},
+		{"not a number", "abc", true},
+		{"zero", "0", true},
+		{"negative", "-1", true},
+		{"below minimum", "0.0005", true},
+		{"above maximum", "100.001", true},
+		{"off lot", "0.0015", true},
+		{"off lot by a tiny amount at large size", "99.9990000001", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testContract().ValidateOrderSize(tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOrderSize(%q) error = %v, wantErr %v", tt.size, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateOrderSizeField(t *testing.T) {
+	c := &ContractInfo{Symbol: "cmt_x", MinOrderSize: "0.3", SizeIncrement: "0.1"}
+	err := c.ValidateOrderSize("0.2")
+	if err == nil {
+		t.Fatal("expected error for size below minimum")
+	}
+	fields := types.ValidationErrors(err)
+	if len(fields) != 1 || fields[0].Field != "size" {
+		t.Errorf("ValidationErrors = %v, want one size error", fields)
+	}
+}
+
+func TestValidatePrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		tick    string
+		price   types.Decimal
+		wantErr bool
+	}{
+		{"on tick", "0.1", "65432.1", false},
+		{"integer", "0.1", "100000", false},
+		{"off tick", "0.1", "65432.15", true},
+		{"off tick by a tiny amount at large price", "0.1", "100000.00001", true},
+		{"tick 0.5", "0.5", "10.5", false},
+		{"tick 0.5 off", "0.5", "10.25", true},
+		{"no tick size", "", "10.123456", false},
+		{"empty", "0.1", "", true},
+		{"zero", "0.1", "0", true},
+		{"negative", "0.1", "-1", true},
+		{"not a number", "0.1", "abc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ContractInfo{Symbol: "cmt_btcusdt", TickSize: tt.tick}
+			err := c.ValidatePrice(tt.price)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePrice(%q) error = %v, wantErr %v", tt.price, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		price      types.Decimal
+		size       types.Decimal
+		leverage   types.Decimal
+		wantFields []string
+	}{
+		{"valid limit", "65432.1", "0.01", "20", nil},
+		{"valid market, no leverage", "", "0.01", "", nil},
+		{"bad price", "65432.15", "0.01", "", []string{"price"}},
+		{"bad size", "65432.1", "0.0015", "", []string{"size"}},
+		{"leverage above max", "65432.1", "0.01", "200", []string{"leverage"}},
+		{"leverage below min", "65432.1", "0.01", "0.5", []string{"leverage"}},
+		{"everything wrong", "1.05", "0", "0", []string{"price", "size", "leverage"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testContract().ValidateOrder(tt.price, tt.size, tt.leverage)
+			fields := types.ValidationErrors(err)
+			if len(fields) != len(tt.wantFields) {
+				t.Fatalf("ValidateOrder() = %v, want fields %v", err, tt.wantFields)
+			}
+			for i, field := range tt.wantFields {
+				if fields[i].Field != field {
+					t.Errorf("error %d field = %q, want %q", i, fields[i].Field, field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateLeverage(t *testing.T) {
+	tests := []struct {
+		name     string
+		leverage types.Decimal
+		expected types.Decimal // normalized form, empty if rejected
+	}{
+		{"valid", "20", "20"},
+		{"min", "1", "1"},
+		{"max", "125", "125"},
+		{"fractional", "2.5", "2.5"},
+		{"canonical", " 10.0", "10"},
+		{"over max", "126", ""},
+		{"over max fractional", "125.0000001", ""},
+		{"below min", "0.5", ""},
+		{"zero", "0", ""},
+		{"negative", "-5", ""},
+		{"empty", "", ""},
+		{"malformed", "ten", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testContract().NormalizeLeverage(tt.leverage)
+			if tt.expected == "" {
+				if err == nil {
+					t.Fatalf("NormalizeLeverage(%q) = %s, want error", tt.leverage, got)
+				}
+				if fields := types.ValidationErrors(err); len(fields) != 1 || fields[0].Field != "leverage" {
+					t.Errorf("NormalizeLeverage(%q) error = %v, want leverage validation error", tt.leverage, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeLeverage(%q) error = %v", tt.leverage, err)
+			}
+			if got != tt.expected {
+				t.Errorf("NormalizeLeverage(%q) = %s, want %s", tt.leverage, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateLeverageNoLimits(t *testing.T) {
+	c := &ContractInfo{Symbol: "cmt_btcusdt"}
+	if err := c.ValidateLeverage("500"); err != nil {
+		t.Errorf("ValidateLeverage(500) without limits error = %v", err)
+	}
+	if err := c.ValidateLeverage("0"); err == nil {
+		t.Error("ValidateLeverage(0) without limits expected error")
+	}
+}
+
+func TestValidatorFields(t *testing.T) {
+	c := testContract()
+	tests := []struct {
+		name  string
+		err   error
+		field string // empty when the value is valid
+	}{
+		{"symbol valid", ValidateSymbol("cmt_btcusdt"), ""},
+		{"symbol empty", ValidateSymbol(""), "symbol"},
+		{"interval valid", ValidateInterval("1m"), ""},
+		{"interval empty", ValidateInterval(""), "interval"},
+		{"interval unsupported", ValidateInterval("7m"), "interval"},
+		{"size valid", c.ValidateOrderSize("0.002"), ""},
+		{"size above maximum", c.ValidateOrderSize("101"), "size"},
+		{"size off lot", c.ValidateOrderSize("0.0015"), "size"},
+		{"price valid", c.ValidatePrice("100000.1"), ""},
+		{"price zero", c.ValidatePrice("0"), "price"},
+		{"price off tick", c.ValidatePrice("100000.05"), "price"},
+		{"leverage valid", c.ValidateLeverage("20"), ""},
+		{"leverage above maximum", c.ValidateLeverage("126"), "leverage"},
+		{"leverage invalid", c.ValidateLeverage("x"), "leverage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.field == "" {
+				if tt.err != nil {
+					t.Errorf("err = %v, want nil", tt.err)
+				}
+				return
+			}
+			var v *types.ValidationError
+			if !errors.As(tt.err, &v) {
+				t.Fatalf("errors.As(%v) = false, want a ValidationError", tt.err)
+			}
+			if v.Field != tt.field {
+				t.Errorf("Field = %q, want %q", v.Field, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package market
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// EstimateFillPrice estimates the fill of a market order by walking the book
+//
+// A BUY consumes asks from the best (lowest) price upward and a SELL consumes
+// bids from the best (highest) price downward, until size is met or the book
+// is exhausted.
+//
+// Returns the volume-weighted average price and the filled quantity. If the
+// book is too thin, filled is less than size and avgPrice covers only the
+// filled part. Sums are exact; avgPrice is cut at 18 decimal places if it
+// does not terminate.
+func (d *Depth) EstimateFillPrice(side types.OrderSide, size types.Decimal) (avgPrice types.Decimal, filled types.Decimal, err error) {
+	target, err := size.Rat()
+	if err != nil {
+		return "", "", fmt.Errorf("invalid size %q: %w", size, err)
+	}
+	if target.Sign() <= 0 {
+		return "", "", fmt.Errorf("size must be greater than 0, got %s", size)
+	}
+
+	var levels [][]string
+	switch side {
+	case types.OrderSideBuy:
+		levels = d.Asks
+	case types.OrderSideSell:
+		levels = d.Bids
+	default:
+		return "", "", fmt.Errorf("side must be BUY or SELL, got %q", side)
+	}
+
+	filledQty, notional := new(big.Rat), new(big.Rat)
+	for i, level := range levels {
+		if filledQty.Cmp(target) >= 0 {
+			break
+		}
+		if len(level) < 2 {
+			return "", "", fmt.Errorf("malformed depth level %d: %v", i, level)
+		}
+		price, err := types.Decimal(level[0]).Rat()
+		if err != nil {
+			return "", "", fmt.Errorf("invalid price at depth level %d: %w", i, err)
+		}
+		qty, err := types.Decimal(level[1]).Rat()
+		if err != nil {
+			return "", "", fmt.Errorf("invalid quantity at depth level %d: %w", i, err)
+		}
+
+		take := qty
+		if remaining := new(big.Rat).Sub(target, filledQty); take.Cmp(remaining) > 0 {
+			take = remaining
+		}
+		filledQty.Add(filledQty, take)
+		notional.Add(notional, new(big.Rat).Mul(take, price))
+	}
+
+	if filledQty.Sign() == 0 {
+		return "", types.NewDecimal(0), fmt.Errorf("no liquidity on %s side", side)
+	}
+
+	return types.NewDecimalFromRat(new(big.Rat).Quo(notional, filledQty)), types.NewDecimalFromRat(filledQty), nil
+}
@@ -0,0 +1,65 @@
+package market_test
+
+import (
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestEstimateFillPrice(t *testing.T) {
+	book := &market.Depth{
+		Asks: [][]string{{"100.1", "1"}, {"100.2", "2"}, {"100.5", "3"}},
+		Bids: [][]string{{"100", "0.5"}, {"99.9", "1.5"}, {"99", "2"}},
+	}
+	tests := []struct {
+		name       string
+		side       types.OrderSide
+		size       types.Decimal
+		wantPrice  types.Decimal
+		wantFilled types.Decimal
+		wantErr    bool
+	}{
+		{"buy best level", types.OrderSideBuy, "0.4", "100.1", "0.4", false},
+		{"buy across levels", types.OrderSideBuy, "3", "100.166666666666666667", "3", false},
+		{"buy exact", types.OrderSideBuy, "2.5", "100.16", "2.5", false},
+		{"buy too thin", types.OrderSideBuy, "10", "100.333333333333333333", "6", false},
+		{"sell best level", types.OrderSideSell, "0.5", "100", "0.5", false},
+		{"sell across levels", types.OrderSideSell, "1", "99.95", "1", false},
+		{"sell too thin", types.OrderSideSell, "5", "99.4625", "4", false},
+		{"zero size", types.OrderSideBuy, "0", "", "", true},
+		{"negative size", types.OrderSideSell, "-1", "", "", true},
+		{"malformed size", types.OrderSideBuy, "abc", "", "", true},
+		{"invalid side", types.OrderSide("HOLD"), "1", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, filled, err := book.EstimateFillPrice(tt.side, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EstimateFillPrice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if price != tt.wantPrice || filled != tt.wantFilled {
+				t.Errorf("EstimateFillPrice() = (%s, %s), want (%s, %s)", price, filled, tt.wantPrice, tt.wantFilled)
+			}
+		})
+	}
+}
+
+func TestEstimateFillPriceBadBook(t *testing.T) {
+	tests := []struct {
+		name string
+		book market.Depth
+	}{
+		{"empty", market.Depth{}},
+		{"short level", market.Depth{Asks: [][]string{{"100"}}}},
+		{"bad price", market.Depth{Asks: [][]string{{"x", "1"}}}},
+		{"bad quantity", market.Depth{Asks: [][]string{{"100", "y"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tt.book.EstimateFillPrice(types.OrderSideBuy, "1"); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// PriceDeviation describes a symbol whose last price deviates from its index price
+type PriceDeviation struct {
+	Symbol     string        // Contract symbol
+	LastPrice  types.Decimal // Last traded price
+	IndexPrice types.Decimal // Index price
+	Deviation  types.Decimal // |last - index| / index
+}
+
+// GetIndexDeviations fetches all tickers and returns the symbols whose
+// |lastPrice - indexPrice| / indexPrice exceeds threshold
+//
+// If symbols is empty, every ticker is checked. Symbols without a ticker or
+// with a missing index price are skipped. Results are sorted by symbol.
+func (s *Service) GetIndexDeviations(ctx context.Context, symbols []string, threshold types.Decimal) ([]PriceDeviation, error) {
+	tickers, err := s.GetAllTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return IndexDeviations(tickers, symbols, threshold)
+}
+
+// IndexDeviations returns the tickers whose last price deviates from the index
+// price by more than threshold (e.g. "0.01" for 1%)
+// Deviations are computed and compared exactly.
+func IndexDeviations(tickers []Ticker, symbols []string, threshold types.Decimal) ([]PriceDeviation, error) {
+	limit, err := threshold.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("invalid threshold %q: %w", threshold, err)
+	}
+	if limit.Sign() < 0 {
+		return nil, fmt.Errorf("threshold cannot be negative, got %s", threshold)
+	}
+
+	var wanted map[string]bool
+	if len(symbols) > 0 {
+		wanted = make(map[string]bool, len(symbols))
+		for _, symbol := range symbols {
+			wanted[symbol] = true
+		}
+	}
+
+	var deviations []PriceDeviation
+	for _, t := range tickers {
+		if wanted != nil && !wanted[t.Symbol] {
+			continue
+		}
+		if t.IndexPrice == "" || t.Last == "" {
+			continue
+		}
+		last, err := types.Decimal(t.Last).Rat()
+		if err != nil {
+			return nil, fmt.Errorf("invalid last price for %s: %w", t.Symbol, err)
+		}
+		index, err := types.Decimal(t.IndexPrice).Rat()
+		if err != nil {
+			return nil, fmt.Errorf("invalid index price for %s: %w", t.Symbol, err)
+		}
+		if index.Sign() <= 0 {
+			continue
+		}
+
+		deviation := new(big.Rat).Sub(last, index)
+		deviation.Abs(deviation).Quo(deviation, index)
+		if deviation.Cmp(limit) > 0 {
+			deviations = append(deviations, PriceDeviation{
+				Symbol:     t.Symbol,
+				LastPrice:  types.Decimal(t.Last),
+				IndexPrice: types.Decimal(t.IndexPrice),
+				Deviation:  types.NewDecimalFromRat(deviation),
+			})
+		}
+	}
+
+	sort.Slice(deviations, func(i, j int) bool {
+		return deviations[i].Symbol < deviations[j].Symbol
+	})
+	return deviations, nil
+}
@@ -0,0 +1,81 @@
+package market_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestIndexDeviations(t *testing.T) {
+	tickers := []market.Ticker{
+		{Symbol: "cmt_btcusdt", Last: "60000", IndexPrice: "60300"}, // 0.4975% below
+		{Symbol: "cmt_ethusdt", Last: "3060", IndexPrice: "3000"},   // 2% above
+		{Symbol: "cmt_solusdt", Last: "101", IndexPrice: "100"},     // exactly 1%
+		{Symbol: "cmt_dogeusdt", Last: "0.09", IndexPrice: "0.1"},   // 10% below
+		{Symbol: "cmt_xrpusdt", Last: "0.5", IndexPrice: ""},        // no index price
+		{Symbol: "cmt_adausdt", Last: "0.4", IndexPrice: "0"},       // zero index price
+	}
+	tests := []struct {
+		name      string
+		symbols   []string
+		threshold types.Decimal
+		want      []market.PriceDeviation
+		wantErr   bool
+	}{
+		{"all symbols", nil, "0.01", []market.PriceDeviation{
+			{Symbol: "cmt_dogeusdt", LastPrice: "0.09", IndexPrice: "0.1", Deviation: "0.1"},
+			{Symbol: "cmt_ethusdt", LastPrice: "3060", IndexPrice: "3000", Deviation: "0.02"},
+		}, false},
+		{"subset", []string{"cmt_btcusdt", "cmt_ethusdt"}, "0.01", []market.PriceDeviation{
+			{Symbol: "cmt_ethusdt", LastPrice: "3060", IndexPrice: "3000", Deviation: "0.02"},
+		}, false},
+		{"boundary is not a deviation", []string{"cmt_solusdt"}, "0.01", nil, false},
+		{"just below boundary", []string{"cmt_solusdt"}, "0.0099", []market.PriceDeviation{
+			{Symbol: "cmt_solusdt", LastPrice: "101", IndexPrice: "100", Deviation: "0.01"},
+		}, false},
+		{"unknown symbol", []string{"cmt_foousdt"}, "0", nil, false},
+		{"negative threshold", nil, "-0.01", nil, true},
+		{"bad threshold", nil, "1%", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := market.IndexDeviations(tickers, tt.symbols, tt.threshold)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IndexDeviations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IndexDeviations() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexDeviationsBadPrice(t *testing.T) {
+	tickers := []market.Ticker{{Symbol: "cmt_btcusdt", Last: "n/a", IndexPrice: "60000"}}
+	if _, err := market.IndexDeviations(tickers, nil, "0.01"); err == nil {
+		t.Error("expected an error for a malformed last price")
+	}
+}
+
+func TestGetIndexDeviations(t *testing.T) {
+	var uri string
+	svc := newTestMarket(t, `{"code":"0","msg":"success","requestTime":1,"data":[
+		{"symbol":"cmt_btcusdt","last":"60000","indexPrice":"60010"},
+		{"symbol":"cmt_ethusdt","last":"2900","indexPrice":"3000"}
+	]}`, &uri)
+
+	got, err := svc.GetIndexDeviations(context.Background(), nil, "0.005")
+	if err != nil {
+		t.Fatalf("GetIndexDeviations() error = %v", err)
+	}
+	want := []market.PriceDeviation{{Symbol: "cmt_ethusdt", LastPrice: "2900", IndexPrice: "3000", Deviation: "0.033333333333333333"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetIndexDeviations() = %+v, want %+v", got, want)
+	}
+	if uri != "/capi/v2/market/tickers" {
+		t.Errorf("request = %s, want /capi/v2/market/tickers", uri)
+	}
+}
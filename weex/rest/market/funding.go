@@ -0,0 +1,43 @@
+package market
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// ProjectFundingCost projects the cumulative funding of holding a position for periods funding intervals
+// notional is the position's absolute value in the quote currency and rate
+// the predicted funding rate per period (e.g. FundingRate.FundingRate). The
+// result is the funding paid: positive when the position pays (a long at a
+// positive rate), negative when it receives. The rate is assumed constant,
+// so the result is notional * rate * periods with the sign set by side.
+func ProjectFundingCost(notional types.Decimal, side types.PositionSide, rate types.Decimal, periods int) (types.Decimal, error) {
+	if periods < 0 {
+		return "", fmt.Errorf("periods must not be negative, got %d", periods)
+	}
+	if sign, err := notional.CmpErr("0"); err != nil {
+		return "", fmt.Errorf("invalid notional %q: %w", notional, err)
+	} else if sign < 0 {
+		return "", fmt.Errorf("notional must not be negative, got %s", notional)
+	}
+
+	perPeriod, err := notional.MulErr(rate)
+	if err != nil {
+		return "", fmt.Errorf("invalid funding rate %q: %w", rate, err)
+	}
+	switch side {
+	case types.PositionSideLong:
+	case types.PositionSideShort:
+		perPeriod = perPeriod.Neg()
+	default:
+		return "", fmt.Errorf("invalid position side %q", side)
+	}
+	return perPeriod.MulErr(types.Decimal(strconv.Itoa(periods)))
+}
+
+// ProjectCost projects the cumulative funding of a position at this rate; see ProjectFundingCost
+func (f *FundingRate) ProjectCost(notional types.Decimal, side types.PositionSide, periods int) (types.Decimal, error) {
+	return ProjectFundingCost(notional, side, types.Decimal(f.FundingRate), periods)
+}
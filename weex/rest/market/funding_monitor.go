@@ -0,0 +1,197 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// FundingEventType represents the kind of funding rate transition
+type FundingEventType int
+
+const (
+	FundingEventSignFlip       FundingEventType = iota // Funding rate changed sign (crossed zero)
+	FundingEventAboveThreshold                         // Funding rate magnitude rose to or above the threshold
+	FundingEventBelowThreshold                         // Funding rate magnitude fell below the threshold
+)
+
+// String returns the string representation of FundingEventType
+func (t FundingEventType) String() string {
+	switch t {
+	case FundingEventSignFlip:
+		return "SIGN_FLIP"
+	case FundingEventAboveThreshold:
+		return "ABOVE_THRESHOLD"
+	case FundingEventBelowThreshold:
+		return "BELOW_THRESHOLD"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// FundingEvent describes a funding rate transition detected by FundingMonitor
+type FundingEvent struct {
+	Symbol   string           // Contract symbol
+	Type     FundingEventType // Transition type
+	Previous types.Decimal    // Last confirmed funding rate before the transition
+	Current  types.Decimal    // Funding rate that confirmed the transition
+	Time     time.Time        // Time the transition was confirmed
+}
+
+// FundingMonitor detects funding rate sign changes and threshold crossings for a symbol
+//
+// A transition is only reported after it has been observed on Debounce
+// consecutive samples, so a single noisy sample does not emit an event.
+// A zero rate keeps the previous sign.
+type FundingMonitor struct {
+	service   *Service
+	symbol    string
+	interval  time.Duration
+	threshold types.Decimal
+	debounce  int
+
+	mu            sync.Mutex
+	initialized   bool
+	lastRate      types.Decimal // Last rate at a confirmed state
+	sign          int           // Confirmed sign (-1, 0 before any non-zero sample, +1)
+	above         bool          // Confirmed |rate| >= threshold
+	pendingSignN  int           // Consecutive samples with the opposite sign
+	pendingAboveN int           // Consecutive samples on the other side of the threshold
+}
+
+// NewFundingMonitor creates a new FundingMonitor
+//
+// Parameters:
+//   - service: Market service used to poll GetFundingRate
+//   - symbol: Contract symbol to monitor
+//   - interval: Polling interval
+//   - threshold: Magnitude threshold; its sign is ignored (empty or 0 disables threshold events)
+func NewFundingMonitor(service *Service, symbol string, interval time.Duration, threshold types.Decimal) *FundingMonitor {
+	return &FundingMonitor{
+		service:   service,
+		symbol:    symbol,
+		interval:  interval,
+		threshold: threshold,
+		debounce:  1,
+	}
+}
+
+// SetDebounce sets how many consecutive samples must confirm a transition (default: 1)
+func (m *FundingMonitor) SetDebounce(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n < 1 {
+		n = 1
+	}
+	m.debounce = n
+}
+
+// Run polls the funding rate until ctx is done, invoking handler for each event
+// Polling errors are returned only if the first poll fails; later errors are skipped
+func (m *FundingMonitor) Run(ctx context.Context, handler func(FundingEvent)) error {
+	if err := m.poll(ctx, handler); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			_ = m.poll(ctx, handler)
+		}
+	}
+}
+
+// poll fetches the current funding rate and dispatches any resulting events
+func (m *FundingMonitor) poll(ctx context.Context, handler func(FundingEvent)) error {
+	rate, err := m.service.GetFundingRate(ctx, m.symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get funding rate for %s: %w", m.symbol, err)
+	}
+	events, err := m.Observe(types.Decimal(rate.FundingRate), time.Now())
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		handler(event)
+	}
+	return nil
+}
+
+// Observe feeds a funding rate sample and returns the transitions it confirms
+// The first sample only initializes state and never emits events
+// Rates and the threshold are compared exactly; an unparsable rate or
+// threshold returns an error and leaves the state unchanged
+func (m *FundingMonitor) Observe(rate types.Decimal, now time.Time) ([]FundingEvent, error) {
+	value, err := rate.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("invalid funding rate: %w", err)
+	}
+	threshold, err := m.threshold.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("invalid funding threshold: %w", err)
+	}
+	threshold.Abs(threshold)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sign := m.sign
+	if s := value.Sign(); s != 0 {
+		sign = s
+	}
+	above := threshold.Sign() > 0 && new(big.Rat).Abs(value).Cmp(threshold) >= 0
+
+	if !m.initialized {
+		m.initialized = true
+		m.sign = sign
+		m.above = above
+		m.lastRate = rate
+		return nil, nil
+	}
+
+	var events []FundingEvent
+
+	// Sign transition (the first non-zero sign after zero is adopted silently)
+	if m.sign == 0 {
+		m.sign = sign
+	} else if sign != m.sign {
+		m.pendingSignN++
+		if m.pendingSignN >= m.debounce {
+			events = append(events, FundingEvent{Symbol: m.symbol, Type: FundingEventSignFlip, Previous: m.lastRate, Current: rate, Time: now})
+			m.sign = sign
+			m.pendingSignN = 0
+		}
+	} else {
+		m.pendingSignN = 0
+	}
+
+	// Threshold transition
+	if above != m.above {
+		m.pendingAboveN++
+		if m.pendingAboveN >= m.debounce {
+			eventType := FundingEventBelowThreshold
+			if above {
+				eventType = FundingEventAboveThreshold
+			}
+			events = append(events, FundingEvent{Symbol: m.symbol, Type: eventType, Previous: m.lastRate, Current: rate, Time: now})
+			m.above = above
+			m.pendingAboveN = 0
+		}
+	} else {
+		m.pendingAboveN = 0
+	}
+
+	if len(events) > 0 || (m.pendingSignN == 0 && m.pendingAboveN == 0) {
+		m.lastRate = rate
+	}
+	return events, nil
+}
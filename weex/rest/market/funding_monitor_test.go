@@ -0,0 +1,115 @@
+package market_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestFundingMonitorObserve(t *testing.T) {
+	type event struct {
+		At   int // Index of the sample that emitted the event
+		Type market.FundingEventType
+	}
+	tests := []struct {
+		name      string
+		threshold types.Decimal
+		debounce  int
+		rates     []types.Decimal
+		want      []event
+	}{
+		{"no transitions", "", 1, []types.Decimal{"0.0001", "0.0002", "0.0001"}, nil},
+		{"sign flips", "", 1, []types.Decimal{"0.0001", "-0.0001", "-0.0002", "0.0003"},
+			[]event{{1, market.FundingEventSignFlip}, {3, market.FundingEventSignFlip}}},
+		{"zero keeps sign", "", 1, []types.Decimal{"0.0001", "0", "0.0002", "0", "-0.0001"},
+			[]event{{4, market.FundingEventSignFlip}}},
+		{"first non-zero after zero is silent", "0", 1, []types.Decimal{"0", "-0.0001", "0.0001"},
+			[]event{{2, market.FundingEventSignFlip}}},
+		{"threshold crossings", "0.001", 1, []types.Decimal{"0.0005", "0.001", "0.002", "0.0009", "-0.0012"},
+			[]event{{1, market.FundingEventAboveThreshold}, {3, market.FundingEventBelowThreshold},
+				{4, market.FundingEventSignFlip}, {4, market.FundingEventAboveThreshold}}},
+		{"negative threshold is a magnitude", "-0.001", 1, []types.Decimal{"-0.0005", "-0.0015"},
+			[]event{{1, market.FundingEventAboveThreshold}}},
+		{"debounce ignores a single noisy sample", "", 2, []types.Decimal{"0.0001", "-0.0001", "0.0001", "-0.0001", "-0.0002"},
+			[]event{{4, market.FundingEventSignFlip}}},
+		{"threshold is exact", "0.0003", 1, []types.Decimal{"0.00029999999999999999", "0.0003", "0.00030000000000000001", "-0.00029999999999999999"},
+			[]event{{1, market.FundingEventAboveThreshold}, {3, market.FundingEventSignFlip}, {3, market.FundingEventBelowThreshold}}},
+		{"debounce threshold", "0.001", 3, []types.Decimal{"0.0005", "0.002", "0.002", "0.0005", "0.002", "0.002", "0.002"},
+			[]event{{6, market.FundingEventAboveThreshold}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := market.NewFundingMonitor(nil, "cmt_btcusdt", time.Second, tt.threshold)
+			m.SetDebounce(tt.debounce)
+
+			var got []event
+			for i, rate := range tt.rates {
+				events, err := m.Observe(rate, time.Unix(int64(i), 0))
+				if err != nil {
+					t.Fatalf("sample %d: Observe() error = %v", i, err)
+				}
+				for _, e := range events {
+					if e.Symbol != "cmt_btcusdt" || e.Current != rate || !e.Time.Equal(time.Unix(int64(i), 0)) {
+						t.Errorf("sample %d: event = %+v", i, e)
+					}
+					got = append(got, event{i, e.Type})
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFundingMonitorEventPrevious(t *testing.T) {
+	m := market.NewFundingMonitor(nil, "cmt_btcusdt", time.Second, "")
+	m.SetDebounce(2)
+
+	var events []market.FundingEvent
+	for _, rate := range []types.Decimal{"0.0003", "-0.0001", "-0.0002"} {
+		got, err := m.Observe(rate, time.Now())
+		if err != nil {
+			t.Fatalf("Observe(%s) error = %v", rate, err)
+		}
+		events = append(events, got...)
+	}
+	if len(events) != 1 {
+		t.Fatalf("events = %+v, want one sign flip", events)
+	}
+	if events[0].Previous != "0.0003" || events[0].Current != "-0.0002" {
+		t.Errorf("Previous, Current = %v, %v, want 0.0003, -0.0002", events[0].Previous, events[0].Current)
+	}
+}
+
+func TestFundingMonitorObserveInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold types.Decimal
+		rate      types.Decimal
+	}{
+		{"invalid rate", "0.001", "abc"},
+		{"invalid threshold", "abc", "0.0001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := market.NewFundingMonitor(nil, "cmt_btcusdt", time.Second, tt.threshold)
+			if _, err := m.Observe(tt.rate, time.Now()); err == nil {
+				t.Fatal("Observe() error = nil, want error")
+			}
+		})
+	}
+
+	// A rejected sample must not initialize the monitor
+	m := market.NewFundingMonitor(nil, "cmt_btcusdt", time.Second, "")
+	for _, rate := range []types.Decimal{"bad", "0.0001"} {
+		_, _ = m.Observe(rate, time.Now())
+	}
+	events, err := m.Observe("-0.0001", time.Now())
+	if err != nil || len(events) != 1 || events[0].Previous != "0.0001" {
+		t.Errorf("Observe() = %+v, %v, want one sign flip from 0.0001", events, err)
+	}
+}
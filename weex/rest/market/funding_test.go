@@ -0,0 +1,66 @@
+package market
+
+import (
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestProjectFundingCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		notional types.Decimal
+		side     types.PositionSide
+		rate     types.Decimal
+		periods  int
+		want     types.Decimal
+		wantErr  bool
+	}{
+		{"long pays positive rate", "10000", types.PositionSideLong, "0.0001", 3, "3", false},
+		{"short receives positive rate", "10000", types.PositionSideShort, "0.0001", 3, "-3", false},
+		{"long receives negative rate", "10000", types.PositionSideLong, "-0.00025", 8, "-20", false},
+		{"short pays negative rate", "10000", types.PositionSideShort, "-0.00025", 8, "20", false},
+		{"exact with many decimals", "12345.6789", types.PositionSideLong, "0.000123456789", 21, "32.0073153754000941", false},
+		{"one period", "0.1", types.PositionSideLong, "0.1", 1, "0.01", false},
+		{"zero periods", "10000", types.PositionSideLong, "0.0001", 0, "0", false},
+		{"zero rate", "10000", types.PositionSideShort, "0", 3, "0", false},
+		{"negative periods", "10000", types.PositionSideLong, "0.0001", -1, "", true},
+		{"negative notional", "-10000", types.PositionSideLong, "0.0001", 3, "", true},
+		{"invalid notional", "abc", types.PositionSideLong, "0.0001", 3, "", true},
+		{"invalid rate", "10000", types.PositionSideLong, "n/a", 3, "", true},
+		{"invalid side", "10000", "BOTH", "0.0001", 3, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProjectFundingCost(tt.notional, tt.side, tt.rate, tt.periods)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProjectFundingCost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Cmp(tt.want) != 0 {
+				t.Errorf("ProjectFundingCost() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFundingRateProjectCost(t *testing.T) {
+	f := &FundingRate{Symbol: "cmt_btcusdt", FundingRate: "0.0001"}
+	tests := []struct {
+		side types.PositionSide
+		want types.Decimal
+	}{
+		{types.PositionSideLong, "15"},
+		{types.PositionSideShort, "-15"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.side), func(t *testing.T) {
+			got, err := f.ProjectCost("50000", tt.side, 3)
+			if err != nil {
+				t.Fatalf("ProjectCost() error = %v", err)
+			}
+			if got.Cmp(tt.want) != 0 {
+				t.Errorf("ProjectCost() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
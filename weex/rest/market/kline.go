@@ -0,0 +1,78 @@
+package market
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// OpenTime returns the kline open time in milliseconds
+func (k Kline) OpenTime() (int64, error) {
+	if len(k) == 0 {
+		return 0, fmt.Errorf("empty kline")
+	}
+	ts, err := strconv.ParseInt(k[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid kline timestamp %q: %w", k[0], err)
+	}
+	return ts, nil
+}
+
+// KlineGap is a range of missing kline slots
+type KlineGap struct {
+	From    int64 // Open time of the first missing kline (milliseconds)
+	To      int64 // Open time of the last missing kline (milliseconds)
+	Missing int   // Number of missing klines
+}
+
+// DetectKlineGaps returns the missing OpenTime slots in klines for the given interval
+//
+// Klines may be in any order; duplicates are ignored. If until is non-zero
+// (e.g. the server time in milliseconds), slots after the last kline that
+// should have closed by until are also reported, so a feed that stopped
+// early is detected. Months are not fixed-length, so interval must be a fixed
+// duration.
+func DetectKlineGaps(klines []Kline, interval time.Duration, until int64) ([]KlineGap, error) {
+	step := interval.Milliseconds()
+	if step <= 0 {
+		return nil, fmt.Errorf("interval must be at least 1ms, got %v", interval)
+	}
+
+	times := make([]int64, 0, len(klines))
+	for i, k := range klines {
+		ts, err := k.OpenTime()
+		if err != nil {
+			return nil, fmt.Errorf("kline %d: %w", i, err)
+		}
+		times = append(times, ts)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+
+	var gaps []KlineGap
+	for i := 1; i < len(times); i++ {
+		if diff := times[i] - times[i-1]; diff > step {
+			missing := (diff - 1) / step
+			gaps = append(gaps, KlineGap{
+				From:    times[i-1] + step,
+				To:      times[i-1] + missing*step,
+				Missing: int(missing),
+			})
+		}
+	}
+
+	// Trailing slots that should have closed by until
+	if until > 0 && len(times) > 0 {
+		last := times[len(times)-1]
+		if next := last + step; next+step <= until {
+			missing := int((until - next) / step)
+			gaps = append(gaps, KlineGap{
+				From:    next,
+				To:      next + int64(missing-1)*step,
+				Missing: missing,
+			})
+		}
+	}
+
+	return gaps, nil
+}
@@ -0,0 +1,178 @@
+package market
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// MaxHistoryKlineLimit is the maximum number of klines GetHistoryKlines returns per request
+const MaxHistoryKlineLimit = 1000
+
+// KlineFormat is the output format of StreamHistoryKlines
+type KlineFormat int
+
+const (
+	KlineFormatCSV   KlineFormat = iota // One comma-separated row per kline, no header
+	KlineFormatJSONL                    // One JSON array per line
+)
+
+// String returns the string representation of KlineFormat
+func (f KlineFormat) String() string {
+	switch f {
+	case KlineFormatCSV:
+		return "CSV"
+	case KlineFormatJSONL:
+		return "JSONL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// KlineStreamProgress reports the progress of StreamHistoryKlines after each page
+type KlineStreamProgress struct {
+	Pages        int   // Pages fetched so far
+	Rows         int   // Klines written so far
+	LastOpenTime int64 // Open time of the last kline written (milliseconds)
+	EndTime      int64 // End of the requested range (milliseconds)
+}
+
+// StreamHistoryKlines fetches req's time range window by window and writes klines to w as they arrive
+//
+// The range is split into windows of at most req.Limit candles (default
+// MaxHistoryKlineLimit) exactly as in GetHistoryKlinesAll, so a window with no
+// klines does not end the stream. Klines are written oldest first, in the raw
+// field order returned by the API, and only one window is held in memory at a
+// time. progress, if non-nil, is called after each window. To store the
+// history compressed, pass a gzip.Writer and close it afterwards. Returns the
+// number of klines written.
+func (s *Service) StreamHistoryKlines(ctx context.Context, req *GetHistoryKlinesRequest, w io.Writer, format KlineFormat, progress func(KlineStreamProgress)) (int, error) {
+	var write func(Kline) error
+	var flush func() error
+	switch format {
+	case KlineFormatCSV:
+		cw := csv.NewWriter(w)
+		write = func(k Kline) error { return cw.Write(k) }
+		flush = func() error { cw.Flush(); return cw.Error() }
+	case KlineFormatJSONL:
+		enc := json.NewEncoder(w)
+		write = func(k Kline) error { return enc.Encode(k) }
+		flush = func() error { return nil }
+	default:
+		return 0, fmt.Errorf("unsupported kline format %s", format)
+	}
+
+	status := KlineStreamProgress{LastOpenTime: -1, EndTime: req.EndTime}
+	err := s.eachHistoryKline(ctx, req, func(k Kline, openTime int64) error {
+		if err := write(k); err != nil {
+			return fmt.Errorf("failed to write kline: %w", err)
+		}
+		status.Rows++
+		status.LastOpenTime = openTime
+		return nil
+	}, func(windows int) error {
+		status.Pages = windows
+		if err := flush(); err != nil {
+			return fmt.Errorf("failed to write klines: %w", err)
+		}
+		if progress != nil {
+			progress(status)
+		}
+		return nil
+	})
+	return status.Rows, err
+}
+
+// GetHistoryKlinesAll fetches every kline in req's time range, splitting it into windows of at most req.Limit candles
+//
+// Each window spans Limit * interval, starting at StartTime and stopping at
+// EndTime. Results are returned oldest first with boundary duplicates
+// removed. Every window is a separate GetHistoryKlines call and waits for
+// rate-limit capacity like any other request; ctx is checked between windows.
+func (s *Service) GetHistoryKlinesAll(ctx context.Context, req *GetHistoryKlinesRequest) ([]Kline, error) {
+	var klines []Kline
+	err := s.eachHistoryKline(ctx, req, func(k Kline, _ int64) error {
+		klines = append(klines, k)
+		return nil
+	}, nil)
+	return klines, err
+}
+
+// eachHistoryKline fetches req's time range in windows of Limit * interval and
+// calls fn for each kline in the range, oldest first, skipping duplicates
+// returned at window boundaries. windowDone, if non-nil, is called after each
+// window with the number of windows fetched so far.
+func (s *Service) eachHistoryKline(ctx context.Context, req *GetHistoryKlinesRequest, fn func(k Kline, openTime int64) error, windowDone func(windows int) error) error {
+	interval, err := req.Interval.Duration()
+	if err != nil {
+		return err
+	}
+	if req.EndTime < req.StartTime {
+		return fmt.Errorf("endTime %d is before startTime %d", req.EndTime, req.StartTime)
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > MaxHistoryKlineLimit {
+		limit = MaxHistoryKlineLimit
+	}
+	window := int64(limit) * interval.Milliseconds()
+
+	var (
+		windows int
+		last    int64 = -1
+	)
+	for start := req.StartTime; start <= req.EndTime; start += window {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		end := start + window - 1
+		if end > req.EndTime {
+			end = req.EndTime
+		}
+
+		page, err := s.GetHistoryKlines(ctx, &GetHistoryKlinesRequest{
+			Symbol:    req.Symbol,
+			Interval:  req.Interval,
+			StartTime: start,
+			EndTime:   end,
+			Limit:     limit,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch klines from %d: %w", start, err)
+		}
+		windows++
+
+		times := make([]int64, len(page))
+		for i, k := range page {
+			if times[i], err = k.OpenTime(); err != nil {
+				return fmt.Errorf("kline %d of window %d: %w", i, start, err)
+			}
+		}
+		order := make([]int, len(page))
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(a, b int) bool { return times[order[a]] < times[order[b]] })
+
+		for _, i := range order {
+			// Skip klines outside the range or already returned by the previous window
+			if times[i] < req.StartTime || times[i] > req.EndTime || times[i] <= last {
+				continue
+			}
+			if err := fn(page[i], times[i]); err != nil {
+				return err
+			}
+			last = times[i]
+		}
+
+		if windowDone != nil {
+			if err := windowDone(windows); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
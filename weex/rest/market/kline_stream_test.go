@@ -0,0 +1,304 @@
+package market_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// klineServer serves count one-minute klines from klineBase, answering each
+// request with up to limit klines from startTime, newest first, and failing
+// after failAfter pages if failAfter > 0
+func klineServer(t *testing.T, count, failAfter int) (*market.Service, *atomic.Int32) {
+	t.Helper()
+	return paddedKlineServer(t, count, failAfter, 0)
+}
+
+// paddedKlineServer is klineServer widening every requested range and limit by
+// pad minutes on both sides, so consecutive windows return overlapping klines
+func paddedKlineServer(t *testing.T, count, failAfter int, pad int64) (*market.Service, *atomic.Int32) {
+	t.Helper()
+	var pages atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n := int(pages.Add(1)); failAfter > 0 && n > failAfter {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		query := r.URL.Query()
+		start, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		start, end, limit = start-pad*60000, end+pad*60000, limit+2*int(pad)
+
+		var page []market.Kline
+		for i := 0; i < count && len(page) < limit; i++ {
+			ts := klineBase + int64(i)*60000
+			if ts >= start && ts <= end {
+				page = append([]market.Kline{minuteKlines(int64(i))[0]}, page...)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+
+	config := weex.NewDefaultConfig().WithBaseURL(server.URL)
+	config.MaxRetries = 0
+	config.EnableRateLimit = false
+	config.Logger = weex.NewNoOpLogger()
+	client, err := weex.NewPublicClient(config)
+	if err != nil {
+		t.Fatalf("NewPublicClient() error = %v", err)
+	}
+	return client.Market(), &pages
+}
+
+func TestStreamHistoryKlines(t *testing.T) {
+	tests := []struct {
+		name      string
+		available int // klines the server holds
+		requested int // klines covered by the requested range
+		limit     int
+		format    market.KlineFormat
+		wantRows  int
+		wantPages int
+		wantCalls []int // Rows reported by each progress call
+	}{
+		{"csv across windows", 25, 25, 10, market.KlineFormatCSV, 25, 3, []int{10, 20, 25}},
+		{"jsonl across windows", 25, 25, 10, market.KlineFormatJSONL, 25, 3, []int{10, 20, 25}},
+		{"exact multiple of limit", 20, 20, 10, market.KlineFormatCSV, 20, 2, []int{10, 20}},
+		{"range shorter than history", 25, 12, 5, market.KlineFormatCSV, 12, 3, []int{5, 10, 12}},
+		{"single page", 7, 7, 0, market.KlineFormatJSONL, 7, 1, []int{7}},
+		{"empty range", 0, 10, 10, market.KlineFormatCSV, 0, 1, []int{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, pages := klineServer(t, tt.available, 0)
+			var buf bytes.Buffer
+			var calls []int
+			rows, err := service.StreamHistoryKlines(context.Background(), &market.GetHistoryKlinesRequest{
+				Symbol:    "cmt_btcusdt",
+				Interval:  types.Interval1Min,
+				StartTime: klineBase,
+				EndTime:   klineBase + int64(tt.requested-1)*60000,
+				Limit:     tt.limit,
+			}, &buf, tt.format, func(p market.KlineStreamProgress) { calls = append(calls, p.Rows) })
+			if err != nil {
+				t.Fatalf("StreamHistoryKlines() error = %v", err)
+			}
+			if rows != tt.wantRows {
+				t.Errorf("rows = %d, want %d", rows, tt.wantRows)
+			}
+			if got := int(pages.Load()); got != tt.wantPages {
+				t.Errorf("pages = %d, want %d", got, tt.wantPages)
+			}
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("progress rows = %v, want %v", calls, tt.wantCalls)
+			}
+
+			var want strings.Builder
+			for i := 0; i < tt.wantRows; i++ {
+				k := minuteKlines(int64(i))[0]
+				if tt.format == market.KlineFormatCSV {
+					want.WriteString(strings.Join(k, ",") + "\n")
+				} else {
+					data, _ := json.Marshal(k)
+					want.Write(append(data, '\n'))
+				}
+			}
+			if buf.String() != want.String() {
+				t.Errorf("written =\n%s\nwant\n%s", buf.String(), want.String())
+			}
+		})
+	}
+}
+
+func TestStreamHistoryKlinesErrors(t *testing.T) {
+	req := &market.GetHistoryKlinesRequest{
+		Symbol: "cmt_btcusdt", Interval: types.Interval1Min, StartTime: klineBase, EndTime: klineBase + 24*60000, Limit: 10,
+	}
+
+	t.Run("unsupported format", func(t *testing.T) {
+		service, pages := klineServer(t, 25, 0)
+		if _, err := service.StreamHistoryKlines(context.Background(), req, &bytes.Buffer{}, market.KlineFormat(9), nil); err == nil {
+			t.Error("StreamHistoryKlines() expected error for an unknown format")
+		}
+		if pages.Load() != 0 {
+			t.Errorf("pages = %d, want 0", pages.Load())
+		}
+	})
+
+	for name, modify := range map[string]func(*market.GetHistoryKlinesRequest){
+		"invalid interval": func(r *market.GetHistoryKlinesRequest) { r.Interval = "5x" },
+		"end before start": func(r *market.GetHistoryKlinesRequest) { r.EndTime = r.StartTime - 1 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			service, pages := klineServer(t, 25, 0)
+			bad := *req
+			modify(&bad)
+			if _, err := service.StreamHistoryKlines(context.Background(), &bad, &bytes.Buffer{}, market.KlineFormatCSV, nil); err == nil {
+				t.Error("StreamHistoryKlines() expected error")
+			}
+			if pages.Load() != 0 {
+				t.Errorf("pages = %d, want 0", pages.Load())
+			}
+		})
+	}
+
+	t.Run("fetch fails mid-stream", func(t *testing.T) {
+		service, _ := klineServer(t, 25, 2)
+		var buf bytes.Buffer
+		rows, err := service.StreamHistoryKlines(context.Background(), req, &buf, market.KlineFormatCSV, nil)
+		if err == nil {
+			t.Fatal("StreamHistoryKlines() expected error")
+		}
+		if rows != 20 || strings.Count(buf.String(), "\n") != 20 {
+			t.Errorf("rows = %d with %d lines written, want the 20 rows fetched before the failure", rows, strings.Count(buf.String(), "\n"))
+		}
+	})
+}
+
+// historyWindowCases are windowed kline fetches shared by GetHistoryKlinesAll and StreamHistoryKlines
+var historyWindowCases = []struct {
+	name      string
+	available int   // klines the server holds
+	from, to  int64 // requested range in minutes from klineBase
+	limit     int
+	pad       int64 // minutes the server returns beyond each window
+	want      []int64
+	wantPages int
+}{
+	{"four chunks", 40, 0, 39, 10, 0, minuteRange(0, 39), 4},
+	{"partial last chunk", 40, 0, 34, 10, 0, minuteRange(0, 34), 4},
+	{"boundary duplicates removed", 40, 0, 39, 10, 1, minuteRange(0, 39), 4},
+	{"range inside history", 40, 5, 24, 5, 2, minuteRange(5, 24), 4},
+	{"history ends early", 15, 0, 39, 10, 0, minuteRange(0, 14), 4},
+	{"single kline", 40, 7, 7, 10, 0, []int64{7}, 1},
+	{"default limit", 40, 0, 39, 0, 0, minuteRange(0, 39), 1},
+}
+
+func TestGetHistoryKlinesAll(t *testing.T) {
+	for _, tt := range historyWindowCases {
+		t.Run(tt.name, func(t *testing.T) {
+			service, pages := paddedKlineServer(t, tt.available, 0, tt.pad)
+			klines, err := service.GetHistoryKlinesAll(context.Background(), &market.GetHistoryKlinesRequest{
+				Symbol:    "cmt_btcusdt",
+				Interval:  types.Interval1Min,
+				StartTime: klineBase + tt.from*60000,
+				EndTime:   klineBase + tt.to*60000,
+				Limit:     tt.limit,
+			})
+			if err != nil {
+				t.Fatalf("GetHistoryKlinesAll() error = %v", err)
+			}
+			if got := int(pages.Load()); got != tt.wantPages {
+				t.Errorf("pages = %d, want %d", got, tt.wantPages)
+			}
+			if want := minuteKlines(tt.want...); !slices.EqualFunc(klines, want, slices.Equal) {
+				t.Errorf("GetHistoryKlinesAll() = %d klines %v, want %d", len(klines), klines, len(want))
+			}
+		})
+	}
+}
+
+func TestStreamHistoryKlinesWindows(t *testing.T) {
+	for _, tt := range historyWindowCases {
+		t.Run(tt.name, func(t *testing.T) {
+			service, pages := paddedKlineServer(t, tt.available, 0, tt.pad)
+			var buf bytes.Buffer
+			var windows []int
+			rows, err := service.StreamHistoryKlines(context.Background(), &market.GetHistoryKlinesRequest{
+				Symbol:    "cmt_btcusdt",
+				Interval:  types.Interval1Min,
+				StartTime: klineBase + tt.from*60000,
+				EndTime:   klineBase + tt.to*60000,
+				Limit:     tt.limit,
+			}, &buf, market.KlineFormatJSONL, func(p market.KlineStreamProgress) { windows = append(windows, p.Pages) })
+			if err != nil {
+				t.Fatalf("StreamHistoryKlines() error = %v", err)
+			}
+			if got := int(pages.Load()); got != tt.wantPages || len(windows) != tt.wantPages {
+				t.Errorf("pages = %d with %d progress calls, want %d", got, len(windows), tt.wantPages)
+			}
+			if rows != len(tt.want) {
+				t.Errorf("rows = %d, want %d", rows, len(tt.want))
+			}
+			var want bytes.Buffer
+			for _, k := range minuteKlines(tt.want...) {
+				data, _ := json.Marshal(k)
+				want.Write(append(data, '\n'))
+			}
+			if buf.String() != want.String() {
+				t.Errorf("written =\n%s\nwant\n%s", buf.String(), want.String())
+			}
+		})
+	}
+}
+
+func TestGetHistoryKlinesAllErrors(t *testing.T) {
+	valid := market.GetHistoryKlinesRequest{
+		Symbol: "cmt_btcusdt", Interval: types.Interval1Min, StartTime: klineBase, EndTime: klineBase + 39*60000, Limit: 10,
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(*market.GetHistoryKlinesRequest)
+		failAfter int
+		cancel    bool
+		wantLen   int
+		wantPages int
+	}{
+		{"invalid interval", func(r *market.GetHistoryKlinesRequest) { r.Interval = "5x" }, 0, false, 0, 0},
+		{"end before start", func(r *market.GetHistoryKlinesRequest) { r.EndTime = r.StartTime - 1 }, 0, false, 0, 0},
+		{"fetch fails mid-range", func(*market.GetHistoryKlinesRequest) {}, 2, false, 20, 3},
+		{"context cancelled", func(*market.GetHistoryKlinesRequest) {}, 0, true, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, pages := klineServer(t, 40, tt.failAfter)
+			req := valid
+			tt.modify(&req)
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.cancel {
+				cancel()
+			}
+			defer cancel()
+
+			klines, err := service.GetHistoryKlinesAll(ctx, &req)
+			if err == nil {
+				t.Fatal("GetHistoryKlinesAll() expected error")
+			}
+			if tt.cancel && !errors.Is(err, context.Canceled) {
+				t.Errorf("error = %v, want context.Canceled", err)
+			}
+			if len(klines) != tt.wantLen {
+				t.Errorf("returned %d klines, want the %d fetched before the failure", len(klines), tt.wantLen)
+			}
+			if got := int(pages.Load()); got != tt.wantPages {
+				t.Errorf("pages = %d, want %d", got, tt.wantPages)
+			}
+		})
+	}
+}
+
+// minuteRange returns the minute offsets from through to
+func minuteRange(from, to int64) []int64 {
+	var minutes []int64
+	for m := from; m <= to; m++ {
+		minutes = append(minutes, m)
+	}
+	return minutes
+}
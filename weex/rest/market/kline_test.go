@@ -0,0 +1,85 @@
+package market_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+)
+
+// klineBase is a minute-aligned open time (milliseconds)
+const klineBase = int64(1700000040000)
+
+// minuteKlines returns 1m klines opening at the given minute offsets from klineBase
+func minuteKlines(minutes ...int64) []market.Kline {
+	klines := make([]market.Kline, len(minutes))
+	for i, m := range minutes {
+		klines[i] = market.Kline{strconv.FormatInt(klineBase+m*60000, 10), "100", "101", "99", "100.5", "10", "1005"}
+	}
+	return klines
+}
+
+func TestDetectKlineGaps(t *testing.T) {
+	tests := []struct {
+		name    string
+		klines  []market.Kline
+		until   int64
+		want    []market.KlineGap
+		wantErr bool
+	}{
+		{"contiguous", minuteKlines(0, 1, 2, 3), 0, nil, false},
+		{"single missing", minuteKlines(0, 1, 3), 0, []market.KlineGap{
+			{From: klineBase + 2*60000, To: klineBase + 2*60000, Missing: 1},
+		}, false},
+		{"missing range", minuteKlines(0, 1, 5, 6), 0, []market.KlineGap{
+			{From: klineBase + 2*60000, To: klineBase + 4*60000, Missing: 3},
+		}, false},
+		{"unordered with duplicates", minuteKlines(6, 0, 1, 1, 5), 0, []market.KlineGap{
+			{From: klineBase + 2*60000, To: klineBase + 4*60000, Missing: 3},
+		}, false},
+		{"several gaps", minuteKlines(0, 2, 3, 7), 0, []market.KlineGap{
+			{From: klineBase + 60000, To: klineBase + 60000, Missing: 1},
+			{From: klineBase + 4*60000, To: klineBase + 6*60000, Missing: 3},
+		}, false},
+		// The kline at 2 is still open at until, so only 1 is missing
+		{"trailing gap before until", minuteKlines(0), klineBase + 2*60000 + 30000, []market.KlineGap{
+			{From: klineBase + 60000, To: klineBase + 60000, Missing: 1},
+		}, false},
+		{"until within the next kline", minuteKlines(0, 1), klineBase + 2*60000 + 59999, nil, false},
+		{"until at the close of the next kline", minuteKlines(0, 1), klineBase + 3*60000, []market.KlineGap{
+			{From: klineBase + 2*60000, To: klineBase + 2*60000, Missing: 1},
+		}, false},
+		{"empty", nil, klineBase, nil, false},
+		{"bad timestamp", []market.Kline{{"x"}}, 0, nil, true},
+		{"empty kline", []market.Kline{{}}, 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := market.DetectKlineGaps(tt.klines, time.Minute, tt.until)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DetectKlineGaps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectKlineGaps() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectKlineGapsInterval(t *testing.T) {
+	if _, err := market.DetectKlineGaps(minuteKlines(0), 0, 0); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+
+	// Hourly klines with the 02:00 candle missing
+	klines := []market.Kline{{"0"}, {"3600000"}, {"10800000"}}
+	got, err := market.DetectKlineGaps(klines, time.Hour, 0)
+	if err != nil {
+		t.Fatalf("DetectKlineGaps() error = %v", err)
+	}
+	if want := []market.KlineGap{{From: 7200000, To: 7200000, Missing: 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectKlineGaps() = %+v, want %+v", got, want)
+	}
+}
@@ -6,13 +6,17 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/weex-api/openapi-contract-go-sdk/weex/rest"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
 )
 
 // Service provides access to market data API endpoints
 type Service struct {
-	client *rest.Client
+	client    *rest.Client
+	symbols   symbolCheck
+	contracts contractCache
 }
 
 // NewService creates a new market service
@@ -46,6 +50,9 @@ func (s *Service) GetContracts(ctx context.Context, req *GetContractsRequest) ([
 //
 // Reference: /contract/Market_API/GetTickerInfo.md
 func (s *Service) GetTicker(ctx context.Context, symbol string) (*Ticker, error) {
+	if err := s.checkSymbol(symbol); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
 	params.Set("symbol", symbol)
 	path := "/market/ticker?" + params.Encode()
@@ -68,12 +75,60 @@ func (s *Service) GetAllTickers(ctx context.Context) ([]Ticker, error) {
 	return tickers, err
 }
 
+// MissingSymbolsError is returned by GetTickers when requested symbols have no ticker
+type MissingSymbolsError struct {
+	Symbols []string // Requested symbols not found, in request order
+}
+
+// Error implements the error interface
+func (e *MissingSymbolsError) Error() string {
+	return fmt.Sprintf("no ticker for symbols: %s", strings.Join(e.Symbols, ", "))
+}
+
+// GetTickers gets tickers for the given symbols, keyed by symbol
+// It makes a single GetAllTickers call (same weight) and filters the result.
+// If some symbols are not found, the tickers that were found are returned
+// together with a *MissingSymbolsError listing the others.
+func (s *Service) GetTickers(ctx context.Context, symbols []string) (map[string]Ticker, error) {
+	all, err := s.GetAllTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bySymbol := make(map[string]Ticker, len(all))
+	for _, ticker := range all {
+		bySymbol[ticker.Symbol] = ticker
+	}
+
+	tickers := make(map[string]Ticker, len(symbols))
+	var missing []string
+	seen := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		if seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		if ticker, ok := bySymbol[symbol]; ok {
+			tickers[symbol] = ticker
+		} else {
+			missing = append(missing, symbol)
+		}
+	}
+	if len(missing) > 0 {
+		return tickers, &MissingSymbolsError{Symbols: missing}
+	}
+	return tickers, nil
+}
+
 // GetDepth gets order book depth data
 // GET /market/depth
 // Weight(IP): 1, Weight(UID): 1
 //
 // Reference: /contract/Market_API/GetDepthData.md
 func (s *Service) GetDepth(ctx context.Context, req *GetDepthRequest) (*Depth, error) {
+	if err := s.checkSymbol(req.Symbol); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
 	params.Set("symbol", req.Symbol)
 
@@ -98,6 +153,9 @@ func (s *Service) GetDepth(ctx context.Context, req *GetDepthRequest) (*Depth, e
 //
 // Reference: /contract/Market_API/GetKLineData.md
 func (s *Service) GetKlines(ctx context.Context, req *GetKlinesRequest) ([]Kline, error) {
+	if err := s.checkSymbol(req.Symbol); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
 	params.Set("symbol", req.Symbol)
 	params.Set("granularity", string(req.Interval))
@@ -122,6 +180,9 @@ func (s *Service) GetKlines(ctx context.Context, req *GetKlinesRequest) ([]Kline
 //
 // Reference: /contract/Market_API/GetHistoryKLineData.md
 func (s *Service) GetHistoryKlines(ctx context.Context, req *GetHistoryKlinesRequest) ([]Kline, error) {
+	if err := s.checkSymbol(req.Symbol); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
 	params.Set("symbol", req.Symbol)
 	params.Set("interval", string(req.Interval))
@@ -145,6 +206,9 @@ func (s *Service) GetHistoryKlines(ctx context.Context, req *GetHistoryKlinesReq
 //
 // Reference: /contract/Market_API/GetTradeData.md
 func (s *Service) GetTrades(ctx context.Context, req *GetTradesRequest) ([]Trade, error) {
+	if err := s.checkSymbol(req.Symbol); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
 	params.Set("symbol", req.Symbol)
 
@@ -172,12 +236,33 @@ func (s *Service) GetServerTime(ctx context.Context) (*ServerTime, error) {
 	return &serverTime, err
 }
 
+// GetServerTimeWithMeta gets the server time along with the HTTP response metadata
+// GET /market/time
+// Weight(IP): 1, Weight(UID): 1
+//
+// Example:
+//
+//	serverTime, meta, err := client.Market().GetServerTimeWithMeta(ctx)
+//	if err == nil {
+//	    fmt.Println(meta.Header.Get("X-RateLimit-Remaining"))
+//	}
+func (s *Service) GetServerTimeWithMeta(ctx context.Context) (*ServerTime, *rest.ResponseMeta, error) {
+	path := "/market/time"
+
+	var serverTime ServerTime
+	meta, err := s.client.GetWithMeta(ctx, path, &serverTime, 1, 1)
+	return &serverTime, meta, err
+}
+
 // GetIndexPrice gets the index price
 // GET /market/index
 // Weight(IP): 5, Weight(UID): 2
 //
 // Reference: /contract/Market_API/GetCurrencyIndex.md
 func (s *Service) GetIndexPrice(ctx context.Context, symbol string) (*IndexPrice, error) {
+	if err := s.checkSymbol(symbol); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
 	params.Set("symbol", symbol)
 	path := "/market/index?" + params.Encode()
@@ -187,6 +272,22 @@ func (s *Service) GetIndexPrice(ctx context.Context, symbol string) (*IndexPrice
 	return &indexPrice, err
 }
 
+// GetMarkPrice gets the mark price
+// GET /market/markPrice
+// Weight(IP): 5, Weight(UID): 2
+func (s *Service) GetMarkPrice(ctx context.Context, symbol string) (*MarkPrice, error) {
+	if err := s.checkSymbol(symbol); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	path := "/market/markPrice?" + params.Encode()
+
+	var markPrice MarkPrice
+	err := s.client.Get(ctx, path, &markPrice, 5, 2)
+	return &markPrice, err
+}
+
 // GetFundingRate gets the current funding rate
 // GET /market/currentFundRate
 // Weight(IP): 1, Weight(UID): 1
@@ -219,6 +320,9 @@ func (s *Service) GetFundingRate(ctx context.Context, symbol string) (*FundingRa
 //
 // Reference: /contract/Market_API/GetContractFundingHistory.md
 func (s *Service) GetFundingHistory(ctx context.Context, req *GetFundingHistoryRequest) ([]FundingRateHistory, error) {
+	if err := s.checkSymbol(req.Symbol); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
 	params.Set("symbol", req.Symbol)
 
@@ -245,6 +349,9 @@ func (s *Service) GetFundingHistory(ctx context.Context, req *GetFundingHistoryR
 //
 // Reference: /contract/Market_API/GetNextContractSettlementTime.md
 func (s *Service) GetSettlementTime(ctx context.Context, symbol string) (*SettlementTime, error) {
+	if err := s.checkSymbol(symbol); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
 	params.Set("symbol", symbol)
 	path := "/market/settlementTime?" + params.Encode()
@@ -261,6 +368,9 @@ func (s *Service) GetSettlementTime(ctx context.Context, symbol string) (*Settle
 // Reference: /contract/Market_API/GetTotalPlatformOpenInterest.md
 // Note: API returns object, not array (despite documentation showing array)
 func (s *Service) GetOpenInterest(ctx context.Context, symbol string) (*OpenInterest, error) {
+	if err := s.checkSymbol(symbol); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
 	params.Set("symbol", symbol)
 	path := "/market/open_interest?" + params.Encode()
@@ -286,7 +396,7 @@ func buildQueryString(params url.Values) string {
 // ValidateSymbol checks if a symbol is valid
 func ValidateSymbol(symbol string) error {
 	if symbol == "" {
-		return fmt.Errorf("symbol cannot be empty")
+		return types.NewValidationError("symbol", "symbol cannot be empty")
 	}
 	return nil
 }
@@ -294,7 +404,10 @@ func ValidateSymbol(symbol string) error {
 // ValidateInterval checks if an interval is valid
 func ValidateInterval(interval string) error {
 	if interval == "" {
-		return fmt.Errorf("interval cannot be empty")
+		return types.NewValidationError("interval", "interval cannot be empty")
+	}
+	if !types.KlineInterval(interval).IsValid() {
+		return types.NewValidationError("interval", "unsupported interval %q", interval)
 	}
 	return nil
 }
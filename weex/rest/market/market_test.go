@@ -0,0 +1,246 @@
+package market_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+)
+
+// newTestMarket returns a market service backed by a server replying body to every request
+// The request URI the server received is stored in *gotURI.
+func newTestMarket(t *testing.T, body string, gotURI *string) *market.Service {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*gotURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	config := weex.NewDefaultConfig().WithBaseURL(server.URL)
+	config.MaxRetries = 0
+	config.Logger = weex.NewNoOpLogger()
+	client, err := weex.NewPublicClient(config)
+	if err != nil {
+		t.Fatalf("NewPublicClient() error = %v", err)
+	}
+	return client.Market()
+}
+
+func TestGetMarkPrice(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want market.MarkPrice
+	}{
+		{
+			"string timestamp",
+			`{"symbol":"cmt_btcusdt","markPrice":"65000.5","timestamp":"1716604853286"}`,
+			market.MarkPrice{Symbol: "cmt_btcusdt", MarkPrice: "65000.5", Timestamp: 1716604853286},
+		},
+		{
+			"numeric timestamp",
+			`{"symbol":"cmt_btcusdt","markPrice":65000.5,"timestamp":1716604853286}`,
+			market.MarkPrice{Symbol: "cmt_btcusdt", MarkPrice: "65000.5", Timestamp: 1716604853286},
+		},
+		{
+			"wrapped",
+			`{"code":"0","msg":"success","requestTime":1,"data":{"symbol":"cmt_btcusdt","markPrice":"65000.5","timestamp":"1716604853286"}}`,
+			market.MarkPrice{Symbol: "cmt_btcusdt", MarkPrice: "65000.5", Timestamp: 1716604853286},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uri string
+			got, err := newTestMarket(t, tt.body, &uri).GetMarkPrice(context.Background(), "cmt_btcusdt")
+			if err != nil {
+				t.Fatalf("GetMarkPrice() error = %v", err)
+			}
+			if uri != "/capi/v2/market/markPrice?symbol=cmt_btcusdt" {
+				t.Errorf("request URI = %s", uri)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("GetMarkPrice() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetServerTimeWithMeta(t *testing.T) {
+	type reply struct {
+		status int
+		body   string
+	}
+	tests := []struct {
+		name        string
+		replies     []reply // One per attempt; the last repeats
+		wantStatus  int
+		wantTime    int64
+		wantErr     bool
+		wantAttempt int
+	}{
+		{"unwrapped", []reply{{200, `{"epoch":"1716710918.113","iso":"2024-05-26T08:08:38.113Z","timestamp":1716710918113}`}}, 200, 0, false, 1},
+		{"wrapped", []reply{{200, `{"code":"0","msg":"success","requestTime":1716710918200,"data":{"timestamp":1716710918113}}`}}, 200, 1716710918200, false, 1},
+		{"api error", []reply{{400, `{"code":"40001","msg":"bad request","requestTime":1716710918300}`}}, 400, 1716710918300, true, 1},
+		{"last attempt after retry", []reply{{503, `{"code":"50001","msg":"service temporarily unavailable"}`}, {200, `{"timestamp":1716710918113}`}}, 200, 0, false, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				rep := tt.replies[min(attempts, len(tt.replies)-1)]
+				attempts++
+				w.Header().Set("X-RateLimit-Remaining", "99")
+				w.WriteHeader(rep.status)
+				w.Write([]byte(rep.body))
+			}))
+			defer server.Close()
+
+			config := weex.NewDefaultConfig().WithBaseURL(server.URL)
+			config.MaxRetries = len(tt.replies) - 1
+			config.InitialBackoff = time.Millisecond
+			config.Logger = weex.NewNoOpLogger()
+			client, err := weex.NewPublicClient(config)
+			if err != nil {
+				t.Fatalf("NewPublicClient() error = %v", err)
+			}
+
+			serverTime, meta, err := client.Market().GetServerTimeWithMeta(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetServerTimeWithMeta() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempt {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempt)
+			}
+			if meta == nil {
+				t.Fatal("meta = nil, want the response metadata")
+			}
+			if meta.StatusCode != tt.wantStatus {
+				t.Errorf("StatusCode = %d, want %d", meta.StatusCode, tt.wantStatus)
+			}
+			if meta.RequestTime != tt.wantTime {
+				t.Errorf("RequestTime = %d, want %d", meta.RequestTime, tt.wantTime)
+			}
+			if got := meta.Header.Get("X-RateLimit-Remaining"); got != "99" {
+				t.Errorf("X-RateLimit-Remaining = %q, want 99", got)
+			}
+			if !tt.wantErr && serverTime.Timestamp != 1716710918113 {
+				t.Errorf("Timestamp = %d, want 1716710918113", serverTime.Timestamp)
+			}
+		})
+	}
+}
+
+func TestGetServerTimeWithMetaNoResponse(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	config := weex.NewDefaultConfig().WithBaseURL(server.URL)
+	config.MaxRetries = 0
+	config.Logger = weex.NewNoOpLogger()
+	client, err := weex.NewPublicClient(config)
+	if err != nil {
+		t.Fatalf("NewPublicClient() error = %v", err)
+	}
+
+	_, meta, err := client.Market().GetServerTimeWithMeta(context.Background())
+	if err == nil {
+		t.Fatal("expected an error from a closed server")
+	}
+	if meta != nil {
+		t.Errorf("meta = %+v, want nil when no response was received", meta)
+	}
+}
+
+func TestGetTickers(t *testing.T) {
+	const body = `[{"symbol":"cmt_btcusdt","last":"65000.5"},{"symbol":"cmt_ethusdt","last":"3400"},{"symbol":"cmt_solusdt","last":"150.2"}]`
+	tests := []struct {
+		name        string
+		symbols     []string
+		want        map[string]string // symbol to last price
+		wantMissing []string
+	}{
+		{"all found", []string{"cmt_btcusdt", "cmt_solusdt"}, map[string]string{"cmt_btcusdt": "65000.5", "cmt_solusdt": "150.2"}, nil},
+		{"duplicates collapsed", []string{"cmt_ethusdt", "cmt_ethusdt"}, map[string]string{"cmt_ethusdt": "3400"}, nil},
+		{"partial match", []string{"cmt_xrpusdt", "cmt_btcusdt", "cmt_dogeusdt"}, map[string]string{"cmt_btcusdt": "65000.5"}, []string{"cmt_xrpusdt", "cmt_dogeusdt"}},
+		{"fully missing", []string{"cmt_xrpusdt"}, map[string]string{}, []string{"cmt_xrpusdt"}},
+		{"no symbols", nil, map[string]string{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uris []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				uris = append(uris, r.URL.RequestURI())
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+			config := weex.NewDefaultConfig().WithBaseURL(server.URL)
+			config.MaxRetries = 0
+			config.Logger = weex.NewNoOpLogger()
+			client, err := weex.NewPublicClient(config)
+			if err != nil {
+				t.Fatalf("NewPublicClient() error = %v", err)
+			}
+
+			got, err := client.Market().GetTickers(context.Background(), tt.symbols)
+
+			// One all-tickers request regardless of how many symbols are asked for
+			if !reflect.DeepEqual(uris, []string{"/capi/v2/market/tickers"}) {
+				t.Errorf("requests = %v, want a single /market/tickers call", uris)
+			}
+			var missing *market.MissingSymbolsError
+			if tt.wantMissing == nil && err != nil || tt.wantMissing != nil && !errors.As(err, &missing) {
+				t.Fatalf("GetTickers() error = %v, want missing %v", err, tt.wantMissing)
+			}
+			if missing != nil {
+				if !reflect.DeepEqual(missing.Symbols, tt.wantMissing) {
+					t.Errorf("missing = %v, want %v", missing.Symbols, tt.wantMissing)
+				}
+				for _, symbol := range tt.wantMissing {
+					if !strings.Contains(err.Error(), symbol) {
+						t.Errorf("error %q does not name %s", err, symbol)
+					}
+				}
+			}
+			last := make(map[string]string, len(got))
+			for symbol, ticker := range got {
+				if ticker.Symbol != symbol {
+					t.Errorf("ticker keyed %s has symbol %s", symbol, ticker.Symbol)
+				}
+				last[symbol] = ticker.Last
+			}
+			if !reflect.DeepEqual(last, tt.want) {
+				t.Errorf("GetTickers() = %v, want %v", last, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTickersRequestFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	config := weex.NewDefaultConfig().WithBaseURL(server.URL)
+	config.MaxRetries = 0
+	config.Logger = weex.NewNoOpLogger()
+	client, err := weex.NewPublicClient(config)
+	if err != nil {
+		t.Fatalf("NewPublicClient() error = %v", err)
+	}
+
+	got, err := client.Market().GetTickers(context.Background(), []string{"cmt_btcusdt"})
+	var missing *market.MissingSymbolsError
+	if err == nil || errors.As(err, &missing) || got != nil {
+		t.Errorf("GetTickers() = %v, %v, want the request error and no tickers", got, err)
+	}
+}
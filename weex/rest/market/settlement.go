@@ -0,0 +1,62 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeUntil returns the time remaining until the settlement at now (negative if it has passed)
+func (s *SettlementTime) TimeUntil(now time.Time) time.Duration {
+	return s.SettlementTime.Time().Sub(now)
+}
+
+// Project returns the next n settlement times, starting with SettlementTime
+// Later settlements are spaced by interval, e.g. FundingRate.CollectCycle minutes.
+func (s *SettlementTime) Project(interval time.Duration, n int) []time.Time {
+	return ProjectSettlements(s.SettlementTime.Time(), interval, n)
+}
+
+// ProjectSettlements returns n settlement times starting at next, spaced by interval
+// Returns nil if n <= 0, or only next if interval <= 0.
+func ProjectSettlements(next time.Time, interval time.Duration, n int) []time.Time {
+	if n <= 0 {
+		return nil
+	}
+	if interval <= 0 {
+		return []time.Time{next}
+	}
+	times := make([]time.Time, n)
+	for i := range times {
+		times[i] = next.Add(time.Duration(i) * interval)
+	}
+	return times
+}
+
+// NextSettlementAfter returns the first settlement strictly after now
+// next is a known settlement time and interval the settlement cycle; next may be
+// in the past, e.g. when a cached SettlementTime has gone stale.
+func NextSettlementAfter(next time.Time, interval time.Duration, now time.Time) time.Time {
+	if interval <= 0 || next.After(now) {
+		return next
+	}
+	cycles := now.Sub(next)/interval + 1
+	return next.Add(cycles * interval)
+}
+
+// GetSettlementSchedule returns the next n settlement times for symbol
+// It combines GetSettlementTime with the funding collection cycle from GetFundingRate.
+func (s *Service) GetSettlementSchedule(ctx context.Context, symbol string, n int) ([]time.Time, error) {
+	settlement, err := s.GetSettlementTime(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := s.GetFundingRate(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if rate.CollectCycle <= 0 {
+		return nil, fmt.Errorf("no funding collection cycle for %s", symbol)
+	}
+	return settlement.Project(time.Duration(rate.CollectCycle)*time.Minute, n), nil
+}
@@ -0,0 +1,150 @@
+package market_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+var settlementNext = time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+
+func TestProjectSettlements(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		n        int
+		expected []time.Time
+	}{
+		{"eight hours", 8 * time.Hour, 4, []time.Time{
+			settlementNext,
+			settlementNext.Add(8 * time.Hour),
+			settlementNext.Add(16 * time.Hour),
+			settlementNext.Add(24 * time.Hour),
+		}},
+		{"one", 8 * time.Hour, 1, []time.Time{settlementNext}},
+		{"none", 8 * time.Hour, 0, nil},
+		{"negative count", 8 * time.Hour, -1, nil},
+		{"no interval", 0, 3, []time.Time{settlementNext}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := market.ProjectSettlements(settlementNext, tt.interval, tt.n); !equalTimes(got, tt.expected) {
+				t.Errorf("ProjectSettlements() = %v, want %v", got, tt.expected)
+			}
+			s := &market.SettlementTime{SettlementTime: types.Millis(settlementNext.UnixMilli())}
+			if got := s.Project(tt.interval, tt.n); !equalTimes(got, tt.expected) {
+				t.Errorf("Project() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSettlementTimeUntil(t *testing.T) {
+	s := &market.SettlementTime{SettlementTime: types.Millis(settlementNext.UnixMilli())}
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Duration
+	}{
+		{"before", settlementNext.Add(-90 * time.Minute), 90 * time.Minute},
+		{"at", settlementNext, 0},
+		{"after", settlementNext.Add(time.Second), -time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.TimeUntil(tt.now); got != tt.expected {
+				t.Errorf("TimeUntil() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNextSettlementAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		now      time.Time
+		expected time.Time
+	}{
+		{"next in future", 8 * time.Hour, settlementNext.Add(-time.Hour), settlementNext},
+		{"at next", 8 * time.Hour, settlementNext, settlementNext.Add(8 * time.Hour)},
+		{"one cycle stale", 8 * time.Hour, settlementNext.Add(time.Hour), settlementNext.Add(8 * time.Hour)},
+		{"several cycles stale", 8 * time.Hour, settlementNext.Add(17 * time.Hour), settlementNext.Add(24 * time.Hour)},
+		{"exactly on later cycle", 8 * time.Hour, settlementNext.Add(16 * time.Hour), settlementNext.Add(24 * time.Hour)},
+		{"no interval", 0, settlementNext.Add(time.Hour), settlementNext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := market.NextSettlementAfter(settlementNext, tt.interval, tt.now); !got.Equal(tt.expected) {
+				t.Errorf("NextSettlementAfter() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetSettlementSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		cycle    string
+		expected []time.Time
+		wantErr  bool
+	}{
+		{"eight hour cycle", "480", []time.Time{settlementNext, settlementNext.Add(8 * time.Hour), settlementNext.Add(16 * time.Hour)}, false},
+		{"no cycle", "0", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/market/settlementTime"):
+					w.Write([]byte(`{"symbol":"cmt_btcusdt","settlementTime":` + settlementMillis() + `}`))
+				case strings.HasSuffix(r.URL.Path, "/market/currentFundRate"):
+					w.Write([]byte(`[{"symbol":"cmt_btcusdt","fundingRate":"0.0001","collectCycle":` + tt.cycle + `,"timestamp":` + settlementMillis() + `}]`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			config := weex.NewDefaultConfig().WithBaseURL(server.URL)
+			config.MaxRetries = 0
+			config.Logger = weex.NewNoOpLogger()
+			client, err := weex.NewPublicClient(config)
+			if err != nil {
+				t.Fatalf("NewPublicClient() error = %v", err)
+			}
+
+			got, err := client.Market().GetSettlementSchedule(context.Background(), "cmt_btcusdt", 3)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetSettlementSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !equalTimes(got, tt.expected) {
+				t.Errorf("GetSettlementSchedule() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// settlementMillis returns settlementNext as a JSON millisecond timestamp
+func settlementMillis() string {
+	return strconv.FormatInt(settlementNext.UnixMilli(), 10)
+}
+
+// equalTimes reports whether a and b hold the same instants, ignoring location
+func equalTimes(a, b []time.Time) bool {
+	return slices.EqualFunc(a, b, time.Time.Equal)
+}
@@ -0,0 +1,155 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxSuggestionDistance is the largest edit distance offered as a "did you mean" suggestion
+const maxSuggestionDistance = 3
+
+// UnknownSymbolError is returned when a symbol is not in the live contract set
+type UnknownSymbolError struct {
+	Symbol      string   // Symbol that was rejected
+	Suggestions []string // Close matches, best first
+}
+
+// Error implements the error interface
+func (e *UnknownSymbolError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("unknown symbol %q", e.Symbol)
+	}
+	return fmt.Sprintf("unknown symbol %q, did you mean %s?", e.Symbol, strings.Join(e.Suggestions, ", "))
+}
+
+// SymbolValidator checks symbols against a known contract symbol set
+type SymbolValidator struct {
+	symbols map[string]struct{}
+}
+
+// NewSymbolValidator creates a new SymbolValidator for the given symbols
+func NewSymbolValidator(symbols []string) *SymbolValidator {
+	v := &SymbolValidator{symbols: make(map[string]struct{}, len(symbols))}
+	for _, symbol := range symbols {
+		v.symbols[symbol] = struct{}{}
+	}
+	return v
+}
+
+// Validate returns nil if symbol is known, or an *UnknownSymbolError with close matches
+func (v *SymbolValidator) Validate(symbol string) error {
+	if err := ValidateSymbol(symbol); err != nil {
+		return err
+	}
+	if _, ok := v.symbols[symbol]; ok {
+		return nil
+	}
+	return &UnknownSymbolError{Symbol: symbol, Suggestions: v.suggest(symbol, 3)}
+}
+
+// Contains returns true if symbol is known
+func (v *SymbolValidator) Contains(symbol string) bool {
+	_, ok := v.symbols[symbol]
+	return ok
+}
+
+// suggest returns up to n known symbols closest to symbol by edit distance
+func (v *SymbolValidator) suggest(symbol string, n int) []string {
+	type candidate struct {
+		symbol   string
+		distance int
+	}
+
+	lower := strings.ToLower(symbol)
+	var candidates []candidate
+	for known := range v.symbols {
+		d := editDistance(lower, strings.ToLower(known))
+		if d <= maxSuggestionDistance {
+			candidates = append(candidates, candidate{known, d})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].symbol < candidates[j].symbol
+	})
+
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	suggestions := make([]string, len(candidates))
+	for i, c := range candidates {
+		suggestions[i] = c.symbol
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// symbolCheck holds the opt-in validator used by Service methods
+type symbolCheck struct {
+	mu        sync.RWMutex
+	validator *SymbolValidator
+}
+
+// EnableSymbolValidation fetches the live contract list and validates symbols
+// passed to market data methods against it before sending requests
+//
+// Validation is opt-in so that callers are not forced into a contracts fetch.
+func (s *Service) EnableSymbolValidation(ctx context.Context) (*SymbolValidator, error) {
+	contracts, err := s.GetContracts(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contracts for symbol validation: %w", err)
+	}
+
+	symbols := make([]string, len(contracts))
+	for i, c := range contracts {
+		symbols[i] = c.Symbol
+	}
+	validator := NewSymbolValidator(symbols)
+	s.SetSymbolValidator(validator)
+	return validator, nil
+}
+
+// SetSymbolValidator sets the validator used to check symbols (nil disables validation)
+func (s *Service) SetSymbolValidator(validator *SymbolValidator) {
+	s.symbols.mu.Lock()
+	defer s.symbols.mu.Unlock()
+	s.symbols.validator = validator
+}
+
+// checkSymbol validates symbol if symbol validation is enabled
+func (s *Service) checkSymbol(symbol string) error {
+	s.symbols.mu.RLock()
+	validator := s.symbols.validator
+	s.symbols.mu.RUnlock()
+
+	if validator == nil {
+		return nil
+	}
+	return validator.Validate(symbol)
+}
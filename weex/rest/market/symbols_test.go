@@ -0,0 +1,87 @@
+package market_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+)
+
+func TestSymbolValidator(t *testing.T) {
+	v := market.NewSymbolValidator([]string{"cmt_btcusdt", "cmt_ethusdt", "cmt_solusdt", "cmt_dogeusdt"})
+	tests := []struct {
+		name            string
+		symbol          string
+		wantErr         bool
+		wantSuggestions []string
+	}{
+		{"known", "cmt_btcusdt", false, nil},
+		{"empty", "", true, nil},
+		{"typo", "cmt_btcusd", true, []string{"cmt_btcusdt", "cmt_ethusdt"}},
+		{"wrong case", "CMT_ETHUSDT", true, []string{"cmt_ethusdt", "cmt_btcusdt", "cmt_solusdt"}},
+		{"several close matches", "cmt_xxxusdt", true, []string{"cmt_btcusdt", "cmt_ethusdt", "cmt_solusdt"}},
+		{"nothing close", "spot_pepe", true, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.symbol)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate(%q) error = %v, wantErr %v", tt.symbol, err, tt.wantErr)
+			}
+			if got := v.Contains(tt.symbol); got != !tt.wantErr {
+				t.Errorf("Contains(%q) = %v", tt.symbol, got)
+			}
+			if tt.wantSuggestions == nil {
+				return
+			}
+			var unknown *market.UnknownSymbolError
+			if !errors.As(err, &unknown) {
+				t.Fatalf("Validate(%q) error = %v, want *UnknownSymbolError", tt.symbol, err)
+			}
+			if !reflect.DeepEqual(unknown.Suggestions, tt.wantSuggestions) {
+				t.Errorf("Suggestions = %v, want %v", unknown.Suggestions, tt.wantSuggestions)
+			}
+			if len(tt.wantSuggestions) > 0 && !strings.Contains(err.Error(), "did you mean "+tt.wantSuggestions[0]) {
+				t.Errorf("Error() = %q, want a suggestion of %s", err.Error(), tt.wantSuggestions[0])
+			}
+		})
+	}
+}
+
+func TestEnableSymbolValidation(t *testing.T) {
+	var uri string
+	svc := newTestMarket(t, `[{"symbol":"cmt_btcusdt"},{"symbol":"cmt_ethusdt"}]`, &uri)
+
+	// Without validation the request is sent as is
+	svc.GetTicker(context.Background(), "cmt_btcusd")
+	if want := "/capi/v2/market/ticker?symbol=cmt_btcusd"; uri != want {
+		t.Fatalf("request = %q, want %q", uri, want)
+	}
+
+	validator, err := svc.EnableSymbolValidation(context.Background())
+	if err != nil {
+		t.Fatalf("EnableSymbolValidation() error = %v", err)
+	}
+	if !validator.Contains("cmt_ethusdt") {
+		t.Error("validator missing a fetched contract")
+	}
+
+	uri = ""
+	_, err = svc.GetTicker(context.Background(), "cmt_btcusd")
+	var unknown *market.UnknownSymbolError
+	if !errors.As(err, &unknown) || unknown.Symbol != "cmt_btcusd" {
+		t.Fatalf("GetTicker() error = %v, want *UnknownSymbolError", err)
+	}
+	if uri != "" {
+		t.Errorf("request %q sent for an unknown symbol", uri)
+	}
+
+	svc.SetSymbolValidator(nil)
+	svc.GetTicker(context.Background(), "cmt_btcusd")
+	if uri == "" {
+		t.Error("request not sent after disabling validation")
+	}
+}
@@ -62,15 +62,15 @@ type Kline []string
 
 // Trade represents a trade record
 type Trade struct {
-	TicketID     string `json:"ticketId"`     // Trade ID
-	Time         int64  `json:"time"`         // Trade time
-	Price        string `json:"price"`        // Price
-	Size         string `json:"size"`         // Size
-	Value        string `json:"value"`        // Value
-	Symbol       string `json:"symbol"`       // Symbol
-	IsBestMatch  bool   `json:"isBestMatch"`  // Is best match
-	IsBuyerMaker bool   `json:"isBuyerMaker"` // Is buyer maker
-	ContractVal  string `json:"contractVal"`  // Contract value
+	TicketID     string       `json:"ticketId"`     // Trade ID
+	Time         types.Millis `json:"time"`         // Trade time
+	Price        string       `json:"price"`        // Price
+	Size         string       `json:"size"`         // Size
+	Value        string       `json:"value"`        // Value
+	Symbol       string       `json:"symbol"`       // Symbol
+	IsBestMatch  bool         `json:"isBestMatch"`  // Is best match
+	IsBuyerMaker bool         `json:"isBuyerMaker"` // Is buyer maker
+	ContractVal  string       `json:"contractVal"`  // Contract value
 }
 
 // ServerTime represents server time response
@@ -87,6 +87,13 @@ type IndexPrice struct {
 	Timestamp string `json:"timestamp"` // Timestamp
 }
 
+// MarkPrice represents the mark price of a contract
+type MarkPrice struct {
+	Symbol    string        `json:"symbol"`    // Contract symbol
+	MarkPrice types.Decimal `json:"markPrice"` // Mark price
+	Timestamp types.Millis  `json:"timestamp"` // Timestamp
+}
+
 // FundingRate represents funding rate information
 type FundingRate struct {
 	Symbol       string `json:"symbol"`       // Contract symbol
@@ -99,13 +106,13 @@ type FundingRate struct {
 type FundingRateHistory struct {
 	Symbol      string        `json:"symbol"`      // Contract symbol
 	FundingRate types.Decimal `json:"fundingRate"` // Funding rate
-	FundingTime int64         `json:"fundingTime"` // Funding time
+	FundingTime types.Millis  `json:"fundingTime"` // Funding time
 }
 
 // SettlementTime represents settlement time information
 type SettlementTime struct {
-	Symbol         string `json:"symbol"`         // Contract symbol
-	SettlementTime int64  `json:"settlementTime"` // Next settlement time
+	Symbol         string       `json:"symbol"`         // Contract symbol
+	SettlementTime types.Millis `json:"settlementTime"` // Next settlement time
 }
 
 // OpenInterest represents open interest information
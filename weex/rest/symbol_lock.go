@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"context"
+	"sync"
+)
+
+// SymbolLocks serializes mutating operations per symbol
+// Operations on different symbols proceed in parallel.
+type SymbolLocks struct {
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+// NewSymbolLocks creates a new SymbolLocks
+func NewSymbolLocks() *SymbolLocks {
+	return &SymbolLocks{locks: make(map[string]chan struct{})}
+}
+
+// Lock waits until symbol is free or ctx is done
+// On success the returned function releases the lock and must be called exactly once.
+func (l *SymbolLocks) Lock(ctx context.Context, symbol string) (func(), error) {
+	l.mu.Lock()
+	lock, ok := l.locks[symbol]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		l.locks[symbol] = lock
+	}
+	l.mu.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// SetSymbolLocking enables or disables per-symbol serialization of mutating requests
+// When enabled, order placement and leverage/margin mode changes on the same
+// symbol wait for each other locally before being sent.
+func (c *Client) SetSymbolLocking(enabled bool) {
+	c.symbolLocksMu.Lock()
+	defer c.symbolLocksMu.Unlock()
+
+	if !enabled {
+		c.symbolLocks = nil
+	} else if c.symbolLocks == nil {
+		c.symbolLocks = NewSymbolLocks()
+	}
+}
+
+// LockSymbol acquires the per-symbol lock if symbol locking is enabled
+// The returned function releases it; it is a no-op when locking is disabled
+// or symbol is empty.
+func (c *Client) LockSymbol(ctx context.Context, symbol string) (func(), error) {
+	c.symbolLocksMu.RLock()
+	locks := c.symbolLocks
+	c.symbolLocksMu.RUnlock()
+
+	if locks == nil || symbol == "" {
+		return func() {}, nil
+	}
+	return locks.Lock(ctx, symbol)
+}
@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSymbolLocks(t *testing.T) {
+	locks := NewSymbolLocks()
+	unlock, err := locks.Lock(context.Background(), "btc")
+	if err != nil {
+		t.Fatalf("Lock(btc) error = %v", err)
+	}
+
+	// Another symbol is independent
+	unlockEth, err := locks.Lock(context.Background(), "eth")
+	if err != nil {
+		t.Fatalf("Lock(eth) error = %v", err)
+	}
+	unlockEth()
+
+	// The same symbol waits until ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := locks.Lock(ctx, "btc"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Lock(btc) while held error = %v, want context.DeadlineExceeded", err)
+	}
+
+	// and succeeds once released
+	acquired := make(chan func())
+	go func() {
+		next, err := locks.Lock(context.Background(), "btc")
+		if err == nil {
+			acquired <- next
+		}
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("Lock(btc) acquired while held")
+	case <-time.After(20 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case next := <-acquired:
+		next()
+	case <-time.After(time.Second):
+		t.Fatal("Lock(btc) not acquired after release")
+	}
+}
+
+func TestLockSymbolDisabled(t *testing.T) {
+	c := &Client{}
+	for i := 0; i < 2; i++ {
+		// Without locking both calls return at once
+		if _, err := c.LockSymbol(context.Background(), "btc"); err != nil {
+			t.Fatalf("LockSymbol() error = %v", err)
+		}
+	}
+
+	c.SetSymbolLocking(true)
+	unlock, err := c.LockSymbol(context.Background(), "btc")
+	if err != nil {
+		t.Fatalf("LockSymbol() error = %v", err)
+	}
+	defer unlock()
+	if _, err := c.LockSymbol(context.Background(), ""); err != nil {
+		t.Errorf("LockSymbol(\"\") error = %v, want no locking without a symbol", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.LockSymbol(ctx, "btc"); err == nil {
+		t.Error("LockSymbol() acquired a held symbol")
+	}
+}
@@ -0,0 +1,163 @@
+package trade
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MaxBatchOrders is the maximum number of orders accepted by PlaceBatchOrders
+const MaxBatchOrders = 20
+
+// BatchItemError describes a single failed item in a batch operation
+type BatchItemError struct {
+	OrderId   string // Order ID (may be empty for rejected placements)
+	ClientOid string // Client order ID
+	Code      string // Error code (if provided)
+	Message   string // Error message
+}
+
+// Error implements the error interface
+func (e *BatchItemError) Error() string {
+	id := e.ClientOid
+	if id == "" {
+		id = e.OrderId
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("%s: [%s] %s", id, e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", id, e.Message)
+}
+
+// BatchError is returned when some items of a batch operation failed
+type BatchError struct {
+	Operation string           // Batch operation ("place" or "cancel")
+	Total     int              // Number of items in the batch
+	Failures  []BatchItemError // Failed items
+}
+
+// Error implements the error interface
+func (e *BatchError) Error() string {
+	parts := make([]string, len(e.Failures))
+	for i := range e.Failures {
+		parts[i] = e.Failures[i].Error()
+	}
+	return fmt.Sprintf("batch %s: %d of %d items failed: %s", e.Operation, len(e.Failures), e.Total, strings.Join(parts, "; "))
+}
+
+// Unwrap returns the per-item errors
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i := range e.Failures {
+		errs[i] = &e.Failures[i]
+	}
+	return errs
+}
+
+// PartialError returns a *BatchError listing the orders that failed, or nil if all succeeded
+func (r *PlaceBatchOrdersResponse) PartialError() error {
+	var failures []BatchItemError
+	for _, info := range r.OrderInfo {
+		if info.Result {
+			continue
+		}
+		failures = append(failures, BatchItemError{
+			OrderId:   info.OrderId,
+			ClientOid: info.ClientOid,
+			Code:      info.ErrorCode,
+			Message:   info.ErrorMessage,
+		})
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return &BatchError{Operation: "place", Total: len(r.OrderInfo), Failures: failures}
+}
+
+// PartialError returns a *BatchError listing the cancellations that failed, or nil if all succeeded
+func (r *CancelBatchOrdersResponse) PartialError() error {
+	var failures []BatchItemError
+	seen := make(map[string]bool)
+	add := func(result CancelOrderResult) {
+		key := result.OrderId + "|" + result.ClientOid
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		failures = append(failures, BatchItemError{
+			OrderId:   result.OrderId,
+			ClientOid: result.ClientOid,
+			Message:   result.ErrMsg,
+		})
+	}
+
+	for _, result := range r.CancelOrderResultList {
+		if !result.Result {
+			add(result)
+		}
+	}
+	for _, result := range r.FailInfos {
+		add(result)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+
+	total := len(r.CancelOrderResultList)
+	if total < len(failures) {
+		total = len(failures)
+	}
+	return &BatchError{Operation: "cancel", Total: total, Failures: failures}
+}
+
+// PlaceOrdersChunked places any number of orders by splitting them into batches of MaxBatchOrders
+//
+// Batches are submitted sequentially, each going through the usual rate limiting
+// and order pacing. If a batch request fails, its orders are recorded as failed
+// with the request error and the remaining batches are still submitted. The
+// returned response lists every order in input order; Result is true only if
+// every order succeeded. Use PartialError on it to collect the failures. A
+// non-nil error is returned only if ctx is done before all batches were sent.
+func (s *Service) PlaceOrdersChunked(ctx context.Context, symbol string, marginMode int, orders []BatchOrderRequest) (*PlaceBatchOrdersResponse, error) {
+	response := &PlaceBatchOrdersResponse{
+		OrderInfo: make([]BatchOrderInfo, 0, len(orders)),
+		Result:    true,
+	}
+
+	for start := 0; start < len(orders); start += MaxBatchOrders {
+		end := min(start+MaxBatchOrders, len(orders))
+		chunk := orders[start:end]
+
+		if err := ctx.Err(); err != nil {
+			failChunk(response, orders[start:], err)
+			return response, err
+		}
+
+		resp, err := s.PlaceBatchOrders(ctx, &PlaceBatchOrdersRequest{
+			Symbol:        symbol,
+			MarginMode:    marginMode,
+			OrderDataList: chunk,
+		})
+		if err != nil {
+			failChunk(response, chunk, err)
+			continue
+		}
+		response.OrderInfo = append(response.OrderInfo, resp.OrderInfo...)
+		if !resp.Result || resp.PartialError() != nil {
+			response.Result = false
+		}
+	}
+	return response, nil
+}
+
+// failChunk records every order in chunk as failed with err
+func failChunk(response *PlaceBatchOrdersResponse, chunk []BatchOrderRequest, err error) {
+	response.Result = false
+	for _, order := range chunk {
+		response.OrderInfo = append(response.OrderInfo, BatchOrderInfo{
+			ClientOid:    order.ClientOid,
+			Result:       false,
+			ErrorMessage: err.Error(),
+		})
+	}
+}
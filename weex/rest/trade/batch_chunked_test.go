@@ -0,0 +1,140 @@
+package trade_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+)
+
+// chunkedOrders returns n limit orders with client oids o-0 ... o-(n-1)
+func chunkedOrders(n int) []trade.BatchOrderRequest {
+	orders := make([]trade.BatchOrderRequest, n)
+	for i := range orders {
+		orders[i] = trade.BatchOrderRequest{
+			ClientOid:  fmt.Sprintf("o-%d", i),
+			Size:       "0.01",
+			Type:       "1",
+			OrderType:  "0",
+			MatchPrice: "0",
+			Price:      "65000",
+		}
+	}
+	return orders
+}
+
+func TestPlaceOrdersChunked(t *testing.T) {
+	tests := []struct {
+		name       string
+		orders     int
+		badSize    []int  // orders given a size the client rejects, failing their whole chunk
+		rejected   string // client oid the exchange rejects
+		wantChunks []int
+		wantFailed []string
+	}{
+		{"25 orders", 25, nil, "", []int{20, 5}, nil},
+		{"exact chunk", 20, nil, "", []int{20}, nil},
+		{"one past chunk", 21, nil, "", []int{20, 1}, nil},
+		{"empty", 0, nil, "", nil, nil},
+		{"exchange rejects one", 25, nil, "o-22", []int{20, 5}, []string{"o-22"}},
+		{"first chunk fails", 25, []int{3}, "", []int{5}, oids(0, 20)},
+		{"middle chunk fails", 45, []int{25}, "", []int{20, 5}, oids(20, 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var chunks []int
+			s := newTestServer(t, testContracts(), func(s *testServer) {
+				s.handlers = map[string]func(*http.Request) string{
+					"/order/batchOrders": func(r *http.Request) string {
+						var req trade.PlaceBatchOrdersRequest
+						json.NewDecoder(r.Body).Decode(&req)
+						mu.Lock()
+						chunks = append(chunks, len(req.OrderDataList))
+						mu.Unlock()
+
+						infos := make([]trade.BatchOrderInfo, len(req.OrderDataList))
+						for i, order := range req.OrderDataList {
+							infos[i] = trade.BatchOrderInfo{OrderId: "id-" + order.ClientOid, ClientOid: order.ClientOid, Result: true}
+							if order.ClientOid == tt.rejected {
+								infos[i] = trade.BatchOrderInfo{ClientOid: order.ClientOid, ErrorCode: "40017", ErrorMessage: "insufficient balance"}
+							}
+						}
+						data, _ := json.Marshal(trade.PlaceBatchOrdersResponse{OrderInfo: infos, Result: true})
+						return string(data)
+					},
+				}
+			})
+			client := newTestClient(t, s)
+
+			orders := chunkedOrders(tt.orders)
+			for _, i := range tt.badSize {
+				orders[i].Size = "0"
+			}
+			resp, err := client.Trade().PlaceOrdersChunked(context.Background(), "cmt_btcusdt", 1, orders)
+			if err != nil {
+				t.Fatalf("PlaceOrdersChunked() error = %v", err)
+			}
+
+			if fmt.Sprint(chunks) != fmt.Sprint(tt.wantChunks) {
+				t.Errorf("chunk sizes = %v, want %v", chunks, tt.wantChunks)
+			}
+			if len(resp.OrderInfo) != tt.orders {
+				t.Fatalf("got %d results, want %d", len(resp.OrderInfo), tt.orders)
+			}
+			for i, info := range resp.OrderInfo {
+				if want := fmt.Sprintf("o-%d", i); info.ClientOid != want {
+					t.Errorf("result %d client oid = %s, want %s", i, info.ClientOid, want)
+				}
+			}
+			if resp.Result != (len(tt.wantFailed) == 0) {
+				t.Errorf("Result = %v, want %v", resp.Result, len(tt.wantFailed) == 0)
+			}
+
+			var failed []string
+			var batchErr *trade.BatchError
+			if errors.As(resp.PartialError(), &batchErr) {
+				for _, failure := range batchErr.Failures {
+					failed = append(failed, failure.ClientOid)
+				}
+			}
+			if strings.Join(failed, ",") != strings.Join(tt.wantFailed, ",") {
+				t.Errorf("failed orders = %v, want %v", failed, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestPlaceOrdersChunkedContextCancelled(t *testing.T) {
+	s := newTestServer(t, testContracts())
+	client := newTestClient(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, err := client.Trade().PlaceOrdersChunked(ctx, "cmt_btcusdt", 1, chunkedOrders(25))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("PlaceOrdersChunked() error = %v, want context.Canceled", err)
+	}
+	if got := s.Calls("/order/batchOrders"); got != 0 {
+		t.Errorf("batch requests = %d, want 0", got)
+	}
+	if len(resp.OrderInfo) != 25 || resp.Result {
+		t.Errorf("got %d results with Result %v, want 25 failed", len(resp.OrderInfo), resp.Result)
+	}
+}
+
+// oids returns the client oids o-from ... o-(to-1)
+func oids(from, to int) []string {
+	var out []string
+	for i := from; i < to; i++ {
+		out = append(out, fmt.Sprintf("o-%d", i))
+	}
+	return out
+}
@@ -0,0 +1,128 @@
+package trade_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+)
+
+func TestPlaceBatchOrdersPartialError(t *testing.T) {
+	tests := []struct {
+		name  string
+		infos []trade.BatchOrderInfo
+		want  []trade.BatchItemError
+	}{
+		{"all succeeded", []trade.BatchOrderInfo{
+			{OrderId: "1", ClientOid: "a", Result: true},
+			{OrderId: "2", ClientOid: "b", Result: true},
+		}, nil},
+		{"mixed", []trade.BatchOrderInfo{
+			{OrderId: "1", ClientOid: "a", Result: true},
+			{ClientOid: "b", ErrorCode: "40017", ErrorMessage: "insufficient balance"},
+			{OrderId: "3", ClientOid: "c", Result: true},
+			{ClientOid: "d", ErrorCode: "40020", ErrorMessage: "price out of range"},
+		}, []trade.BatchItemError{
+			{ClientOid: "b", Code: "40017", Message: "insufficient balance"},
+			{ClientOid: "d", Code: "40020", Message: "price out of range"},
+		}},
+		{"empty", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &trade.PlaceBatchOrdersResponse{OrderInfo: tt.infos}
+			err := resp.PartialError()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("PartialError() = %v, want nil", err)
+				}
+				return
+			}
+
+			var batchErr *trade.BatchError
+			if !errors.As(err, &batchErr) {
+				t.Fatalf("PartialError() = %v, want *BatchError", err)
+			}
+			if batchErr.Operation != "place" || batchErr.Total != len(tt.infos) {
+				t.Errorf("Operation, Total = %s, %d, want place, %d", batchErr.Operation, batchErr.Total, len(tt.infos))
+			}
+			if !reflect.DeepEqual(batchErr.Failures, tt.want) {
+				t.Errorf("Failures = %+v, want %+v", batchErr.Failures, tt.want)
+			}
+
+			var itemErr *trade.BatchItemError
+			if !errors.As(err, &itemErr) || *itemErr != tt.want[0] {
+				t.Errorf("errors.As(*BatchItemError) = %+v, want %+v", itemErr, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestCancelBatchOrdersPartialError(t *testing.T) {
+	tests := []struct {
+		name      string
+		resp      trade.CancelBatchOrdersResponse
+		wantTotal int
+		want      []trade.BatchItemError
+	}{
+		{"all succeeded", trade.CancelBatchOrdersResponse{CancelOrderResultList: []trade.CancelOrderResult{
+			{OrderId: "1", Result: true},
+			{OrderId: "2", Result: true},
+		}}, 0, nil},
+		{"mixed", trade.CancelBatchOrdersResponse{CancelOrderResultList: []trade.CancelOrderResult{
+			{OrderId: "1", Result: true},
+			{OrderId: "2", ErrMsg: "order not found"},
+			{OrderId: "3", ClientOid: "c", Result: true},
+		}}, 3, []trade.BatchItemError{{OrderId: "2", Message: "order not found"}}},
+		{"fail infos deduplicated", trade.CancelBatchOrdersResponse{
+			CancelOrderResultList: []trade.CancelOrderResult{
+				{OrderId: "1", Result: true},
+				{OrderId: "2", ErrMsg: "order not found"},
+			},
+			FailInfos: []trade.CancelOrderResult{
+				{OrderId: "2", ErrMsg: "order not found"},
+				{ClientOid: "x", ErrMsg: "already filled"},
+			},
+		}, 2, []trade.BatchItemError{
+			{OrderId: "2", Message: "order not found"},
+			{ClientOid: "x", Message: "already filled"},
+		}},
+		{"only fail infos", trade.CancelBatchOrdersResponse{FailInfos: []trade.CancelOrderResult{
+			{OrderId: "9", ErrMsg: "order not found"},
+		}}, 1, []trade.BatchItemError{{OrderId: "9", Message: "order not found"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.resp.PartialError()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("PartialError() = %v, want nil", err)
+				}
+				return
+			}
+
+			var batchErr *trade.BatchError
+			if !errors.As(err, &batchErr) {
+				t.Fatalf("PartialError() = %v, want *BatchError", err)
+			}
+			if batchErr.Operation != "cancel" || batchErr.Total != tt.wantTotal {
+				t.Errorf("Operation, Total = %s, %d, want cancel, %d", batchErr.Operation, batchErr.Total, tt.wantTotal)
+			}
+			if !reflect.DeepEqual(batchErr.Failures, tt.want) {
+				t.Errorf("Failures = %+v, want %+v", batchErr.Failures, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchErrorMessage(t *testing.T) {
+	err := &trade.BatchError{Operation: "place", Total: 3, Failures: []trade.BatchItemError{
+		{ClientOid: "b", Code: "40017", Message: "insufficient balance"},
+		{OrderId: "7", Message: "rejected"},
+	}}
+	want := "batch place: 2 of 3 items failed: b: [40017] insufficient balance; 7: rejected"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+package trade
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// clientOidPrefix marks client order IDs generated by NewClientOid
+const clientOidPrefix = "sdk"
+
+// NewClientOid generates a unique client order ID
+// IDs come from a shared NewMonotonicClientOidGenerator with the prefix "sdk"
+// (e.g. "sdklxk3f9a0g0000a1b2"), well under MaxClientOidLength.
+func NewClientOid() string {
+	return defaultClientOidGenerator()
+}
+
+// OrderBuilder builds a PlaceOrderRequest from typed values
+//
+// Example:
+//
+//	req, err := trade.NewOrder("cmt_btcusdt").
+//	    OpenLong().
+//	    Limit("50000").
+//	    Size("0.001").
+//	    PostOnly().
+//	    MarginMode(types.MarginModeShared).
+//	    Build()
+type OrderBuilder struct {
+	req        PlaceOrderRequest
+	orderType  types.OrderType
+	execution  types.OrderExecutionType
+	match      types.PriceMatch
+	marginMode types.MarginMode
+}
+
+// NewOrder starts building a normal limit order for symbol
+func NewOrder(symbol string) *OrderBuilder {
+	return &OrderBuilder{
+		req:       PlaceOrderRequest{Symbol: symbol},
+		execution: types.OrderExecNormal,
+		match:     types.PriceMatchLimit,
+	}
+}
+
+// Type sets the order type
+func (b *OrderBuilder) Type(orderType types.OrderType) *OrderBuilder {
+	b.orderType = orderType
+	return b
+}
+
+// OpenLong sets the order type to open long
+func (b *OrderBuilder) OpenLong() *OrderBuilder {
+	return b.Type(types.OrderTypeOpenLong)
+}
+
+// OpenShort sets the order type to open short
+func (b *OrderBuilder) OpenShort() *OrderBuilder {
+	return b.Type(types.OrderTypeOpenShort)
+}
+
+// CloseLong sets the order type to close long
+func (b *OrderBuilder) CloseLong() *OrderBuilder {
+	return b.Type(types.OrderTypeCloseLong)
+}
+
+// CloseShort sets the order type to close short
+func (b *OrderBuilder) CloseShort() *OrderBuilder {
+	return b.Type(types.OrderTypeCloseShort)
+}
+
+// Limit makes the order a limit order at price
+func (b *OrderBuilder) Limit(price types.Decimal) *OrderBuilder {
+	b.match = types.PriceMatchLimit
+	b.req.Price = string(price)
+	return b
+}
+
+// Market makes the order a market order
+func (b *OrderBuilder) Market() *OrderBuilder {
+	b.match = types.PriceMatchMarket
+	return b
+}
+
+// Size sets the order quantity
+func (b *OrderBuilder) Size(size types.Decimal) *OrderBuilder {
+	b.req.Size = string(size)
+	return b
+}
+
+// Execution sets the execution type
+func (b *OrderBuilder) Execution(execution types.OrderExecutionType) *OrderBuilder {
+	b.execution = execution
+	return b
+}
+
+// PostOnly makes the order post-only (maker only)
+func (b *OrderBuilder) PostOnly() *OrderBuilder {
+	return b.Execution(types.OrderExecPostOnly)
+}
+
+// FillOrKill makes the order fill-or-kill
+func (b *OrderBuilder) FillOrKill() *OrderBuilder {
+	return b.Execution(types.OrderExecFillOrKill)
+}
+
+// ImmediateOrCancel makes the order immediate-or-cancel
+func (b *OrderBuilder) ImmediateOrCancel() *OrderBuilder {
+	return b.Execution(types.OrderExecImmediateOrCancel)
+}
+
+// MarginMode sets the margin mode
+func (b *OrderBuilder) MarginMode(mode types.MarginMode) *OrderBuilder {
+	b.marginMode = mode
+	return b
+}
+
+// ClientOid sets the client order ID; one is generated by Build if not set
+func (b *OrderBuilder) ClientOid(clientOid string) *OrderBuilder {
+	b.req.ClientOid = clientOid
+	return b
+}
+
+// TakeProfit sets the preset take-profit price
+func (b *OrderBuilder) TakeProfit(price types.Decimal) *OrderBuilder {
+	b.req.PresetTakeProfitPrice = string(price)
+	return b
+}
+
+// StopLoss sets the preset stop-loss price
+func (b *OrderBuilder) StopLoss(price types.Decimal) *OrderBuilder {
+	b.req.PresetStopLossPrice = string(price)
+	return b
+}
+
+// Build validates the order and returns the request
+// A ClientOid is generated with NewClientOid if none was set. All problems are
+// reported together, as with ValidatePlaceOrder.
+func (b *OrderBuilder) Build() (*PlaceOrderRequest, error) {
+	req := b.req
+	if req.ClientOid == "" {
+		req.ClientOid = NewClientOid()
+	}
+	req.Type = strconv.Itoa(int(b.orderType))
+	req.OrderType = strconv.Itoa(int(b.execution))
+	req.MatchPrice = strconv.Itoa(int(b.match))
+	req.MarginMode = int(b.marginMode)
+
+	var errs []error
+	if b.match == types.PriceMatchMarket && req.Price != "" {
+		errs = append(errs, types.NewValidationError("price", "market orders must not set a price, got %s", req.Price))
+	}
+	if err := ValidatePlaceOrder(&req, nil); err != nil {
+		errs = append(errs, err)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
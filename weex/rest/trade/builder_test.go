@@ -0,0 +1,105 @@
+package trade_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestOrderBuilder(t *testing.T) {
+	tests := []struct {
+		name   string
+		build  func() *trade.OrderBuilder
+		want   trade.PlaceOrderRequest // ClientOid is checked separately when empty
+		fields []string                // ValidationError.Field of every expected violation
+	}{
+		{"post-only limit", func() *trade.OrderBuilder {
+			return trade.NewOrder("cmt_btcusdt").OpenLong().Limit("50000").Size("0.001").PostOnly().MarginMode(types.MarginModeShared)
+		}, trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Size: "0.001", Type: "1", OrderType: "1", MatchPrice: "0", Price: "50000", MarginMode: 1}, nil},
+		{"market IOC", func() *trade.OrderBuilder {
+			return trade.NewOrder("cmt_btcusdt").OpenShort().Market().Size("2").ImmediateOrCancel()
+		}, trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Size: "2", Type: "2", OrderType: "3", MatchPrice: "1"}, nil},
+		{"close with client_oid", func() *trade.OrderBuilder {
+			return trade.NewOrder("cmt_btcusdt").CloseShort().Limit("100").Size("1").FillOrKill().ClientOid("mine").MarginMode(types.MarginModeIsolated)
+		}, trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", ClientOid: "mine", Size: "1", Type: "4", OrderType: "2", MatchPrice: "0", Price: "100", MarginMode: 3}, nil},
+		{"presets", func() *trade.OrderBuilder {
+			return trade.NewOrder("cmt_btcusdt").OpenLong().Limit("100").Size("1").TakeProfit("110").StopLoss("90")
+		}, trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Size: "1", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100",
+			PresetTakeProfitPrice: "110", PresetStopLossPrice: "90"}, nil},
+		{"market with price", func() *trade.OrderBuilder {
+			return trade.NewOrder("cmt_btcusdt").OpenLong().Limit("100").Market().Size("1")
+		}, trade.PlaceOrderRequest{}, []string{"price"}},
+		{"no order type", func() *trade.OrderBuilder {
+			return trade.NewOrder("cmt_btcusdt").Limit("100").Size("1")
+		}, trade.PlaceOrderRequest{}, []string{"type"}},
+		{"client_oid too long", func() *trade.OrderBuilder {
+			return trade.NewOrder("cmt_btcusdt").CloseLong().Limit("100").Size("1").ClientOid(strings.Repeat("x", trade.MaxClientOidLength+1))
+		}, trade.PlaceOrderRequest{}, []string{"client_oid"}},
+		{"post-only market", func() *trade.OrderBuilder {
+			return trade.NewOrder("cmt_btcusdt").OpenLong().Market().Size("1").PostOnly()
+		}, trade.PlaceOrderRequest{}, []string{"match_price"}},
+		{"limit without price", func() *trade.OrderBuilder {
+			return trade.NewOrder("cmt_btcusdt").OpenLong().Size("1")
+		}, trade.PlaceOrderRequest{}, []string{"price"}},
+		{"missing size", func() *trade.OrderBuilder {
+			return trade.NewOrder("cmt_btcusdt").OpenLong().Limit("100")
+		}, trade.PlaceOrderRequest{}, []string{"size"}},
+		{"invalid execution and margin mode", func() *trade.OrderBuilder {
+			return trade.NewOrder("cmt_btcusdt").OpenLong().Limit("100").Size("1").Execution(7).MarginMode(2)
+		}, trade.PlaceOrderRequest{}, []string{"order_type", "marginMode"}},
+		{"everything wrong", func() *trade.OrderBuilder {
+			return trade.NewOrder("").Limit("100").Market().Size("-1").TakeProfit("0")
+		}, trade.PlaceOrderRequest{}, []string{"price", "symbol", "type", "size", "presetTakeProfitPrice"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.build().Build()
+
+			var fields []string
+			for _, v := range types.ValidationErrors(err) {
+				fields = append(fields, v.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Fatalf("violations = %v, want %v (err: %v)", fields, tt.fields, err)
+			}
+			if tt.fields != nil {
+				if req != nil {
+					t.Errorf("Build() = %+v with an error, want nil", req)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			want := tt.want
+			if want.ClientOid == "" {
+				if !strings.HasPrefix(req.ClientOid, "sdk") || len(req.ClientOid) > trade.MaxClientOidLength {
+					t.Errorf("generated ClientOid = %q, want an sdk-prefixed ID of at most %d characters", req.ClientOid, trade.MaxClientOidLength)
+				}
+				want.ClientOid = req.ClientOid
+			}
+			if *req != want {
+				t.Errorf("Build() = %+v\nwant %+v", *req, want)
+			}
+		})
+	}
+}
+
+func TestOrderBuilderClientOidsUnique(t *testing.T) {
+	builder := trade.NewOrder("cmt_btcusdt").OpenLong().Market().Size("1")
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		req, err := builder.Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		if seen[req.ClientOid] {
+			t.Fatalf("duplicate ClientOid %q after %d builds", req.ClientOid, i)
+		}
+		seen[req.ClientOid] = true
+	}
+}
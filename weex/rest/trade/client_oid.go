@@ -0,0 +1,198 @@
+package trade
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest"
+)
+
+// DefaultClientOidTTL is how long a client order ID is remembered for duplicate detection
+const DefaultClientOidTTL = time.Minute
+
+// ErrDuplicateClientOid is returned when an order reuses a recently sent client order ID
+var ErrDuplicateClientOid = errors.New("duplicate client order id")
+
+// ClientOidGenerator returns a new client order ID on each call
+type ClientOidGenerator func() string
+
+// clientOidSuffixLength is the length of the time, sequence and random parts of a generated ID
+const clientOidSuffixLength = 9 + 4 + 4
+
+// NewMonotonicClientOidGenerator returns a generator of ULID-like client order IDs
+// IDs are the prefix followed by the Unix millisecond time (9 base36 digits), a
+// 4-digit base36 sequence and 4 random hex characters. IDs from one generator
+// sort in generation order, even when several are generated within the same
+// millisecond. The prefix is truncated to keep IDs within MaxClientOidLength.
+func NewMonotonicClientOidGenerator(prefix string) ClientOidGenerator {
+	if max := MaxClientOidLength - clientOidSuffixLength; len(prefix) > max {
+		prefix = prefix[:max]
+	}
+
+	var (
+		mu     sync.Mutex
+		lastMs int64
+		seq    int64
+	)
+	return func() string {
+		mu.Lock()
+		ms := time.Now().UnixMilli()
+		if ms <= lastMs {
+			ms = lastMs
+			seq++
+			if seq >= 36*36*36*36 {
+				ms++
+				seq = 0
+			}
+		} else {
+			seq = 0
+		}
+		lastMs = ms
+		n := seq
+		mu.Unlock()
+
+		var b [2]byte
+		_, _ = rand.Read(b[:])
+		return prefix + padBase36(ms, 9) + padBase36(n, 4) + hex.EncodeToString(b[:])
+	}
+}
+
+// padBase36 formats n in base36, left-padded with zeros to width
+func padBase36(n int64, width int) string {
+	s := strconv.FormatInt(n, 36)
+	if len(s) < width {
+		s = strings.Repeat("0", width-len(s)) + s
+	}
+	return s
+}
+
+// defaultClientOidGenerator is used by NewClientOid and services without a custom generator
+var defaultClientOidGenerator = NewMonotonicClientOidGenerator(clientOidPrefix)
+
+// SetClientOidGenerator sets the generator used to fill empty client order IDs
+// Pass nil to restore the default generator.
+func (s *Service) SetClientOidGenerator(generator ClientOidGenerator) {
+	s.oids.mu.Lock()
+	defer s.oids.mu.Unlock()
+	s.oids.generator = generator
+}
+
+// SetClientOidTTL sets how long sent client order IDs are remembered to reject local duplicates
+// Pass 0 to disable duplicate detection.
+func (s *Service) SetClientOidTTL(ttl time.Duration) {
+	s.oids.mu.Lock()
+	defer s.oids.mu.Unlock()
+	s.oids.ttl = ttl
+	s.oids.ttlSet = true
+	if ttl <= 0 {
+		s.oids.recent = nil
+	}
+}
+
+// clientOidGuard fills empty client order IDs and remembers recently sent ones
+type clientOidGuard struct {
+	mu        sync.Mutex
+	generator ClientOidGenerator
+	ttl       time.Duration
+	ttlSet    bool                 // false = DefaultClientOidTTL
+	recent    map[string]time.Time // client order ID -> expiry
+}
+
+// claim fills *clientOid if empty and records it as sent
+// Returns ErrDuplicateClientOid if the ID was already sent within the TTL.
+func (g *clientOidGuard) claim(clientOid *string) error {
+	return g.claimAll([]*string{clientOid})
+}
+
+// claimAll fills each empty client order ID and records them all as sent
+// If any ID was already sent within the TTL, or appears twice in clientOids,
+// ErrDuplicateClientOid is returned, none of them is recorded and the empty
+// IDs are left empty.
+func (g *clientOidGuard) claimAll(clientOids []*string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	generator := g.generator
+	if generator == nil {
+		generator = defaultClientOidGenerator
+	}
+	oids := make([]string, len(clientOids))
+	for i, clientOid := range clientOids {
+		oids[i] = *clientOid
+		if oids[i] == "" {
+			oids[i] = generator()
+		}
+	}
+
+	if ttl := g.pruneLocked(); ttl > 0 {
+		now := time.Now()
+		seen := make(map[string]bool, len(oids))
+		for _, oid := range oids {
+			if _, ok := g.recent[oid]; ok || seen[oid] {
+				return fmt.Errorf("%w: %s", ErrDuplicateClientOid, oid)
+			}
+			seen[oid] = true
+		}
+		if g.recent == nil {
+			g.recent = make(map[string]time.Time)
+		}
+		for _, oid := range oids {
+			g.recent[oid] = now.Add(ttl)
+		}
+	}
+
+	for i, clientOid := range clientOids {
+		*clientOid = oids[i]
+	}
+	return nil
+}
+
+// pruneLocked drops expired IDs and returns the TTL in effect (0 = duplicate detection disabled)
+// g.mu must be held.
+func (g *clientOidGuard) pruneLocked() time.Duration {
+	ttl := DefaultClientOidTTL
+	if g.ttlSet {
+		ttl = g.ttl
+	}
+	if ttl > 0 {
+		now := time.Now()
+		for oid, expiry := range g.recent {
+			if !now.Before(expiry) {
+				delete(g.recent, oid)
+			}
+		}
+	}
+	return ttl
+}
+
+// check returns ErrDuplicateClientOid if clientOid was sent within the TTL, without recording it
+func (g *clientOidGuard) check(clientOid string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.pruneLocked() <= 0 {
+		return nil
+	}
+	if _, ok := g.recent[clientOid]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateClientOid, clientOid)
+	}
+	return nil
+}
+
+// releaseUnsent forgets claimed client order IDs if err shows their request never left
+// The IDs can then be reused, e.g. to retry the same request.
+func (g *clientOidGuard) releaseUnsent(err error, clientOids ...string) {
+	if !errors.Is(err, rest.ErrRequestNotSent) {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, clientOid := range clientOids {
+		delete(g.recent, clientOid)
+	}
+}
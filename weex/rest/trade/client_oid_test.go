@@ -0,0 +1,391 @@
+package trade_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+)
+
+// oidPlacements places an order with the given client order ID on each path
+// that fills empty IDs, returning the ID that was sent
+var oidPlacements = []struct {
+	name  string
+	path  string
+	place func(ctx context.Context, s *trade.Service, oid string) (string, error)
+}{
+	{"PlaceOrder", "/order/placeOrder", func(ctx context.Context, s *trade.Service, oid string) (string, error) {
+		req := &trade.PlaceOrderRequest{
+			Symbol: "cmt_btcusdt", ClientOid: oid, Size: "0.01", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+		}
+		_, err := s.PlaceOrder(ctx, req)
+		return req.ClientOid, err
+	}},
+	{"PlacePendingOrder", "/order/plan_order", func(ctx context.Context, s *trade.Service, oid string) (string, error) {
+		req := &trade.PlacePendingOrderRequest{
+			Symbol: "cmt_btcusdt", ClientOid: oid, Size: "0.01", Type: "1", MatchType: "0", ExecutePrice: "100000", TriggerPrice: "99000",
+		}
+		_, err := s.PlacePendingOrder(ctx, req)
+		return req.ClientOid, err
+	}},
+	{"PlaceTpSlOrder", "/order/placeTpSlOrder", func(ctx context.Context, s *trade.Service, oid string) (string, error) {
+		req := &trade.PlaceTpSlOrderRequest{
+			Symbol: "cmt_btcusdt", ClientOrderId: oid, PlanType: "profit_plan", TriggerPrice: "110000", Size: "0.01", PositionSide: "long",
+		}
+		_, err := s.PlaceTpSlOrder(ctx, req)
+		return req.ClientOrderId, err
+	}},
+}
+
+func TestClientOidGuard(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(s *trade.Service)
+		oids      []string // client order IDs of successive orders
+		wantSent  int      // orders that reach the server
+		wantDup   bool     // last order rejected as a duplicate
+		check     func(t *testing.T, sent []string)
+	}{
+		{"empty filled by default generator", nil, []string{"", ""}, 2, false, func(t *testing.T, sent []string) {
+			for _, oid := range sent {
+				if !strings.HasPrefix(oid, "sdk") || len(oid) > trade.MaxClientOidLength {
+					t.Errorf("generated client oid %q, want sdk prefix and at most %d characters", oid, trade.MaxClientOidLength)
+				}
+			}
+			if sent[0] == sent[1] {
+				t.Errorf("generated client oids not unique: %v", sent)
+			}
+		}},
+		{"custom generator", func(s *trade.Service) {
+			n := 0
+			s.SetClientOidGenerator(func() string { n++; return fmt.Sprintf("custom-%d", n) })
+		}, []string{"", ""}, 2, false, func(t *testing.T, sent []string) {
+			if !slices.Equal(sent, []string{"custom-1", "custom-2"}) {
+				t.Errorf("client oids = %v, want [custom-1 custom-2]", sent)
+			}
+		}},
+		{"explicit kept", nil, []string{"mine"}, 1, false, func(t *testing.T, sent []string) {
+			if sent[0] != "mine" {
+				t.Errorf("client oid = %q, want mine", sent[0])
+			}
+		}},
+		{"duplicate rejected", nil, []string{"mine", "other", "mine"}, 2, true, nil},
+		{"duplicate generated rejected", func(s *trade.Service) {
+			s.SetClientOidGenerator(func() string { return "same" })
+		}, []string{"", ""}, 1, true, nil},
+		{"duplicate allowed with ttl disabled", func(s *trade.Service) {
+			s.SetClientOidTTL(0)
+		}, []string{"mine", "mine"}, 2, false, nil},
+	}
+
+	for _, placement := range oidPlacements {
+		for _, tt := range tests {
+			t.Run(placement.name+"/"+tt.name, func(t *testing.T) {
+				server := newTestServer(t, testContracts())
+				service := newTestClient(t, server, func(c *weex.Config) { c.EnableRateLimit = false }).Trade()
+				if tt.configure != nil {
+					tt.configure(service)
+				}
+
+				var sent []string
+				var err error
+				for _, oid := range tt.oids {
+					var got string
+					got, err = placement.place(context.Background(), service, oid)
+					if err == nil {
+						sent = append(sent, got)
+					} else if got != oid {
+						t.Errorf("rejected order client oid = %q, want it left as %q", got, oid)
+					}
+				}
+				if tt.wantDup != errors.Is(err, trade.ErrDuplicateClientOid) {
+					t.Errorf("last order error = %v, want duplicate %v", err, tt.wantDup)
+				} else if !tt.wantDup && err != nil {
+					t.Errorf("last order error = %v", err)
+				}
+				if got := server.Calls(placement.path); got != tt.wantSent {
+					t.Errorf("requests = %d, want %d", got, tt.wantSent)
+				}
+				if tt.check != nil && len(sent) == len(tt.oids) {
+					tt.check(t, sent)
+				}
+			})
+		}
+	}
+}
+
+// failingOrderLimiter fails the first WaitForOrders call and allows the rest
+type failingOrderLimiter struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (l *failingOrderLimiter) WaitForOrders(context.Context, int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls++
+	if l.calls == 1 {
+		return errors.New("order limiter closed")
+	}
+	return nil
+}
+
+// drainUIDWeight stops order pacing and empties the UID weight budget, so the
+// next order waits for rate limit capacity until its context is done
+func drainUIDWeight(client *weex.Client) {
+	client.Trade().SetOrderLimiter(nil)
+	for client.GetRateLimiter().TryAcquire(0, 1) {
+	}
+}
+
+func TestClientOidClaimTiming(t *testing.T) {
+	type placeFunc func(ctx context.Context, oid string) (string, error)
+	tests := []struct {
+		name       string
+		orderDelay time.Duration // Time the server takes to answer orders
+		// fail makes an order with client oid "mine" fail
+		fail     func(t *testing.T, client *weex.Client, place placeFunc) error
+		wantDup  bool // Retrying "mine" is rejected as a duplicate
+		wantSent int  // Orders that reach the server, including the retry
+	}{
+		{"order limiter failure before claim", 0, func(t *testing.T, client *weex.Client, place placeFunc) error {
+			client.Trade().SetOrderLimiter(&failingOrderLimiter{})
+			_, err := place(context.Background(), "mine")
+			return err
+		}, false, 1},
+		{"canceled waiting for rate limit", 0, func(t *testing.T, client *weex.Client, place placeFunc) error {
+			drainUIDWeight(client)
+			ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+			defer cancel()
+			_, err := place(ctx, "mine")
+			if !errors.Is(err, rest.ErrRequestNotSent) {
+				t.Errorf("order error = %v, want rest.ErrRequestNotSent", err)
+			}
+			client.SetRateLimitEnabled(false)
+			return err
+		}, false, 1},
+		{"timed out after sending", 300 * time.Millisecond, func(t *testing.T, client *weex.Client, place placeFunc) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_, err := place(ctx, "mine")
+			if errors.Is(err, rest.ErrRequestNotSent) {
+				t.Errorf("order error = %v, want a sent request", err)
+			}
+			return err
+		}, true, 1},
+	}
+
+	for _, placement := range oidPlacements {
+		for _, tt := range tests {
+			t.Run(placement.name+"/"+tt.name, func(t *testing.T) {
+				server := newTestServer(t, testContracts(), func(s *testServer) { s.orderDelay = tt.orderDelay })
+				client := newTestClient(t, server)
+				client.Trade().SetOrderLimiter(nil)
+				place := func(ctx context.Context, oid string) (string, error) {
+					return placement.place(ctx, client.Trade(), oid)
+				}
+
+				if err := tt.fail(t, client, place); err == nil {
+					t.Fatal("first order succeeded, want an error")
+				}
+				_, err := place(context.Background(), "mine")
+				if tt.wantDup != errors.Is(err, trade.ErrDuplicateClientOid) {
+					t.Errorf("retry error = %v, want duplicate %v", err, tt.wantDup)
+				} else if !tt.wantDup && err != nil {
+					t.Errorf("retry error = %v", err)
+				}
+				if got := server.Calls(placement.path); got != tt.wantSent {
+					t.Errorf("requests = %d, want %d", got, tt.wantSent)
+				}
+			})
+		}
+	}
+}
+
+func TestPlaceBatchOrdersClientOids(t *testing.T) {
+	order := func(oid string) trade.BatchOrderRequest {
+		return trade.BatchOrderRequest{ClientOid: oid, Size: "0.01", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000"}
+	}
+	tests := []struct {
+		name     string
+		batches  [][]string // client order IDs of successive batches
+		wantDups []bool     // batch rejected as a duplicate
+		wantSent int
+	}{
+		{"empty filled", [][]string{{"", ""}}, []bool{false}, 1},
+		{"explicit kept", [][]string{{"a", "b"}}, []bool{false}, 1},
+		{"duplicate within batch", [][]string{{"a", "a"}}, []bool{true}, 0},
+		{"duplicate of a recent order", [][]string{{"a", "b"}, {"c", "a"}}, []bool{false, true}, 1},
+		{"rejected batch claims nothing", [][]string{{"a"}, {"c", "a"}, {"c"}}, []bool{false, true, false}, 2},
+		{"rejected batch leaves empty ids empty", [][]string{{"a"}, {"", "a"}}, []bool{false, true}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, testContracts())
+			service := newTestClient(t, server, func(c *weex.Config) { c.EnableRateLimit = false }).Trade()
+
+			for i, oids := range tt.batches {
+				req := &trade.PlaceBatchOrdersRequest{Symbol: "cmt_btcusdt", MarginMode: 1}
+				for _, oid := range oids {
+					req.OrderDataList = append(req.OrderDataList, order(oid))
+				}
+				_, err := service.PlaceBatchOrders(context.Background(), req)
+				if tt.wantDups[i] != errors.Is(err, trade.ErrDuplicateClientOid) {
+					t.Fatalf("batch %d error = %v, want duplicate %v", i+1, err, tt.wantDups[i])
+				} else if !tt.wantDups[i] && err != nil {
+					t.Fatalf("batch %d error = %v", i+1, err)
+				}
+				if tt.wantDups[i] {
+					for j, rejected := range req.OrderDataList {
+						if rejected.ClientOid != oids[j] {
+							t.Errorf("batch %d order %d client oid = %q, want it left as %q", i+1, j, rejected.ClientOid, oids[j])
+						}
+					}
+					continue
+				}
+				for j, sent := range req.OrderDataList {
+					if sent.ClientOid == "" || (oids[j] != "" && sent.ClientOid != oids[j]) {
+						t.Errorf("batch %d order %d client oid = %q, want %q or generated", i+1, j, sent.ClientOid, oids[j])
+					}
+				}
+				if len(req.OrderDataList) == 2 && req.OrderDataList[0].ClientOid == req.OrderDataList[1].ClientOid {
+					t.Errorf("batch %d client oids not unique: %q", i+1, req.OrderDataList[0].ClientOid)
+				}
+			}
+			if got := server.Calls("/order/batchOrders"); got != tt.wantSent {
+				t.Errorf("requests = %d, want %d", got, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestPlaceBatchOrdersReleasesUnsentClientOids(t *testing.T) {
+	server := newTestServer(t, testContracts())
+	client := newTestClient(t, server)
+	service := client.Trade()
+	batch := func(oids ...string) *trade.PlaceBatchOrdersRequest {
+		req := &trade.PlaceBatchOrdersRequest{Symbol: "cmt_btcusdt", MarginMode: 1}
+		for _, oid := range oids {
+			req.OrderDataList = append(req.OrderDataList, trade.BatchOrderRequest{ClientOid: oid, Size: "0.01", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000"})
+		}
+		return req
+	}
+
+	drainUIDWeight(client)
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	if _, err := service.PlaceBatchOrders(ctx, batch("a", "b")); !errors.Is(err, rest.ErrRequestNotSent) {
+		t.Fatalf("batch error = %v, want rest.ErrRequestNotSent", err)
+	}
+
+	client.SetRateLimitEnabled(false)
+	if _, err := service.PlaceBatchOrders(context.Background(), batch("a", "b")); err != nil {
+		t.Errorf("retried batch error = %v", err)
+	}
+	if got := server.Calls("/order/batchOrders"); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestClientOidConcurrentUnique(t *testing.T) {
+	const goroutines, perGoroutine = 20, 10
+
+	server := newTestServer(t, testContracts())
+	service := newTestClient(t, server, func(c *weex.Config) { c.EnableRateLimit = false }).Trade()
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				oid, err := oidPlacements[0].place(context.Background(), service, "")
+				if err != nil {
+					t.Errorf("PlaceOrder() error = %v", err)
+					return
+				}
+				mu.Lock()
+				if seen[oid] {
+					t.Errorf("client oid %s generated twice", oid)
+				}
+				seen[oid] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != goroutines*perGoroutine {
+		t.Errorf("unique client oids = %d, want %d", len(seen), goroutines*perGoroutine)
+	}
+	if got := server.Calls("/order/placeOrder"); got != goroutines*perGoroutine {
+		t.Errorf("requests = %d, want %d", got, goroutines*perGoroutine)
+	}
+}
+
+func TestMonotonicClientOidGenerator(t *testing.T) {
+	tests := []struct {
+		name       string
+		prefix     string
+		wantPrefix string
+	}{
+		{"short prefix", "bot1-", "bot1-"},
+		{"no prefix", "", ""},
+		{"long prefix truncated", strings.Repeat("p", 40), strings.Repeat("p", 23)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generate := trade.NewMonotonicClientOidGenerator(tt.prefix)
+			var previous string
+			for i := 0; i < 5000; i++ {
+				oid := generate()
+				if !strings.HasPrefix(oid, tt.wantPrefix) || len(oid) > trade.MaxClientOidLength {
+					t.Fatalf("oid %q, want prefix %q and at most %d characters", oid, tt.wantPrefix, trade.MaxClientOidLength)
+				}
+				if oid[:len(oid)-4] <= previous {
+					t.Fatalf("oid %q does not sort after %q", oid, previous)
+				}
+				previous = oid[:len(oid)-4]
+			}
+		})
+	}
+}
+
+func TestMonotonicClientOidGeneratorConcurrent(t *testing.T) {
+	const goroutines, perGoroutine = 8, 2000
+
+	generate := trade.NewMonotonicClientOidGenerator("c-")
+	results := make([][]string, goroutines)
+	var wg sync.WaitGroup
+	for g := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				results[g] = append(results[g], generate())
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, goroutines*perGoroutine)
+	for _, oids := range results {
+		for _, oid := range oids {
+			if seen[oid] {
+				t.Fatalf("client oid %s generated twice", oid)
+			}
+			seen[oid] = true
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package trade
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+)
+
+// SetMarketService sets the market service whose contract cache is used for contract lookups
+// Caching is controlled by market.Service.SetContractCacheTTL, so the trade and
+// market services share one cache when given the same market service.
+func (s *Service) SetMarketService(markets *market.Service) {
+	s.markets = markets
+}
+
+// Contract returns the contract metadata for symbol
+// Contracts are fetched with market.Service.GetContractsMap, so they are reused
+// while that service's contract cache is fresh (weex.Config.ContractCacheTTL,
+// 5 minutes by default) and refetched otherwise.
+func (s *Service) Contract(ctx context.Context, symbol string) (*market.ContractInfo, error) {
+	contracts, err := s.markets.GetContractsMap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch contract %s: %w", symbol, err)
+	}
+	contract, ok := contracts[symbol]
+	if !ok {
+		return nil, &market.UnknownSymbolError{Symbol: symbol}
+	}
+	return &contract, nil
+}
+
+// sizeContract returns the cached contract for symbol used to check order sizes, or nil if it is not cached
+// The order path never fetches contracts: lot size and order size limits apply
+// only once the contract is in the market service's cache (e.g. after Contract,
+// PrevalidateOrder or GetContractsMap). Otherwise only the positive size check
+// applies and the exchange validates the rest.
+func (s *Service) sizeContract(symbol string) *market.ContractInfo {
+	contract, ok := s.markets.CachedContract(symbol)
+	if !ok {
+		return nil
+	}
+	return &contract
+}
+
+// validateSize checks an order size with ValidateSize against the cached contract for symbol, if any
+func (s *Service) validateSize(symbol, size string) error {
+	if err := ValidateSize(size, nil); err != nil {
+		return err
+	}
+	return ValidateSize(size, s.sizeContract(symbol))
+}
+
+// ClearContractCache drops the market service's cached contracts so the next lookup refetches them
+func (s *Service) ClearContractCache() {
+	s.markets.InvalidateContractCache()
+}
+
+// PrevalidateOrder checks req against the contract for its symbol before sending
+// It runs ValidatePlaceOrder with the contract's tick size, lot size and order size
+// limits. Use ContractInfo.RoundPrice and RoundSize to fix values it rejects.
+// This is the opt-in contract check: it fetches contracts if they are not cached,
+// which also lets later placements check sizes against the cached contract.
+func (s *Service) PrevalidateOrder(ctx context.Context, req *PlaceOrderRequest) error {
+	if req == nil {
+		return fmt.Errorf("order request cannot be nil")
+	}
+	contract, err := s.Contract(ctx, req.Symbol)
+	if err != nil {
+		return err
+	}
+	return ValidatePlaceOrder(req, contract)
+}
@@ -0,0 +1,219 @@
+package trade_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestContractUsesMarketCache(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		between     func(client *trade.Service, markets *market.Service)
+		wantFetches int
+	}{
+		{"caching disabled", 0, nil, 2},
+		{"cached", time.Minute, nil, 1},
+		{"cleared through trade", time.Minute, func(client *trade.Service, _ *market.Service) { client.ClearContractCache() }, 2},
+		{"invalidated through market", time.Minute, func(_ *trade.Service, markets *market.Service) { markets.InvalidateContractCache() }, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, testContracts())
+			client := newTestClient(t, server)
+			client.Market().SetContractCacheTTL(tt.ttl)
+
+			ctx := context.Background()
+			if _, err := client.Trade().Contract(ctx, "cmt_btcusdt"); err != nil {
+				t.Fatalf("Contract() error = %v", err)
+			}
+			if tt.between != nil {
+				tt.between(client.Trade(), client.Market())
+			}
+			contract, err := client.Trade().Contract(ctx, "cmt_btcusdt")
+			if err != nil {
+				t.Fatalf("Contract() error = %v", err)
+			}
+			if contract.SizeIncrement != "0.001" {
+				t.Errorf("SizeIncrement = %q, want 0.001", contract.SizeIncrement)
+			}
+			if got := server.Calls("/market/contracts"); got != tt.wantFetches {
+				t.Errorf("contract fetches = %d, want %d", got, tt.wantFetches)
+			}
+		})
+	}
+}
+
+func TestPrevalidateOrderCachesByDefault(t *testing.T) {
+	server := newTestServer(t, testContracts())
+	client := newTestClient(t, server)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		err := client.Trade().PrevalidateOrder(ctx, &trade.PlaceOrderRequest{
+			Symbol: "cmt_btcusdt", ClientOid: "oid", Size: "0.002", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+		})
+		if err != nil {
+			t.Fatalf("PrevalidateOrder() error = %v", err)
+		}
+	}
+	if got := server.Calls("/market/contracts"); got != 1 {
+		t.Errorf("contract fetches = %d, want 1", got)
+	}
+}
+
+func TestPrevalidateOrderEnablesPlacementSizeCheck(t *testing.T) {
+	server := newTestServer(t, testContracts())
+	client := newTestClient(t, server)
+
+	ctx := context.Background()
+	offLot := func() *trade.PlaceOrderRequest {
+		return &trade.PlaceOrderRequest{
+			Symbol: "cmt_btcusdt", Size: "0.0015", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+		}
+	}
+	if _, err := client.Trade().PlaceOrder(ctx, offLot()); err != nil {
+		t.Fatalf("PlaceOrder() before PrevalidateOrder error = %v, want the exchange to validate", err)
+	}
+	if got := server.Calls("/market/contracts"); got != 0 {
+		t.Fatalf("contract fetches = %d, want none before PrevalidateOrder", got)
+	}
+
+	err := client.Trade().PrevalidateOrder(ctx, &trade.PlaceOrderRequest{
+		Symbol: "cmt_btcusdt", ClientOid: "oid", Size: "0.002", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+	})
+	if err != nil {
+		t.Fatalf("PrevalidateOrder() error = %v", err)
+	}
+	_, err = client.Trade().PlaceOrder(ctx, offLot())
+	var verr *types.ValidationError
+	if !errors.As(err, &verr) || verr.Field != "size" {
+		t.Errorf("PlaceOrder() after PrevalidateOrder error = %v, want validation error on size", err)
+	}
+	if got := server.Calls("/order/placeOrder"); got != 1 {
+		t.Errorf("orders sent = %d, want 1", got)
+	}
+	if got := server.Calls("/market/contracts"); got != 1 {
+		t.Errorf("contract fetches = %d, want 1", got)
+	}
+}
+
+func TestContractSharedWithMarket(t *testing.T) {
+	server := newTestServer(t, testContracts())
+	client := newTestClient(t, server)
+	client.Market().SetContractCacheTTL(time.Minute)
+
+	ctx := context.Background()
+	if _, err := client.Market().GetContractsMap(ctx); err != nil {
+		t.Fatalf("GetContractsMap() error = %v", err)
+	}
+	if _, err := client.Trade().Contract(ctx, "cmt_btcusdt"); err != nil {
+		t.Fatalf("Contract() error = %v", err)
+	}
+	if got := server.Calls("/market/contracts"); got != 1 {
+		t.Errorf("contract fetches = %d, want 1", got)
+	}
+}
+
+func TestContractUnknownSymbol(t *testing.T) {
+	server := newTestServer(t, testContracts())
+	client := newTestClient(t, server)
+
+	_, err := client.Trade().Contract(context.Background(), "cmt_nopeusdt")
+	var unknown *market.UnknownSymbolError
+	if !errors.As(err, &unknown) || unknown.Symbol != "cmt_nopeusdt" {
+		t.Fatalf("Contract() error = %v, want UnknownSymbolError", err)
+	}
+}
+
+func TestPlaceOrderValidatesAgainstCachedContract(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		warm      bool // Contracts fetched before placing
+		symbol    string
+		size      string
+		wantField string
+		wantSent  int // Orders sent out of two placements
+	}{
+		{"cached contract rejects off-lot size", time.Minute, true, "cmt_btcusdt", "0.0015", "size", 0},
+		{"cached contract accepts lot multiple", time.Minute, true, "cmt_btcusdt", "0.002", "", 2},
+		{"cold cache skips contract limits", time.Minute, false, "cmt_btcusdt", "0.0015", "", 2},
+		{"caching disabled skips contract limits", 0, true, "cmt_btcusdt", "0.0015", "", 2},
+		{"unlisted symbol skips contract limits", time.Minute, true, "cmt_nopeusdt", "0.0015", "", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, testContracts())
+			client := newTestClient(t, server)
+			client.Market().SetContractCacheTTL(tt.ttl)
+
+			ctx := context.Background()
+			wantFetches := 0
+			if tt.warm {
+				if _, err := client.Market().GetContractsMap(ctx); err != nil {
+					t.Fatalf("GetContractsMap() error = %v", err)
+				}
+				wantFetches = 1
+			}
+			for i := 0; i < 2; i++ {
+				_, err := client.Trade().PlaceOrder(ctx, &trade.PlaceOrderRequest{
+					Symbol: tt.symbol, Size: tt.size, Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+				})
+				if tt.wantField != "" {
+					var verr *types.ValidationError
+					if !errors.As(err, &verr) || verr.Field != tt.wantField {
+						t.Errorf("PlaceOrder() error = %v, want validation error on %s", err, tt.wantField)
+					}
+				} else if err != nil {
+					t.Errorf("PlaceOrder() error = %v", err)
+				}
+			}
+			if got := server.Calls("/order/placeOrder"); got != tt.wantSent {
+				t.Errorf("orders sent = %d, want %d", got, tt.wantSent)
+			}
+			if got := server.Calls("/market/contracts"); got != wantFetches {
+				t.Errorf("contract fetches = %d, want %d (placement must not fetch)", got, wantFetches)
+			}
+		})
+	}
+}
+
+func TestPlaceOrderContractsOutage(t *testing.T) {
+	server := newTestServer(t, testContracts(), func(s *testServer) {
+		s.contractsErr = `{"code":"50001","msg":"service unavailable"}`
+	})
+	client := newTestClient(t, server)
+
+	ctx := context.Background()
+	req := &trade.PlaceOrderRequest{
+		Symbol: "cmt_btcusdt", Size: "0.002", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+	}
+	if err := client.Trade().PrevalidateOrder(ctx, req); err == nil {
+		t.Fatal("PrevalidateOrder() error = nil, want the contracts fetch error")
+	}
+	fetches := server.Calls("/market/contracts")
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		order := *req
+		if _, err := client.Trade().PlaceOrder(ctx, &order); err != nil {
+			t.Fatalf("PlaceOrder() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("placements took %v, want no wait on the contracts endpoint", elapsed)
+	}
+	if got := server.Calls("/market/contracts"); got != fetches {
+		t.Errorf("contract fetches = %d after placing, want %d", got, fetches)
+	}
+	if got := server.Calls("/order/placeOrder"); got != 2 {
+		t.Errorf("orders sent = %d, want 2", got)
+	}
+}
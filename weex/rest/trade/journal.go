@@ -0,0 +1,37 @@
+package trade
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// Normalize converts the fill into an exchange-neutral TradeRecord
+// The fill price is derived as FillValue / FillSize. REST fills report neither
+// the fee currency nor the liquidity role, so FeeCoin and Liquidity are left empty.
+func (f *Fill) Normalize() (types.TradeRecord, error) {
+	side, err := types.ParseOrderSide(f.OrderSide)
+	if err != nil {
+		return types.TradeRecord{}, fmt.Errorf("fill %d: %w", f.TradeId, err)
+	}
+	price, err := types.Decimal(f.FillValue).DivErr(types.Decimal(f.FillSize))
+	if err != nil {
+		return types.TradeRecord{}, fmt.Errorf("fill %d: invalid fill value %q or size %q: %w", f.TradeId, f.FillValue, f.FillSize, err)
+	}
+	pnl := types.Decimal(f.RealizePnl)
+	if pnl == "" {
+		pnl = "0"
+	}
+	return types.TradeRecord{
+		TradeId:     strconv.FormatInt(f.TradeId, 10),
+		OrderId:     strconv.FormatInt(f.OrderId, 10),
+		Symbol:      f.Symbol,
+		Side:        side,
+		Quantity:    types.Decimal(f.FillSize),
+		Price:       price,
+		Fee:         types.Decimal(f.FillFee),
+		RealizedPnl: pnl,
+		Time:        f.CreatedTime.Time(),
+	}, nil
+}
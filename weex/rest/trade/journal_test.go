@@ -0,0 +1,56 @@
+package trade_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestFillNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		fill    string
+		want    types.TradeRecord
+		wantErr bool
+	}{
+		{"closing sell", `{"tradeId":7,"orderId":42,"symbol":"cmt_btcusdt","orderSide":"SELL","fillSize":"0.02","fillValue":"1300.01","fillFee":"0.78","realizePnl":"12.5","createdTime":1716604853286}`,
+			types.TradeRecord{TradeId: "7", OrderId: "42", Symbol: "cmt_btcusdt", Side: types.OrderSideSell, Quantity: "0.02", Price: "65000.5",
+				Fee: "0.78", RealizedPnl: "12.5", Time: time.UnixMilli(1716604853286)}, false},
+		{"opening buy without pnl", `{"tradeId":8,"orderId":43,"symbol":"cmt_ethusdt","orderSide":"buy","fillSize":"3","fillValue":"10000","fillFee":"-0.5","createdTime":"1716604853286"}`,
+			types.TradeRecord{TradeId: "8", OrderId: "43", Symbol: "cmt_ethusdt", Side: types.OrderSideBuy, Quantity: "3", Price: "3333.333333333333333333",
+				Fee: "-0.5", RealizedPnl: "0", Time: time.UnixMilli(1716604853286)}, false},
+		{"unknown side", `{"tradeId":9,"orderSide":"LONG","fillSize":"1","fillValue":"1"}`, types.TradeRecord{}, true},
+		{"zero size", `{"tradeId":9,"orderSide":"BUY","fillSize":"0","fillValue":"1"}`, types.TradeRecord{}, true},
+		{"invalid value", `{"tradeId":9,"orderSide":"BUY","fillSize":"1","fillValue":"n/a"}`, types.TradeRecord{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fill trade.Fill
+			if err := json.Unmarshal([]byte(tt.fill), &fill); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			got, err := fill.Normalize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Price.Cmp(tt.want.Price) != 0 {
+				t.Errorf("Price = %s, want %s", got.Price, tt.want.Price)
+			}
+			got.Price = tt.want.Price
+			if !got.Time.Equal(tt.want.Time) {
+				t.Errorf("Time = %v, want %v", got.Time, tt.want.Time)
+			}
+			got.Time = tt.want.Time
+			if got != tt.want {
+				t.Errorf("Normalize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrReplaceCancelFailed is returned by ReplaceOrder when the original order was not canceled
+// No replacement order is placed in that case, so the caller cannot end up with both orders
+var ErrReplaceCancelFailed = errors.New("replace: cancel of original order failed")
+
+// replaceSuffix separates a client_oid from its replacement counter (e.g. "quote1-r2")
+const replaceSuffix = "-r"
+
+// ReplaceOrderResult is the outcome of ReplaceOrder
+type ReplaceOrderResult struct {
+	Canceled  bool                 // True if the original order was canceled
+	Cancel    *CancelOrderResponse // Cancel response (nil if the cancel request failed)
+	Placed    *PlaceOrderResponse  // Placement response (nil if no new order was placed)
+	ClientOid string               // client_oid used for the replacement order
+}
+
+// OrderId returns the new order's ID, or "" if none was placed
+func (r *ReplaceOrderResult) OrderId() string {
+	if r.Placed == nil {
+		return ""
+	}
+	return r.Placed.OrderId
+}
+
+// ReplaceOrder cancels oldOrderId and then places newReq in its place
+//
+// The contract API has no endpoint to amend the price or size of a live order
+// (only ModifyTpSlOrder for TP/SL orders), so ReplaceOrder is the way to move
+// a resting limit order.
+//
+// newReq is checked with ValidatePlaceOrder (against the cached contract for
+// its symbol, if any) and an explicit client_oid against recently sent ones
+// before anything is canceled, so a replacement that would be rejected locally
+// leaves the original order in place.
+//
+// The cancel must succeed before the new order is sent. If it does not, an
+// error wrapping ErrReplaceCancelFailed is returned and nothing is placed. If
+// newReq.ClientOid is empty, a client_oid is derived from the original one by
+// appending or incrementing a "-rN" counter (e.g. "quote1" -> "quote1-r1" ->
+// "quote1-r2") so replacements of the same quote can be correlated; counters
+// already sent recently are skipped. The original order ID is used as the base
+// if the order had no client_oid.
+//
+// The returned result is non-nil whenever the cancel was attempted; check
+// Canceled and OrderId to see what happened on error.
+func (s *Service) ReplaceOrder(ctx context.Context, oldOrderId string, newReq *PlaceOrderRequest) (*ReplaceOrderResult, error) {
+	if oldOrderId == "" {
+		return nil, fmt.Errorf("original order ID is required")
+	}
+	if newReq == nil {
+		return nil, fmt.Errorf("replacement order request cannot be nil")
+	}
+
+	if err := ValidatePlaceOrder(newReq, s.sizeContract(newReq.Symbol)); err != nil {
+		return nil, err
+	}
+	if newReq.ClientOid != "" {
+		if err := s.oids.check(newReq.ClientOid); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ReplaceOrderResult{}
+
+	cancel, err := s.CancelOrder(ctx, &CancelOrderRequest{OrderId: oldOrderId})
+	if err != nil {
+		return result, fmt.Errorf("%w: order %s: %w", ErrReplaceCancelFailed, oldOrderId, err)
+	}
+	result.Cancel = cancel
+	if !cancel.Result {
+		return result, fmt.Errorf("%w: order %s: %s", ErrReplaceCancelFailed, oldOrderId, cancel.ErrMsg)
+	}
+	result.Canceled = true
+
+	req := *newReq
+	if req.ClientOid == "" {
+		base := cancel.ClientOid
+		if base == "" {
+			base = oldOrderId
+		}
+		req.ClientOid = NextReplaceClientOid(base)
+		for s.oids.check(req.ClientOid) != nil {
+			req.ClientOid = NextReplaceClientOid(req.ClientOid)
+		}
+	}
+	result.ClientOid = req.ClientOid
+
+	placed, err := s.PlaceOrder(ctx, &req)
+	if err != nil {
+		return result, fmt.Errorf("replace: original order %s canceled but placing replacement failed: %w", oldOrderId, err)
+	}
+	result.Placed = placed
+	return result, nil
+}
+
+// NextReplaceClientOid derives the client_oid for a replacement of an order with clientOid
+// "abc" becomes "abc-r1" and "abc-r1" becomes "abc-r2"; the result is at most 40 characters.
+// An empty clientOid gives "", which PlaceOrder fills from the service's ClientOidGenerator.
+func NextReplaceClientOid(clientOid string) string {
+	if clientOid == "" {
+		return ""
+	}
+
+	base, n := clientOid, 0
+	if i := strings.LastIndex(clientOid, replaceSuffix); i >= 0 {
+		if v, err := strconv.Atoi(clientOid[i+len(replaceSuffix):]); err == nil && v > 0 {
+			base, n = clientOid[:i], v
+		}
+	}
+
+	suffix := replaceSuffix + strconv.Itoa(n+1)
+	if len(base)+len(suffix) > MaxClientOidLength {
+		base = base[:MaxClientOidLength-len(suffix)]
+	}
+	return base + suffix
+}
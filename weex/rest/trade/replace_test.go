@@ -0,0 +1,228 @@
+package trade_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestReplaceOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		cancel       string // Data payload of the cancel response
+		place        string // Data payload of the placement response
+		clientOid    string // ClientOid of the replacement request
+		wantCancelEr bool   // Error wraps ErrReplaceCancelFailed
+		wantErr      bool
+		wantPlaced   int    // Placement requests received
+		wantOid      string // client_oid sent with the replacement
+		wantOrderId  string
+	}{
+		{"derived from original client_oid",
+			`{"order_id":"100","client_oid":"quote1","result":true}`, `{"order_id":"101","client_oid":"quote1-r1"}`,
+			"", false, false, 1, "quote1-r1", "101"},
+		{"counter incremented",
+			`{"order_id":"100","client_oid":"quote1-r4","result":true}`, `{"order_id":"101","client_oid":"quote1-r5"}`,
+			"", false, false, 1, "quote1-r5", "101"},
+		{"derived from order id",
+			`{"order_id":"100","result":true}`, `{"order_id":"101","client_oid":"100-r1"}`,
+			"", false, false, 1, "100-r1", "101"},
+		{"explicit client_oid kept",
+			`{"order_id":"100","client_oid":"quote1","result":true}`, `{"order_id":"101","client_oid":"mine"}`,
+			"mine", false, false, 1, "mine", "101"},
+		{"cancel rejected",
+			`{"order_id":"100","result":false,"err_msg":"order already filled"}`, `{"order_id":"101"}`,
+			"", true, true, 0, "", ""},
+		{"cancel request failed",
+			`"unexpected"`, `{"order_id":"101"}`,
+			"", true, true, 0, "", ""},
+		{"placement failed after cancel",
+			`{"order_id":"100","client_oid":"quote1","result":true}`, `"unexpected"`,
+			"", false, true, 1, "quote1-r1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sentOid string
+			server := newTestServer(t, testContracts(), func(s *testServer) {
+				s.handlers = map[string]func(*http.Request) string{
+					"/order/cancel_order": func(*http.Request) string { return tt.cancel },
+					"/order/placeOrder": func(r *http.Request) string {
+						var body struct {
+							ClientOid string `json:"client_oid"`
+						}
+						data, _ := io.ReadAll(r.Body)
+						json.Unmarshal(data, &body)
+						sentOid = body.ClientOid
+						return tt.place
+					},
+				}
+			})
+			client := newTestClient(t, server)
+
+			result, err := client.Trade().ReplaceOrder(context.Background(), "100", &trade.PlaceOrderRequest{
+				Symbol: "cmt_btcusdt", ClientOid: tt.clientOid, Size: "0.01",
+				Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReplaceOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, trade.ErrReplaceCancelFailed); got != tt.wantCancelEr {
+				t.Errorf("errors.Is(err, ErrReplaceCancelFailed) = %v, want %v (err: %v)", got, tt.wantCancelEr, err)
+			}
+			if got := server.Calls("/order/placeOrder"); got != tt.wantPlaced {
+				t.Errorf("placement requests = %d, want %d", got, tt.wantPlaced)
+			}
+			if sentOid != tt.wantOid {
+				t.Errorf("replacement client_oid = %q, want %q", sentOid, tt.wantOid)
+			}
+			if result == nil {
+				t.Fatal("result = nil, want a result once the cancel was attempted")
+			}
+			if result.Canceled == tt.wantCancelEr {
+				t.Errorf("Canceled = %v, want %v", result.Canceled, !tt.wantCancelEr)
+			}
+			if got := result.OrderId(); got != tt.wantOrderId {
+				t.Errorf("OrderId() = %q, want %q", got, tt.wantOrderId)
+			}
+		})
+	}
+}
+
+func TestReplaceOrderArguments(t *testing.T) {
+	server := newTestServer(t, testContracts())
+	client := newTestClient(t, server)
+
+	if _, err := client.Trade().ReplaceOrder(context.Background(), "", &trade.PlaceOrderRequest{}); err == nil {
+		t.Error("expected an error without an order ID")
+	}
+	if _, err := client.Trade().ReplaceOrder(context.Background(), "100", nil); err == nil {
+		t.Error("expected an error without a replacement request")
+	}
+	if n := server.Calls("/order/cancel_order"); n != 0 {
+		t.Errorf("cancel requests = %d, want 0", n)
+	}
+}
+
+func TestReplaceOrderInvalidReplacementKeepsOriginal(t *testing.T) {
+	valid := func() *trade.PlaceOrderRequest {
+		return &trade.PlaceOrderRequest{
+			Symbol: "cmt_btcusdt", Size: "0.01", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+		}
+	}
+	tests := []struct {
+		name      string
+		warm      bool // Contracts cached before replacing
+		edit      func(req *trade.PlaceOrderRequest)
+		wantField string // Field of the expected ValidationError, empty for a duplicate client_oid
+	}{
+		{"zero size", false, func(req *trade.PlaceOrderRequest) { req.Size = "0" }, "size"},
+		{"empty symbol", false, func(req *trade.PlaceOrderRequest) { req.Symbol = "" }, "symbol"},
+		{"missing price", false, func(req *trade.PlaceOrderRequest) { req.Price = "" }, "price"},
+		{"off lot against cached contract", true, func(req *trade.PlaceOrderRequest) { req.Size = "0.0015" }, "size"},
+		{"reused client_oid", false, func(req *trade.PlaceOrderRequest) { req.ClientOid = "sent" }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, testContracts())
+			client := newTestClient(t, server)
+			ctx := context.Background()
+			if tt.warm {
+				if _, err := client.Market().GetContractsMap(ctx); err != nil {
+					t.Fatalf("GetContractsMap() error = %v", err)
+				}
+			}
+			sent := valid()
+			sent.ClientOid = "sent"
+			if _, err := client.Trade().PlaceOrder(ctx, sent); err != nil {
+				t.Fatalf("PlaceOrder() error = %v", err)
+			}
+
+			req := valid()
+			tt.edit(req)
+			result, err := client.Trade().ReplaceOrder(ctx, "100", req)
+			if tt.wantField == "" {
+				if !errors.Is(err, trade.ErrDuplicateClientOid) {
+					t.Errorf("ReplaceOrder() error = %v, want ErrDuplicateClientOid", err)
+				}
+			} else {
+				var verr *types.ValidationError
+				if !errors.As(err, &verr) || verr.Field != tt.wantField {
+					t.Errorf("ReplaceOrder() error = %v, want validation error on %s", err, tt.wantField)
+				}
+			}
+			if result != nil {
+				t.Errorf("result = %+v, want nil when nothing was attempted", result)
+			}
+			if n := server.Calls("/order/cancel_order"); n != 0 {
+				t.Errorf("cancel requests = %d, want 0", n)
+			}
+			if n := server.Calls("/order/placeOrder"); n != 1 {
+				t.Errorf("placement requests = %d, want only the original", n)
+			}
+		})
+	}
+}
+
+func TestReplaceOrderSkipsSentDerivedClientOid(t *testing.T) {
+	server := newTestServer(t, testContracts(), func(s *testServer) {
+		s.handlers = map[string]func(*http.Request) string{
+			"/order/cancel_order": func(*http.Request) string {
+				return `{"order_id":"100","client_oid":"quote1","result":true}`
+			},
+		}
+	})
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	for _, oid := range []string{"quote1-r1", "quote1-r2"} {
+		if _, err := client.Trade().PlaceOrder(ctx, &trade.PlaceOrderRequest{
+			Symbol: "cmt_btcusdt", ClientOid: oid, Size: "0.01", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+		}); err != nil {
+			t.Fatalf("PlaceOrder(%s) error = %v", oid, err)
+		}
+	}
+	result, err := client.Trade().ReplaceOrder(ctx, "100", &trade.PlaceOrderRequest{
+		Symbol: "cmt_btcusdt", Size: "0.01", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+	})
+	if err != nil {
+		t.Fatalf("ReplaceOrder() error = %v", err)
+	}
+	if result.ClientOid != "quote1-r3" {
+		t.Errorf("ClientOid = %q, want quote1-r3", result.ClientOid)
+	}
+}
+
+func TestNextReplaceClientOid(t *testing.T) {
+	long := strings.Repeat("a", trade.MaxClientOidLength)
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"quote1", "quote1-r1"},
+		{"quote1-r1", "quote1-r2"},
+		{"quote1-r9", "quote1-r10"},
+		{"quote1-r0", "quote1-r0-r1"},
+		{"quote1-rx", "quote1-rx-r1"},
+		{"a-r1-r3", "a-r1-r4"},
+		{long, long[:trade.MaxClientOidLength-3] + "-r1"},
+		{long[:trade.MaxClientOidLength-4] + "-r99", long[:trade.MaxClientOidLength-5] + "-r100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := trade.NextReplaceClientOid(tt.in)
+			if got != tt.want {
+				t.Errorf("NextReplaceClientOid(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if len(got) > trade.MaxClientOidLength {
+				t.Errorf("len = %d, exceeds %d", len(got), trade.MaxClientOidLength)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package trade_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/account"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+)
+
+// testServer is a local REST server counting requests per path
+type testServer struct {
+	*httptest.Server
+
+	account      account.AccountResponse               // Served for GET /account/getAccounts
+	accountErr   string                                // Optional: error body served for GET /account/getAccounts instead
+	contractsErr string                                // Optional: error body served for GET /market/contracts instead
+	orderDelay   time.Duration                         // Time taken to answer order placement requests
+	handlers     map[string]func(*http.Request) string // Optional: data payloads by path, replacing the defaults
+
+	mu          sync.Mutex
+	calls       map[string]int
+	queries     map[string]string
+	orderTimes  []time.Time
+	inFlight    int
+	maxInFlight int
+}
+
+// newTestServer starts a server serving contracts for GET /market/contracts
+// and replying to every other path with an empty success wrapper.
+// configure, if set, is applied before the server starts.
+func newTestServer(t *testing.T, contracts []market.ContractInfo, configure ...func(*testServer)) *testServer {
+	t.Helper()
+	s := &testServer{calls: make(map[string]int), queries: make(map[string]string)}
+	for _, fn := range configure {
+		fn(s)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/capi/v2")
+		s.mu.Lock()
+		s.calls[path]++
+		s.queries[path] = r.URL.RawQuery
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch path {
+		case "/market/contracts":
+			if s.contractsErr != "" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(s.contractsErr))
+				return
+			}
+			json.NewEncoder(w).Encode(contracts)
+			return
+		case "/account/getAccounts":
+			if s.accountErr != "" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(s.accountErr))
+				return
+			}
+			json.NewEncoder(w).Encode(s.account)
+			return
+		case "/order/placeOrder", "/order/batchOrders", "/order/plan_order", "/order/placeTpSlOrder":
+			s.trackOrder()
+		}
+		if handler, ok := s.handlers[path]; ok {
+			w.Write([]byte(`{"code":"0","msg":"success","requestTime":1700000000000,"data":` + handler(r) + `}`))
+			return
+		}
+		switch path {
+		case "/order/placeTpSlOrder", "/order/current", "/order/currentPlan", "/order/cancelAllOrders", "/order/closePositions":
+			w.Write([]byte(`{"code":"0","msg":"success","requestTime":1700000000000,"data":[]}`))
+			return
+		}
+		w.Write([]byte(`{"code":"0","msg":"success","requestTime":1700000000000,"data":{}}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// trackOrder records an order request and holds it for orderDelay
+func (s *testServer) trackOrder() {
+	s.mu.Lock()
+	s.orderTimes = append(s.orderTimes, time.Now())
+	s.inFlight++
+	s.maxInFlight = max(s.maxInFlight, s.inFlight)
+	s.mu.Unlock()
+
+	time.Sleep(s.orderDelay)
+
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+}
+
+// OrderTimes returns the arrival times of order placement requests
+func (s *testServer) OrderTimes() []time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]time.Time(nil), s.orderTimes...)
+}
+
+// MaxInFlight returns the most order placement requests handled at once
+func (s *testServer) MaxInFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxInFlight
+}
+
+// Query returns the raw query string of the last request received for path (without the /capi/v2 prefix)
+func (s *testServer) Query(path string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.queries[path]
+}
+
+// Calls returns the number of requests received for path (without the /capi/v2 prefix)
+func (s *testServer) Calls(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[path]
+}
+
+// newTestClient returns an authenticated client pointed at s with retries disabled
+// configure, if set, is applied to the config before the client is created.
+func newTestClient(t *testing.T, s *testServer, configure ...func(*weex.Config)) *weex.Client {
+	t.Helper()
+	config := weex.NewDefaultConfig().
+		WithBaseURL(s.URL).
+		WithAPIKey("test-api-key").
+		WithSecretKey("test-secret-key").
+		WithPassphrase("test-passphrase")
+	config.MaxRetries = 0
+	config.Logger = weex.NewNoOpLogger()
+	for _, fn := range configure {
+		fn(config)
+	}
+	client, err := weex.NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+// testContracts returns a single cmt_btcusdt contract with a 0.001 lot size
+func testContracts() []market.ContractInfo {
+	return []market.ContractInfo{{
+		Symbol:        "cmt_btcusdt",
+		TickSize:      "0.1",
+		SizeIncrement: "0.001",
+		MinOrderSize:  "0.001",
+		MaxOrderSize:  "100",
+		MinLeverage:   1,
+		MaxLeverage:   125,
+	}}
+}
@@ -0,0 +1,176 @@
+package trade_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// sizedPlacements places one order of the given size through every placement method, with the API path it posts to
+// structured is false for PlaceOrdersChunked, which reports the rejection as a
+// *trade.BatchError message rather than a ValidationError.
+var sizedPlacements = []struct {
+	name       string
+	path       string
+	structured bool
+	place      func(ctx context.Context, s *trade.Service, size string) error
+}{
+	{"PlaceOrder", "/order/placeOrder", true, func(ctx context.Context, s *trade.Service, size string) error {
+		_, err := s.PlaceOrder(ctx, &trade.PlaceOrderRequest{
+			Symbol: "cmt_btcusdt", Size: size, Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+		})
+		return err
+	}},
+	{"PlaceBatchOrders", "/order/batchOrders", true, func(ctx context.Context, s *trade.Service, size string) error {
+		_, err := s.PlaceBatchOrders(ctx, &trade.PlaceBatchOrdersRequest{
+			Symbol: "cmt_btcusdt",
+			OrderDataList: []trade.BatchOrderRequest{
+				{Size: "0.01", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000"},
+				{Size: size, Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000"},
+			},
+		})
+		return err
+	}},
+	{"PlaceOrdersChunked", "/order/batchOrders", false, func(ctx context.Context, s *trade.Service, size string) error {
+		resp, err := s.PlaceOrdersChunked(ctx, "cmt_btcusdt", 1, []trade.BatchOrderRequest{
+			{Size: size, Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000"},
+		})
+		if err != nil {
+			return err
+		}
+		return resp.PartialError()
+	}},
+	{"PlacePendingOrder", "/order/plan_order", true, func(ctx context.Context, s *trade.Service, size string) error {
+		_, err := s.PlacePendingOrder(ctx, &trade.PlacePendingOrderRequest{
+			Symbol: "cmt_btcusdt", Size: size, Type: "1", MatchType: "0", ExecutePrice: "100000", TriggerPrice: "99000",
+		})
+		return err
+	}},
+	{"PlaceTpSlOrder", "/order/placeTpSlOrder", true, func(ctx context.Context, s *trade.Service, size string) error {
+		_, err := s.PlaceTpSlOrder(ctx, &trade.PlaceTpSlOrderRequest{
+			Symbol: "cmt_btcusdt", PlanType: "profit_plan", TriggerPrice: "110000", Size: size, PositionSide: "long",
+		})
+		return err
+	}},
+}
+
+func TestOrderSizeValidated(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     string
+		contract bool   // contract listed by the exchange and cached before placing
+		wantErr  string // empty when the order is sent
+	}{
+		{"valid", "0.01", false, ""},
+		{"valid on lot", "0.011", true, ""},
+		{"empty", "", false, "size cannot be empty"},
+		{"zero", "0", false, "size must be greater than 0"},
+		{"zero with decimals", "0.000", false, "size must be greater than 0"},
+		{"negative", "-1", false, "size must be greater than 0"},
+		{"not a number", "abc", false, "invalid size"},
+		{"off lot without contract", "0.0015", false, ""},
+		{"off lot", "0.0015", true, "not a multiple of the lot size"},
+		{"above maximum", "101", true, "exceeds the maximum order size"},
+	}
+	for _, p := range sizedPlacements {
+		for _, tt := range tests {
+			t.Run(p.name+"/"+tt.name, func(t *testing.T) {
+				var contracts []market.ContractInfo
+				if tt.contract {
+					contracts = testContracts()
+				}
+				server := newTestServer(t, contracts)
+				client := newTestClient(t, server)
+				ctx := context.Background()
+				if _, err := client.Market().GetContractsMap(ctx); err != nil {
+					t.Fatalf("GetContractsMap() error = %v", err)
+				}
+
+				err := p.place(ctx, client.Trade(), tt.size)
+				if tt.wantErr == "" {
+					if err != nil {
+						t.Fatalf("%s() error = %v", p.name, err)
+					}
+					if got := server.Calls(p.path); got != 1 {
+						t.Errorf("%s requests = %d, want 1", p.path, got)
+					}
+					return
+				}
+
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("%s() error = %v, want %q", p.name, err, tt.wantErr)
+				}
+				var v *types.ValidationError
+				if p.structured && (!errors.As(err, &v) || v.Field != "size") {
+					t.Errorf("%s() error = %v, want a size ValidationError", p.name, err)
+				}
+				if got := server.Calls(p.path); got != 0 {
+					t.Errorf("%s requests = %d, want the order rejected before sending", p.path, got)
+				}
+			})
+		}
+	}
+}
+
+func TestOrderPlacementNilRequest(t *testing.T) {
+	server := newTestServer(t, testContracts())
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	if _, err := client.Trade().PlaceOrder(ctx, nil); err == nil {
+		t.Error("PlaceOrder(nil) error = nil, want an error")
+	}
+	if _, err := client.Trade().PlaceBatchOrders(ctx, nil); err == nil {
+		t.Error("PlaceBatchOrders(nil) error = nil, want an error")
+	}
+	if _, err := client.Trade().PlacePendingOrder(ctx, nil); err == nil {
+		t.Error("PlacePendingOrder(nil) error = nil, want an error")
+	}
+	if _, err := client.Trade().PlaceTpSlOrder(ctx, nil); err == nil {
+		t.Error("PlaceTpSlOrder(nil) error = nil, want an error")
+	}
+}
+
+func TestValidateSize(t *testing.T) {
+	contract := &testContracts()[0]
+	tests := []struct {
+		name     string
+		size     string
+		contract bool
+		wantErr  bool
+	}{
+		{"positive", "0.5", false, false},
+		{"tiny positive", "0.000000000000000001", false, false},
+		{"empty", "", false, true},
+		{"zero", "0", false, true},
+		{"negative zero", "-0", false, true},
+		{"negative", "-0.5", false, true},
+		{"invalid", "1e", false, true},
+		{"fraction", "1/2", false, true},
+		{"exponent", "1e-3", false, true},
+		{"on lot", "0.5", true, false},
+		{"below minimum", "0.0001", true, true},
+		{"off lot", "0.5005", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := contract
+			if !tt.contract {
+				c = nil
+			}
+			err := trade.ValidateSize(tt.size, c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateSize(%q) error = %v, wantErr %v", tt.size, err, tt.wantErr)
+			}
+			var v *types.ValidationError
+			if err != nil && (!errors.As(err, &v) || v.Field != "size") {
+				t.Errorf("ValidateSize(%q) error = %v, want a size ValidationError", tt.size, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package trade
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// SliceMode selects how child orders of a sliced order are released
+type SliceMode int
+
+const (
+	SliceModeTWAP    SliceMode = iota // Place a child every interval until the total is placed
+	SliceModeIceberg                  // Place the next child only once the previous children are filled
+)
+
+// String returns the string representation of SliceMode
+func (m SliceMode) String() string {
+	switch m {
+	case SliceModeTWAP:
+		return "TWAP"
+	case SliceModeIceberg:
+		return "ICEBERG"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// SlicedOrderRequest describes a large order executed as smaller child orders
+type SlicedOrderRequest struct {
+	Template        PlaceOrderRequest // Child order template (Size and ClientOid are set per child)
+	TotalSize       types.Decimal     // Required: total quantity to execute
+	ChildSize       types.Decimal     // Required: quantity per child order
+	Interval        time.Duration     // Required: time between schedule ticks
+	Mode            SliceMode         // Optional: TWAP (default) or iceberg
+	ClientOidPrefix string            // Optional: child client_oid prefix (default: Template.ClientOid), truncated to fit MaxClientOidLength
+}
+
+// SlicedChild is a placed child order and its last observed fill
+type SlicedChild struct {
+	OrderId   string        // Exchange order ID
+	ClientOid string        // Child client_oid
+	Size      types.Decimal // Child quantity
+	Filled    types.Decimal // Last observed filled quantity
+	Done      bool          // Child reached a terminal status (filled or canceled)
+}
+
+// SlicedOrderProgress reports the state of a sliced order execution
+type SlicedOrderProgress struct {
+	Children []SlicedChild // Placed child orders in placement order
+	Placed   types.Decimal // Total quantity placed, excluding the unfilled part of done children
+	Filled   types.Decimal // Total quantity filled
+	Total    types.Decimal // Target quantity
+}
+
+// Complete returns true once the total quantity is filled
+func (p *SlicedOrderProgress) Complete() bool {
+	return p.Filled.Cmp(p.Total) >= 0
+}
+
+// ExecuteSliced executes a large order as child orders placed on a schedule
+//
+// On every tick the fills of placed children are polled via GetSingleOrderInfo
+// and, depending on Mode, the next child is placed via PlaceOrder (which honors
+// the order rate limiter, if set). Execution stops when the total is filled or
+// ctx is done; in the latter case the progress so far is returned with ctx.Err().
+// Unfilled children are left working on cancellation.
+//
+// A child that ends canceled before it is fully filled (common for post-only
+// and IOC children) no longer counts its unfilled quantity as placed,
+// so that quantity is placed again by a later child.
+//
+// onProgress, if non-nil, is invoked after every tick.
+func (s *Service) ExecuteSliced(ctx context.Context, req *SlicedOrderRequest, onProgress func(*SlicedOrderProgress)) (*SlicedOrderProgress, error) {
+	total, err := req.TotalSize.Rat()
+	if err != nil || total.Sign() <= 0 {
+		return nil, fmt.Errorf("totalSize must be a positive number, got %q", req.TotalSize)
+	}
+	child, err := req.ChildSize.Rat()
+	if err != nil || child.Sign() <= 0 {
+		return nil, fmt.Errorf("childSize must be a positive number, got %q", req.ChildSize)
+	}
+	if req.Interval <= 0 {
+		return nil, fmt.Errorf("interval must be greater than 0")
+	}
+	prefix := req.ClientOidPrefix
+	if prefix == "" {
+		prefix = req.Template.ClientOid
+	}
+	if prefix == "" {
+		prefix = fmt.Sprintf("slice%d", time.Now().UnixMilli())
+	}
+
+	progress := &SlicedOrderProgress{
+		Total:  types.NewDecimalFromRat(total),
+		Placed: "0",
+		Filled: "0",
+	}
+
+	ticker := time.NewTicker(req.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.refreshSlicedFills(ctx, progress); err != nil {
+			return progress, err
+		}
+
+		remaining := progress.Total.Sub(progress.Placed)
+		if remaining.Cmp("0") > 0 && (req.Mode == SliceModeTWAP || progress.Filled.Cmp(progress.Placed) >= 0) {
+			size := types.NewDecimalFromRat(child)
+			if size.Cmp(remaining) > 0 {
+				size = remaining
+			}
+
+			order := req.Template
+			order.Size = size.String()
+			order.ClientOid = slicedClientOid(prefix, len(progress.Children)+1)
+
+			resp, err := s.PlaceOrder(ctx, &order)
+			if err != nil {
+				return progress, fmt.Errorf("failed to place child order %s: %w", order.ClientOid, err)
+			}
+			progress.Children = append(progress.Children, SlicedChild{
+				OrderId:   resp.OrderId,
+				ClientOid: order.ClientOid,
+				Size:      size,
+			})
+			progress.Placed = progress.Placed.Add(size)
+		}
+
+		if onProgress != nil {
+			onProgress(progress)
+		}
+		if progress.Complete() {
+			return progress, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return progress, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// slicedClientOid returns the client_oid of the nth child, "prefix-n"
+// The prefix is truncated so the result is at most MaxClientOidLength characters.
+func slicedClientOid(prefix string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	if len(prefix)+len(suffix) > MaxClientOidLength {
+		prefix = prefix[:MaxClientOidLength-len(suffix)]
+	}
+	return prefix + suffix
+}
+
+// refreshSlicedFills polls the fill state of children that are not yet done
+// and recomputes Placed and Filled. The unfilled part of a done child is
+// dropped from Placed so it is returned to the remaining quantity.
+func (s *Service) refreshSlicedFills(ctx context.Context, progress *SlicedOrderProgress) error {
+	placed := types.Decimal("0")
+	filled := types.Decimal("0")
+	for i := range progress.Children {
+		c := &progress.Children[i]
+		if !c.Done && c.Filled.Cmp(c.Size) < 0 && c.OrderId != "" {
+			order, err := s.GetSingleOrderInfo(ctx, c.OrderId)
+			if err != nil {
+				return fmt.Errorf("failed to poll child order %s: %w", c.OrderId, err)
+			}
+			if qty, err := types.Decimal(order.FilledQty).Rat(); err == nil {
+				c.Filled = types.NewDecimalFromRat(qty)
+			}
+			if status, err := types.ParseOrderStatus(order.Status); err == nil && status.IsTerminal() {
+				c.Done = true
+			}
+		}
+		if c.Filled.Cmp(c.Size) >= 0 {
+			c.Done = true
+		}
+		if c.Done {
+			placed = placed.Add(c.Filled)
+		} else {
+			placed = placed.Add(c.Size)
+		}
+		filled = filled.Add(c.Filled)
+	}
+	progress.Placed = placed
+	progress.Filled = filled
+	return nil
+}
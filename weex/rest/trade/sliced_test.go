@@ -0,0 +1,286 @@
+package trade_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// sliceExchange mocks order placement and fills for sliced execution
+// Each child is reported unfilled for fillAfter polls, then fully filled.
+// Children listed in canceled are instead reported canceled with the given fill.
+type sliceExchange struct {
+	fillAfter int
+	canceled  map[string]string // Order ID -> filled quantity when canceled
+
+	mu     sync.Mutex
+	sizes  map[string]string
+	polls  map[string]int
+	placed []string
+}
+
+func newSliceExchange(fillAfter int) *sliceExchange {
+	return &sliceExchange{fillAfter: fillAfter, sizes: make(map[string]string), polls: make(map[string]int)}
+}
+
+func (e *sliceExchange) install(s *testServer) {
+	s.handlers = map[string]func(*http.Request) string{
+		"/order/placeOrder": func(r *http.Request) string {
+			var req trade.PlaceOrderRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			id := fmt.Sprintf("%d", len(e.placed)+1)
+			e.sizes[id] = req.Size
+			e.placed = append(e.placed, req.ClientOid)
+			return fmt.Sprintf(`{"client_oid":%q,"order_id":%q}`, req.ClientOid, id)
+		},
+		"/order/detail": func(r *http.Request) string {
+			id := r.URL.Query().Get("orderId")
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			e.polls[id]++
+			if filled, ok := e.canceled[id]; ok {
+				return fmt.Sprintf(`{"order_id":%q,"size":%q,"filled_qty":%q,"status":"canceled"}`, id, e.sizes[id], filled)
+			}
+			filled := "0"
+			if e.polls[id] > e.fillAfter {
+				filled = e.sizes[id]
+			}
+			return fmt.Sprintf(`{"order_id":%q,"size":%q,"filled_qty":%q}`, id, e.sizes[id], filled)
+		},
+	}
+}
+
+// Placed returns the client_oids of placed children in order
+func (e *sliceExchange) Placed() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.placed...)
+}
+
+func TestExecuteSliced(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      trade.SliceMode
+		total     types.Decimal
+		child     types.Decimal
+		fillAfter int
+		sizes     []types.Decimal
+		maxOpen   int // Most children placed but not yet filled at any tick
+	}{
+		{"TWAP even", trade.SliceModeTWAP, "0.9", "0.3", 0, []types.Decimal{"0.3", "0.3", "0.3"}, 1},
+		{"TWAP remainder", trade.SliceModeTWAP, "1", "0.3", 0, []types.Decimal{"0.3", "0.3", "0.3", "0.1"}, 1},
+		{"TWAP slow fills", trade.SliceModeTWAP, "0.9", "0.3", 2, []types.Decimal{"0.3", "0.3", "0.3"}, 3},
+		{"iceberg", trade.SliceModeIceberg, "0.7", "0.3", 2, []types.Decimal{"0.3", "0.3", "0.1"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exchange := newSliceExchange(tt.fillAfter)
+			server := newTestServer(t, testContracts(), exchange.install)
+			client := newTestClient(t, server)
+
+			req := &trade.SlicedOrderRequest{
+				Template:        trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "1", OrderType: "0", MatchPrice: "1"},
+				TotalSize:       tt.total,
+				ChildSize:       tt.child,
+				Interval:        5 * time.Millisecond,
+				Mode:            tt.mode,
+				ClientOidPrefix: "twap",
+			}
+			maxOpen := 0
+			var lastFilled types.Decimal = "0"
+			progress, err := client.Trade().ExecuteSliced(context.Background(), req, func(p *trade.SlicedOrderProgress) {
+				open := 0
+				for _, c := range p.Children {
+					if c.Filled.Cmp(c.Size) < 0 {
+						open++
+					}
+				}
+				maxOpen = max(maxOpen, open)
+				if p.Filled.Cmp(lastFilled) < 0 {
+					t.Errorf("filled went backwards: %s after %s", p.Filled, lastFilled)
+				}
+				lastFilled = p.Filled
+			})
+			if err != nil {
+				t.Fatalf("ExecuteSliced() error = %v", err)
+			}
+
+			if !progress.Complete() || progress.Filled.Cmp(tt.total) != 0 || progress.Placed.Cmp(tt.total) != 0 {
+				t.Errorf("Placed, Filled = %s, %s, want complete at %s", progress.Placed, progress.Filled, tt.total)
+			}
+			var sizes []types.Decimal
+			var oids []string
+			for i, c := range progress.Children {
+				sizes = append(sizes, c.Size)
+				oids = append(oids, fmt.Sprintf("twap-%d", i+1))
+			}
+			if !reflect.DeepEqual(sizes, tt.sizes) {
+				t.Errorf("child sizes = %v, want %v", sizes, tt.sizes)
+			}
+			if got := exchange.Placed(); !reflect.DeepEqual(got, oids) {
+				t.Errorf("placed client_oids = %v, want %v", got, oids)
+			}
+			if maxOpen > tt.maxOpen {
+				t.Errorf("open children = %d, want at most %d", maxOpen, tt.maxOpen)
+			}
+		})
+	}
+}
+
+func TestExecuteSlicedClientOidLength(t *testing.T) {
+	long := strings.Repeat("p", trade.MaxClientOidLength+5)
+	tests := []struct {
+		name   string
+		prefix string
+		oid    string // Template.ClientOid
+		want   []string
+	}{
+		{"short prefix kept", "twap", "", []string{"twap-1", "twap-2", "twap-3", "twap-4", "twap-5", "twap-6", "twap-7", "twap-8", "twap-9", "twap-10"}},
+		{"long prefix truncated", long, "", []string{
+			long[:38] + "-1", long[:38] + "-2", long[:38] + "-3", long[:38] + "-4", long[:38] + "-5",
+			long[:38] + "-6", long[:38] + "-7", long[:38] + "-8", long[:38] + "-9", long[:37] + "-10",
+		}},
+		{"long template client oid truncated", "", long, []string{
+			long[:38] + "-1", long[:38] + "-2", long[:38] + "-3", long[:38] + "-4", long[:38] + "-5",
+			long[:38] + "-6", long[:38] + "-7", long[:38] + "-8", long[:38] + "-9", long[:37] + "-10",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exchange := newSliceExchange(0)
+			server := newTestServer(t, testContracts(), exchange.install)
+			client := newTestClient(t, server, func(c *weex.Config) { c.EnableRateLimit = false })
+
+			_, err := client.Trade().ExecuteSliced(context.Background(), &trade.SlicedOrderRequest{
+				Template:        trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", ClientOid: tt.oid, Type: "1", OrderType: "0", MatchPrice: "1"},
+				TotalSize:       "1",
+				ChildSize:       "0.1",
+				Interval:        time.Millisecond,
+				ClientOidPrefix: tt.prefix,
+			}, nil)
+			if err != nil {
+				t.Fatalf("ExecuteSliced() error = %v", err)
+			}
+			placed := exchange.Placed()
+			if !reflect.DeepEqual(placed, tt.want) {
+				t.Errorf("placed client_oids = %v, want %v", placed, tt.want)
+			}
+			for _, oid := range placed {
+				if len(oid) > trade.MaxClientOidLength {
+					t.Errorf("client_oid %q is %d characters, want at most %d", oid, len(oid), trade.MaxClientOidLength)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteSlicedCanceledChild(t *testing.T) {
+	for _, mode := range []trade.SliceMode{trade.SliceModeTWAP, trade.SliceModeIceberg} {
+		t.Run(mode.String(), func(t *testing.T) {
+			exchange := newSliceExchange(0)
+			exchange.canceled = map[string]string{"1": "0.1", "2": "0"}
+			server := newTestServer(t, testContracts(), exchange.install)
+			client := newTestClient(t, server)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			progress, err := client.Trade().ExecuteSliced(ctx, &trade.SlicedOrderRequest{
+				Template:        trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "1", OrderType: "0", MatchPrice: "1"},
+				TotalSize:       "0.6",
+				ChildSize:       "0.3",
+				Interval:        5 * time.Millisecond,
+				Mode:            mode,
+				ClientOidPrefix: "twap",
+			}, nil)
+			if err != nil {
+				t.Fatalf("ExecuteSliced() error = %v", err)
+			}
+			if !progress.Complete() || progress.Filled.Cmp("0.6") != 0 || progress.Placed.Cmp("0.6") != 0 {
+				t.Errorf("Placed, Filled = %s, %s, want complete at 0.6", progress.Placed, progress.Filled)
+			}
+			var placed types.Decimal = "0"
+			for _, c := range progress.Children {
+				if !c.Done {
+					t.Errorf("child %s Done = false, want true", c.OrderId)
+				}
+				placed = placed.Add(c.Size)
+			}
+			// 0.3 canceled with 0.1 filled, 0.3 canceled unfilled: 0.5 re-placed
+			if placed.Cmp("1.1") != 0 {
+				t.Errorf("total child size = %s, want 1.1", placed)
+			}
+		})
+	}
+}
+
+func TestExecuteSlicedCancelled(t *testing.T) {
+	exchange := newSliceExchange(1 << 30)
+	server := newTestServer(t, testContracts(), exchange.install)
+	client := newTestClient(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	progress, err := client.Trade().ExecuteSliced(ctx, &trade.SlicedOrderRequest{
+		Template:  trade.PlaceOrderRequest{Symbol: "cmt_btcusdt", Type: "1", OrderType: "0", MatchPrice: "1"},
+		TotalSize: "1",
+		ChildSize: "0.1",
+		Interval:  5 * time.Millisecond,
+		Mode:      trade.SliceModeIceberg,
+	}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ExecuteSliced() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if progress == nil || len(progress.Children) != 1 || progress.Complete() {
+		t.Fatalf("progress = %+v, want a single unfilled child", progress)
+	}
+	if progress.Filled.Cmp("0") != 0 {
+		t.Errorf("Filled = %s, want 0", progress.Filled)
+	}
+}
+
+func TestExecuteSlicedValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    types.Decimal
+		child    types.Decimal
+		interval time.Duration
+	}{
+		{"zero total", "0", "0.1", time.Second},
+		{"invalid total", "abc", "0.1", time.Second},
+		{"negative child", "1", "-0.1", time.Second},
+		{"empty child", "1", "", time.Second},
+		{"zero interval", "1", "0.1", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, testContracts())
+			client := newTestClient(t, server)
+
+			_, err := client.Trade().ExecuteSliced(context.Background(), &trade.SlicedOrderRequest{
+				Template:  trade.PlaceOrderRequest{Symbol: "cmt_btcusdt"},
+				TotalSize: tt.total,
+				ChildSize: tt.child,
+				Interval:  tt.interval,
+			}, nil)
+			if err == nil {
+				t.Fatal("ExecuteSliced() error = nil, want a validation error")
+			}
+			if got := server.Calls("/order/placeOrder"); got != 0 {
+				t.Errorf("orders placed = %d, want 0", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package trade
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// Slippage is the realized slippage of an order's fills versus its intended price
+// Absolute and Percent are signed by side: positive means the fills were worse
+// than intended (paid more on a buy, received less on a sell), negative means
+// price improvement.
+type Slippage struct {
+	IntendedPrice types.Decimal // Price the order was meant to fill at
+	FillPrice     types.Decimal // Volume-weighted average fill price
+	FilledSize    types.Decimal // Total filled quantity
+	Absolute      types.Decimal // Price difference per unit
+	Percent       types.Decimal // Absolute as a percentage of IntendedPrice
+}
+
+// OrderSlippage computes the slippage of order's fills versus its limit price
+// Only fills with the order's ID are counted. Market orders have no limit
+// price; use ComputeSlippage with a reference price (e.g. the mid price at
+// submission) for those.
+func OrderSlippage(order *Order, fills []Fill) (*Slippage, error) {
+	code, err := strconv.Atoi(order.Type)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order type %q: %w", order.Type, err)
+	}
+	if order.Price == "" || types.Decimal(order.Price).IsZero() {
+		return nil, fmt.Errorf("order %s has no price to measure slippage against", order.OrderId)
+	}
+
+	own := fills
+	if id, err := strconv.ParseInt(order.OrderId, 10, 64); err == nil {
+		own = make([]Fill, 0, len(fills))
+		for _, fill := range fills {
+			if fill.OrderId == id {
+				own = append(own, fill)
+			}
+		}
+	}
+	return ComputeSlippage(types.OrderType(code), types.Decimal(order.Price), own)
+}
+
+// ComputeSlippage computes the slippage of fills versus intended for an order of orderType
+func ComputeSlippage(orderType types.OrderType, intended types.Decimal, fills []Fill) (*Slippage, error) {
+	size := types.Decimal("0")
+	value := types.Decimal("0")
+	for _, fill := range fills {
+		var err error
+		if size, err = size.AddErr(types.Decimal(fill.FillSize)); err != nil {
+			return nil, fmt.Errorf("invalid fill size %q in trade %d: %w", fill.FillSize, fill.TradeId, err)
+		}
+		if value, err = value.AddErr(types.Decimal(fill.FillValue)); err != nil {
+			return nil, fmt.Errorf("invalid fill value %q in trade %d: %w", fill.FillValue, fill.TradeId, err)
+		}
+	}
+	if size.IsZero() {
+		return nil, fmt.Errorf("no filled quantity to measure slippage")
+	}
+
+	vwap, err := value.DivErr(size)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := vwap.SubErr(intended)
+	if err != nil {
+		return nil, fmt.Errorf("invalid intended price %q: %w", intended, err)
+	}
+	if !orderType.IsBuy() {
+		diff = diff.Neg()
+	}
+	percent, err := diff.Mul("100").DivErr(intended)
+	if err != nil {
+		return nil, fmt.Errorf("invalid intended price %q: %w", intended, err)
+	}
+
+	return &Slippage{
+		IntendedPrice: intended,
+		FillPrice:     vwap,
+		FilledSize:    size,
+		Absolute:      diff,
+		Percent:       percent,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package trade_test
+
+import (
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestOrderSlippage(t *testing.T) {
+	above := []trade.Fill{
+		{TradeId: 1, OrderId: 42, FillSize: "1", FillValue: "100.5"},
+		{TradeId: 2, OrderId: 42, FillSize: "3", FillValue: "303"},
+		{TradeId: 3, OrderId: 99, FillSize: "10", FillValue: "2000"}, // Another order's fill
+	}
+	below := []trade.Fill{
+		{TradeId: 4, OrderId: 42, FillSize: "2", FillValue: "198"},
+		{TradeId: 5, OrderId: 42, FillSize: "2", FillValue: "199"},
+	}
+
+	tests := []struct {
+		name        string
+		orderType   string
+		fills       []trade.Fill
+		wantVWAP    types.Decimal
+		wantSize    types.Decimal
+		wantAbs     types.Decimal
+		wantPercent types.Decimal
+	}{
+		{"open long filled above", "1", above, "100.875", "4", "0.875", "0.875"},
+		{"close short filled above", "4", above, "100.875", "4", "0.875", "0.875"},
+		{"open short filled above is improvement", "2", above, "100.875", "4", "-0.875", "-0.875"},
+		{"close long filled below", "3", below, "99.25", "4", "0.75", "0.75"},
+		{"open long filled below is improvement", "1", below, "99.25", "4", "-0.75", "-0.75"},
+		{"exact fill", "1", []trade.Fill{{OrderId: 42, FillSize: "0.003", FillValue: "0.3"}}, "100", "0.003", "0", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &trade.Order{OrderId: "42", Type: tt.orderType, Price: "100"}
+			got, err := trade.OrderSlippage(order, tt.fills)
+			if err != nil {
+				t.Fatalf("OrderSlippage() error = %v", err)
+			}
+			checks := []struct {
+				field     string
+				got, want types.Decimal
+			}{
+				{"IntendedPrice", got.IntendedPrice, "100"},
+				{"FillPrice", got.FillPrice, tt.wantVWAP},
+				{"FilledSize", got.FilledSize, tt.wantSize},
+				{"Absolute", got.Absolute, tt.wantAbs},
+				{"Percent", got.Percent, tt.wantPercent},
+			}
+			for _, c := range checks {
+				if c.got.Cmp(c.want) != 0 {
+					t.Errorf("%s = %s, want %s", c.field, c.got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderSlippageErrors(t *testing.T) {
+	fills := []trade.Fill{{OrderId: 42, FillSize: "1", FillValue: "100"}}
+
+	tests := []struct {
+		name  string
+		order trade.Order
+		fills []trade.Fill
+	}{
+		{"market order", trade.Order{OrderId: "42", Type: "1", Price: "0"}, fills},
+		{"no price", trade.Order{OrderId: "42", Type: "1"}, fills},
+		{"invalid type", trade.Order{OrderId: "42", Type: "open_long", Price: "100"}, fills},
+		{"no fills", trade.Order{OrderId: "42", Type: "1", Price: "100"}, nil},
+		{"only other orders' fills", trade.Order{OrderId: "42", Type: "1", Price: "100"}, []trade.Fill{{OrderId: 7, FillSize: "1", FillValue: "100"}}},
+		{"invalid fill size", trade.Order{OrderId: "42", Type: "1", Price: "100"}, []trade.Fill{{OrderId: 42, FillSize: "x", FillValue: "100"}}},
+		{"invalid fill value", trade.Order{OrderId: "42", Type: "1", Price: "100"}, []trade.Fill{{OrderId: 42, FillSize: "1", FillValue: "n/a"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := trade.OrderSlippage(&tt.order, tt.fills); err == nil {
+				t.Errorf("OrderSlippage() = %+v, want error", got)
+			}
+		})
+	}
+}
+
+func TestComputeSlippageReferencePrice(t *testing.T) {
+	fills := []trade.Fill{
+		{FillSize: "0.5", FillValue: "32500.25"},
+		{FillSize: "0.5", FillValue: "32501.25"},
+	}
+	got, err := trade.ComputeSlippage(types.OrderTypeOpenLong, "65000", fills)
+	if err != nil {
+		t.Fatalf("ComputeSlippage() error = %v", err)
+	}
+	if got.FillPrice.Cmp("65001.5") != 0 || got.Absolute.Cmp("1.5") != 0 {
+		t.Errorf("ComputeSlippage() = %+v, want fill price 65001.5 and slippage 1.5", got)
+	}
+	// 1.5 * 100 / 65000, rounded once to 18 places
+	if got.Percent != "0.002307692307692308" {
+		t.Errorf("Percent = %s, want 0.002307692307692308", got.Percent)
+	}
+}
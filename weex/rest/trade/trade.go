@@ -3,43 +3,140 @@ package trade
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 
 	"github.com/weex-api/openapi-contract-go-sdk/weex/rest"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
 )
 
+// OrderLimiter interface (to avoid importing weex package)
+type OrderLimiter interface {
+	WaitForOrders(ctx context.Context, n int) error
+}
+
 // Service provides access to trading API endpoints
 type Service struct {
-	client *rest.Client
+	client       *rest.Client
+	orderLimiter OrderLimiter
+	markets      *market.Service
+	oids         clientOidGuard
 }
 
 // NewService creates a new trade service
+// Contract lookups use a private market service caching contracts for
+// market.DefaultContractCacheTTL until SetMarketService replaces it.
 func NewService(client *rest.Client) *Service {
-	return &Service{client: client}
+	markets := market.NewService(client)
+	markets.SetContractCacheTTL(market.DefaultContractCacheTTL)
+	return &Service{client: client, markets: markets}
+}
+
+// SetOrderLimiter sets the limiter used to pace order placement
+// Pass nil to disable order pacing
+func (s *Service) SetOrderLimiter(limiter OrderLimiter) {
+	s.orderLimiter = limiter
+}
+
+// waitForOrders waits for the order limiter to allow n order placements
+func (s *Service) waitForOrders(ctx context.Context, n int) error {
+	if s.orderLimiter == nil {
+		return nil
+	}
+	if err := s.orderLimiter.WaitForOrders(ctx, n); err != nil {
+		return fmt.Errorf("order rate limit wait failed: %w", err)
+	}
+	return nil
+}
+
+// beginOrders takes the symbol lock and waits for the order limiter to allow n placements
+// Every order placement method calls it before sending; call the returned
+// function once the request completes to release the lock.
+func (s *Service) beginOrders(ctx context.Context, symbol string, n int) (func(), error) {
+	unlock, err := s.client.LockSymbol(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.waitForOrders(ctx, n); err != nil {
+		unlock()
+		return nil, err
+	}
+	return unlock, nil
 }
 
 // PlaceOrder places a new order
+// An empty req.ClientOid is filled in by the service's ClientOidGenerator.
 // POST /capi/v2/order/placeOrder
 // Weight(IP): 2, Weight(UID): 5
 func (s *Service) PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, error) {
 	path := "/order/placeOrder"
+	if req == nil {
+		return nil, fmt.Errorf("order request cannot be nil")
+	}
+	if err := s.validateSize(req.Symbol, req.Size); err != nil {
+		return nil, err
+	}
+	unlock, err := s.beginOrders(ctx, req.Symbol, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if err := s.oids.claim(&req.ClientOid); err != nil {
+		return nil, err
+	}
 	var response PlaceOrderResponse
-	err := s.client.Post(ctx, path, req, &response, 2, 5)
+	err = s.client.Post(ctx, path, req, &response, 2, 5)
+	s.oids.releaseUnsent(err, req.ClientOid)
 	return &response, err
 }
 
 // PlaceBatchOrders places multiple orders in a batch
+// Empty client_oid values are filled in by the service's ClientOidGenerator, and
+// the batch is rejected if any client_oid was recently sent or repeats within it.
 // POST /capi/v2/order/batchOrders
 // Weight(IP): 5, Weight(UID): 10
 func (s *Service) PlaceBatchOrders(ctx context.Context, req *PlaceBatchOrdersRequest) (*PlaceBatchOrdersResponse, error) {
 	path := "/order/batchOrders"
-	if len(req.OrderDataList) > 20 {
-		return nil, fmt.Errorf("maximum 20 orders allowed in batch, got %d", len(req.OrderDataList))
+	if req == nil {
+		return nil, fmt.Errorf("order request cannot be nil")
+	}
+	if len(req.OrderDataList) > MaxBatchOrders {
+		return nil, fmt.Errorf("maximum %d orders allowed in batch, got %d", MaxBatchOrders, len(req.OrderDataList))
+	}
+	var sizeErrs []error
+	contract := s.sizeContract(req.Symbol)
+	for i, order := range req.OrderDataList {
+		if err := ValidateSize(order.Size, contract); err != nil {
+			sizeErrs = append(sizeErrs, types.NewValidationError("size", "order %d: %w", i, err))
+		}
+	}
+	if err := errors.Join(sizeErrs...); err != nil {
+		return nil, err
+	}
+	unlock, err := s.beginOrders(ctx, req.Symbol, len(req.OrderDataList))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	clientOids := make([]*string, len(req.OrderDataList))
+	for i := range req.OrderDataList {
+		clientOids[i] = &req.OrderDataList[i].ClientOid
+	}
+	if err := s.oids.claimAll(clientOids); err != nil {
+		return nil, err
 	}
 	var response PlaceBatchOrdersResponse
-	err := s.client.Post(ctx, path, req, &response, 5, 10)
+	err = s.client.Post(ctx, path, req, &response, 5, 10)
+	if err != nil {
+		sent := make([]string, len(clientOids))
+		for i, clientOid := range clientOids {
+			sent[i] = *clientOid
+		}
+		s.oids.releaseUnsent(err, sent...)
+	}
 	return &response, err
 }
 
@@ -80,12 +177,28 @@ func (s *Service) CancelAllOrders(ctx context.Context, req *CancelAllOrdersReque
 }
 
 // PlacePendingOrder places a pending/trigger order
+// An empty req.ClientOid is filled in by the service's ClientOidGenerator.
 // POST /capi/v2/order/plan_order
 // Weight(IP): 2, Weight(UID): 5
 func (s *Service) PlacePendingOrder(ctx context.Context, req *PlacePendingOrderRequest) (*PlaceOrderResponse, error) {
 	path := "/order/plan_order"
+	if req == nil {
+		return nil, fmt.Errorf("order request cannot be nil")
+	}
+	if err := s.validateSize(req.Symbol, req.Size); err != nil {
+		return nil, err
+	}
+	unlock, err := s.beginOrders(ctx, req.Symbol, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if err := s.oids.claim(&req.ClientOid); err != nil {
+		return nil, err
+	}
 	var response PlaceOrderResponse
-	err := s.client.Post(ctx, path, req, &response, 2, 5)
+	err = s.client.Post(ctx, path, req, &response, 2, 5)
+	s.oids.releaseUnsent(err, req.ClientOid)
 	return &response, err
 }
 
@@ -103,28 +216,18 @@ func (s *Service) CancelPendingOrder(ctx context.Context, req *CancelPendingOrde
 // GET /capi/v2/order/currentPlan
 // Weight(IP): 3, Weight(UID): 3
 func (s *Service) GetCurrentPendingOrders(ctx context.Context, symbol string, orderId int64, startTime, endTime int64, limit, page int) ([]PlanOrder, error) {
-	params := url.Values{}
-	if symbol != "" {
-		params.Set("symbol", symbol)
-	}
-	if orderId > 0 {
-		params.Set("orderId", strconv.FormatInt(orderId, 10))
-	}
-	if startTime > 0 {
-		params.Set("startTime", strconv.FormatInt(startTime, 10))
-	}
-	if endTime > 0 {
-		params.Set("endTime", strconv.FormatInt(endTime, 10))
-	}
-	if limit > 0 {
-		params.Set("limit", strconv.Itoa(limit))
-	}
-	if page > 0 {
-		params.Set("page", strconv.Itoa(page))
-	}
+	return s.GetPendingOrders(ctx, &GetOrdersRequest{
+		Symbol: symbol, OrderId: orderId, StartTime: startTime, EndTime: endTime, Limit: limit, Page: page,
+	})
+}
 
+// GetPendingOrders gets current pending/trigger orders matching req
+// It is GetCurrentPendingOrders with the filters in a request struct; req may be nil.
+// GET /capi/v2/order/currentPlan
+// Weight(IP): 3, Weight(UID): 3
+func (s *Service) GetPendingOrders(ctx context.Context, req *GetOrdersRequest) ([]PlanOrder, error) {
 	path := "/order/currentPlan"
-	if len(params) > 0 {
+	if params := req.query(); len(params) > 0 {
 		path = path + "?" + params.Encode()
 	}
 
@@ -134,12 +237,28 @@ func (s *Service) GetCurrentPendingOrders(ctx context.Context, symbol string, or
 }
 
 // PlaceTpSlOrder places a take profit/stop loss order
+// An empty req.ClientOrderId is filled in by the service's ClientOidGenerator.
 // POST /capi/v2/order/placeTpSlOrder
 // Weight(IP): 2, Weight(UID): 5
 func (s *Service) PlaceTpSlOrder(ctx context.Context, req *PlaceTpSlOrderRequest) ([]PlaceTpSlOrderResultItem, error) {
 	path := "/order/placeTpSlOrder"
+	if req == nil {
+		return nil, fmt.Errorf("order request cannot be nil")
+	}
+	if err := s.validateSize(req.Symbol, req.Size); err != nil {
+		return nil, err
+	}
+	unlock, err := s.beginOrders(ctx, req.Symbol, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if err := s.oids.claim(&req.ClientOrderId); err != nil {
+		return nil, err
+	}
 	var response []PlaceTpSlOrderResultItem
-	err := s.client.Post(ctx, path, req, &response, 2, 5)
+	err = s.client.Post(ctx, path, req, &response, 2, 5)
+	s.oids.releaseUnsent(err, req.ClientOrderId)
 	return response, err
 }
 
@@ -208,28 +327,18 @@ func (s *Service) GetOrderHistory(ctx context.Context, symbol string, pageSize i
 // GET /capi/v2/order/current
 // Weight(IP): 2, Weight(UID): 2
 func (s *Service) GetCurrentOrderStatus(ctx context.Context, symbol string, orderId int64, startTime, endTime int64, limit, page int) ([]Order, error) {
-	params := url.Values{}
-	if symbol != "" {
-		params.Set("symbol", symbol)
-	}
-	if orderId > 0 {
-		params.Set("orderId", strconv.FormatInt(orderId, 10))
-	}
-	if startTime > 0 {
-		params.Set("startTime", strconv.FormatInt(startTime, 10))
-	}
-	if endTime > 0 {
-		params.Set("endTime", strconv.FormatInt(endTime, 10))
-	}
-	if limit > 0 {
-		params.Set("limit", strconv.Itoa(limit))
-	}
-	if page > 0 {
-		params.Set("page", strconv.Itoa(page))
-	}
+	return s.GetOpenOrders(ctx, &GetOrdersRequest{
+		Symbol: symbol, OrderId: orderId, StartTime: startTime, EndTime: endTime, Limit: limit, Page: page,
+	})
+}
 
+// GetOpenOrders gets current open orders matching req
+// It is GetCurrentOrderStatus with the filters in a request struct; req may be nil.
+// GET /capi/v2/order/current
+// Weight(IP): 2, Weight(UID): 2
+func (s *Service) GetOpenOrders(ctx context.Context, req *GetOrdersRequest) ([]Order, error) {
 	path := "/order/current"
-	if len(params) > 0 {
+	if params := req.query(); len(params) > 0 {
 		path = path + "?" + params.Encode()
 	}
 
@@ -242,25 +351,18 @@ func (s *Service) GetCurrentOrderStatus(ctx context.Context, symbol string, orde
 // GET /capi/v2/order/fills
 // Weight(IP): 5, Weight(UID): 5
 func (s *Service) GetTradeDetails(ctx context.Context, symbol string, orderId int64, startTime, endTime int64, limit int) (*FillsResponse, error) {
-	params := url.Values{}
-	if symbol != "" {
-		params.Set("symbol", symbol)
-	}
-	if orderId > 0 {
-		params.Set("orderId", strconv.FormatInt(orderId, 10))
-	}
-	if startTime > 0 {
-		params.Set("startTime", strconv.FormatInt(startTime, 10))
-	}
-	if endTime > 0 {
-		params.Set("endTime", strconv.FormatInt(endTime, 10))
-	}
-	if limit > 0 {
-		params.Set("limit", strconv.Itoa(limit))
-	}
+	return s.GetFills(ctx, &GetFillsRequest{
+		Symbol: symbol, OrderId: orderId, StartTime: startTime, EndTime: endTime, Limit: limit,
+	})
+}
 
+// GetFills gets trade fill details matching req
+// It is GetTradeDetails with the filters in a request struct; req may be nil.
+// GET /capi/v2/order/fills
+// Weight(IP): 5, Weight(UID): 5
+func (s *Service) GetFills(ctx context.Context, req *GetFillsRequest) (*FillsResponse, error) {
 	path := "/order/fills"
-	if len(params) > 0 {
+	if params := req.query(); len(params) > 0 {
 		path = path + "?" + params.Encode()
 	}
 
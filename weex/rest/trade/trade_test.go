@@ -0,0 +1,152 @@
+package trade_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+)
+
+// placements covers every order placement path of trade.Service
+var placements = []struct {
+	name  string
+	place func(ctx context.Context, s *trade.Service) error
+}{
+	{"PlaceOrder", func(ctx context.Context, s *trade.Service) error {
+		_, err := s.PlaceOrder(ctx, &trade.PlaceOrderRequest{
+			Symbol: "cmt_btcusdt", Size: "0.01", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+		})
+		return err
+	}},
+	{"PlaceBatchOrders", func(ctx context.Context, s *trade.Service) error {
+		_, err := s.PlaceBatchOrders(ctx, &trade.PlaceBatchOrdersRequest{
+			Symbol:        "cmt_btcusdt",
+			OrderDataList: []trade.BatchOrderRequest{{Size: "0.01", Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000"}},
+		})
+		return err
+	}},
+	{"PlacePendingOrder", func(ctx context.Context, s *trade.Service) error {
+		_, err := s.PlacePendingOrder(ctx, &trade.PlacePendingOrderRequest{
+			Symbol: "cmt_btcusdt", Size: "0.01", Type: "1", MatchType: "0", ExecutePrice: "100000", TriggerPrice: "99000",
+		})
+		return err
+	}},
+	{"PlaceTpSlOrder", func(ctx context.Context, s *trade.Service) error {
+		_, err := s.PlaceTpSlOrder(ctx, &trade.PlaceTpSlOrderRequest{
+			Symbol: "cmt_btcusdt", PlanType: "profit_plan", TriggerPrice: "110000", Size: "0.01", PositionSide: "long",
+		})
+		return err
+	}},
+}
+
+func TestOrderPlacementPaced(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	for _, tt := range placements {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, testContracts(), func(s *testServer) {
+				s.account.Account.CreateOrderDelayMilliseconds = int(delay / time.Millisecond)
+			})
+			client := newTestClient(t, server)
+
+			// The limiter is never fetched explicitly
+			for i := 0; i < 3; i++ {
+				if err := tt.place(context.Background(), client.Trade()); err != nil {
+					t.Fatalf("order %d: %v", i, err)
+				}
+			}
+
+			if got := server.Calls("/account/getAccounts"); got != 1 {
+				t.Errorf("account config fetches = %d, want 1", got)
+			}
+			times := server.OrderTimes()
+			if len(times) != 3 {
+				t.Fatalf("orders sent = %d, want 3", len(times))
+			}
+			for i := 1; i < len(times); i++ {
+				// Allow for timer slack between reserving and sending
+				if gap := times[i].Sub(times[i-1]); gap < delay-10*time.Millisecond {
+					t.Errorf("gap between orders %d and %d = %v, want at least %v", i-1, i, gap, delay)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderPlacementUnpacedWhenAccountFetchFails(t *testing.T) {
+	for _, tt := range placements {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, testContracts(), func(s *testServer) {
+				s.accountErr = `{"code":"40022","msg":"Insufficient permissions","requestTime":1}`
+			})
+			client := newTestClient(t, server)
+
+			for i := 0; i < 3; i++ {
+				if err := tt.place(context.Background(), client.Trade()); err != nil {
+					t.Fatalf("order %d: %v", i, err)
+				}
+			}
+			if got := len(server.OrderTimes()); got != 3 {
+				t.Errorf("orders sent = %d, want 3", got)
+			}
+			if got := server.Calls("/account/getAccounts"); got != 1 {
+				t.Errorf("account config fetches = %d, want 1 until the retry delay passes", got)
+			}
+		})
+	}
+}
+
+func TestOrderPlacementUnpacedWithoutRateLimit(t *testing.T) {
+	server := newTestServer(t, testContracts(), func(s *testServer) {
+		s.account.Account.CreateOrderDelayMilliseconds = 1000
+	})
+	client := newTestClient(t, server, func(config *weex.Config) {
+		config.EnableRateLimit = false
+	})
+
+	for _, tt := range placements {
+		if err := tt.place(context.Background(), client.Trade()); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+	}
+	if got := server.Calls("/account/getAccounts"); got != 0 {
+		t.Errorf("account config fetches = %d, want 0", got)
+	}
+}
+
+func TestOrderPlacementSymbolLocked(t *testing.T) {
+	for _, tt := range placements {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, testContracts(), func(s *testServer) {
+				s.orderDelay = 20 * time.Millisecond
+			})
+			client := newTestClient(t, server, func(config *weex.Config) {
+				config.SymbolLocking = true
+			})
+
+			service := client.Trade()
+			var wg sync.WaitGroup
+			errs := make(chan error, 3)
+			for i := 0; i < 3; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs <- tt.place(context.Background(), service)
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				if err != nil {
+					t.Fatalf("place: %v", err)
+				}
+			}
+
+			if got := server.MaxInFlight(); got != 1 {
+				t.Errorf("max concurrent orders for one symbol = %d, want 1", got)
+			}
+		})
+	}
+}
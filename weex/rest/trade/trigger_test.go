@@ -0,0 +1,80 @@
+package trade_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestTriggerPriceTypeSent(t *testing.T) {
+	requests := []struct {
+		name string
+		path string
+		send func(ctx context.Context, s *trade.Service, priceType types.TriggerPriceType) error
+	}{
+		{"PlacePendingOrder", "/order/plan_order", func(ctx context.Context, s *trade.Service, priceType types.TriggerPriceType) error {
+			_, err := s.PlacePendingOrder(ctx, &trade.PlacePendingOrderRequest{
+				Symbol: "cmt_btcusdt", Size: "0.01", Type: "1", MatchType: "1", ExecutePrice: "0", TriggerPrice: "99000", TriggerPriceType: priceType,
+			})
+			return err
+		}},
+		{"PlaceTpSlOrder", "/order/placeTpSlOrder", func(ctx context.Context, s *trade.Service, priceType types.TriggerPriceType) error {
+			_, err := s.PlaceTpSlOrder(ctx, &trade.PlaceTpSlOrderRequest{
+				Symbol: "cmt_btcusdt", PlanType: "loss_plan", TriggerPrice: "90000", Size: "0.01", PositionSide: "long", TriggerPriceType: priceType,
+			})
+			return err
+		}},
+		{"ModifyTpSlOrder", "/order/modifyTpSlOrder", func(ctx context.Context, s *trade.Service, priceType types.TriggerPriceType) error {
+			_, err := s.ModifyTpSlOrder(ctx, &trade.ModifyTpSlOrderRequest{OrderId: 7, TriggerPrice: "91000", TriggerPriceType: priceType})
+			return err
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		priceType types.TriggerPriceType
+		want      interface{} // triggerPriceType in the JSON body, nil if omitted
+	}{
+		{"last", types.TriggerPriceLast, float64(1)},
+		{"mark", types.TriggerPriceMark, float64(3)},
+		{"unset defaults on the exchange", 0, nil},
+	}
+
+	for _, req := range requests {
+		for _, tt := range tests {
+			t.Run(req.name+"/"+tt.name, func(t *testing.T) {
+				var body map[string]interface{}
+				s := newTestServer(t, testContracts(), func(s *testServer) {
+					s.handlers = map[string]func(*http.Request) string{
+						req.path: func(r *http.Request) string {
+							data, _ := io.ReadAll(r.Body)
+							json.Unmarshal(data, &body)
+							if req.path == "/order/placeTpSlOrder" {
+								return `[]`
+							}
+							return `{}`
+						},
+					}
+				})
+				if err := req.send(context.Background(), newTestClient(t, s).Trade(), tt.priceType); err != nil {
+					t.Fatalf("%s() error = %v", req.name, err)
+				}
+				got, ok := body["triggerPriceType"]
+				if tt.want == nil {
+					if ok {
+						t.Errorf("triggerPriceType = %v, want omitted", got)
+					}
+					return
+				}
+				if got != tt.want {
+					t.Errorf("triggerPriceType = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	}
+}
@@ -1,5 +1,12 @@
 package trade
 
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
 // PlaceOrderRequest is the request for PlaceOrder
 type PlaceOrderRequest struct {
 	Symbol                string `json:"symbol"`                          // Required: Trading pair
@@ -14,6 +21,21 @@ type PlaceOrderRequest struct {
 	MarginMode            int    `json:"marginMode,omitempty"`            // Optional: 1:Cross, 3:Isolated (default 1)
 }
 
+// NewPlaceOrderRequestTyped creates a PlaceOrderRequest from typed enums
+// The enums are encoded to the numeric strings the API expects (e.g.
+// types.OrderTypeOpenLong is sent as "type":"1"). Pass an empty price for
+// market orders.
+func NewPlaceOrderRequestTyped(symbol string, orderType types.OrderType, execution types.OrderExecutionType, match types.PriceMatch, price, size types.Decimal) *PlaceOrderRequest {
+	return &PlaceOrderRequest{
+		Symbol:     symbol,
+		Size:       string(size),
+		Type:       strconv.Itoa(int(orderType)),
+		OrderType:  strconv.Itoa(int(execution)),
+		MatchPrice: strconv.Itoa(int(match)),
+		Price:      string(price),
+	}
+}
+
 // PlaceOrderResponse is the response for PlaceOrder
 type PlaceOrderResponse struct {
 	ClientOid string `json:"client_oid"` // Client-generated order identifier
@@ -32,6 +54,18 @@ type BatchOrderRequest struct {
 	PresetStopLossPrice   string `json:"presetStopLossPrice,omitempty"`   // Optional
 }
 
+// NewBatchOrderRequestTyped creates a BatchOrderRequest from typed enums
+// See NewPlaceOrderRequestTyped for how the enums are encoded.
+func NewBatchOrderRequestTyped(orderType types.OrderType, execution types.OrderExecutionType, match types.PriceMatch, price, size types.Decimal) BatchOrderRequest {
+	return BatchOrderRequest{
+		Size:       string(size),
+		Type:       strconv.Itoa(int(orderType)),
+		OrderType:  strconv.Itoa(int(execution)),
+		MatchPrice: strconv.Itoa(int(match)),
+		Price:      string(price),
+	}
+}
+
 // PlaceBatchOrdersRequest is the request for batch orders
 type PlaceBatchOrdersRequest struct {
 	Symbol        string              `json:"symbol"`               // Required: Trading pair
@@ -105,14 +139,15 @@ type CancelAllOrdersResultItem struct {
 
 // PlacePendingOrderRequest is the request for PlacePendingOrder (trigger order)
 type PlacePendingOrderRequest struct {
-	Symbol       string `json:"symbol"`               // Required: Trading pair
-	ClientOid    string `json:"client_oid"`           // Required: Custom order ID (≤40 chars)
-	Size         string `json:"size"`                 // Required: Order quantity
-	Type         string `json:"type"`                 // Required: 1:Open long, 2:Open short, 3:Close long, 4:Close short
-	MatchType    string `json:"match_type"`           // Required: 0:Limit price, 1:Market price
-	ExecutePrice string `json:"execute_price"`        // Required: Execution price
-	TriggerPrice string `json:"trigger_price"`        // Required: Trigger price
-	MarginMode   int    `json:"marginMode,omitempty"` // Optional: 1:Cross, 3:Isolated
+	Symbol           string                 `json:"symbol"`                     // Required: Trading pair
+	ClientOid        string                 `json:"client_oid"`                 // Required: Custom order ID (≤40 chars)
+	Size             string                 `json:"size"`                       // Required: Order quantity
+	Type             string                 `json:"type"`                       // Required: 1:Open long, 2:Open short, 3:Close long, 4:Close short
+	MatchType        string                 `json:"match_type"`                 // Required: 0:Limit price, 1:Market price
+	ExecutePrice     string                 `json:"execute_price"`              // Required: Execution price
+	TriggerPrice     string                 `json:"trigger_price"`              // Required: Trigger price
+	MarginMode       int                    `json:"marginMode,omitempty"`       // Optional: 1:Cross, 3:Isolated
+	TriggerPriceType types.TriggerPriceType `json:"triggerPriceType,omitempty"` // Optional: 1:Last price (default), 3:Mark price
 }
 
 // CancelPendingOrderRequest is the request for CancelPendingOrder
@@ -122,14 +157,15 @@ type CancelPendingOrderRequest struct {
 
 // PlaceTpSlOrderRequest is the request for PlaceTpSlOrder
 type PlaceTpSlOrderRequest struct {
-	Symbol        string `json:"symbol"`                 // Required: Trading pair
-	ClientOrderId string `json:"clientOrderId"`          // Required: Custom order ID (max 40 chars)
-	PlanType      string `json:"planType"`               // Required: "profit_plan" or "loss_plan"
-	TriggerPrice  string `json:"triggerPrice"`           // Required: Trigger price
-	ExecutePrice  string `json:"executePrice,omitempty"` // Optional: Execution price (0 or empty = market)
-	Size          string `json:"size"`                   // Required: Order quantity
-	PositionSide  string `json:"positionSide"`           // Required: "long" or "short"
-	MarginMode    int    `json:"marginMode,omitempty"`   // Optional: 1:Cross, 3:Isolated
+	Symbol           string                 `json:"symbol"`                     // Required: Trading pair
+	ClientOrderId    string                 `json:"clientOrderId"`              // Required: Custom order ID (max 40 chars)
+	PlanType         string                 `json:"planType"`                   // Required: "profit_plan" or "loss_plan"
+	TriggerPrice     string                 `json:"triggerPrice"`               // Required: Trigger price
+	ExecutePrice     string                 `json:"executePrice,omitempty"`     // Optional: Execution price (0 or empty = market)
+	Size             string                 `json:"size"`                       // Required: Order quantity
+	PositionSide     string                 `json:"positionSide"`               // Required: "long" or "short"
+	MarginMode       int                    `json:"marginMode,omitempty"`       // Optional: 1:Cross, 3:Isolated
+	TriggerPriceType types.TriggerPriceType `json:"triggerPriceType,omitempty"` // Optional: 1:Last price (default), 3:Mark price
 }
 
 // PlaceTpSlOrderResultItem represents single TP/SL order result
@@ -140,10 +176,10 @@ type PlaceTpSlOrderResultItem struct {
 
 // ModifyTpSlOrderRequest is the request for ModifyTpSlOrder
 type ModifyTpSlOrderRequest struct {
-	OrderId          int64  `json:"orderId"`                    // Required: Order ID
-	TriggerPrice     string `json:"triggerPrice"`               // Required: New trigger price
-	ExecutePrice     string `json:"executePrice,omitempty"`     // Optional: New execution price
-	TriggerPriceType int    `json:"triggerPriceType,omitempty"` // Optional: 1:Last price, 3:Mark price
+	OrderId          int64                  `json:"orderId"`                    // Required: Order ID
+	TriggerPrice     string                 `json:"triggerPrice"`               // Required: New trigger price
+	ExecutePrice     string                 `json:"executePrice,omitempty"`     // Optional: New execution price
+	TriggerPriceType types.TriggerPriceType `json:"triggerPriceType,omitempty"` // Optional: 1:Last price, 3:Mark price
 }
 
 // ModifyTpSlOrderResponse is the response for ModifyTpSlOrder
@@ -154,6 +190,9 @@ type ModifyTpSlOrderResponse struct {
 	Data        string `json:"data"`        // Response data
 }
 
+// ResponseWrapper marks ModifyTpSlOrderResponse as modeling the whole response wrapper
+func (r *ModifyTpSlOrderResponse) ResponseWrapper() {}
+
 // ClosePositionsRequest is the request for ClosePositions
 type ClosePositionsRequest struct {
 	Symbol string `json:"symbol,omitempty"` // Trading pair (optional, if not provided, closes all)
@@ -169,68 +208,150 @@ type ClosePositionsResultItem struct {
 
 // Order represents an order (for current/history queries)
 type Order struct {
-	Symbol                string `json:"symbol"`                // Trading pair
-	Size                  string `json:"size"`                  // Order amount
-	ClientOid             string `json:"client_oid"`            // Client identifier
-	CreateTime            string `json:"createTime"`            // Creation time (Unix millisecond timestamp)
-	FilledQty             string `json:"filled_qty"`            // Filled quantity
-	Fee                   string `json:"fee"`                   // Transaction fee
-	OrderId               string `json:"order_id"`              // Order ID
-	Price                 string `json:"price"`                 // Order price
-	PriceAvg              string `json:"price_avg"`             // Average filled price
-	Status                string `json:"status"`                // Order status
-	Type                  string `json:"type"`                  // Order type
-	OrderType             string `json:"order_type"`            // Order type
-	TotalProfits          string `json:"totalProfits"`          // Total PnL
-	Contracts             int    `json:"contracts"`             // Order size in contract units
-	FilledQtyContracts    int    `json:"filledQtyContracts"`    // Filled quantity in contract units
-	PresetTakeProfitPrice string `json:"presetTakeProfitPrice"` // Preset take-profit price
-	PresetStopLossPrice   string `json:"presetStopLossPrice"`   // Preset stop-loss price
+	Symbol                string          `json:"symbol"`                // Trading pair
+	Size                  string          `json:"size"`                  // Order amount
+	ClientOid             string          `json:"client_oid"`            // Client identifier
+	CreateTime            types.Timestamp `json:"createTime"`            // Creation time (Unix millisecond timestamp)
+	FilledQty             string          `json:"filled_qty"`            // Filled quantity
+	Fee                   string          `json:"fee"`                   // Transaction fee
+	OrderId               string          `json:"order_id"`              // Order ID
+	Price                 string          `json:"price"`                 // Order price
+	PriceAvg              string          `json:"price_avg"`             // Average filled price
+	Status                string          `json:"status"`                // Order status
+	Type                  string          `json:"type"`                  // Order type
+	OrderType             string          `json:"order_type"`            // Order type
+	TotalProfits          string          `json:"totalProfits"`          // Total PnL
+	Contracts             int             `json:"contracts"`             // Order size in contract units
+	FilledQtyContracts    int             `json:"filledQtyContracts"`    // Filled quantity in contract units
+	PresetTakeProfitPrice string          `json:"presetTakeProfitPrice"` // Preset take-profit price
+	PresetStopLossPrice   string          `json:"presetStopLossPrice"`   // Preset stop-loss price
+}
+
+// ExecutionType returns the order's execution type (normal, post-only, FOK, IOC)
+func (o *Order) ExecutionType() (types.OrderExecutionType, error) {
+	return types.ParseOrderExecutionType(o.OrderType)
+}
+
+// StatusEnum returns the order's status as a types.OrderStatus
+func (o *Order) StatusEnum() (types.OrderStatus, error) {
+	return types.ParseOrderStatus(o.Status)
 }
 
 // PlanOrder represents a plan/trigger order
 type PlanOrder struct {
-	Symbol                string `json:"symbol"`                // Trading pair
-	Size                  string `json:"size"`                  // Order amount
-	ClientOid             string `json:"client_oid"`            // Client identifier
-	CreateTime            string `json:"createTime"`            // Creation time (Unix millisecond timestamp)
-	FilledQty             string `json:"filled_qty"`            // Filled quantity
-	Fee                   string `json:"fee"`                   // Transaction fee
-	OrderId               string `json:"order_id"`              // Order ID
-	Price                 string `json:"price"`                 // Order price
-	PriceAvg              string `json:"price_avg"`             // Average filled price
-	Status                string `json:"status"`                // Order status
-	Type                  string `json:"type"`                  // Order type
-	OrderType             string `json:"order_type"`            // Order type
-	TotalProfits          string `json:"totalProfits"`          // Total PnL
-	TriggerPrice          string `json:"triggerPrice"`          // Trigger price
-	TriggerPriceType      string `json:"triggerPriceType"`      // Trigger price type
-	TriggerTime           string `json:"triggerTime"`           // Trigger time (Unix millisecond timestamp)
-	PresetTakeProfitPrice string `json:"presetTakeProfitPrice"` // Preset take-profit price
-	PresetStopLossPrice   string `json:"presetStopLossPrice"`   // Preset stop-loss price
+	Symbol                string          `json:"symbol"`                // Trading pair
+	Size                  string          `json:"size"`                  // Order amount
+	ClientOid             string          `json:"client_oid"`            // Client identifier
+	CreateTime            types.Timestamp `json:"createTime"`            // Creation time (Unix millisecond timestamp)
+	FilledQty             string          `json:"filled_qty"`            // Filled quantity
+	Fee                   string          `json:"fee"`                   // Transaction fee
+	OrderId               string          `json:"order_id"`              // Order ID
+	Price                 string          `json:"price"`                 // Order price
+	PriceAvg              string          `json:"price_avg"`             // Average filled price
+	Status                string          `json:"status"`                // Order status
+	Type                  string          `json:"type"`                  // Order type
+	OrderType             string          `json:"order_type"`            // Order type
+	TotalProfits          string          `json:"totalProfits"`          // Total PnL
+	TriggerPrice          string          `json:"triggerPrice"`          // Trigger price
+	TriggerPriceType      string          `json:"triggerPriceType"`      // Trigger price type
+	TriggerTime           types.Timestamp `json:"triggerTime"`           // Trigger time (Unix millisecond timestamp)
+	PresetTakeProfitPrice string          `json:"presetTakeProfitPrice"` // Preset take-profit price
+	PresetStopLossPrice   string          `json:"presetStopLossPrice"`   // Preset stop-loss price
 }
 
 // Fill represents a trade fill
 type Fill struct {
-	TradeId             int64  `json:"tradeId"`             // Filled order ID
-	OrderId             int64  `json:"orderId"`             // Associated order ID
-	Symbol              string `json:"symbol"`              // Trading pair name
-	MarginMode          string `json:"marginMode"`          // Margin mode
-	SeparatedMode       string `json:"separatedMode"`       // Separated mode
-	PositionSide        string `json:"positionSide"`        // Position direction
-	OrderSide           string `json:"orderSide"`           // Order direction
-	FillSize            string `json:"fillSize"`            // Actual filled quantity
-	FillValue           string `json:"fillValue"`           // Actual filled value
-	FillFee             string `json:"fillFee"`             // Actual trading fee
-	LiquidateFee        string `json:"liquidateFee"`        // Closing fee
-	RealizePnl          string `json:"realizePnl"`          // Actual realized PnL
-	Direction           string `json:"direction"`           // Actual execution direction
-	LiquidateType       string `json:"liquidateType"`       // Liquidation order type
-	LegacyOrdeDirection string `json:"legacyOrdeDirection"` // Compatible with legacy order direction types
-	CreatedTime         int64  `json:"createdTime"`         // Timestamp (Unix millisecond timestamp)
-}
-
-// FillsResponse is the response for GetTradeDetails
+	TradeId             int64           `json:"tradeId"`             // Filled order ID
+	OrderId             int64           `json:"orderId"`             // Associated order ID
+	Symbol              string          `json:"symbol"`              // Trading pair name
+	MarginMode          string          `json:"marginMode"`          // Margin mode
+	SeparatedMode       string          `json:"separatedMode"`       // Separated mode
+	PositionSide        string          `json:"positionSide"`        // Position direction
+	OrderSide           string          `json:"orderSide"`           // Order direction
+	FillSize            string          `json:"fillSize"`            // Actual filled quantity
+	FillValue           string          `json:"fillValue"`           // Actual filled value
+	FillFee             string          `json:"fillFee"`             // Actual trading fee
+	LiquidateFee        string          `json:"liquidateFee"`        // Closing fee
+	RealizePnl          string          `json:"realizePnl"`          // Actual realized PnL
+	Direction           string          `json:"direction"`           // Actual execution direction
+	LiquidateType       string          `json:"liquidateType"`       // Liquidation order type
+	LegacyOrdeDirection string          `json:"legacyOrdeDirection"` // Compatible with legacy order direction types
+	CreatedTime         types.Timestamp `json:"createdTime"`         // Timestamp (Unix millisecond timestamp)
+}
+
+// GetOrdersRequest is the request for GetOpenOrders and GetPendingOrders
+// Zero fields are omitted, so an empty request matches all orders.
+type GetOrdersRequest struct {
+	Symbol    string `json:"symbol,omitempty"`    // Optional: Trading pair
+	OrderId   int64  `json:"orderId,omitempty"`   // Optional: Order ID
+	StartTime int64  `json:"startTime,omitempty"` // Optional: Start time (Unix timestamp in ms)
+	EndTime   int64  `json:"endTime,omitempty"`   // Optional: End time (Unix timestamp in ms)
+	Limit     int    `json:"limit,omitempty"`     // Optional: Page size
+	Page      int    `json:"page,omitempty"`      // Optional: Page number
+}
+
+// query returns the request as URL query parameters
+func (r *GetOrdersRequest) query() url.Values {
+	params := url.Values{}
+	if r == nil {
+		return params
+	}
+	if r.Symbol != "" {
+		params.Set("symbol", r.Symbol)
+	}
+	if r.OrderId > 0 {
+		params.Set("orderId", strconv.FormatInt(r.OrderId, 10))
+	}
+	if r.StartTime > 0 {
+		params.Set("startTime", strconv.FormatInt(r.StartTime, 10))
+	}
+	if r.EndTime > 0 {
+		params.Set("endTime", strconv.FormatInt(r.EndTime, 10))
+	}
+	if r.Limit > 0 {
+		params.Set("limit", strconv.Itoa(r.Limit))
+	}
+	if r.Page > 0 {
+		params.Set("page", strconv.Itoa(r.Page))
+	}
+	return params
+}
+
+// GetFillsRequest is the request for GetFills
+// Zero fields are omitted, so an empty request matches all fills.
+type GetFillsRequest struct {
+	Symbol    string `json:"symbol,omitempty"`    // Optional: Trading pair
+	OrderId   int64  `json:"orderId,omitempty"`   // Optional: Order ID
+	StartTime int64  `json:"startTime,omitempty"` // Optional: Start time (Unix timestamp in ms)
+	EndTime   int64  `json:"endTime,omitempty"`   // Optional: End time (Unix timestamp in ms)
+	Limit     int    `json:"limit,omitempty"`     // Optional: Page size
+}
+
+// query returns the request as URL query parameters
+func (r *GetFillsRequest) query() url.Values {
+	params := url.Values{}
+	if r == nil {
+		return params
+	}
+	if r.Symbol != "" {
+		params.Set("symbol", r.Symbol)
+	}
+	if r.OrderId > 0 {
+		params.Set("orderId", strconv.FormatInt(r.OrderId, 10))
+	}
+	if r.StartTime > 0 {
+		params.Set("startTime", strconv.FormatInt(r.StartTime, 10))
+	}
+	if r.EndTime > 0 {
+		params.Set("endTime", strconv.FormatInt(r.EndTime, 10))
+	}
+	if r.Limit > 0 {
+		params.Set("limit", strconv.Itoa(r.Limit))
+	}
+	return params
+}
+
+// FillsResponse is the response for GetTradeDetails and GetFills
 type FillsResponse struct {
 	List     []Fill `json:"list"`     // Transaction details
 	NextFlag bool   `json:"nextFlag"` // Whether more pages exist
@@ -0,0 +1,295 @@
+package trade_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestGetOrdersRequestJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		req  trade.GetOrdersRequest
+		want string
+	}{
+		{"empty", trade.GetOrdersRequest{}, `{}`},
+		{"symbol", trade.GetOrdersRequest{Symbol: "cmt_btcusdt", Limit: 10}, `{"symbol":"cmt_btcusdt","limit":10}`},
+		{"all", trade.GetOrdersRequest{Symbol: "cmt_btcusdt", OrderId: 42, StartTime: 1, EndTime: 2, Limit: 10, Page: 3},
+			`{"symbol":"cmt_btcusdt","orderId":42,"startTime":1,"endTime":2,"limit":10,"page":3}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.req)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFillsRequestJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		req  trade.GetFillsRequest
+		want string
+	}{
+		{"empty", trade.GetFillsRequest{}, `{}`},
+		{"all", trade.GetFillsRequest{Symbol: "cmt_btcusdt", OrderId: 42, StartTime: 1, EndTime: 2, Limit: 10},
+			`{"symbol":"cmt_btcusdt","orderId":42,"startTime":1,"endTime":2,"limit":10}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.req)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderQueryParameters(t *testing.T) {
+	full := &trade.GetOrdersRequest{Symbol: "cmt_btcusdt", OrderId: 42, StartTime: 1, EndTime: 2, Limit: 10, Page: 3}
+	fills := &trade.GetFillsRequest{Symbol: "cmt_btcusdt", OrderId: 42, Limit: 10}
+
+	tests := []struct {
+		name  string
+		path  string
+		query func(ctx context.Context, s *trade.Service) error
+		want  string
+	}{
+		{"current none", "/order/current", func(ctx context.Context, s *trade.Service) error {
+			_, err := s.GetCurrentOrderStatus(ctx, "", 0, 0, 0, 0, 0)
+			return err
+		}, ""},
+		{"current", "/order/current", func(ctx context.Context, s *trade.Service) error {
+			_, err := s.GetCurrentOrderStatus(ctx, "cmt_btcusdt", 42, 1, 2, 10, 3)
+			return err
+		}, "endTime=2&limit=10&orderId=42&page=3&startTime=1&symbol=cmt_btcusdt"},
+		{"open nil", "/order/current", func(ctx context.Context, s *trade.Service) error {
+			_, err := s.GetOpenOrders(ctx, nil)
+			return err
+		}, ""},
+		{"open", "/order/current", func(ctx context.Context, s *trade.Service) error {
+			_, err := s.GetOpenOrders(ctx, full)
+			return err
+		}, "endTime=2&limit=10&orderId=42&page=3&startTime=1&symbol=cmt_btcusdt"},
+		{"current pending", "/order/currentPlan", func(ctx context.Context, s *trade.Service) error {
+			_, err := s.GetCurrentPendingOrders(ctx, "cmt_btcusdt", 0, 0, 0, 10, 0)
+			return err
+		}, "limit=10&symbol=cmt_btcusdt"},
+		{"pending", "/order/currentPlan", func(ctx context.Context, s *trade.Service) error {
+			_, err := s.GetPendingOrders(ctx, &trade.GetOrdersRequest{Symbol: "cmt_btcusdt", Limit: 10})
+			return err
+		}, "limit=10&symbol=cmt_btcusdt"},
+		{"trade details", "/order/fills", func(ctx context.Context, s *trade.Service) error {
+			_, err := s.GetTradeDetails(ctx, "cmt_btcusdt", 42, 0, 0, 10)
+			return err
+		}, "limit=10&orderId=42&symbol=cmt_btcusdt"},
+		{"fills nil", "/order/fills", func(ctx context.Context, s *trade.Service) error {
+			_, err := s.GetFills(ctx, nil)
+			return err
+		}, ""},
+		{"fills", "/order/fills", func(ctx context.Context, s *trade.Service) error {
+			_, err := s.GetFills(ctx, fills)
+			return err
+		}, "limit=10&orderId=42&symbol=cmt_btcusdt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, testContracts())
+			client := newTestClient(t, server)
+
+			if err := tt.query(context.Background(), client.Trade()); err != nil {
+				t.Fatalf("query error = %v", err)
+			}
+			if got := server.Query(tt.path); got != tt.want {
+				t.Errorf("query = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListResponses(t *testing.T) {
+	server := newTestServer(t, testContracts())
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() (int, error)
+	}{
+		{"GetCurrentOrderStatus", func() (int, error) {
+			resp, err := client.Trade().GetCurrentOrderStatus(ctx, "", 0, 0, 0, 0, 0)
+			return len(resp), err
+		}},
+		{"GetOpenOrders", func() (int, error) {
+			resp, err := client.Trade().GetOpenOrders(ctx, nil)
+			return len(resp), err
+		}},
+		{"GetCurrentPendingOrders", func() (int, error) {
+			resp, err := client.Trade().GetCurrentPendingOrders(ctx, "", 0, 0, 0, 0, 0)
+			return len(resp), err
+		}},
+		{"GetPendingOrders", func() (int, error) {
+			resp, err := client.Trade().GetPendingOrders(ctx, nil)
+			return len(resp), err
+		}},
+		{"CancelAllOrders", func() (int, error) {
+			resp, err := client.Trade().CancelAllOrders(ctx, &trade.CancelAllOrdersRequest{Symbol: "cmt_btcusdt"})
+			return len(resp), err
+		}},
+		{"ClosePositions", func() (int, error) {
+			resp, err := client.Trade().ClosePositions(ctx, &trade.ClosePositionsRequest{Symbol: "cmt_btcusdt"})
+			return len(resp), err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := tt.call()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if n != 0 {
+				t.Errorf("len = %d, want 0", n)
+			}
+		})
+	}
+}
+
+func TestOrderExecutionType(t *testing.T) {
+	tests := []struct {
+		orderType string
+		want      types.OrderExecutionType
+		wantErr   bool
+	}{
+		{"0", types.OrderExecNormal, false},
+		{"1", types.OrderExecPostOnly, false},
+		{"2", types.OrderExecFillOrKill, false},
+		{"3", types.OrderExecImmediateOrCancel, false},
+		{"ioc", types.OrderExecImmediateOrCancel, false},
+		{"limit", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.orderType, func(t *testing.T) {
+			var order trade.Order
+			if err := json.Unmarshal([]byte(`{"order_id":"1","order_type":"`+tt.orderType+`"}`), &order); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			got, err := order.ExecutionType()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecutionType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ExecutionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestampRepresentations(t *testing.T) {
+	const ms = 1716710918113
+	want := time.UnixMilli(ms)
+
+	tests := []struct {
+		name string
+		time func(data string) (types.Timestamp, error)
+		json string // Object holding the timestamp field as %s
+	}{
+		{"Order.CreateTime", func(data string) (types.Timestamp, error) {
+			var v trade.Order
+			err := json.Unmarshal([]byte(data), &v)
+			return v.CreateTime, err
+		}, `{"order_id":"1","createTime":%s}`},
+		{"PlanOrder.CreateTime", func(data string) (types.Timestamp, error) {
+			var v trade.PlanOrder
+			err := json.Unmarshal([]byte(data), &v)
+			return v.CreateTime, err
+		}, `{"order_id":"1","createTime":%s}`},
+		{"PlanOrder.TriggerTime", func(data string) (types.Timestamp, error) {
+			var v trade.PlanOrder
+			err := json.Unmarshal([]byte(data), &v)
+			return v.TriggerTime, err
+		}, `{"order_id":"1","triggerTime":%s}`},
+		{"Fill.CreatedTime", func(data string) (types.Timestamp, error) {
+			var v trade.Fill
+			err := json.Unmarshal([]byte(data), &v)
+			return v.CreatedTime, err
+		}, `{"tradeId":1,"createdTime":%s}`},
+	}
+	for _, tt := range tests {
+		for _, raw := range []string{`1716710918113`, `"1716710918113"`} {
+			t.Run(tt.name+"/"+raw, func(t *testing.T) {
+				got, err := tt.time(fmt.Sprintf(tt.json, raw))
+				if err != nil {
+					t.Fatalf("Unmarshal() error = %v", err)
+				}
+				if got != ms || !got.Time().Equal(want) {
+					t.Errorf("timestamp = %d (%v), want %d (%v)", got, got.Time(), int64(ms), want)
+				}
+			})
+		}
+	}
+}
+
+func TestTypedOrderRequestJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		orderType types.OrderType
+		execution types.OrderExecutionType
+		match     types.PriceMatch
+		price     types.Decimal
+		want      map[string]string
+	}{
+		{"post-only open long limit", types.OrderTypeOpenLong, types.OrderExecPostOnly, types.PriceMatchLimit, "65000.5",
+			map[string]string{"type": "1", "order_type": "1", "match_price": "0", "price": "65000.5", "size": "0.01"}},
+		{"IOC close short market", types.OrderTypeCloseShort, types.OrderExecImmediateOrCancel, types.PriceMatchMarket, "",
+			map[string]string{"type": "4", "order_type": "3", "match_price": "1", "price": "", "size": "0.01"}},
+		{"FOK open short limit", types.OrderTypeOpenShort, types.OrderExecFillOrKill, types.PriceMatchLimit, "100",
+			map[string]string{"type": "2", "order_type": "2", "match_price": "0", "price": "100", "size": "0.01"}},
+		{"normal close long limit", types.OrderTypeCloseLong, types.OrderExecNormal, types.PriceMatchLimit, "100",
+			map[string]string{"type": "3", "order_type": "0", "match_price": "0", "price": "100", "size": "0.01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := map[string]interface{}{
+				"place": trade.NewPlaceOrderRequestTyped("cmt_btcusdt", tt.orderType, tt.execution, tt.match, tt.price, "0.01"),
+				"batch": trade.NewBatchOrderRequestTyped(tt.orderType, tt.execution, tt.match, tt.price, "0.01"),
+			}
+			for kind, req := range requests {
+				data, err := json.Marshal(req)
+				if err != nil {
+					t.Fatalf("%s: Marshal() error = %v", kind, err)
+				}
+				var got map[string]interface{}
+				if err := json.Unmarshal(data, &got); err != nil {
+					t.Fatalf("%s: Unmarshal() error = %v", kind, err)
+				}
+				for key, want := range tt.want {
+					if got[key] != want {
+						t.Errorf("%s: %q = %#v, want %q in %s", kind, key, got[key], want, data)
+					}
+				}
+			}
+
+			place := trade.NewPlaceOrderRequestTyped("cmt_btcusdt", tt.orderType, tt.execution, tt.match, tt.price, "0.01")
+			if place.Symbol != "cmt_btcusdt" {
+				t.Errorf("Symbol = %q, want cmt_btcusdt", place.Symbol)
+			}
+			if execution, err := types.ParseOrderExecutionType(place.OrderType); err != nil || execution != tt.execution {
+				t.Errorf("order_type %q parses as %v, %v, want %v", place.OrderType, execution, err, tt.execution)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package trade
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// MaxClientOidLength is the maximum length of a client order ID
+const MaxClientOidLength = 40
+
+// ValidatePlaceOrder checks a PlaceOrderRequest against every applicable rule before sending
+//
+// All problems are reported together in a single error built with errors.Join,
+// so a request does not pass some checks only to fail others at the exchange.
+// Each problem is a *types.ValidationError naming the offending field; use
+// types.ValidationErrors to list them.
+// contract is optional; when set, the symbol, tick size and lot size limits
+// of the contract are also checked.
+//
+// Example:
+//
+//	if err := trade.ValidatePlaceOrder(req, contract); err != nil {
+//	    return err // lists every violation, one per line
+//	}
+func ValidatePlaceOrder(req *PlaceOrderRequest, contract *market.ContractInfo) error {
+	if req == nil {
+		return fmt.Errorf("order request cannot be nil")
+	}
+
+	var errs []error
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if req.Symbol == "" {
+		check(types.NewValidationError("symbol", "symbol cannot be empty"))
+	} else if contract != nil && contract.Symbol != "" && req.Symbol != contract.Symbol {
+		check(types.NewValidationError("symbol", "symbol %s does not match contract %s", req.Symbol, contract.Symbol))
+	}
+
+	// An empty client_oid is allowed: PlaceOrder generates one
+	if req.ClientOid != "" {
+		check(ValidateClientOid(req.ClientOid))
+	}
+	check(ValidateOrderType(req.Type))
+	check(ValidateExecutionType(req.OrderType))
+	check(ValidateMatchPrice(req.MatchPrice))
+
+	if req.MarginMode != 0 && req.MarginMode != int(types.MarginModeShared) && req.MarginMode != int(types.MarginModeIsolated) {
+		check(types.NewValidationError("marginMode", "invalid margin mode %d: must be 1 (cross) or 3 (isolated)", req.MarginMode))
+	}
+
+	// Size
+	sizeErr := ValidateSize(req.Size, nil)
+	check(sizeErr)
+
+	// Price: required for limit orders, post-only requires a limit order
+	isMarket := req.MatchPrice == "1"
+	var price *big.Rat
+	if !isMarket {
+		if req.Price == "" {
+			check(types.NewValidationError("price", "price is required for limit orders"))
+		} else if p, err := types.Decimal(req.Price).Rat(); err != nil || p.Sign() <= 0 {
+			check(types.NewValidationError("price", "invalid price %q: must be greater than 0", req.Price))
+		} else {
+			price = p
+		}
+	}
+	validPrice := price != nil
+	if isMarket && req.OrderType == "1" {
+		check(types.NewValidationError("match_price", "post-only orders must use a limit price (match_price 0)"))
+	}
+
+	// Contract tick and lot size
+	if contract != nil {
+		if validPrice {
+			check(contract.ValidatePrice(types.Decimal(req.Price)))
+		}
+		if sizeErr == nil {
+			check(contract.ValidateOrderSize(types.Decimal(req.Size)))
+		}
+	}
+
+	// Preset take-profit / stop-loss ordering
+	errs = append(errs, validatePresets(req, price)...)
+
+	return errors.Join(errs...)
+}
+
+// JSON names of the preset TP/SL fields, used as ValidationError.Field
+const (
+	fieldTakeProfit = "presetTakeProfitPrice"
+	fieldStopLoss   = "presetStopLossPrice"
+)
+
+// validatePresets checks preset TP/SL prices are positive and on the correct side of the order;
+// price is nil when the order has no valid limit price
+func validatePresets(req *PlaceOrderRequest, price *big.Rat) []error {
+	var errs []error
+
+	parse := func(field, name, value string) *big.Rat {
+		if value == "" {
+			return nil
+		}
+		v, err := types.Decimal(value).Rat()
+		if err != nil || v.Sign() <= 0 {
+			errs = append(errs, types.NewValidationError(field, "invalid %s %q: must be greater than 0", name, value))
+			return nil
+		}
+		return v
+	}
+	tp := parse(fieldTakeProfit, "take-profit price", req.PresetTakeProfitPrice)
+	sl := parse(fieldStopLoss, "stop-loss price", req.PresetStopLossPrice)
+	hasTP, hasSL, hasPrice := tp != nil, sl != nil, price != nil
+
+	switch req.Type {
+	case "1": // Open long: SL < price < TP
+		if hasTP && hasSL && tp.Cmp(sl) <= 0 {
+			errs = append(errs, types.NewValidationError(fieldTakeProfit, "take-profit %s must be above stop-loss %s for a long", req.PresetTakeProfitPrice, req.PresetStopLossPrice))
+		}
+		if hasPrice && hasTP && tp.Cmp(price) <= 0 {
+			errs = append(errs, types.NewValidationError(fieldTakeProfit, "take-profit %s must be above the order price %s for a long", req.PresetTakeProfitPrice, req.Price))
+		}
+		if hasPrice && hasSL && sl.Cmp(price) >= 0 {
+			errs = append(errs, types.NewValidationError(fieldStopLoss, "stop-loss %s must be below the order price %s for a long", req.PresetStopLossPrice, req.Price))
+		}
+	case "2": // Open short: TP < price < SL
+		if hasTP && hasSL && tp.Cmp(sl) >= 0 {
+			errs = append(errs, types.NewValidationError(fieldTakeProfit, "take-profit %s must be below stop-loss %s for a short", req.PresetTakeProfitPrice, req.PresetStopLossPrice))
+		}
+		if hasPrice && hasTP && tp.Cmp(price) >= 0 {
+			errs = append(errs, types.NewValidationError(fieldTakeProfit, "take-profit %s must be below the order price %s for a short", req.PresetTakeProfitPrice, req.Price))
+		}
+		if hasPrice && hasSL && sl.Cmp(price) <= 0 {
+			errs = append(errs, types.NewValidationError(fieldStopLoss, "stop-loss %s must be above the order price %s for a short", req.PresetStopLossPrice, req.Price))
+		}
+	default:
+		if hasTP || hasSL {
+			field := fieldTakeProfit
+			if !hasTP {
+				field = fieldStopLoss
+			}
+			errs = append(errs, types.NewValidationError(field, "preset take-profit/stop-loss is only supported on open orders (type 1 or 2)"))
+		}
+	}
+	return errs
+}
+
+// ValidateSize checks size is a positive decimal and, if contract is set, meets its lot size and order size limits
+// Every order placement method of Service applies it before sending.
+func ValidateSize(size string, contract *market.ContractInfo) error {
+	if size == "" {
+		return types.NewValidationError("size", "size cannot be empty")
+	}
+	sign, err := types.Decimal(size).CmpErr("0")
+	if err != nil {
+		return types.NewValidationError("size", "invalid size %q: %w", size, err)
+	}
+	if sign <= 0 {
+		return types.NewValidationError("size", "size must be greater than 0, got %s", size)
+	}
+	if contract != nil {
+		return contract.ValidateOrderSize(types.Decimal(size))
+	}
+	return nil
+}
+
+// ValidateClientOid checks a client order ID is present and at most 40 characters
+func ValidateClientOid(clientOid string) error {
+	if clientOid == "" {
+		return types.NewValidationError("client_oid", "client_oid cannot be empty")
+	}
+	if len(clientOid) > MaxClientOidLength {
+		return types.NewValidationError("client_oid", "client_oid exceeds %d characters (got %d)", MaxClientOidLength, len(clientOid))
+	}
+	return nil
+}
+
+// ValidateOrderType checks an order type is 1 (open long), 2 (open short), 3 (close long) or 4 (close short)
+func ValidateOrderType(orderType string) error {
+	switch orderType {
+	case "1", "2", "3", "4":
+		return nil
+	}
+	return types.NewValidationError("type", "invalid order type %q: must be 1-4", orderType)
+}
+
+// ValidateExecutionType checks an execution type is 0 (normal), 1 (post-only), 2 (FOK) or 3 (IOC)
+func ValidateExecutionType(executionType string) error {
+	switch executionType {
+	case "0", "1", "2", "3":
+		return nil
+	}
+	return types.NewValidationError("order_type", "invalid order_type %q: must be 0-3", executionType)
+}
+
+// ValidateMatchPrice checks a match price is 0 (limit) or 1 (market)
+func ValidateMatchPrice(matchPrice string) error {
+	switch matchPrice {
+	case "0", "1":
+		return nil
+	}
+	return types.NewValidationError("match_price", "invalid match_price %q: must be 0 (limit) or 1 (market)", matchPrice)
+}
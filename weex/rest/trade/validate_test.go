@@ -0,0 +1,124 @@
+package trade_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/trade"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// validOrder returns a limit order that passes every check against testContracts
+func validOrder() *trade.PlaceOrderRequest {
+	return &trade.PlaceOrderRequest{
+		Symbol: "cmt_btcusdt", ClientOid: "order-1", Size: "0.01",
+		Type: "1", OrderType: "0", MatchPrice: "0", Price: "100000",
+	}
+}
+
+func TestValidatePlaceOrder(t *testing.T) {
+	contract := &testContracts()[0]
+
+	tests := []struct {
+		name     string
+		modify   func(*trade.PlaceOrderRequest)
+		contract *market.ContractInfo
+		fields   []string // ValidationError.Field of every expected violation, in order
+	}{
+		{"valid", func(*trade.PlaceOrderRequest) {}, contract, nil},
+		{"valid market order", func(r *trade.PlaceOrderRequest) { r.MatchPrice, r.Price = "1", "" }, contract, nil},
+		{"missing price", func(r *trade.PlaceOrderRequest) { r.Price = "" }, nil, []string{"price"}},
+		{"empty client oid", func(r *trade.PlaceOrderRequest) { r.ClientOid = "" }, contract, nil},
+		{"off tick", func(r *trade.PlaceOrderRequest) { r.Price = "100000.05" }, contract, []string{"price"}},
+		{"symbol mismatch", func(r *trade.PlaceOrderRequest) { r.Symbol = "cmt_ethusdt" }, contract, []string{"symbol"}},
+		{"post-only market", func(r *trade.PlaceOrderRequest) { r.OrderType, r.MatchPrice = "1", "1" }, nil, []string{"match_price"}},
+		{"invalid margin mode", func(r *trade.PlaceOrderRequest) { r.MarginMode = 2 }, nil, []string{"marginMode"}},
+		{"every field invalid", func(r *trade.PlaceOrderRequest) {
+			*r = trade.PlaceOrderRequest{ClientOid: strings.Repeat("x", trade.MaxClientOidLength+1), Type: "9", OrderType: "7", MatchPrice: "2", Size: "-1"}
+		}, nil, []string{"symbol", "client_oid", "type", "order_type", "match_price", "size", "price"}},
+		{"presets valid for long", func(r *trade.PlaceOrderRequest) {
+			r.PresetTakeProfitPrice, r.PresetStopLossPrice = "110000", "90000"
+		}, nil, nil},
+		{"presets inverted for long", func(r *trade.PlaceOrderRequest) {
+			r.PresetTakeProfitPrice, r.PresetStopLossPrice = "90000", "110000"
+		}, nil, []string{"presetTakeProfitPrice", "presetTakeProfitPrice", "presetStopLossPrice"}},
+		{"presets inverted for short", func(r *trade.PlaceOrderRequest) {
+			r.Type, r.PresetTakeProfitPrice, r.PresetStopLossPrice = "2", "110000", "90000"
+		}, nil, []string{"presetTakeProfitPrice", "presetTakeProfitPrice", "presetStopLossPrice"}},
+		{"presets on close order", func(r *trade.PlaceOrderRequest) { r.Type, r.PresetStopLossPrice = "3", "90000" }, nil, []string{"presetStopLossPrice"}},
+		{"non-positive preset", func(r *trade.PlaceOrderRequest) { r.PresetTakeProfitPrice = "0" }, nil, []string{"presetTakeProfitPrice"}},
+		// Indistinguishable as float64, but the take-profit is strictly above the price
+		{"preset compared exactly", func(r *trade.PlaceOrderRequest) {
+			r.Price, r.PresetTakeProfitPrice = "0.3", "0.30000000000000001"
+		}, nil, nil},
+		{"preset equal to price", func(r *trade.PlaceOrderRequest) {
+			r.Price, r.PresetTakeProfitPrice = "0.3", "0.30"
+		}, nil, []string{"presetTakeProfitPrice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validOrder()
+			tt.modify(req)
+
+			err := trade.ValidatePlaceOrder(req, tt.contract)
+			var fields []string
+			for _, v := range types.ValidationErrors(err) {
+				fields = append(fields, v.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("fields = %v, want %v (err: %v)", fields, tt.fields, err)
+			}
+			if (err != nil) != (len(tt.fields) > 0) {
+				t.Errorf("err = %v, want %d violations", err, len(tt.fields))
+			}
+		})
+	}
+}
+
+func TestValidatePlaceOrderNil(t *testing.T) {
+	if err := trade.ValidatePlaceOrder(nil, nil); err == nil {
+		t.Error("expected an error for a nil request")
+	}
+}
+
+func TestValidatorFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		field string // empty when the value is valid
+	}{
+		{"client oid valid", trade.ValidateClientOid("order-1"), ""},
+		{"client oid empty", trade.ValidateClientOid(""), "client_oid"},
+		{"client oid too long", trade.ValidateClientOid(strings.Repeat("x", trade.MaxClientOidLength+1)), "client_oid"},
+		{"order type valid", trade.ValidateOrderType("4"), ""},
+		{"order type invalid", trade.ValidateOrderType("5"), "type"},
+		{"execution type valid", trade.ValidateExecutionType("3"), ""},
+		{"execution type invalid", trade.ValidateExecutionType(""), "order_type"},
+		{"match price valid", trade.ValidateMatchPrice("1"), ""},
+		{"match price invalid", trade.ValidateMatchPrice("2"), "match_price"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.field == "" {
+				if tt.err != nil {
+					t.Errorf("err = %v, want nil", tt.err)
+				}
+				return
+			}
+			var v *types.ValidationError
+			if !errors.As(tt.err, &v) {
+				t.Fatalf("errors.As(%v) = false, want a ValidationError", tt.err)
+			}
+			if v.Field != tt.field {
+				t.Errorf("Field = %q, want %q", v.Field, tt.field)
+			}
+			if v.Error() == "" {
+				t.Error("Error() is empty")
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package trade
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxWaitPollFactor caps the poll interval growth in WaitForTerminalState
+const maxWaitPollFactor = 4
+
+// WaitForTerminalState polls an order until it is filled or canceled
+//
+// The first poll happens immediately; the interval then starts at poll and
+// grows by half after each unchanged status, up to four times poll, and resets
+// whenever the status changes. When ctx is done the last polled order is
+// returned together with an error naming its status and wrapping ctx.Err().
+// symbol is checked against the polled order when both are set.
+func (s *Service) WaitForTerminalState(ctx context.Context, symbol, orderId string, poll time.Duration) (*Order, error) {
+	if orderId == "" {
+		return nil, fmt.Errorf("order ID is required")
+	}
+	if poll <= 0 {
+		return nil, fmt.Errorf("poll interval must be greater than 0")
+	}
+
+	var last *Order
+	lastStatus := "unknown"
+	interval := poll
+	for {
+		order, err := s.GetSingleOrderInfo(ctx, orderId)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			return last, fmt.Errorf("failed to poll order %s: %w", orderId, err)
+		case err == nil:
+			if symbol != "" && order.Symbol != "" && order.Symbol != symbol {
+				return order, fmt.Errorf("order %s belongs to %s, not %s", orderId, order.Symbol, symbol)
+			}
+			status, statusErr := order.StatusEnum()
+			if statusErr == nil && status.IsTerminal() {
+				return order, nil
+			}
+			if order.Status != lastStatus {
+				interval = poll
+			} else {
+				interval = min(interval+interval/2, maxWaitPollFactor*poll)
+			}
+			last, lastStatus = order, order.Status
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, fmt.Errorf("order %s not filled or canceled, last status %s: %w", orderId, lastStatus, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package trade_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+)
+
+func TestWaitForTerminalState(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []string // status of each poll, the last one repeating
+		symbol     string
+		timeout    time.Duration
+		wantStatus string
+		wantCalls  int // exact number of polls, 0 to skip the check
+		wantErr    string
+	}{
+		{"pending partial filled", []string{"open", "partial_filled", "filled"}, "cmt_btcusdt", time.Second, "filled", 3, ""},
+		{"numeric statuses", []string{"0", "1", "2"}, "", time.Second, "2", 3, ""},
+		{"canceled", []string{"open", "canceled"}, "cmt_btcusdt", time.Second, "canceled", 2, ""},
+		{"already filled", []string{"filled"}, "cmt_btcusdt", time.Second, "filled", 1, ""},
+		{"canceling is not terminal", []string{"canceling", "canceling", "canceled"}, "", time.Second, "canceled", 3, ""},
+		{"timeout", []string{"open", "partial_filled"}, "cmt_btcusdt", 50 * time.Millisecond, "partial_filled", 0, "last status partial_filled"},
+		{"symbol mismatch", []string{"open"}, "cmt_ethusdt", time.Second, "open", 1, "belongs to cmt_btcusdt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls atomic.Int32
+			s := newTestServer(t, testContracts(), func(s *testServer) {
+				s.handlers = map[string]func(*http.Request) string{
+					"/order/detail": func(r *http.Request) string {
+						i := min(int(polls.Add(1)), len(tt.statuses)) - 1
+						return `{"symbol":"cmt_btcusdt","order_id":"` + r.URL.Query().Get("orderId") + `","status":"` + tt.statuses[i] + `"}`
+					},
+				}
+			})
+			client := newTestClient(t, s, func(c *weex.Config) { c.EnableRateLimit = false })
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			order, err := client.Trade().WaitForTerminalState(ctx, tt.symbol, "42", time.Millisecond)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("WaitForTerminalState() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("WaitForTerminalState() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if tt.timeout < time.Second && !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("WaitForTerminalState() error = %v, want context.DeadlineExceeded", err)
+			}
+			if order == nil || order.Status != tt.wantStatus {
+				t.Fatalf("WaitForTerminalState() order = %+v, want status %s", order, tt.wantStatus)
+			}
+			if tt.wantCalls > 0 && int(polls.Load()) != tt.wantCalls {
+				t.Errorf("polls = %d, want %d", polls.Load(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWaitForTerminalStateArguments(t *testing.T) {
+	s := newTestServer(t, testContracts())
+	client := newTestClient(t, s)
+
+	if _, err := client.Trade().WaitForTerminalState(context.Background(), "cmt_btcusdt", "", time.Millisecond); err == nil {
+		t.Error("WaitForTerminalState() with empty order ID expected error")
+	}
+	if _, err := client.Trade().WaitForTerminalState(context.Background(), "cmt_btcusdt", "42", 0); err == nil {
+		t.Error("WaitForTerminalState() with zero poll interval expected error")
+	}
+	if got := s.Calls("/order/detail"); got != 0 {
+		t.Errorf("polls = %d, want 0", got)
+	}
+}
@@ -4,10 +4,50 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
+	"sync"
 	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
 )
 
+// RetryPolicy overrides the maximum number of retries per error class
+//
+// A code override takes precedence over a type override; errors matching
+// neither use the Retrier's global maxRetries. NetworkError is classified
+// as types.ErrTypeNetwork.
+type RetryPolicy struct {
+	ByType map[types.ErrorType]int // Max retries per error type
+	ByCode map[string]int          // Max retries per API error code
+}
+
+// Clone returns a copy of p with its own ByType and ByCode maps (nil if p is nil)
+func (p *RetryPolicy) Clone() *RetryPolicy {
+	if p == nil {
+		return nil
+	}
+	return &RetryPolicy{ByType: maps.Clone(p.ByType), ByCode: maps.Clone(p.ByCode)}
+}
+
+// validate checks that all overrides are non-negative
+func (p *RetryPolicy) validate() error {
+	if p == nil {
+		return nil
+	}
+	for errType, n := range p.ByType {
+		if n < 0 {
+			return fmt.Errorf("%w: RetryPolicy for %s cannot be negative", ErrInvalidConfig, errType)
+		}
+	}
+	for code, n := range p.ByCode {
+		if n < 0 {
+			return fmt.Errorf("%w: RetryPolicy for code %s cannot be negative", ErrInvalidConfig, code)
+		}
+	}
+	return nil
+}
+
 // Retrier handles retry logic with exponential backoff
 type Retrier struct {
 	maxRetries     int
@@ -15,6 +55,10 @@ type Retrier struct {
 	maxBackoff     time.Duration
 	backoffFactor  float64
 	logger         Logger
+
+	mu         sync.RWMutex
+	maxElapsed time.Duration // Total time budget across all attempts (0 = unlimited)
+	policy     *RetryPolicy
 }
 
 // NewRetrier creates a new Retrier instance
@@ -34,6 +78,12 @@ func NewRetrier(maxRetries int, initialBackoff, maxBackoff time.Duration, backof
 //   - It returns a retriable error (APIError with IsRetriable() == true)
 //   - It returns a NetworkError
 //   - The context is not canceled
+//   - The next attempt starts within the max elapsed budget (if set)
+//
+// Backoff is exponential unless the error carries a Retry-After delay, which is used instead,
+// even when it exceeds the max backoff. The wait still ends when ctx is done, and if the
+// delay would run past the max elapsed budget the error is returned at once, wrapped in
+// ErrMaxRetriesExceeded with APIError.RetryAfter set so the caller can reschedule.
 //
 // Parameters:
 //   - ctx: Context for cancellation
@@ -41,19 +91,42 @@ func NewRetrier(maxRetries int, initialBackoff, maxBackoff time.Duration, backof
 //
 // Returns the error from the last attempt if all retries fail
 func (r *Retrier) DoWithRetry(ctx context.Context, fn func() error) error {
+	return r.DoWithRetryContext(ctx, func(context.Context) error {
+		return fn()
+	})
+}
+
+// DoWithRetryContext executes fn with retry logic like DoWithRetry
+// fn receives a context whose deadline is the max elapsed budget (if set), so
+// an attempt in flight is cut off when the budget runs out rather than only
+// being checked before the next backoff.
+func (r *Retrier) DoWithRetryContext(ctx context.Context, fn func(ctx context.Context) error) error {
 	var lastErr error
+	start := time.Now()
 
-	for attempt := 0; attempt <= r.maxRetries; attempt++ {
+	parent := ctx
+	maxElapsed := r.MaxElapsed()
+	if maxElapsed > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, start.Add(maxElapsed))
+		defer cancel()
+	}
+
+	for attempt := 0; ; attempt++ {
 		// Check context before attempting
 		select {
-		case <-ctx.Done():
+		case <-parent.Done():
 			r.logger.Debug("Context canceled, stopping retries")
-			return ctx.Err()
+			return parent.Err()
 		default:
 		}
+		if attempt > 0 && ctx.Err() != nil {
+			r.logger.Warn("Max retry elapsed time (%v) exceeded, giving up", maxElapsed)
+			break
+		}
 
 		// Execute the function
-		err := fn()
+		err := fn(ctx)
 		if err == nil {
 			// Success
 			if attempt > 0 {
@@ -64,6 +137,12 @@ func (r *Retrier) DoWithRetry(ctx context.Context, fn func() error) error {
 
 		lastErr = err
 
+		// The elapsed budget ran out while the attempt was in flight
+		if ctx.Err() != nil && parent.Err() == nil {
+			r.logger.Warn("Max retry elapsed time (%v) exceeded, giving up", maxElapsed)
+			break
+		}
+
 		// Check if error is retriable
 		if !r.isRetriable(err) {
 			r.logger.Debug("Error is not retriable: %v", err)
@@ -71,27 +150,109 @@ func (r *Retrier) DoWithRetry(ctx context.Context, fn func() error) error {
 		}
 
 		// Don't sleep after the last attempt
-		if attempt >= r.maxRetries {
-			r.logger.Warn("Max retries (%d) exceeded, giving up", r.maxRetries)
+		maxRetries := r.MaxRetriesFor(err)
+		if attempt >= maxRetries {
+			r.logger.Warn("Max retries (%d) exceeded, giving up", maxRetries)
 			break
 		}
 
 		// Calculate backoff duration
 		backoff := r.calculateBackoff(attempt)
+
+		// Prefer the server-requested delay (Retry-After) when present; retrying
+		// sooner would most likely be rate limited again
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			backoff = apiErr.RetryAfter
+		}
+
+		// Give up if the next attempt would start after the elapsed budget
+		if maxElapsed > 0 && time.Since(start)+backoff > maxElapsed {
+			r.logger.Warn("Max retry elapsed time (%v) exceeded, giving up", maxElapsed)
+			break
+		}
+
 		r.logger.Info("Request failed (attempt %d/%d), retrying after %v: %v",
-			attempt+1, r.maxRetries+1, backoff, err)
+			attempt+1, maxRetries+1, backoff, err)
 
 		// Wait with context support
 		select {
 		case <-time.After(backoff):
 			// Continue to next retry
 		case <-ctx.Done():
-			r.logger.Debug("Context canceled during backoff")
-			return ctx.Err()
+			// The elapsed budget expiring is reported on the next iteration
+			if err := parent.Err(); err != nil {
+				r.logger.Debug("Context canceled during backoff")
+				return err
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %w", ErrMaxRetriesExceeded, lastErr)
+}
+
+// SetMaxElapsed caps the total time spent across all attempts, including backoffs
+// Pass 0 to bound retries by attempt count only
+func (r *Retrier) SetMaxElapsed(maxElapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maxElapsed = maxElapsed
+}
+
+// MaxElapsed returns the total time budget across all attempts (0 if unlimited)
+func (r *Retrier) MaxElapsed() time.Duration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.maxElapsed
+}
+
+// SetPolicy sets the per-error retry count overrides
+// The policy is copied, so later changes to it do not affect the retrier.
+// Pass nil to use the global maxRetries for every error
+func (r *Retrier) SetPolicy(policy *RetryPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = policy.Clone()
+}
+
+// Policy returns a copy of the current per-error retry count overrides (nil if none)
+func (r *Retrier) Policy() *RetryPolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.policy.Clone()
+}
+
+// MaxRetriesFor returns the maximum number of retries allowed for an error
+func (r *Retrier) MaxRetriesFor(err error) int {
+	r.mu.RLock()
+	policy := r.policy
+	r.mu.RUnlock()
+
+	if policy == nil || err == nil {
+		return r.maxRetries
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if n, ok := policy.ByCode[apiErr.Code]; ok {
+			return n
+		}
+		if apiErr.Category != nil {
+			if n, ok := policy.ByType[apiErr.Category.Type]; ok {
+				return n
+			}
+		}
+		return r.maxRetries
+	}
+
+	var netErr *NetworkError
+	if errors.As(err, &netErr) {
+		if n, ok := policy.ByType[types.ErrTypeNetwork]; ok {
+			return n
 		}
 	}
 
-	return fmt.Errorf("%w: %v", ErrMaxRetriesExceeded, lastErr)
+	return r.maxRetries
 }
 
 // isRetriable determines if an error is retriable
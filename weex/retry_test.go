@@ -0,0 +1,357 @@
+package weex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func newTestRetrier(maxRetries int) *Retrier {
+	return NewRetrier(maxRetries, time.Millisecond, time.Millisecond, 1, NewNoOpLogger())
+}
+
+func rateLimitError(retryAfter time.Duration) *APIError {
+	err := NewAPIError("429", "too many requests", 429, 0)
+	err.Category = &types.ErrorCategory{Type: types.ErrTypeRateLimit, Retriable: true}
+	err.RetryAfter = retryAfter
+	return err
+}
+
+func TestDoWithRetryExhaustedKeepsLastError(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		check func(t *testing.T, err error)
+	}{
+		{"api error", rateLimitError(time.Millisecond), func(t *testing.T, err error) {
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("errors.As(*APIError) failed for %v", err)
+			}
+			if apiErr.RetryAfter != time.Millisecond {
+				t.Errorf("RetryAfter = %v, want 1ms", apiErr.RetryAfter)
+			}
+		}},
+		{"network error", types.NewNetworkError("dial", "https://example.com", errors.New("connection refused")), func(t *testing.T, err error) {
+			var netErr *NetworkError
+			if !errors.As(err, &netErr) {
+				t.Fatalf("errors.As(*NetworkError) failed for %v", err)
+			}
+			if netErr.Operation != "dial" {
+				t.Errorf("Operation = %q, want dial", netErr.Operation)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := newTestRetrier(2).DoWithRetry(context.Background(), func() error {
+				calls++
+				return tt.err
+			})
+			if calls != 3 {
+				t.Errorf("calls = %d, want 3", calls)
+			}
+			if !errors.Is(err, ErrMaxRetriesExceeded) {
+				t.Fatalf("expected ErrMaxRetriesExceeded, got %v", err)
+			}
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestDoWithRetryContextMaxElapsed(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        func(ctx context.Context) error
+		wantCalls int
+	}{
+		{"attempt in flight is cut off", func(ctx context.Context) error {
+			<-ctx.Done()
+			return types.NewNetworkError("read", "https://example.com", ctx.Err())
+		}, 1},
+		{"backoff past the budget is skipped", func(ctx context.Context) error {
+			return rateLimitError(time.Hour)
+		}, 1},
+		{"retries until the budget runs out", func(ctx context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			return rateLimitError(0)
+		}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRetrier(10)
+			r.SetMaxElapsed(50 * time.Millisecond)
+
+			calls := 0
+			start := time.Now()
+			err := r.DoWithRetryContext(context.Background(), func(ctx context.Context) error {
+				calls++
+				return tt.fn(ctx)
+			})
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("took %v, want the 50ms budget to stop it", elapsed)
+			}
+			if !errors.Is(err, ErrMaxRetriesExceeded) {
+				t.Fatalf("expected ErrMaxRetriesExceeded, got %v", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDoWithRetryContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := newTestRetrier(10)
+	r.SetMaxElapsed(time.Minute)
+
+	err := r.DoWithRetryContext(ctx, func(ctx context.Context) error {
+		cancel()
+		return rateLimitError(time.Millisecond)
+	})
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrMaxRetriesExceeded) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestSetMaxElapsedConcurrent(t *testing.T) {
+	r := newTestRetrier(0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			r.SetMaxElapsed(time.Duration(i) * time.Millisecond)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_ = r.DoWithRetry(context.Background(), func() error { return nil })
+	}
+	<-done
+	if got := r.MaxElapsed(); got != 99*time.Millisecond {
+		t.Errorf("MaxElapsed() = %v, want 99ms", got)
+	}
+}
+
+func TestRetryPolicyAttempts(t *testing.T) {
+	policy := &RetryPolicy{
+		ByType: map[types.ErrorType]int{types.ErrTypeNetwork: 4, types.ErrTypeSystem: 1},
+		ByCode: map[string]int{"429": 0},
+	}
+	tests := []struct {
+		name      string
+		policy    *RetryPolicy
+		err       error
+		wantCalls int
+	}{
+		{"network error extra attempts", policy, types.NewNetworkError("dial", "https://example.com", errors.New("refused")), 5},
+		{"system error fewer attempts", policy, NewAPIError("50001", "system error", 500, 0), 2},
+		{"code override", policy, rateLimitError(time.Millisecond), 1},
+		{"no policy", nil, types.NewNetworkError("dial", "https://example.com", errors.New("refused")), 3},
+		{"unlisted type uses global", &RetryPolicy{ByType: map[types.ErrorType]int{types.ErrTypeSystem: 5}},
+			types.NewNetworkError("dial", "https://example.com", errors.New("refused")), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRetrier(2)
+			r.SetPolicy(tt.policy)
+
+			calls := 0
+			err := r.DoWithRetry(context.Background(), func() error {
+				calls++
+				return tt.err
+			})
+			if !errors.Is(err, ErrMaxRetriesExceeded) {
+				t.Fatalf("expected ErrMaxRetriesExceeded, got %v", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetrierPolicyIsCopied(t *testing.T) {
+	policy := &RetryPolicy{ByCode: map[string]int{"50001": 0}}
+	r := newTestRetrier(2)
+	r.SetPolicy(policy)
+
+	// Neither the policy passed in nor the one returned by Policy is live
+	policy.ByCode["50001"] = 2
+	r.Policy().ByCode["50001"] = 2
+
+	calls := 0
+	err := r.DoWithRetry(context.Background(), func() error {
+		calls++
+		return NewAPIError("50001", "system error", 500, 0)
+	})
+	if !errors.Is(err, ErrMaxRetriesExceeded) {
+		t.Fatalf("expected ErrMaxRetriesExceeded, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (policy changed after SetPolicy)", calls)
+	}
+	if got := r.Policy().ByCode["50001"]; got != 0 {
+		t.Errorf("Policy().ByCode[50001] = %d, want 0", got)
+	}
+}
+
+func TestRetryPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *RetryPolicy
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"valid", &RetryPolicy{ByType: map[types.ErrorType]int{types.ErrTypeNetwork: 5}, ByCode: map[string]int{"50001": 0}}, false},
+		{"negative type", &RetryPolicy{ByType: map[types.ErrorType]int{types.ErrTypeNetwork: -1}}, true},
+		{"negative code", &RetryPolicy{ByCode: map[string]int{"50001": -1}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewDefaultConfig().WithAPIKey("key").WithSecretKey("secret").WithPassphrase("passphrase").
+				WithRetryPolicy(tt.policy).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+}
+
+func TestRetryAfterHonored(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter func() string
+		wantMin    time.Duration
+		wantMax    time.Duration
+	}{
+		{"seconds", func() string { return "1" }, time.Second, 2 * time.Second},
+		// HTTP-dates have second precision, so the delay is between one and two seconds
+		{"http date", func() string { return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat) }, time.Second, 3 * time.Second},
+		{"absent uses backoff", func() string { return "" }, 0, 500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					if v := tt.retryAfter(); v != "" {
+						w.Header().Set("Retry-After", v)
+					}
+					w.WriteHeader(http.StatusTooManyRequests)
+					w.Write([]byte(`{"code":"429","msg":"too many requests","requestTime":1}`))
+					return
+				}
+				w.Write([]byte(`{"code":"0","msg":"success","requestTime":1,"data":{"timestamp":1700000000000}}`))
+			}))
+			defer server.Close()
+
+			config := NewDefaultConfig().WithBaseURL(server.URL)
+			config.InitialBackoff = time.Millisecond
+			config.Logger = NewNoOpLogger()
+			client, err := NewPublicClient(config)
+			if err != nil {
+				t.Fatalf("NewPublicClient() error = %v", err)
+			}
+
+			start := time.Now()
+			if _, err := client.Market().GetServerTime(context.Background()); err != nil {
+				t.Fatalf("GetServerTime() error = %v", err)
+			}
+			elapsed := time.Since(start)
+			if calls.Load() != 2 {
+				t.Errorf("requests = %d, want 2", calls.Load())
+			}
+			if elapsed < tt.wantMin || elapsed > tt.wantMax {
+				t.Errorf("retried after %v, want between %v and %v", elapsed, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestRetryAfterAboveMaxBackoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter time.Duration
+		maxElapsed time.Duration
+		timeout    time.Duration // Context timeout (0 = none)
+		wantCalls  int
+		wantErr    error // nil when the retry succeeds
+		wantMin    time.Duration
+	}{
+		{"waited out", 30 * time.Millisecond, 0, 0, 2, nil, 30 * time.Millisecond},
+		{"within elapsed budget", 30 * time.Millisecond, time.Minute, 0, 2, nil, 30 * time.Millisecond},
+		{"beyond elapsed budget", time.Hour, 50 * time.Millisecond, 0, 1, ErrMaxRetriesExceeded, 0},
+		{"bounded by context", time.Hour, 0, 30 * time.Millisecond, 1, context.DeadlineExceeded, 30 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A 1ms max backoff, far below every Retry-After
+			r := newTestRetrier(3)
+			r.SetMaxElapsed(tt.maxElapsed)
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			calls := 0
+			start := time.Now()
+			err := r.DoWithRetry(ctx, func() error {
+				calls++
+				if calls == 1 {
+					return rateLimitError(tt.retryAfter)
+				}
+				return nil
+			})
+			elapsed := time.Since(start)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("DoWithRetry() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("attempts = %d, want %d", calls, tt.wantCalls)
+			}
+			if elapsed < tt.wantMin || elapsed > time.Second {
+				t.Errorf("returned after %v, want between %v and 1s", elapsed, tt.wantMin)
+			}
+			var apiErr *APIError
+			if errors.Is(tt.wantErr, ErrMaxRetriesExceeded) && (!errors.As(err, &apiErr) || apiErr.RetryAfter != tt.retryAfter) {
+				t.Errorf("error = %v, want an APIError with RetryAfter %v", err, tt.retryAfter)
+			}
+		})
+	}
+}
+
+func TestRetryAfterSurfaced(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"code":"429","msg":"too many requests","requestTime":1}`))
+	}))
+	defer server.Close()
+
+	config := NewDefaultConfig().WithBaseURL(server.URL)
+	config.MaxRetries = 0
+	config.Logger = NewNoOpLogger()
+	client, err := NewPublicClient(config)
+	if err != nil {
+		t.Fatalf("NewPublicClient() error = %v", err)
+	}
+
+	_, err = client.Market().GetServerTime(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 7*time.Second {
+		t.Errorf("GetServerTime() error = %v, want an APIError with RetryAfter 7s", err)
+	}
+}
@@ -0,0 +1,102 @@
+package weex
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// SlogLogger adapts a *slog.Logger to the Logger interface
+//
+// In the default formatted mode, messages and args are combined with
+// fmt.Sprintf as the SDK's printf-style calls expect. In structured mode
+// (NewStructuredSlogLogger) calls whose message has no format verbs and whose
+// args are key/value pairs or slog.Attrs are passed to slog as attributes, for
+// application code that logs through the same Logger; printf-style calls,
+// including the SDK's own, are still formatted.
+type SlogLogger struct {
+	logger     *slog.Logger
+	level      atomic.Int32
+	structured bool
+}
+
+// NewSlogLogger creates a Logger that formats printf-style messages and writes them to logger
+// The level starts at LogLevelDebug, leaving filtering to logger's handler
+// until SetLevel is called.
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{logger: logger}
+}
+
+// NewStructuredSlogLogger creates a Logger that passes key/value args to logger as attributes
+func NewStructuredSlogLogger(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{logger: logger, structured: true}
+}
+
+// Debug logs a debug message
+func (l *SlogLogger) Debug(msg string, args ...interface{}) {
+	l.log(LogLevelDebug, slog.LevelDebug, msg, args...)
+}
+
+// Info logs an info message
+func (l *SlogLogger) Info(msg string, args ...interface{}) {
+	l.log(LogLevelInfo, slog.LevelInfo, msg, args...)
+}
+
+// Warn logs a warning message
+func (l *SlogLogger) Warn(msg string, args ...interface{}) {
+	l.log(LogLevelWarn, slog.LevelWarn, msg, args...)
+}
+
+// Error logs an error message
+func (l *SlogLogger) Error(msg string, args ...interface{}) {
+	l.log(LogLevelError, slog.LevelError, msg, args...)
+}
+
+// SetLevel sets the minimum level passed on to the slog logger
+func (l *SlogLogger) SetLevel(level LogLevel) {
+	l.level.Store(int32(level))
+}
+
+// log writes msg at level unless it is below the configured minimum
+func (l *SlogLogger) log(level LogLevel, slogLevel slog.Level, msg string, args ...interface{}) {
+	if level < LogLevel(l.level.Load()) {
+		return
+	}
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, slogLevel) {
+		return
+	}
+	if l.structured && !hasFormatVerbs(msg) && isKeyValues(args) {
+		l.logger.Log(ctx, slogLevel, strings.ReplaceAll(msg, "%%", "%"), args...)
+		return
+	}
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	l.logger.Log(ctx, slogLevel, msg)
+}
+
+// hasFormatVerbs returns true if msg contains a printf verb (a % other than %%)
+func hasFormatVerbs(msg string) bool {
+	return strings.Contains(strings.ReplaceAll(msg, "%%", ""), "%")
+}
+
+// isKeyValues returns true if args are slog.Attrs and string-keyed key/value pairs
+func isKeyValues(args []interface{}) bool {
+	for i := 0; i < len(args); {
+		switch args[i].(type) {
+		case slog.Attr:
+			i++
+		case string:
+			if i+1 >= len(args) {
+				return false
+			}
+			i += 2
+		default:
+			return false
+		}
+	}
+	return true
+}
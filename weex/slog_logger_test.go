@@ -0,0 +1,92 @@
+package weex
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordHandler is a slog.Handler that records messages and their attributes
+type recordHandler struct {
+	mu      sync.Mutex
+	level   slog.Level
+	records []string
+}
+
+func (h *recordHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	line := r.Level.String() + " " + r.Message
+	r.Attrs(func(a slog.Attr) bool {
+		line += fmt.Sprintf(" %s=%v", a.Key, a.Value)
+		return true
+	})
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, line)
+	return nil
+}
+
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler      { return h }
+
+func TestSlogLogger(t *testing.T) {
+	tests := []struct {
+		name       string
+		structured bool
+		log        func(l *SlogLogger)
+		want       []string
+	}{
+		{"formatted printf", false, func(l *SlogLogger) {
+			l.Info("Request succeeded after %d retries", 2)
+		}, []string{"INFO Request succeeded after 2 retries"}},
+		{"formatted no args", false, func(l *SlogLogger) {
+			l.Warn("WebSocket disconnected")
+		}, []string{"WARN WebSocket disconnected"}},
+		{"structured printf is formatted", true, func(l *SlogLogger) {
+			l.Info("Request failed (attempt %d/%d), retrying after %v: %v", 1, 4, "1s", "timeout")
+		}, []string{"INFO Request failed (attempt 1/4), retrying after 1s: timeout"}},
+		{"structured printf with string pairs is formatted", true, func(l *SlogLogger) {
+			l.Error("Failed to resubscribe to %s: %v", "ticker.btc", "rejected")
+		}, []string{"ERROR Failed to resubscribe to ticker.btc: rejected"}},
+		{"structured key/values", true, func(l *SlogLogger) {
+			l.Info("order placed", "symbol", "cmt_btcusdt", "size", 1)
+		}, []string{"INFO order placed symbol=cmt_btcusdt size=1"}},
+		{"structured attrs", true, func(l *SlogLogger) {
+			l.Debug("order placed", slog.String("symbol", "cmt_btcusdt"), "size", "0.01")
+		}, []string{"DEBUG order placed symbol=cmt_btcusdt size=0.01"}},
+		{"structured percent literal", true, func(l *SlogLogger) {
+			l.Info("fill rate 100%%", "symbol", "cmt_btcusdt")
+		}, []string{"INFO fill rate 100% symbol=cmt_btcusdt"}},
+		{"level filter", false, func(l *SlogLogger) {
+			l.SetLevel(LogLevelWarn)
+			l.Info("dropped")
+			l.Error("kept %d", 1)
+		}, []string{"ERROR kept 1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &recordHandler{level: slog.LevelDebug}
+			logger := NewSlogLogger(slog.New(handler))
+			if tt.structured {
+				logger = NewStructuredSlogLogger(slog.New(handler))
+			}
+			tt.log(logger)
+
+			if !reflect.DeepEqual(handler.records, tt.want) {
+				t.Errorf("records = %q, want %q", handler.records, tt.want)
+			}
+			for _, record := range handler.records {
+				if strings.Contains(record, "!BADKEY") {
+					t.Errorf("record has a bad key: %s", record)
+				}
+			}
+		})
+	}
+}
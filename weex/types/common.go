@@ -2,8 +2,13 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // MarginMode represents the margin mode for positions
@@ -92,6 +97,46 @@ func (o OrderType) String() string {
 	}
 }
 
+// PositionSide returns the position side an order of this type affects in hedge mode
+// Open long and close long affect the LONG position; open short and close short the SHORT position
+func (o OrderType) PositionSide() (PositionSide, error) {
+	switch o {
+	case OrderTypeOpenLong, OrderTypeCloseLong:
+		return PositionSideLong, nil
+	case OrderTypeOpenShort, OrderTypeCloseShort:
+		return PositionSideShort, nil
+	default:
+		return "", fmt.Errorf("invalid order type %d", o)
+	}
+}
+
+// IsOpen returns true for order types that open a position
+func (o OrderType) IsOpen() bool {
+	return o == OrderTypeOpenLong || o == OrderTypeOpenShort
+}
+
+// IsBuy returns true for order types that buy (open long, close short)
+func (o OrderType) IsBuy() bool {
+	return o == OrderTypeOpenLong || o == OrderTypeCloseShort
+}
+
+// PositionSideForOrder returns the position an order affects under the given position mode
+//
+// In hedge mode long and short positions are held separately and the order
+// type alone decides the side. Otherwise positions are netted: an order on the
+// opposite side of an existing net position reduces that position, so net is
+// the current net position side ("" if flat) and is returned when set.
+func PositionSideForOrder(orderType OrderType, mode PositionMode, net PositionSide) (PositionSide, error) {
+	side, err := orderType.PositionSide()
+	if err != nil {
+		return "", err
+	}
+	if mode == PositionModeHedge || net == "" {
+		return side, nil
+	}
+	return net, nil
+}
+
 // OrderExecutionType represents the order execution type
 type OrderExecutionType int
 
@@ -118,6 +163,23 @@ func (o OrderExecutionType) String() string {
 	}
 }
 
+// ParseOrderExecutionType parses an execution type from its numeric ("0"-"3") or
+// string ("NORMAL", "POST_ONLY", "FILL_OR_KILL", "IMMEDIATE_OR_CANCEL") form
+func ParseOrderExecutionType(s string) (OrderExecutionType, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "0", "NORMAL":
+		return OrderExecNormal, nil
+	case "1", "POST_ONLY":
+		return OrderExecPostOnly, nil
+	case "2", "FILL_OR_KILL", "FOK":
+		return OrderExecFillOrKill, nil
+	case "3", "IMMEDIATE_OR_CANCEL", "IOC":
+		return OrderExecImmediateOrCancel, nil
+	default:
+		return 0, fmt.Errorf("unknown order execution type %q", s)
+	}
+}
+
 // PriceMatch represents the price matching type
 type PriceMatch int
 
@@ -138,6 +200,39 @@ func (p PriceMatch) String() string {
 	}
 }
 
+// TriggerPriceType represents the price a trigger order is evaluated against
+type TriggerPriceType int
+
+const (
+	TriggerPriceLast TriggerPriceType = 1 // Last traded price
+	TriggerPriceMark TriggerPriceType = 3 // Mark price (not moved by single-trade wicks)
+)
+
+// String returns the string representation of TriggerPriceType
+func (t TriggerPriceType) String() string {
+	switch t {
+	case TriggerPriceLast:
+		return "LAST"
+	case TriggerPriceMark:
+		return "MARK"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseTriggerPriceType parses a trigger price type from its numeric ("1", "3")
+// or string ("LAST", "MARK") form
+func ParseTriggerPriceType(s string) (TriggerPriceType, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "1", "LAST":
+		return TriggerPriceLast, nil
+	case "3", "MARK":
+		return TriggerPriceMark, nil
+	default:
+		return 0, fmt.Errorf("unknown trigger price type %q", s)
+	}
+}
+
 // PositionSide represents the position side
 type PositionSide string
 
@@ -146,6 +241,42 @@ const (
 	PositionSideShort PositionSide = "SHORT" // Short position (空头)
 )
 
+// ParsePositionSide parses a position side, case-insensitively
+func ParsePositionSide(s string) (PositionSide, error) {
+	switch PositionSide(strings.ToUpper(strings.TrimSpace(s))) {
+	case PositionSideLong:
+		return PositionSideLong, nil
+	case PositionSideShort:
+		return PositionSideShort, nil
+	default:
+		return "", fmt.Errorf("invalid position side %q", s)
+	}
+}
+
+// Opposite returns the other position side
+func (p PositionSide) Opposite() PositionSide {
+	if p == PositionSideLong {
+		return PositionSideShort
+	}
+	return PositionSideLong
+}
+
+// OpenOrderType returns the order type that opens (increases) a position on this side
+func (p PositionSide) OpenOrderType() OrderType {
+	if p == PositionSideShort {
+		return OrderTypeOpenShort
+	}
+	return OrderTypeOpenLong
+}
+
+// CloseOrderType returns the order type that closes (reduces) a position on this side
+func (p PositionSide) CloseOrderType() OrderType {
+	if p == PositionSideShort {
+		return OrderTypeCloseShort
+	}
+	return OrderTypeCloseLong
+}
+
 // OrderSide represents the order side
 type OrderSide string
 
@@ -154,6 +285,18 @@ const (
 	OrderSideSell OrderSide = "SELL" // Sell order (卖出)
 )
 
+// ParseOrderSide parses an order side, case-insensitively
+func ParseOrderSide(s string) (OrderSide, error) {
+	switch OrderSide(strings.ToUpper(strings.TrimSpace(s))) {
+	case OrderSideBuy:
+		return OrderSideBuy, nil
+	case OrderSideSell:
+		return OrderSideSell, nil
+	default:
+		return "", fmt.Errorf("invalid order side %q", s)
+	}
+}
+
 // OrderStatus represents the status of an order
 type OrderStatus int
 
@@ -186,6 +329,33 @@ func (o OrderStatus) String() string {
 	}
 }
 
+// IsTerminal returns true if the order can no longer change (filled or canceled)
+func (o OrderStatus) IsTerminal() bool {
+	return o == OrderStatusFilled || o == OrderStatusCanceled
+}
+
+// ParseOrderStatus parses an order status from its numeric ("-1"-"4") or
+// string form as returned by the REST order endpoints (e.g. "open", "partial_filled",
+// "filled", "canceling", "canceled")
+func ParseOrderStatus(s string) (OrderStatus, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "-1", "NOT_TRIGGERED", "UNTRIGGERED":
+		return OrderStatusNotTriggered, nil
+	case "0", "PENDING", "OPEN", "NEW", "INIT":
+		return OrderStatusPending, nil
+	case "1", "PARTIAL", "PARTIAL_FILLED", "PARTIALLY_FILLED", "PARTIAL_FILL":
+		return OrderStatusPartial, nil
+	case "2", "FILLED", "FULL_FILL":
+		return OrderStatusFilled, nil
+	case "3", "CANCELING", "CANCELLING":
+		return OrderStatusCanceling, nil
+	case "4", "CANCELED", "CANCELLED":
+		return OrderStatusCanceled, nil
+	default:
+		return 0, fmt.Errorf("unknown order status %q", s)
+	}
+}
+
 // Decimal represents a decimal number as a string to avoid precision loss.
 // All price and quantity fields use this type.
 type Decimal string
@@ -205,8 +375,10 @@ func (d Decimal) MustFloat64() float64 {
 }
 
 // IsZero returns true if the decimal is zero or empty
+// Any zero form such as "0.000" or "-0" is zero
 func (d Decimal) IsZero() bool {
-	return d == "" || d == "0" || d == "0.0"
+	r, err := d.Rat()
+	return err == nil && r.Sign() == 0
 }
 
 // String returns the string representation of Decimal
@@ -214,6 +386,62 @@ func (d Decimal) String() string {
 	return string(d)
 }
 
+// MarshalJSON encodes the Decimal as a JSON string
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(d))
+}
+
+// UnmarshalJSON decodes a Decimal from a JSON string, a bare JSON number or null
+// Numbers are normalized to plain decimal notation (1.5e-3 becomes "0.0015");
+// null becomes the empty Decimal
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(trimmed, []byte("null")):
+		*d = ""
+		return nil
+	case len(trimmed) > 0 && trimmed[0] == '"':
+		var s string
+		if err := json.Unmarshal(trimmed, &s); err != nil {
+			return fmt.Errorf("invalid decimal %s: %w", trimmed, err)
+		}
+		*d = Decimal(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(trimmed, &n); err != nil {
+		return fmt.Errorf("invalid decimal %s: %w", trimmed, err)
+	}
+	s := n.String()
+	if strings.ContainsAny(s, "eE") {
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return fmt.Errorf("invalid decimal %s", s)
+		}
+		s = ratString(r)
+	}
+	*d = Decimal(s)
+	return nil
+}
+
+// ratString formats r in plain decimal notation without trailing zeros
+// Non-terminating fractions are rounded to 18 decimal places
+func ratString(r *big.Rat) string {
+	places, ok := terminatingPlaces(r)
+	if !ok {
+		places = maxDecimalPlaces
+	}
+	s := r.FloatString(places)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
+
 // NewDecimal creates a new Decimal from a float64
 func NewDecimal(f float64) Decimal {
 	return Decimal(strconv.FormatFloat(f, 'f', -1, 64))
@@ -251,6 +479,60 @@ const (
 	Interval1Month KlineInterval = "1M"
 )
 
+// IsValid returns true if k is one of the supported intervals
+func (k KlineInterval) IsValid() bool {
+	switch k {
+	case Interval1Min, Interval3Min, Interval5Min, Interval15Min, Interval30Min,
+		Interval1Hour, Interval2Hour, Interval4Hour, Interval6Hour, Interval8Hour, Interval12Hour,
+		Interval1Day, Interval3Day, Interval1Week, Interval1Month:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseKlineInterval parses a supported interval such as "15m", "4h" or "1M"
+// Units are case-sensitive since "1m" is a minute and "1M" a month.
+func ParseKlineInterval(s string) (KlineInterval, error) {
+	k := KlineInterval(strings.TrimSpace(s))
+	if !k.IsValid() {
+		return "", fmt.Errorf("unknown kline interval %q", s)
+	}
+	return k, nil
+}
+
+// Duration returns the length of one candle of the interval
+// The unit suffix is m (minutes), h (hours), d (days), w (weeks) or M
+// (months); a month is counted as 30 days, so bucket math on 1M candles is
+// approximate.
+func (k KlineInterval) Duration() (time.Duration, error) {
+	s := string(k)
+	if len(s) < 2 {
+		return 0, fmt.Errorf("invalid kline interval %q", s)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid kline interval %q", s)
+	}
+
+	var unit time.Duration
+	switch s[len(s)-1] {
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	case 'M':
+		unit = 30 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid kline interval %q: unknown unit", s)
+	}
+	return time.Duration(n) * unit, nil
+}
+
 // Constants for API base URLs
 const (
 	DefaultBaseURL       = "https://api-contract.weex.com"
@@ -259,6 +541,14 @@ const (
 	DefaultAPIPathPrefix = "/capi/v2"
 )
 
+// MaskSecret masks a credential for logging, keeping only its first 4 characters (e.g. "abcd***")
+func MaskSecret(s string) string {
+	if len(s) <= 4 {
+		return "***"
+	}
+	return s[:4] + "***"
+}
+
 // HTTP headers
 const (
 	HeaderAccessKey        = "ACCESS-KEY"
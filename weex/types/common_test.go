@@ -0,0 +1,411 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseOrderExecutionType(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    OrderExecutionType
+		wantErr bool
+	}{
+		{"0", OrderExecNormal, false},
+		{"1", OrderExecPostOnly, false},
+		{"2", OrderExecFillOrKill, false},
+		{"3", OrderExecImmediateOrCancel, false},
+		{"normal", OrderExecNormal, false},
+		{"POST_ONLY", OrderExecPostOnly, false},
+		{"fok", OrderExecFillOrKill, false},
+		{" IOC ", OrderExecImmediateOrCancel, false},
+		{"IMMEDIATE_OR_CANCEL", OrderExecImmediateOrCancel, false},
+		{"4", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseOrderExecutionType(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOrderExecutionType(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOrderExecutionType(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderExecutionTypeRoundTrip(t *testing.T) {
+	for _, want := range []OrderExecutionType{OrderExecNormal, OrderExecPostOnly, OrderExecFillOrKill, OrderExecImmediateOrCancel} {
+		got, err := ParseOrderExecutionType(want.String())
+		if err != nil || got != want {
+			t.Errorf("ParseOrderExecutionType(%q) = %v, %v, want %v", want.String(), got, err, want)
+		}
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Decimal
+		out     string // JSON produced by re-encoding the decoded value
+		wantErr bool
+	}{
+		{`123`, "123", `"123"`, false},
+		{`"123"`, "123", `"123"`, false},
+		{`1.5e-3`, "0.0015", `"0.0015"`, false},
+		{`-2E2`, "-200", `"-200"`, false},
+		{`12345.60`, "12345.60", `"12345.60"`, false},
+		{`"0.0001"`, "0.0001", `"0.0001"`, false},
+		{` null `, "", `""`, false},
+		{`""`, "", `""`, false},
+		{`true`, "", "", true},
+		{`{"v":1}`, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d := Decimal("stale")
+			err := json.Unmarshal([]byte(tt.in), &d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if d != tt.want {
+				t.Errorf("Unmarshal(%s) = %q, want %q", tt.in, d, tt.want)
+			}
+			out, err := json.Marshal(d)
+			if err != nil || string(out) != tt.out {
+				t.Errorf("Marshal(%q) = %s, %v, want %s", d, out, err, tt.out)
+			}
+		})
+	}
+}
+
+func TestDecimalJSONField(t *testing.T) {
+	var ticker struct {
+		Last Decimal `json:"last"`
+		High Decimal `json:"high"`
+		Low  Decimal `json:"low"`
+	}
+	if err := json.Unmarshal([]byte(`{"last":12345.6,"high":"12400","low":null}`), &ticker); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if ticker.Last != "12345.6" || ticker.High != "12400" || ticker.Low != "" {
+		t.Errorf("ticker = %+v, want {Last:12345.6 High:12400 Low:}", ticker)
+	}
+}
+
+func TestPositionSideForOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		orderType OrderType
+		mode      PositionMode
+		net       PositionSide
+		want      PositionSide
+		wantErr   bool
+	}{
+		{"hedge open long", OrderTypeOpenLong, PositionModeHedge, "", PositionSideLong, false},
+		{"hedge open short", OrderTypeOpenShort, PositionModeHedge, "", PositionSideShort, false},
+		{"hedge close long", OrderTypeCloseLong, PositionModeHedge, "", PositionSideLong, false},
+		{"hedge close short", OrderTypeCloseShort, PositionModeHedge, "", PositionSideShort, false},
+		{"hedge ignores net", OrderTypeOpenShort, PositionModeHedge, PositionSideLong, PositionSideShort, false},
+		{"one-way flat", OrderTypeOpenShort, PositionModeUnknown, "", PositionSideShort, false},
+		{"one-way reduces net long", OrderTypeOpenShort, PositionModeUnknown, PositionSideLong, PositionSideLong, false},
+		{"one-way adds to net short", OrderTypeOpenShort, PositionModeUnknown, PositionSideShort, PositionSideShort, false},
+		{"invalid order type", OrderType(0), PositionModeHedge, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PositionSideForOrder(tt.orderType, tt.mode, tt.net)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PositionSideForOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PositionSideForOrder() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositionSideOrderTypes(t *testing.T) {
+	tests := []struct {
+		side        PositionSide
+		open, close OrderType
+		opposite    PositionSide
+	}{
+		{PositionSideLong, OrderTypeOpenLong, OrderTypeCloseLong, PositionSideShort},
+		{PositionSideShort, OrderTypeOpenShort, OrderTypeCloseShort, PositionSideLong},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.side), func(t *testing.T) {
+			if got := tt.side.OpenOrderType(); got != tt.open {
+				t.Errorf("OpenOrderType() = %v, want %v", got, tt.open)
+			}
+			if got := tt.side.CloseOrderType(); got != tt.close {
+				t.Errorf("CloseOrderType() = %v, want %v", got, tt.close)
+			}
+			if got := tt.side.Opposite(); got != tt.opposite {
+				t.Errorf("Opposite() = %v, want %v", got, tt.opposite)
+			}
+			// The reverse mapping leads back to the same side
+			for _, o := range []OrderType{tt.open, tt.close} {
+				if got, err := o.PositionSide(); err != nil || got != tt.side {
+					t.Errorf("%v.PositionSide() = %q, %v, want %q", o, got, err, tt.side)
+				}
+			}
+			if !tt.open.IsOpen() || tt.close.IsOpen() {
+				t.Errorf("IsOpen() = %v/%v, want true/false", tt.open.IsOpen(), tt.close.IsOpen())
+			}
+		})
+	}
+}
+
+func TestParsePositionSide(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    PositionSide
+		wantErr bool
+	}{
+		{"LONG", PositionSideLong, false},
+		{" short ", PositionSideShort, false},
+		{"Long", PositionSideLong, false},
+		{"net", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParsePositionSide(tt.in)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParsePositionSide(%q) = %q, %v, want %q (wantErr %v)", tt.in, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseOrderStatus(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     OrderStatus
+		terminal bool
+		wantErr  bool
+	}{
+		{"-1", OrderStatusNotTriggered, false, false},
+		{"0", OrderStatusPending, false, false},
+		{"open", OrderStatusPending, false, false},
+		{"1", OrderStatusPartial, false, false},
+		{"partial_filled", OrderStatusPartial, false, false},
+		{"2", OrderStatusFilled, true, false},
+		{"filled", OrderStatusFilled, true, false},
+		{"3", OrderStatusCanceling, false, false},
+		{"canceling", OrderStatusCanceling, false, false},
+		{"4", OrderStatusCanceled, true, false},
+		{" Cancelled ", OrderStatusCanceled, true, false},
+		{"", 0, false, true},
+		{"5", 0, false, true},
+		{"done", 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseOrderStatus(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOrderStatus(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseOrderStatus(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+			if got.IsTerminal() != tt.terminal {
+				t.Errorf("%s.IsTerminal() = %v, want %v", got, got.IsTerminal(), tt.terminal)
+			}
+		})
+	}
+}
+
+func TestParseTriggerPriceType(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    TriggerPriceType
+		wantErr bool
+	}{
+		{"1", TriggerPriceLast, false},
+		{"last", TriggerPriceLast, false},
+		{"3", TriggerPriceMark, false},
+		{" MARK ", TriggerPriceMark, false},
+		{"2", 0, true},
+		{"index", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseTriggerPriceType(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTriggerPriceType(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTriggerPriceType(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+			if !tt.wantErr {
+				if back, err := ParseTriggerPriceType(got.String()); err != nil || back != got {
+					t.Errorf("round trip of %s = %v, %v", got, back, err)
+				}
+			}
+		})
+	}
+	if got := TriggerPriceType(2).String(); got != "UNKNOWN" {
+		t.Errorf("TriggerPriceType(2).String() = %s, want UNKNOWN", got)
+	}
+}
+
+func TestOrderTypeIsBuy(t *testing.T) {
+	tests := []struct {
+		orderType OrderType
+		want      bool
+	}{
+		{OrderTypeOpenLong, true},
+		{OrderTypeOpenShort, false},
+		{OrderTypeCloseLong, false},
+		{OrderTypeCloseShort, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.orderType.String(), func(t *testing.T) {
+			if got := tt.orderType.IsBuy(); got != tt.want {
+				t.Errorf("IsBuy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOrderSide(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    OrderSide
+		wantErr bool
+	}{
+		{"BUY", OrderSideBuy, false},
+		{"buy", OrderSideBuy, false},
+		{" Sell ", OrderSideSell, false},
+		{"", "", true},
+		{"long", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseOrderSide(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOrderSide(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOrderSide(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKlineIntervalDuration(t *testing.T) {
+	tests := []struct {
+		in      KlineInterval
+		want    time.Duration
+		wantErr bool
+	}{
+		{Interval1Min, time.Minute, false},
+		{Interval15Min, 15 * time.Minute, false},
+		{Interval4Hour, 4 * time.Hour, false},
+		{Interval12Hour, 12 * time.Hour, false},
+		{Interval3Day, 72 * time.Hour, false},
+		{Interval1Week, 7 * 24 * time.Hour, false},
+		{Interval1Month, 30 * 24 * time.Hour, false},
+		{"", 0, true},
+		{"m", 0, true},
+		{"0m", 0, true},
+		{"-1h", 0, true},
+		{"5x", 0, true},
+		{"1H", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.in), func(t *testing.T) {
+			got, err := tt.in.Duration()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Duration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Duration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKlineIntervalConstants(t *testing.T) {
+	tests := []struct {
+		in   KlineInterval
+		want time.Duration
+	}{
+		{Interval1Min, time.Minute},
+		{Interval3Min, 3 * time.Minute},
+		{Interval5Min, 5 * time.Minute},
+		{Interval15Min, 15 * time.Minute},
+		{Interval30Min, 30 * time.Minute},
+		{Interval1Hour, time.Hour},
+		{Interval2Hour, 2 * time.Hour},
+		{Interval4Hour, 4 * time.Hour},
+		{Interval6Hour, 6 * time.Hour},
+		{Interval8Hour, 8 * time.Hour},
+		{Interval12Hour, 12 * time.Hour},
+		{Interval1Day, 24 * time.Hour},
+		{Interval3Day, 3 * 24 * time.Hour},
+		{Interval1Week, 7 * 24 * time.Hour},
+		{Interval1Month, 30 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.in), func(t *testing.T) {
+			if !tt.in.IsValid() {
+				t.Errorf("IsValid() = false")
+			}
+			if got, err := ParseKlineInterval(string(tt.in)); err != nil || got != tt.in {
+				t.Errorf("ParseKlineInterval(%q) = %q, %v", tt.in, got, err)
+			}
+			if got, err := tt.in.Duration(); err != nil || got != tt.want {
+				t.Errorf("Duration() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseKlineInterval(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    KlineInterval
+		wantErr bool
+	}{
+		{"15m", Interval15Min, false},
+		{" 4h ", Interval4Hour, false},
+		{"1M", Interval1Month, false},
+		{"", "", true},
+		{"2m", "", true}, // well-formed but not offered by the exchange
+		{"1H", "", true},
+		{"1 m", "", true},
+		{"month", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseKlineInterval(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKlineInterval(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseKlineInterval(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if !tt.wantErr && !got.IsValid() || tt.wantErr && KlineInterval(tt.in).IsValid() {
+				t.Errorf("IsValid() disagrees with ParseKlineInterval(%q)", tt.in)
+			}
+		})
+	}
+}
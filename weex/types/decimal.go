@@ -0,0 +1,218 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrDivisionByZero is returned when dividing a Decimal by zero
+var ErrDivisionByZero = errors.New("decimal division by zero")
+
+// maxDecimalPlaces bounds the digits kept for non-terminating results (e.g. 1/3)
+const maxDecimalPlaces = 18
+
+// Rat parses the Decimal into a big.Rat; the empty Decimal is zero
+// Only plain decimal notation is accepted: an optional sign, digits and at
+// most one '.'. Fractions ("1/2"), exponents ("1e3") and hex ("0x1p-2") are
+// rejected, since the exchange would receive them verbatim.
+func (d Decimal) Rat() (*big.Rat, error) {
+	s := strings.TrimSpace(string(d))
+	if s == "" {
+		return new(big.Rat), nil
+	}
+	if !isPlainDecimal(s) {
+		return nil, fmt.Errorf("invalid decimal %q", string(d))
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", string(d))
+	}
+	return r, nil
+}
+
+// isPlainDecimal returns true if s is an optional sign followed by digits with at most one '.'
+func isPlainDecimal(s string) bool {
+	if s[0] == '-' || s[0] == '+' {
+		s = s[1:]
+	}
+	digits, dot := 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '.' && !dot:
+			dot = true
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// NewDecimalFromRat creates a new Decimal from a big.Rat
+// Terminating values are exact; others are rounded to 18 decimal places
+func NewDecimalFromRat(r *big.Rat) Decimal {
+	return Decimal(ratString(r))
+}
+
+// AddErr returns d + other
+func (d Decimal) AddErr(other Decimal) (Decimal, error) {
+	return d.apply(other, (*big.Rat).Add)
+}
+
+// SubErr returns d - other
+func (d Decimal) SubErr(other Decimal) (Decimal, error) {
+	return d.apply(other, (*big.Rat).Sub)
+}
+
+// MulErr returns d * other
+func (d Decimal) MulErr(other Decimal) (Decimal, error) {
+	return d.apply(other, (*big.Rat).Mul)
+}
+
+// DivErr returns d / other, or ErrDivisionByZero if other is zero
+func (d Decimal) DivErr(other Decimal) (Decimal, error) {
+	b, err := other.Rat()
+	if err != nil {
+		return "", err
+	}
+	if b.Sign() == 0 {
+		return "", ErrDivisionByZero
+	}
+	return d.apply(other, (*big.Rat).Quo)
+}
+
+// CmpErr compares d and other, returning -1, 0 or +1
+func (d Decimal) CmpErr(other Decimal) (int, error) {
+	a, err := d.Rat()
+	if err != nil {
+		return 0, err
+	}
+	b, err := other.Rat()
+	if err != nil {
+		return 0, err
+	}
+	return a.Cmp(b), nil
+}
+
+// Add returns d + other, panics if either is not a valid decimal
+func (d Decimal) Add(other Decimal) Decimal {
+	return must(d.AddErr(other))
+}
+
+// Sub returns d - other, panics if either is not a valid decimal
+func (d Decimal) Sub(other Decimal) Decimal {
+	return must(d.SubErr(other))
+}
+
+// Mul returns d * other, panics if either is not a valid decimal
+func (d Decimal) Mul(other Decimal) Decimal {
+	return must(d.MulErr(other))
+}
+
+// Div returns d / other, panics on an invalid decimal or division by zero
+func (d Decimal) Div(other Decimal) Decimal {
+	return must(d.DivErr(other))
+}
+
+// Cmp compares d and other, returning -1, 0 or +1; panics if either is not a valid decimal
+func (d Decimal) Cmp(other Decimal) int {
+	c, err := d.CmpErr(other)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Neg returns -d, panics if d is not a valid decimal
+func (d Decimal) Neg() Decimal {
+	r := mustRat(d)
+	return NewDecimalFromRat(r.Neg(r))
+}
+
+// IsNegative returns true if d is less than zero
+func (d Decimal) IsNegative() bool {
+	r, err := d.Rat()
+	return err == nil && r.Sign() < 0
+}
+
+// Round rounds d to places decimal places, halves away from zero
+// Panics if d is not a valid decimal
+func (d Decimal) Round(places int) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	r := mustRat(d)
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(scale))
+
+	// Round |scaled| half up, then restore the sign
+	num := new(big.Int).Abs(scaled.Num())
+	den := scaled.Denom()
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(rem, big.NewInt(2)).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if scaled.Sign() < 0 {
+		q.Neg(q)
+	}
+	return NewDecimalFromRat(new(big.Rat).SetFrac(q, scale))
+}
+
+// apply parses both operands and applies op
+func (d Decimal) apply(other Decimal, op func(z, x, y *big.Rat) *big.Rat) (Decimal, error) {
+	a, err := d.Rat()
+	if err != nil {
+		return "", err
+	}
+	b, err := other.Rat()
+	if err != nil {
+		return "", err
+	}
+	return NewDecimalFromRat(op(new(big.Rat), a, b)), nil
+}
+
+// mustRat parses d, panicking if it is not a valid decimal
+func mustRat(d Decimal) *big.Rat {
+	r, err := d.Rat()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// must returns d, panicking on err
+func must(d Decimal, err error) Decimal {
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// terminatingPlaces returns the decimal places needed to print r exactly,
+// or false if r does not have a terminating decimal expansion
+func terminatingPlaces(r *big.Rat) (int, bool) {
+	den := new(big.Int).Set(r.Denom())
+	five, mod := big.NewInt(5), new(big.Int)
+
+	var twos, fives int
+	for den.Bit(0) == 0 {
+		den.Rsh(den, 1)
+		twos++
+	}
+	for {
+		q, m := new(big.Int).QuoRem(den, five, mod)
+		if m.Sign() != 0 {
+			break
+		}
+		den = q
+		fives++
+	}
+	if !den.IsInt64() || den.Int64() != 1 {
+		return 0, false
+	}
+	return max(twos, fives), true
+}
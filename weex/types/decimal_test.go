@@ -0,0 +1,176 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestDecimalArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func() Decimal
+		want Decimal
+	}{
+		{"0.1 + 0.2", func() Decimal { return Decimal("0.1").Add("0.2") }, "0.3"},
+		{"empty is zero", func() Decimal { return Decimal("").Add("1.5") }, "1.5"},
+		{"trailing zeros normalized", func() Decimal { return Decimal("1.500").Add("0.500") }, "2"},
+		{"sub below zero", func() Decimal { return Decimal("0.3").Sub("0.5") }, "-0.2"},
+		{"sub to zero", func() Decimal { return Decimal("-0.1").Sub("-0.1") }, "0"},
+		{"mul", func() Decimal { return Decimal("0.001").Mul("65432.1") }, "65.4321"},
+		{"div exact", func() Decimal { return Decimal("1").Div("8") }, "0.125"},
+		{"div non-terminating", func() Decimal { return Decimal("1").Div("3") }, "0.333333333333333333"},
+		{"div beyond 18 places", func() Decimal { return Decimal("1").Div("1048576") }, "0.00000095367431640625"},
+		{"neg", func() Decimal { return Decimal("12.5").Neg() }, "-12.5"},
+		{"neg zero", func() Decimal { return Decimal("0").Neg() }, "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func() (Decimal, error)
+		wantErr error // nil only checks that an error is returned
+	}{
+		{"div by zero", func() (Decimal, error) { return Decimal("1").DivErr("0") }, ErrDivisionByZero},
+		{"div by empty", func() (Decimal, error) { return Decimal("1").DivErr("") }, ErrDivisionByZero},
+		{"invalid left", func() (Decimal, error) { return Decimal("abc").AddErr("1") }, nil},
+		{"invalid right", func() (Decimal, error) { return Decimal("1").MulErr("1.2.3") }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op()
+			if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != "" {
+				t.Errorf("got %q on error, want empty", got)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Div by zero did not panic")
+		}
+	}()
+	Decimal("1").Div("0")
+}
+
+func TestDecimalRatSyntax(t *testing.T) {
+	tests := []struct {
+		d       Decimal
+		want    string // Expected value as a big.Rat string
+		wantErr bool
+	}{
+		{"", "0/1", false},
+		{"1.5", "3/2", false},
+		{" -0.25 ", "-1/4", false},
+		{"+2", "2/1", false},
+		{".5", "1/2", false},
+		{"5.", "5/1", false},
+		{"010", "10/1", false},
+		{"1/2", "", true},
+		{"010/1", "", true},
+		{"1e3", "", true},
+		{"0x1p-2", "", true},
+		{"1.2.3", "", true},
+		{"-", "", true},
+		{".", "", true},
+		{"1 000", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.d), func(t *testing.T) {
+			r, err := tt.d.Rat()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Rat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && r.String() != tt.want {
+				t.Errorf("Rat() = %s, want %s", r, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	tests := []struct {
+		a, b     Decimal
+		want     int
+		negative bool
+	}{
+		{"0.3", "0.30", 0, false},
+		{"0.1", "0.2", -1, false},
+		{"100000.05", "100000.049999999999999999", 1, false},
+		{"", "0", 0, false},
+		{"-0.000000000000000001", "0", -1, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.a)+" vs "+string(tt.b), func(t *testing.T) {
+			if got := tt.a.Cmp(tt.b); got != tt.want {
+				t.Errorf("Cmp() = %d, want %d", got, tt.want)
+			}
+			if got := tt.a.IsNegative(); got != tt.negative {
+				t.Errorf("IsNegative() = %v, want %v", got, tt.negative)
+			}
+		})
+	}
+
+	if _, err := Decimal("x").CmpErr("1"); err == nil {
+		t.Error("CmpErr() with an invalid decimal returned no error")
+	}
+	if Decimal("x").IsNegative() {
+		t.Error("IsNegative() = true for an invalid decimal")
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	tests := []struct {
+		d      Decimal
+		places int
+		want   Decimal
+	}{
+		{"1.2345", 2, "1.23"},
+		{"1.235", 2, "1.24"},
+		{"-1.235", 2, "-1.24"},
+		{"2.5", 0, "3"},
+		{"-2.5", 0, "-3"},
+		{"1.2", 4, "1.2"},
+		{"0.0049", 2, "0"},
+		{"1.5", -1, "2"},
+		{"", 2, "0"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.d), func(t *testing.T) {
+			if got := tt.d.Round(tt.places); got != tt.want {
+				t.Errorf("Round(%d) = %q, want %q", tt.places, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDecimalFromRat(t *testing.T) {
+	tests := []struct {
+		r    *big.Rat
+		want Decimal
+	}{
+		{big.NewRat(3, 10), "0.3"},
+		{big.NewRat(-7, 4), "-1.75"},
+		{big.NewRat(2, 3), "0.666666666666666667"},
+		{big.NewRat(100, 1), "100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.r.String(), func(t *testing.T) {
+			got := NewDecimalFromRat(tt.r)
+			if got != tt.want {
+				t.Errorf("NewDecimalFromRat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,13 @@
 package types
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
 // ErrorType represents the category of an error
 type ErrorType int
 
@@ -117,3 +125,169 @@ func IsRateLimitError(code string) bool {
 	cat := GetErrorCategory(code)
 	return cat.Type == ErrTypeRateLimit
 }
+
+// IsPermissionError checks if an error code means the API key lacks the required permission
+func IsPermissionError(code string) bool {
+	cat := GetErrorCategory(code)
+	return cat.Type == ErrTypePermission
+}
+
+// APIError represents an error returned by the WEEX Contract API
+type APIError struct {
+	Code        string         // Error code from API
+	Message     string         // Error message from API
+	HTTPStatus  int            // HTTP status code
+	RequestTime int64          // Request timestamp from API response
+	Category    *ErrorCategory // Error category
+	Underlying  error          // Underlying error if any
+	RetryAfter  time.Duration  // Server-requested delay before retrying (0 if not provided)
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Underlying != nil {
+		return fmt.Sprintf("API error [%s]: %s (HTTP %d) - %v", e.Code, e.Message, e.HTTPStatus, e.Underlying)
+	}
+	return fmt.Sprintf("API error [%s]: %s (HTTP %d)", e.Code, e.Message, e.HTTPStatus)
+}
+
+// IsRetriable returns true if the error is retriable
+func (e *APIError) IsRetriable() bool {
+	return e.Category != nil && e.Category.Retriable
+}
+
+// IsAuthError returns true if the error is an authentication error
+func (e *APIError) IsAuthError() bool {
+	return e.Category != nil && e.Category.Type == ErrTypeAuth
+}
+
+// IsRateLimitError returns true if the error is a rate limiting error
+func (e *APIError) IsRateLimitError() bool {
+	return e.Category != nil && e.Category.Type == ErrTypeRateLimit
+}
+
+// IsValidationError returns true if the error is a validation error
+func (e *APIError) IsValidationError() bool {
+	return e.Category != nil && e.Category.Type == ErrTypeValidation
+}
+
+// IsSystemError returns true if the error is a system error
+func (e *APIError) IsSystemError() bool {
+	return e.Category != nil && e.Category.Type == ErrTypeSystem
+}
+
+// IsPermissionError returns true if the API key lacks permission for the operation
+// e.g. 40022 when a read-only key is used to trade. The contract API has no
+// endpoint to query a key's scopes, so this is how missing permissions surface.
+func (e *APIError) IsPermissionError() bool {
+	return e.Category != nil && e.Category.Type == ErrTypePermission
+}
+
+// NewAPIError creates a new APIError from API response
+func NewAPIError(code, message string, httpStatus int, requestTime int64) *APIError {
+	return &APIError{
+		Code:        code,
+		Message:     message,
+		HTTPStatus:  httpStatus,
+		RequestTime: requestTime,
+		Category:    GetErrorCategory(code),
+	}
+}
+
+// NewHTTPStatusError creates an APIError for an HTTP error response without an API error body
+// 429 is categorized as a rate limit error and 500/502/503/504 as retriable system errors
+func NewHTTPStatusError(statusCode int) *APIError {
+	err := NewAPIError(strconv.Itoa(statusCode), http.StatusText(statusCode), statusCode, 0)
+	switch statusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		err.Category = &ErrorCategory{Type: ErrTypeSystem, Retriable: true}
+	}
+	return err
+}
+
+// NetworkError represents a network-related error
+type NetworkError struct {
+	Operation string // Operation being performed (e.g., "dial", "read", "write")
+	URL       string // URL being accessed
+	Err       error  // Underlying error
+}
+
+// Error implements the error interface
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("network error during %s to %s: %v", e.Operation, e.URL, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetriable returns true for network errors
+func (e *NetworkError) IsRetriable() bool {
+	return true
+}
+
+// NewNetworkError creates a new NetworkError
+func NewNetworkError(operation, url string, err error) *NetworkError {
+	return &NetworkError{
+		Operation: operation,
+		URL:       url,
+		Err:       err,
+	}
+}
+
+// ValidationError reports a request field that failed local validation
+// Several can be combined with errors.Join; use errors.As to get the first or
+// ValidationErrors to get all of them.
+type ValidationError struct {
+	Field  string // Name of the offending field as sent to the API (e.g. "client_oid")
+	Reason string // Human-readable description of the problem
+	Err    error  // Underlying error, if any
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return e.Reason
+}
+
+// Unwrap returns the underlying error
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// NewValidationError creates a ValidationError for field with a message formatted as by fmt.Errorf
+// An error wrapped with %w is kept as Err.
+func NewValidationError(field, format string, args ...interface{}) *ValidationError {
+	err := fmt.Errorf(format, args...)
+	return &ValidationError{
+		Field:  field,
+		Reason: err.Error(),
+		Err:    errors.Unwrap(err),
+	}
+}
+
+// ValidationErrors returns every ValidationError in err's tree, including those combined with errors.Join
+func ValidationErrors(err error) []*ValidationError {
+	var found []*ValidationError
+	var walk func(error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		if v, ok := err.(*ValidationError); ok {
+			found = append(found, v)
+			return
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				walk(e)
+			}
+		case interface{ Unwrap() error }:
+			walk(u.Unwrap())
+		}
+	}
+	walk(err)
+	return found
+}
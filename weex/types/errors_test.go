@@ -0,0 +1,109 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsPermissionError(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+		auth bool
+	}{
+		{"40022", true, false},
+		{"50003", true, false},
+		{"50004", true, false},
+		{"40001", false, true},
+		{"429", false, false},
+		{"99999", false, false},
+		{"", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := IsPermissionError(tt.code); got != tt.want {
+				t.Errorf("IsPermissionError(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+
+			// The predicate survives wrapping, as returned by service methods
+			err := fmt.Errorf("place order: %w", NewAPIError(tt.code, "msg", 400, 1700000000000))
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("errors.As(%v) = false", err)
+			}
+			if got := apiErr.IsPermissionError(); got != tt.want {
+				t.Errorf("APIError.IsPermissionError() = %v, want %v", got, tt.want)
+			}
+			if got := apiErr.IsAuthError(); got != tt.auth {
+				t.Errorf("APIError.IsAuthError() = %v, want %v", got, tt.auth)
+			}
+			if apiErr.IsRetriable() && tt.want {
+				t.Error("permission errors must not be retried")
+			}
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	sentinel := errors.New("invalid amount")
+	tests := []struct {
+		name       string
+		err        *ValidationError
+		wantField  string
+		wantReason string
+		wantErr    error
+	}{
+		{"plain reason", NewValidationError("symbol", "symbol cannot be empty"), "symbol", "symbol cannot be empty", nil},
+		{"formatted reason", NewValidationError("size", "size %s is below %s", "0.1", "0.5"), "size", "size 0.1 is below 0.5", nil},
+		{"wrapped sentinel", NewValidationError("amount", "%w: %q", sentinel, "x"), "amount", `invalid amount: "x"`, sentinel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("place order: %w", tt.err)
+			var v *ValidationError
+			if !errors.As(wrapped, &v) {
+				t.Fatalf("errors.As(%v) = false", wrapped)
+			}
+			if v.Field != tt.wantField || v.Reason != tt.wantReason || v.Error() != tt.wantReason {
+				t.Errorf("ValidationError = {%q, %q}, Error() = %q, want {%q, %q}", v.Field, v.Reason, v.Error(), tt.wantField, tt.wantReason)
+			}
+			if tt.wantErr != nil && !errors.Is(wrapped, tt.wantErr) {
+				t.Errorf("errors.Is(%v, %v) = false", wrapped, tt.wantErr)
+			}
+			if tt.wantErr == nil && v.Unwrap() != nil {
+				t.Errorf("Unwrap() = %v, want nil", v.Unwrap())
+			}
+		})
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	symbol := NewValidationError("symbol", "symbol cannot be empty")
+	size := NewValidationError("size", "invalid size")
+	price := NewValidationError("price", "invalid price")
+
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"nil", nil, nil},
+		{"unrelated error", errors.New("boom"), nil},
+		{"single", symbol, []string{"symbol"}},
+		{"joined", errors.Join(symbol, errors.New("boom"), size), []string{"symbol", "size"}},
+		{"wrapped join", fmt.Errorf("validate: %w", errors.Join(symbol, size)), []string{"symbol", "size"}},
+		{"nested join", errors.Join(symbol, errors.Join(size, price)), []string{"symbol", "size", "price"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range ValidationErrors(tt.err) {
+				got = append(got, v.Field)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ValidationErrors() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
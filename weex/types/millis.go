@@ -0,0 +1,91 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Millis is a Unix timestamp in milliseconds
+//
+// It decodes from a JSON number or a numeric string and encodes as a JSON
+// number, so it can replace raw int64 timestamp fields without changing the
+// wire format. The zero value means the timestamp was missing.
+type Millis int64
+
+// Timestamp is a Unix millisecond timestamp delivered as either a JSON number or a numeric string
+// It is the same type as Millis; the name is used for fields whose wire type varies by endpoint
+type Timestamp = Millis
+
+// Time returns the timestamp as a time.Time (the zero time.Time if m is zero)
+func (m Millis) Time() time.Time {
+	if m == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(m))
+}
+
+// IsZero returns true if the timestamp is missing
+func (m Millis) IsZero() bool {
+	return m == 0
+}
+
+// Int64 returns the timestamp in milliseconds
+func (m Millis) Int64() int64 {
+	return int64(m)
+}
+
+// String returns the timestamp in RFC 3339 format with milliseconds, or "" if zero
+func (m Millis) String() string {
+	if m == 0 {
+		return ""
+	}
+	return m.Time().UTC().Format("2006-01-02T15:04:05.000Z07:00")
+}
+
+// NewMillis creates a Millis from a time.Time (zero time.Time gives zero Millis)
+func NewMillis(t time.Time) Millis {
+	if t.IsZero() {
+		return 0
+	}
+	return Millis(t.UnixMilli())
+}
+
+// MarshalJSON encodes the timestamp as a JSON number
+func (m Millis) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(m), 10), nil
+}
+
+// UnmarshalJSON decodes the timestamp from a JSON number, a numeric string, "" or null
+func (m *Millis) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.Equal(trimmed, []byte("null")) {
+		*m = 0
+		return nil
+	}
+	if len(trimmed) > 0 && trimmed[0] == '"' {
+		var s string
+		if err := json.Unmarshal(trimmed, &s); err != nil {
+			return fmt.Errorf("invalid timestamp %s: %w", trimmed, err)
+		}
+		trimmed = []byte(s)
+		if len(trimmed) == 0 {
+			*m = 0
+			return nil
+		}
+	}
+
+	v, err := strconv.ParseInt(string(trimmed), 10, 64)
+	if err != nil {
+		// Some payloads carry integral values in float notation (e.g. 1.7e12)
+		f, ferr := strconv.ParseFloat(string(trimmed), 64)
+		if ferr != nil {
+			return fmt.Errorf("invalid timestamp %q: %w", trimmed, err)
+		}
+		v = int64(f)
+	}
+	*m = Millis(v)
+	return nil
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMillisUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Millis
+		wantErr bool
+	}{
+		{`1716710918113`, 1716710918113, false},
+		{`"1716710918113"`, 1716710918113, false},
+		{`1.716710918113e12`, 1716710918113, false},
+		{`"1.7e12"`, 1700000000000, false},
+		{`0`, 0, false},
+		{`""`, 0, false},
+		{`null`, 0, false},
+		{`"abc"`, 0, true},
+		{`true`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			m := Millis(42)
+			err := json.Unmarshal([]byte(tt.in), &m)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && m != tt.want {
+				t.Errorf("Unmarshal(%s) = %d, want %d", tt.in, m, tt.want)
+			}
+		})
+	}
+}
+
+func TestMillisMarshalJSON(t *testing.T) {
+	// Encoded as a number whatever representation it was decoded from
+	var v struct {
+		Time Millis `json:"time"`
+	}
+	if err := json.Unmarshal([]byte(`{"time":"1716710918113"}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil || string(out) != `{"time":1716710918113}` {
+		t.Errorf("Marshal() = %s, %v, want {\"time\":1716710918113}", out, err)
+	}
+}
+
+func TestMillisTime(t *testing.T) {
+	tests := []struct {
+		name   string
+		m      Millis
+		want   time.Time
+		str    string
+		isZero bool
+	}{
+		{"set", 1716710918113, time.Date(2024, 5, 26, 8, 8, 38, 113e6, time.UTC), "2024-05-26T08:08:38.113Z", false},
+		{"epoch second", 1000, time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC), "1970-01-01T00:00:01.000Z", false},
+		{"missing", 0, time.Time{}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Time(); !got.Equal(tt.want) {
+				t.Errorf("Time() = %v, want %v", got, tt.want)
+			}
+			if got := tt.m.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+			if got := tt.m.IsZero(); got != tt.isZero {
+				t.Errorf("IsZero() = %v, want %v", got, tt.isZero)
+			}
+			if got := NewMillis(tt.want); got != tt.m {
+				t.Errorf("NewMillis(%v) = %d, want %d", tt.want, got, tt.m)
+			}
+		})
+	}
+}
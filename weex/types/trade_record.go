@@ -0,0 +1,28 @@
+package types
+
+import "time"
+
+// Liquidity is whether a fill added (maker) or removed (taker) liquidity
+type Liquidity string
+
+const (
+	LiquidityUnknown Liquidity = ""      // Not reported by the source
+	LiquidityMaker   Liquidity = "MAKER" // Resting order was filled
+	LiquidityTaker   Liquidity = "TAKER" // Order crossed the book
+)
+
+// TradeRecord is an exchange-neutral record of a single fill, e.g. for a trade journal
+// Produced by the Normalize methods of the REST and WebSocket fill types.
+type TradeRecord struct {
+	TradeId     string    // Exchange fill ID
+	OrderId     string    // Exchange order ID
+	Symbol      string    // Exchange symbol (e.g. "cmt_btcusdt")
+	Side        OrderSide // BUY or SELL
+	Quantity    Decimal   // Filled quantity
+	Price       Decimal   // Fill price
+	Fee         Decimal   // Fee as reported by the exchange
+	FeeCoin     string    // Fee currency ("" if not reported)
+	RealizedPnl Decimal   // Realized PnL of the fill ("0" when opening)
+	Time        time.Time // Fill time
+	Liquidity   Liquidity // Maker or taker, if reported
+}
@@ -0,0 +1,203 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default subscribe ack timeouts used when the config does not set one
+const (
+	DefaultAckTimeout        = 5 * time.Second
+	DefaultPrivateAckTimeout = 15 * time.Second
+)
+
+// privateChannelTypes lists channel types served by the private endpoint
+var privateChannelTypes = map[string]bool{
+	"account":   true,
+	"positions": true,
+	"orders":    true,
+	"fill":      true,
+}
+
+// ChannelType returns the type of a channel, i.e. its prefix before the first dot
+// Example: "candlestick.cmt_btcusdt.1m" -> "candlestick"
+func ChannelType(channel string) string {
+	if i := strings.IndexByte(channel, '.'); i >= 0 {
+		return channel[:i]
+	}
+	return channel
+}
+
+// IsPrivateChannel returns true if the channel is served by the private endpoint
+func IsPrivateChannel(channel string) bool {
+	return privateChannelTypes[ChannelType(channel)]
+}
+
+// SubscriptionError is a subscribe rejection reported by the server
+// SubscribeAwait returns it when the server answers with an error code.
+type SubscriptionError struct {
+	Channel string // Channel named by the server, if any
+	Code    string // Server error code
+	Message string // Server error message
+}
+
+// Error implements the error interface
+func (e *SubscriptionError) Error() string {
+	return fmt.Sprintf("subscription error [%s]: %s", e.Code, e.Message)
+}
+
+// ackRegistry tracks callers waiting for subscribe acks, keyed by channel
+type ackRegistry struct {
+	mu      sync.Mutex
+	waiters map[string][]chan error
+}
+
+// newAckRegistry creates a new ack registry
+func newAckRegistry() *ackRegistry {
+	return &ackRegistry{
+		waiters: make(map[string][]chan error),
+	}
+}
+
+// register adds a one-shot waiter for a channel
+func (r *ackRegistry) register(channel string) chan error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ch := make(chan error, 1)
+	r.waiters[channel] = append(r.waiters[channel], ch)
+	return ch
+}
+
+// remove drops a waiter that is no longer interested
+func (r *ackRegistry) remove(channel string, ch chan error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	waiters := r.waiters[channel]
+	for i, w := range waiters {
+		if w == ch {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(waiters) == 0 {
+		delete(r.waiters, channel)
+	} else {
+		r.waiters[channel] = waiters
+	}
+}
+
+// resolve delivers an ack result to all waiters on a channel
+// Returns true if any waiter was resolved
+func (r *ackRegistry) resolve(channel string, err error) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	waiters := r.waiters[channel]
+	delete(r.waiters, channel)
+	for _, ch := range waiters {
+		ch <- err
+	}
+	return len(waiters) > 0
+}
+
+// resolveSole delivers an ack result when exactly one channel has waiters
+// Used for error events that do not name a channel
+func (r *ackRegistry) resolveSole(err error) bool {
+	r.mu.Lock()
+	if len(r.waiters) != 1 {
+		r.mu.Unlock()
+		return false
+	}
+	var channel string
+	for ch := range r.waiters {
+		channel = ch
+	}
+	r.mu.Unlock()
+
+	return r.resolve(channel, err)
+}
+
+// waitAck waits for waiter's ack result until ctx is done
+// An ack that has already arrived is always taken, even if ctx is done, so
+// that channels acked before a shared deadline are not reported as failed.
+// Returns false if no ack arrived.
+func waitAck(ctx context.Context, waiter chan error) (bool, error) {
+	select {
+	case err := <-waiter:
+		return true, err
+	default:
+	}
+	select {
+	case err := <-waiter:
+		return true, err
+	case <-ctx.Done():
+		return false, nil
+	}
+}
+
+// AckTimeout returns the subscribe ack timeout for a channel
+//
+// Resolution order: Config.WSAckTimeoutByChannel[ChannelType(channel)], then
+// Config.WSPrivateAckTimeout for private channels, then Config.WSAckTimeout
+func (c *Client) AckTimeout(channel string) time.Duration {
+	if timeout, ok := c.config.WSAckTimeoutByChannel[ChannelType(channel)]; ok && timeout > 0 {
+		return timeout
+	}
+	if c.isPrivate || IsPrivateChannel(channel) {
+		if c.config.WSPrivateAckTimeout > 0 {
+			return c.config.WSPrivateAckTimeout
+		}
+		return DefaultPrivateAckTimeout
+	}
+	if c.config.WSAckTimeout > 0 {
+		return c.config.WSAckTimeout
+	}
+	return DefaultAckTimeout
+}
+
+// SubscribeAwait subscribes to a channel and waits for the server's ack
+//
+// The wait is bounded by ctx and by AckTimeout(channel), whichever is shorter.
+// If the server rejects the subscription, the handler is removed and a
+// *SubscriptionError with the server's code and message is returned. If no
+// ack arrives in time, the handler is removed as well and a best-effort
+// unsubscribe is sent, so that a late subscription does not stay open on the
+// server without a handler.
+func (c *Client) SubscribeAwait(ctx context.Context, channel string, handler MessageHandler) error {
+	ctx, cancel := context.WithTimeout(ctx, c.AckTimeout(channel))
+	defer cancel()
+
+	waiter := c.acks.register(channel)
+	defer c.acks.remove(channel, waiter)
+
+	if err := c.SubscribeContext(ctx, channel, handler); err != nil {
+		return err
+	}
+
+	acked, err := waitAck(ctx, waiter)
+	if !acked {
+		c.dropUnacked([]string{channel})
+		return fmt.Errorf("subscribe ack for %s not received: %w", channel, ctx.Err())
+	}
+	if err != nil {
+		c.subscriptions.Remove(channel)
+		return err
+	}
+	return nil
+}
+
+// dropUnacked removes the handlers of channels whose subscribe ack never arrived
+// and sends a best-effort unsubscribe for them; a send failure is only logged
+func (c *Client) dropUnacked(channels []string) {
+	for _, channel := range channels {
+		c.subscriptions.Remove(channel)
+	}
+	if err := c.writeFrame("unsubscribe", channels); err != nil {
+		c.logger.Warn("Failed to unsubscribe unacked channels %v: %v", channels, err)
+	}
+}
@@ -0,0 +1,163 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestSubscribeAwait(t *testing.T) {
+	server := newTestServer(t, func(req wstest.Frame) []string {
+		switch channel := req.Args[0]; channel {
+		case "ticker.ok":
+			return []string{wstest.Ack(channel)}
+		case "ticker.bad":
+			return []string{wstest.Error(channel, "30001", "channel does not exist")}
+		}
+		return nil // ticker.silent is never acked
+	})
+	client := connectTestClient(t, server, func(config *weex.Config) {
+		config.WSAckTimeout = 100 * time.Millisecond
+	})
+
+	tests := []struct {
+		channel        string
+		wantCode       string
+		wantTimeout    bool
+		wantSubscribed bool
+	}{
+		{channel: "ticker.ok", wantSubscribed: true},
+		{channel: "ticker.bad", wantCode: "30001"},
+		{channel: "ticker.silent", wantTimeout: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.channel, func(t *testing.T) {
+			err := client.SubscribeAwait(context.Background(), tt.channel, noopHandler)
+
+			var subErr *SubscriptionError
+			switch {
+			case tt.wantCode != "":
+				if !errors.As(err, &subErr) || subErr.Code != tt.wantCode {
+					t.Errorf("SubscribeAwait() error = %v, want SubscriptionError code %s", err, tt.wantCode)
+				}
+			case tt.wantTimeout:
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("SubscribeAwait() error = %v, want deadline exceeded", err)
+				}
+			case err != nil:
+				t.Errorf("SubscribeAwait() error = %v", err)
+			}
+
+			if got := client.subscriptions.Exists(tt.channel); got != tt.wantSubscribed {
+				t.Errorf("subscribed = %v, want %v", got, tt.wantSubscribed)
+			}
+		})
+	}
+
+	// Only the channel that timed out is unsubscribed on the server
+	if got, want := unsubscribedChannels(server, 1), []string{"ticker.silent"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unsubscribed = %v, want %v", got, want)
+	}
+}
+
+// unsubscribedChannels waits until server has received unsubscribes for at
+// least n channels, then returns every unsubscribed channel in order
+func unsubscribedChannels(server *wstest.Server, n int) []string {
+	var channels []string
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		channels = nil
+		for _, frame := range server.Frames() {
+			if frame.Op == "unsubscribe" {
+				channels = append(channels, frame.Args...)
+			}
+		}
+		if len(channels) >= n || time.Now().After(deadline) {
+			return channels
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAckTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(config *weex.Config)
+		private   bool
+		channel   string
+		want      time.Duration
+	}{
+		{"public default", nil, false, "ticker.cmt_btcusdt", 5 * time.Second},
+		{"private channel default", nil, false, "orders", 15 * time.Second},
+		{"private client default", nil, true, "ticker.cmt_btcusdt", 15 * time.Second},
+		{"configured public", func(c *weex.Config) { c.WSAckTimeout = time.Second }, false, "depth.cmt_btcusdt", time.Second},
+		{"configured private", func(c *weex.Config) { c.WSPrivateAckTimeout = 30 * time.Second }, false, "fill", 30 * time.Second},
+		{"channel type override", func(c *weex.Config) { c.WithWSAckTimeout("candlestick", 2*time.Second) }, false,
+			"candlestick.cmt_btcusdt.1m", 2 * time.Second},
+		{"override beats private", func(c *weex.Config) { c.WithWSAckTimeout("orders", 3*time.Second) }, true, "orders", 3 * time.Second},
+		{"zero falls back to defaults", func(c *weex.Config) {
+			c.WSAckTimeout, c.WSPrivateAckTimeout = 0, 0
+			c.WithWSAckTimeout("ticker", 0)
+		}, false, "ticker.cmt_btcusdt", DefaultAckTimeout},
+		{"zero private falls back", func(c *weex.Config) { c.WSPrivateAckTimeout = 0 }, false, "positions", DefaultPrivateAckTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := weex.NewDefaultConfig()
+			config.Logger = weex.NewNoOpLogger()
+			if tt.configure != nil {
+				tt.configure(config)
+			}
+			client := newClient(config, nil, tt.private)
+			if got := client.AckTimeout(tt.channel); got != tt.want {
+				t.Errorf("AckTimeout(%q) = %v, want %v", tt.channel, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscribeAwaitSlowAck(t *testing.T) {
+	const ackDelay = 150 * time.Millisecond
+	server := newTestServer(t, func(req wstest.Frame) []string {
+		time.Sleep(ackDelay)
+		return []string{wstest.Ack(req.Args[0])}
+	})
+	client := connectTestClient(t, server, func(config *weex.Config) {
+		config.WSAckTimeout = 50 * time.Millisecond
+		config.WSPrivateAckTimeout = 2 * time.Second
+	})
+
+	tests := []struct {
+		channel     string
+		wantTimeout bool
+	}{
+		{"ticker.cmt_btcusdt", true},
+		{"orders", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.channel, func(t *testing.T) {
+			start := time.Now()
+			err := client.SubscribeAwait(context.Background(), tt.channel, noopHandler)
+			elapsed := time.Since(start)
+			if tt.wantTimeout {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("SubscribeAwait() error = %v, want deadline exceeded", err)
+				}
+				if elapsed >= ackDelay {
+					t.Errorf("waited %v, want the short public timeout", elapsed)
+				}
+				// Let the late ack arrive before the next subscription
+				time.Sleep(ackDelay)
+				return
+			}
+			if err != nil {
+				t.Errorf("SubscribeAwait() error = %v, want the private timeout to cover the slow ack", err)
+			}
+		})
+	}
+}
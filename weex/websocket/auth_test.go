@@ -0,0 +1,179 @@
+package websocket
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestHandleLoginResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		frame   string
+		want    AuthStatus
+		wantErr string
+	}{
+		{"success", `{"event":"login","code":"0"}`, AuthSucceeded, ""},
+		{"success without code", `{"event":"login"}`, AuthSucceeded, ""},
+		{"rejected", `{"event":"login","code":"30005","msg":"invalid sign"}`, AuthFailed, "login error [30005]: invalid sign"},
+		{"error event while pending", `{"event":"error","code":"30004","msg":"invalid apiKey"}`, AuthFailed, "login error [30004]: invalid apiKey"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(&weex.Config{Logger: weex.NewNoOpLogger()}, nil, true)
+			result := make(chan error, 1)
+			c.authStatus = AuthPending
+			c.authResult = result
+
+			type event struct {
+				status AuthStatus
+				err    error
+			}
+			events := make(chan event, 1)
+			c.SetOnAuth(func(status AuthStatus, err error) { events <- event{status, err} })
+
+			c.handleMessage([]byte(tt.frame))
+
+			if got := c.GetAuthStatus(); got != tt.want {
+				t.Errorf("GetAuthStatus() = %v, want %v", got, tt.want)
+			}
+			if got := c.IsAuthenticated(); got != (tt.want == AuthSucceeded) {
+				t.Errorf("IsAuthenticated() = %v", got)
+			}
+			checkErr := func(what string, err error) {
+				t.Helper()
+				if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+					t.Errorf("%s error = %v, want %q", what, err, tt.wantErr)
+				}
+			}
+			checkErr("login result", <-result)
+			select {
+			case ev := <-events:
+				if ev.status != tt.want {
+					t.Errorf("OnAuth status = %v, want %v", ev.status, tt.want)
+				}
+				checkErr("OnAuth", ev.err)
+			case <-time.After(time.Second):
+				t.Fatal("OnAuth not called")
+			}
+		})
+	}
+}
+
+func TestHandleLoginIgnoredWhenNotPending(t *testing.T) {
+	c := newClient(&weex.Config{Logger: weex.NewNoOpLogger()}, nil, true)
+	called := make(chan struct{}, 1)
+	c.SetOnAuth(func(AuthStatus, error) { called <- struct{}{} })
+
+	c.handleMessage([]byte(`{"event":"login","code":"0"}`))
+
+	if got := c.GetAuthStatus(); got != AuthNone {
+		t.Errorf("GetAuthStatus() = %v, want %v", got, AuthNone)
+	}
+	select {
+	case <-called:
+		t.Error("OnAuth called for an unsolicited login response")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestConnectLogin(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   []string
+		want    AuthStatus
+		wantErr string
+	}{
+		{"accepted", []string{`{"event":"login","code":"0"}`}, AuthSucceeded, ""},
+		{"rejected", []string{`{"event":"login","code":"30005","msg":"invalid sign"}`}, AuthFailed, "invalid sign"},
+		{"no response", nil, AuthPending, "login response not received"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, func(req wstest.Frame) []string {
+				if req.Op == "login" {
+					return tt.reply
+				}
+				return nil
+			})
+			config := testConfig(server)
+			config.WSPrivateURL = config.WSPublicURL
+			config.WSPrivateAckTimeout = 100 * time.Millisecond
+			client := NewPrivateClient(config, weex.NewAuthenticator("test-api-key", "test-secret-key", "test-passphrase"))
+			defer client.Close()
+
+			err := client.Connect(context.Background())
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Connect() error = %v, want %q", err, tt.wantErr)
+			}
+			if got := client.GetAuthStatus(); got != tt.want {
+				t.Errorf("GetAuthStatus() = %v, want %v", got, tt.want)
+			}
+			if frames := server.Frames(); len(frames) == 0 || frames[0].Op != "login" {
+				t.Errorf("first frame = %+v, want login", frames)
+			}
+		})
+	}
+}
+
+func TestReconnectLoginUsesRotatedCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		rotation []string
+	}{
+		{"unchanged", nil},
+		{"rotated once", []string{"key2"}},
+		{"rotated twice", []string{"key2", "key3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, func(req wstest.Frame) []string {
+				if req.Op == "login" {
+					return []string{`{"event":"login","code":"0"}`}
+				}
+				return nil
+			})
+			config := testConfig(server)
+			config.WSPrivateURL = config.WSPublicURL
+			auth := weex.NewAuthenticator("key1", "secret1", "passphrase1")
+			client := NewPrivateClient(config, auth)
+			client.reconnectDelay = 10 * time.Millisecond
+			defer client.Close()
+
+			if err := client.Connect(context.Background()); err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+
+			logins := func() []string {
+				var keys []string
+				for _, frame := range server.Frames() {
+					if frame.Op == "login" {
+						keys = append(keys, frame.Args[0])
+					}
+				}
+				return keys
+			}
+
+			want := []string{"key1"}
+			for _, key := range tt.rotation {
+				auth.SetCredentials(key, "secret-"+key, "passphrase-"+key)
+				want = append(want, key)
+				server.DropConns()
+
+				deadline := time.Now().Add(5 * time.Second)
+				for len(logins()) < len(want) && time.Now().Before(deadline) {
+					time.Sleep(5 * time.Millisecond)
+				}
+			}
+
+			got := logins()
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("login keys = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
 )
 
 const (
@@ -18,6 +20,7 @@ const (
 	DefaultReconnectDelay  = 1 * time.Second
 	DefaultMaxReconnect    = 10
 	DefaultWriteWait       = 10 * time.Second
+	DefaultDialTimeout     = 30 * time.Second
 	DefaultReadBufferSize  = 1024 * 1024
 	DefaultWriteBufferSize = 1024 * 1024
 )
@@ -34,15 +37,28 @@ type Client struct {
 	state     ConnectionState
 	url       string
 	isPrivate bool
+	replaying bool // Offline replay mode: no connection, writes are discarded
 
 	// Subscription management
 	subscriptions *SubscriptionManager
+	acks          *ackRegistry
+
+	// Connection statistics
+	stats      *statsTracker
+	generation atomic.Uint64 // Incremented on every successful connection
+	freshness  atomic.Pointer[FreshnessProbe]
+
+	// Reaction to handler errors (HandlerErrorPolicy)
+	handlerErrorPolicy atomic.Int32
 
 	// Control channels
 	done      chan struct{}
 	reconnect chan struct{}
 	writeChan chan []byte
 
+	// Write queue monitoring
+	writeQueue writeQueueMonitor
+
 	// Reconnection settings
 	reconnectDelay time.Duration
 	maxReconnect   int
@@ -53,10 +69,17 @@ type Client struct {
 	pongWait     time.Duration
 	writeWait    time.Duration
 
+	// Authentication (private channels)
+	authStatus AuthStatus
+	authResult chan error
+
 	// Callbacks
 	onConnect    func()
 	onDisconnect func(error)
 	onError      func(error)
+	onAuth       func(AuthStatus, error)
+	onUnhandled  func([]byte)
+	onRaw        func([]byte)
 }
 
 // NewClient creates a new WebSocket client for public channels
@@ -87,9 +110,11 @@ func newClient(config *weex.Config, auth *weex.Authenticator, isPrivate bool) *C
 		url:            url,
 		isPrivate:      isPrivate,
 		subscriptions:  NewSubscriptionManager(),
+		acks:           newAckRegistry(),
+		stats:          newStatsTracker(),
 		done:           make(chan struct{}),
 		reconnect:      make(chan struct{}, 1),
-		writeChan:      make(chan []byte, 256),
+		writeChan:      make(chan []byte, writeQueueSize(config)),
 		reconnectDelay: DefaultReconnectDelay,
 		maxReconnect:   DefaultMaxReconnect,
 		pingInterval:   DefaultPingInterval,
@@ -106,14 +131,30 @@ func (c *Client) Connect(ctx context.Context) error {
 		return fmt.Errorf("already connected or connecting")
 	}
 	c.setState(StateConnecting)
+
+	// Fresh control channels for this connection; those of a previous
+	// connection were closed when it dropped or was closed
+	c.done = make(chan struct{})
+	c.reconnect = make(chan struct{}, 1)
+	c.writeChan = make(chan []byte, writeQueueSize(c.config))
+	done, writeChan := c.done, c.writeChan
 	c.mu.Unlock()
 
 	c.logger.Info("Connecting to WebSocket: %s", c.url)
 
+	// Bound the dial when the caller did not set a deadline
+	dialTimeout := c.dialTimeout()
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, dialTimeout)
+		defer cancel()
+	}
+
 	// Create WebSocket connection
 	dialer := websocket.Dialer{
-		ReadBufferSize:  DefaultReadBufferSize,
-		WriteBufferSize: DefaultWriteBufferSize,
+		ReadBufferSize:   DefaultReadBufferSize,
+		WriteBufferSize:  DefaultWriteBufferSize,
+		HandshakeTimeout: dialTimeout,
 	}
 
 	conn, _, err := dialer.DialContext(ctx, c.url, nil)
@@ -128,22 +169,24 @@ func (c *Client) Connect(ctx context.Context) error {
 	c.reconnectCount = 0
 	c.mu.Unlock()
 
+	c.generation.Add(1)
+	c.stats.recordConnected(time.Now())
 	c.logger.Info("WebSocket connected successfully")
 
-	// Authenticate for private channels
+	// Start goroutines for read/write/ping bound to this connection
+	go c.readPump(conn, done)
+	go c.writePump(conn, done, writeChan)
+	go c.pingPump(done)
+
+	// Authenticate for private channels and wait for the login response
 	if c.isPrivate && c.auth != nil {
-		if err := c.authenticate(); err != nil {
+		if err := c.authenticate(ctx); err != nil {
 			c.Close()
 			return fmt.Errorf("authentication failed: %w", err)
 		}
 		c.logger.Info("WebSocket authenticated successfully")
 	}
 
-	// Start goroutines for read/write/ping
-	go c.readPump()
-	go c.writePump()
-	go c.pingPump()
-
 	// Trigger onConnect callback
 	if c.onConnect != nil {
 		go c.onConnect()
@@ -162,22 +205,30 @@ func (c *Client) Close() error {
 	}
 
 	c.logger.Info("Closing WebSocket connection")
+	c.stats.recordClosed()
 
 	close(c.done)
 
 	if c.conn != nil {
-		// Send close message
-		c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		// Send close message; WriteControl is safe alongside writePump
+		c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(c.writeWait))
 		c.conn.Close()
 		c.conn = nil
 	}
 
+	c.replaying = false
 	c.setState(StateDisconnected)
 	return nil
 }
 
 // Subscribe subscribes to a channel with a message handler
 func (c *Client) Subscribe(channel string, handler MessageHandler) error {
+	return c.SubscribeContext(context.Background(), channel, handler)
+}
+
+// SubscribeContext subscribes to a channel with a message handler
+// It returns ctx.Err() if ctx is done before the frame is queued for sending.
+func (c *Client) SubscribeContext(ctx context.Context, channel string, handler MessageHandler) error {
 	c.mu.RLock()
 	if c.state != StateConnected {
 		c.mu.RUnlock()
@@ -200,8 +251,11 @@ func (c *Client) Subscribe(channel string, handler MessageHandler) error {
 		return fmt.Errorf("failed to marshal subscribe request: %w", err)
 	}
 
-	if err := c.write(data); err != nil {
+	if err := c.writeContext(ctx, data); err != nil {
 		c.subscriptions.Remove(channel)
+		if err == ctx.Err() {
+			return err
+		}
 		return fmt.Errorf("failed to send subscribe request: %w", err)
 	}
 
@@ -209,8 +263,73 @@ func (c *Client) Subscribe(channel string, handler MessageHandler) error {
 	return nil
 }
 
+// SubscribeMany subscribes to several channels
+// Channels are sent in frames of at most Config.WSMaxArgsPerFrame args. If a
+// frame cannot be sent, handlers for it and all later frames are removed; channels
+// in frames already sent stay subscribed.
+func (c *Client) SubscribeMany(subs []Subscription) error {
+	c.mu.RLock()
+	if c.state != StateConnected {
+		c.mu.RUnlock()
+		return fmt.Errorf("not connected")
+	}
+	c.mu.RUnlock()
+
+	channels := make([]string, len(subs))
+	for i, sub := range subs {
+		c.subscriptions.Add(sub.Channel, sub.Handler)
+		channels[i] = sub.Channel
+	}
+
+	sent := 0
+	for _, chunk := range chunkArgs(channels, c.maxArgsPerFrame()) {
+		if err := c.writeFrame("subscribe", chunk); err != nil {
+			for _, channel := range channels[sent:] {
+				c.subscriptions.Remove(channel)
+			}
+			return fmt.Errorf("subscribed %d of %d channels: %w", sent, len(channels), err)
+		}
+		sent += len(chunk)
+	}
+
+	c.logger.Info("Subscribed to %d channels", len(channels))
+	return nil
+}
+
+// UnsubscribeMany unsubscribes from several channels
+// Channels are sent in frames of at most Config.WSMaxArgsPerFrame args
+func (c *Client) UnsubscribeMany(channels []string) error {
+	c.mu.RLock()
+	if c.state != StateConnected {
+		c.mu.RUnlock()
+		return fmt.Errorf("not connected")
+	}
+	c.mu.RUnlock()
+
+	for _, channel := range channels {
+		c.subscriptions.Remove(channel)
+	}
+
+	sent := 0
+	for _, chunk := range chunkArgs(channels, c.maxArgsPerFrame()) {
+		if err := c.writeFrame("unsubscribe", chunk); err != nil {
+			return fmt.Errorf("unsubscribed %d of %d channels: %w", sent, len(channels), err)
+		}
+		sent += len(chunk)
+	}
+
+	c.logger.Info("Unsubscribed from %d channels", len(channels))
+	return nil
+}
+
 // Unsubscribe unsubscribes from a channel
 func (c *Client) Unsubscribe(channel string) error {
+	return c.UnsubscribeContext(context.Background(), channel)
+}
+
+// UnsubscribeContext unsubscribes from a channel
+// It returns ctx.Err() if ctx is done before the frame is queued for sending.
+func (c *Client) UnsubscribeContext(ctx context.Context, channel string) error {
 	c.mu.RLock()
 	if c.state != StateConnected {
 		c.mu.RUnlock()
@@ -232,7 +351,10 @@ func (c *Client) Unsubscribe(channel string) error {
 		return fmt.Errorf("failed to marshal unsubscribe request: %w", err)
 	}
 
-	if err := c.write(data); err != nil {
+	if err := c.writeContext(ctx, data); err != nil {
+		if err == ctx.Err() {
+			return err
+		}
 		return fmt.Errorf("failed to send unsubscribe request: %w", err)
 	}
 
@@ -240,57 +362,137 @@ func (c *Client) Unsubscribe(channel string) error {
 	return nil
 }
 
-// authenticate sends authentication message for private channels
-func (c *Client) authenticate() error {
+// authenticate sends the login message for private channels and waits for the login response
+func (c *Client) authenticate(ctx context.Context) error {
 	timestamp := time.Now().Unix()
 	path := "/users/self/verify"
-	sign := c.auth.SignWebSocket(timestamp, "GET", path, "")
-
 	req := AuthRequest{
 		Op:   "login",
-		Args: []string{c.auth.GetAPIKey(), c.auth.GetPassphrase(), fmt.Sprintf("%d", timestamp), sign},
+		Args: c.auth.WebSocketLoginArgs(timestamp, path),
 	}
+	c.logger.Debug("WebSocket login: %v", c.loggableLoginArgs(req.Args))
 
 	data, err := json.Marshal(req)
 	if err != nil {
 		return fmt.Errorf("failed to marshal auth request: %w", err)
 	}
 
-	return c.write(data)
+	result := make(chan error, 1)
+	c.mu.Lock()
+	c.authStatus = AuthPending
+	c.authResult = result
+	c.mu.Unlock()
+
+	if err := c.write(data); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(c.AckTimeout("login"))
+	defer timer.Stop()
+
+	select {
+	case err := <-result:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("login response not received within %v", c.AckTimeout("login"))
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// loggableLoginArgs returns login args for debug logging with the API key, passphrase and signature masked
+// Args are logged verbatim only if Config.LogSecrets is set.
+func (c *Client) loggableLoginArgs(args []string) []string {
+	if c.config.LogSecrets {
+		return args
+	}
+	masked := make([]string, len(args))
+	for i, arg := range args {
+		if i == 2 {
+			masked[i] = arg // timestamp
+			continue
+		}
+		masked[i] = types.MaskSecret(arg)
+	}
+	return masked
+}
+
+// handleLogin records the outcome of a login attempt and notifies listeners
+func (c *Client) handleLogin(err error) {
+	c.mu.Lock()
+	if c.authStatus != AuthPending {
+		c.mu.Unlock()
+		return
+	}
+	if err != nil {
+		c.authStatus = AuthFailed
+	} else {
+		c.authStatus = AuthSucceeded
+	}
+	status := c.authStatus
+	result := c.authResult
+	c.authResult = nil
+	c.mu.Unlock()
+
+	if err != nil {
+		c.logger.Error("WebSocket login failed: %v", err)
+	}
+	if result != nil {
+		result <- err
+	}
+	if c.onAuth != nil {
+		go c.onAuth(status, err)
+	}
 }
 
 // write sends data to the WebSocket connection
 func (c *Client) write(data []byte) error {
+	return c.writeContext(context.Background(), data)
+}
+
+// writeContext sends data to the WebSocket connection, giving up when ctx is done
+func (c *Client) writeContext(ctx context.Context, data []byte) error {
+	c.mu.RLock()
+	done, writeChan, replaying := c.done, c.writeChan, c.replaying
+	c.mu.RUnlock()
+
+	if replaying {
+		return nil
+	}
+
 	select {
-	case c.writeChan <- data:
+	case writeChan <- data:
+		c.writeQueue.observe(len(writeChan), cap(writeChan))
 		return nil
-	case <-c.done:
+	case <-done:
 		return fmt.Errorf("connection closed")
+	case <-ctx.Done():
+		return ctx.Err()
 	case <-time.After(c.writeWait):
-		return fmt.Errorf("write timeout")
+		return fmt.Errorf("write timeout: write queue full (%d/%d)", len(writeChan), cap(writeChan))
 	}
 }
 
 // readPump reads messages from the WebSocket connection
-func (c *Client) readPump() {
+func (c *Client) readPump(conn *websocket.Conn, done chan struct{}) {
 	defer func() {
-		c.handleDisconnect(nil)
+		c.handleDisconnect(conn, nil)
 	}()
 
-	c.conn.SetReadDeadline(time.Now().Add(c.pongWait))
-	c.conn.SetPongHandler(func(string) error {
-		c.conn.SetReadDeadline(time.Now().Add(c.pongWait))
+	conn.SetReadDeadline(time.Now().Add(c.pongWait))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(c.pongWait))
 		return nil
 	})
 
 	for {
 		select {
-		case <-c.done:
+		case <-done:
 			return
 		default:
 		}
 
-		_, message, err := c.conn.ReadMessage()
+		message, err := readMessage(conn, c.pongWait)
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
 				c.logger.Error("WebSocket read error: %v", err)
@@ -298,23 +500,27 @@ func (c *Client) readPump() {
 			return
 		}
 
+		if c.onRaw != nil {
+			c.onRaw(message)
+		}
 		c.handleMessage(message)
 	}
 }
 
 // writePump writes messages to the WebSocket connection
-func (c *Client) writePump() {
+func (c *Client) writePump(conn *websocket.Conn, done chan struct{}, writeChan chan []byte) {
 	defer func() {
-		c.handleDisconnect(nil)
+		c.handleDisconnect(conn, nil)
 	}()
 
 	for {
 		select {
-		case <-c.done:
+		case <-done:
 			return
-		case message := <-c.writeChan:
-			c.conn.SetWriteDeadline(time.Now().Add(c.writeWait))
-			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+		case message := <-writeChan:
+			c.writeQueue.observe(len(writeChan), cap(writeChan))
+			conn.SetWriteDeadline(time.Now().Add(c.writeWait))
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
 				c.logger.Error("WebSocket write error: %v", err)
 				return
 			}
@@ -323,13 +529,13 @@ func (c *Client) writePump() {
 }
 
 // pingPump sends periodic ping messages
-func (c *Client) pingPump() {
+func (c *Client) pingPump(done chan struct{}) {
 	ticker := time.NewTicker(c.pingInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-c.done:
+		case <-done:
 			return
 		case <-ticker.C:
 			ping := PingMessage{Op: "ping"}
@@ -348,6 +554,7 @@ func (c *Client) handleMessage(message []byte) {
 	var base BaseMessage
 	if err := json.Unmarshal(message, &base); err != nil {
 		c.logger.Error("Failed to parse WebSocket message: %v", err)
+		c.unhandled(message)
 		return
 	}
 
@@ -356,20 +563,45 @@ func (c *Client) handleMessage(message []byte) {
 		return
 	}
 
+	// Handle login response
+	if base.Event == "login" {
+		if base.Code != "" && base.Code != "0" {
+			c.handleLogin(fmt.Errorf("login error [%s]: %s", base.Code, base.Message))
+		} else {
+			c.handleLogin(nil)
+		}
+		return
+	}
+
 	// Handle subscription response
 	if base.Event == "subscribe" || base.Event == "unsubscribe" {
+		var subErr error
 		if base.Code != "" && base.Code != "0" {
+			subErr = &SubscriptionError{Channel: base.Channel, Code: base.Code, Message: base.Message}
 			c.logger.Error("Subscription error: code=%s, msg=%s", base.Code, base.Message)
 			if c.onError != nil {
 				go c.onError(fmt.Errorf("subscription error: %s", base.Message))
 			}
 		}
+		if base.Event == "subscribe" {
+			c.acks.resolve(base.Channel, subErr)
+		}
 		return
 	}
 
 	// Handle error
 	if base.Event == "error" {
 		c.logger.Error("WebSocket error: code=%s, msg=%s", base.Code, base.Message)
+		if c.GetAuthStatus() == AuthPending {
+			c.handleLogin(fmt.Errorf("login error [%s]: %s", base.Code, base.Message))
+			return
+		}
+		ackErr := &SubscriptionError{Channel: base.Channel, Code: base.Code, Message: base.Message}
+		if base.Channel != "" {
+			c.acks.resolve(base.Channel, ackErr)
+		} else {
+			c.acks.resolveSole(ackErr)
+		}
 		if c.onError != nil {
 			go c.onError(fmt.Errorf("websocket error: %s", base.Message))
 		}
@@ -378,18 +610,35 @@ func (c *Client) handleMessage(message []byte) {
 
 	// Route to subscription handler
 	if base.Channel != "" {
+		c.stats.recordMessage(base.Channel)
+		if probe := c.freshness.Load(); probe != nil {
+			probe.Observe(base.Channel, message, time.Now())
+		}
 		if sub, exists := c.subscriptions.Get(base.Channel); exists {
 			if err := sub.Handler(message); err != nil {
-				c.logger.Error("Handler error for channel %s: %v", base.Channel, err)
+				c.handleHandlerError(base.Channel, err)
 			}
+			return
 		}
 	}
+
+	c.unhandled(message)
+}
+
+// unhandled reports a message that could not be routed to a handler
+func (c *Client) unhandled(message []byte) {
+	c.logger.Debug("Unhandled WebSocket message: %s", message)
+	if c.onUnhandled != nil {
+		go c.onUnhandled(message)
+	}
 }
 
 // handleDisconnect handles connection disconnection and triggers reconnection
-func (c *Client) handleDisconnect(err error) {
+// conn is the connection whose pump stopped; calls for a connection that has
+// already been replaced or closed are ignored
+func (c *Client) handleDisconnect(conn *websocket.Conn, err error) {
 	c.mu.Lock()
-	if c.state == StateDisconnected {
+	if c.state == StateDisconnected || c.conn != conn {
 		c.mu.Unlock()
 		return
 	}
@@ -397,12 +646,16 @@ func (c *Client) handleDisconnect(err error) {
 	oldState := c.state
 	c.setState(StateDisconnected)
 
+	// Stop the remaining pumps of this connection
+	close(c.done)
+
 	if c.conn != nil {
 		c.conn.Close()
 		c.conn = nil
 	}
 	c.mu.Unlock()
 
+	c.stats.recordDisconnected(time.Now())
 	c.logger.Warn("WebSocket disconnected")
 
 	// Trigger onDisconnect callback
@@ -439,7 +692,7 @@ func (c *Client) attemptReconnect() {
 	c.logger.Info("Reconnecting in %v (attempt %d/%d)", delay, count, c.maxReconnect)
 	time.Sleep(delay)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), c.dialTimeout())
 	defer cancel()
 
 	if err := c.Connect(ctx); err != nil {
@@ -453,6 +706,12 @@ func (c *Client) attemptReconnect() {
 }
 
 // resubscribe resubscribes to all channels after reconnection
+//
+// Channels are re-sent in frames of at most Config.WSMaxArgsPerFrame args, one
+// frame at a time, and each frame's acks are awaited for the longest
+// resubscribeTimeout of its channels. Channels that are rejected or not acked
+// are logged and reported through the onError callback; their handlers are
+// kept so a later Subscribe for the same channel replaces them.
 func (c *Client) resubscribe() {
 	channels := c.subscriptions.GetChannels()
 	if len(channels) == 0 {
@@ -461,20 +720,44 @@ func (c *Client) resubscribe() {
 
 	c.logger.Info("Resubscribing to %d channels", len(channels))
 
-	req := SubscribeRequest{
-		Op:   "subscribe",
-		Args: channels,
+	for _, chunk := range chunkArgs(channels, c.maxArgsPerFrame()) {
+		var timeout time.Duration
+		for _, channel := range chunk {
+			timeout = max(timeout, c.resubscribeTimeout(channel))
+		}
+
+		failed, err := c.sendChunk(context.Background(), chunk, timeout)
+		if err != nil {
+			failed = make(map[string]error, len(chunk))
+			for _, channel := range chunk {
+				failed[channel] = err
+			}
+		}
+		for _, channel := range chunk {
+			if err, ok := failed[channel]; ok {
+				c.logger.Error("Failed to resubscribe to %s: %v", channel, err)
+				if c.onError != nil {
+					go c.onError(fmt.Errorf("resubscribe %s: %w", channel, err))
+				}
+			}
+		}
 	}
+}
 
-	data, err := json.Marshal(req)
-	if err != nil {
-		c.logger.Error("Failed to marshal resubscribe request: %v", err)
-		return
+// resubscribeTimeout returns the configured resubscribe ack timeout or AckTimeout(channel)
+func (c *Client) resubscribeTimeout(channel string) time.Duration {
+	if c.config.WSResubscribeTimeout > 0 {
+		return c.config.WSResubscribeTimeout
 	}
+	return c.AckTimeout(channel)
+}
 
-	if err := c.write(data); err != nil {
-		c.logger.Error("Failed to send resubscribe request: %v", err)
+// dialTimeout returns the configured dial timeout or the default
+func (c *Client) dialTimeout() time.Duration {
+	if c.config.WSDialTimeout > 0 {
+		return c.config.WSDialTimeout
 	}
+	return DefaultDialTimeout
 }
 
 // setState sets the connection state
@@ -497,6 +780,23 @@ func (c *Client) IsConnected() bool {
 	return c.state == StateConnected
 }
 
+// GetAuthStatus returns the outcome of the most recent login attempt
+func (c *Client) GetAuthStatus() AuthStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.authStatus
+}
+
+// IsAuthenticated returns true if the most recent login succeeded
+func (c *Client) IsAuthenticated() bool {
+	return c.GetAuthStatus() == AuthSucceeded
+}
+
+// SetOnAuth sets the callback for login results (AuthSucceeded or AuthFailed)
+func (c *Client) SetOnAuth(callback func(status AuthStatus, err error)) {
+	c.onAuth = callback
+}
+
 // SetOnConnect sets the callback for connection events
 func (c *Client) SetOnConnect(callback func()) {
 	c.onConnect = callback
@@ -512,7 +812,26 @@ func (c *Client) SetOnError(callback func(error)) {
 	c.onError = callback
 }
 
+// SetOnUnhandled sets the callback for messages that cannot be routed
+// It receives the raw frame for messages that fail to parse, name no known
+// event, or target a channel without a subscription (e.g. a late message after
+// unsubscribing or a channel the SDK does not model).
+func (c *Client) SetOnUnhandled(callback func(message []byte)) {
+	c.onUnhandled = callback
+}
+
 // GetSubscriptions returns all active subscriptions
 func (c *Client) GetSubscriptions() []string {
 	return c.subscriptions.GetChannels()
 }
+
+// Generation returns a counter incremented on every successful connection
+// A change between two messages means a reconnect happened in between
+func (c *Client) Generation() uint64 {
+	return c.generation.Load()
+}
+
+// Stats returns aggregate connection statistics (reconnects, downtime, messages per channel)
+func (c *Client) Stats() Stats {
+	return c.stats.snapshot()
+}
@@ -0,0 +1,29 @@
+package websocket
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+)
+
+func TestLoggableLoginArgs(t *testing.T) {
+	args := []string{"bg_0123456789abcdef", "passphrase", "1700000000000", "c2lnbmF0dXJlLXZhbHVl"}
+	tests := []struct {
+		name   string
+		config *weex.Config
+		want   []string
+	}{
+		{"config literal masks", &weex.Config{}, []string{"bg_0***", "pass***", "1700000000000", "c2ln***"}},
+		{"default config masks", weex.NewDefaultConfig(), []string{"bg_0***", "pass***", "1700000000000", "c2ln***"}},
+		{"log secrets", &weex.Config{LogSecrets: true}, args},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(tt.config)
+			if got := c.loggableLoginArgs(args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package websocket
+
+import (
+	"container/list"
+	"sync"
+)
+
+// DefaultDedupSize is the default number of recent IDs remembered by an IDCache
+const DefaultDedupSize = 10000
+
+// IDCache is a bounded LRU set of recently seen IDs
+//
+// It is used to suppress trades and fills that are delivered twice across a
+// reconnect boundary (e.g. once from a backfill and once from the live feed).
+type IDCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+// NewIDCache creates a new IDCache holding up to capacity IDs
+// A capacity <= 0 uses DefaultDedupSize
+func NewIDCache(capacity int) *IDCache {
+	if capacity <= 0 {
+		capacity = DefaultDedupSize
+	}
+	return &IDCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Seen records id and returns true if it was already present
+// Empty IDs are never considered duplicates
+func (c *IDCache) Seen(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[id]; ok {
+		c.order.MoveToFront(elem)
+		return true
+	}
+
+	c.items[id] = c.order.PushFront(id)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(string))
+	}
+	return false
+}
+
+// Len returns the number of remembered IDs
+func (c *IDCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
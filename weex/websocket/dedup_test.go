@@ -0,0 +1,35 @@
+package websocket
+
+import "testing"
+
+func TestIDCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		ids      []string
+		want     []bool // Seen result per id
+		wantLen  int
+	}{
+		{"duplicates", 10, []string{"1", "2", "1", "3", "2"}, []bool{false, false, true, false, true}, 3},
+		{"empty ids never seen", 10, []string{"", "", "1"}, []bool{false, false, false}, 1},
+		{"oldest evicted", 2, []string{"1", "2", "3", "1"}, []bool{false, false, false, false}, 2},
+		{"recent use kept", 2, []string{"1", "2", "1", "3", "1", "2"}, []bool{false, false, true, false, true, false}, 2},
+		{"default capacity", 0, []string{"1", "1"}, []bool{false, true}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewIDCache(tt.capacity)
+			for i, id := range tt.ids {
+				if got := cache.Seen(id); got != tt.want[i] {
+					t.Errorf("Seen(%q) #%d = %v, want %v", id, i, got, tt.want[i])
+				}
+			}
+			if got := cache.Len(); got != tt.wantLen {
+				t.Errorf("Len() = %d, want %d", got, tt.wantLen)
+			}
+		})
+	}
+	if got := NewIDCache(-1).capacity; got != DefaultDedupSize {
+		t.Errorf("capacity for -1 = %d, want %d", got, DefaultDedupSize)
+	}
+}
@@ -0,0 +1,106 @@
+package websocket
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+)
+
+func TestDialTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{"configured", 5 * time.Second, 5 * time.Second},
+		{"zero falls back to default", 0, DefaultDialTimeout},
+		{"negative falls back to default", -time.Second, DefaultDialTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(&weex.Config{WSDialTimeout: tt.timeout})
+			if got := c.dialTimeout(); got != tt.want {
+				t.Errorf("dialTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// silentListener accepts TCP connections but never answers the WebSocket
+// handshake, so a dial only ends when its timeout fires
+func silentListener(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	var (
+		mu    sync.Mutex
+		conns []net.Conn
+	)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+		}
+	}()
+	t.Cleanup(func() {
+		ln.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	})
+	return "ws://" + ln.Addr().String()
+}
+
+func TestConnectDialTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		dialTimeout time.Duration
+		ctxTimeout  time.Duration
+		want        time.Duration
+	}{
+		{"configured timeout without ctx deadline", 100 * time.Millisecond, 0, 100 * time.Millisecond},
+		{"ctx deadline shorter than configured", 10 * time.Second, 100 * time.Millisecond, 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := weex.NewDefaultConfig().
+				WithWSDialTimeout(tt.dialTimeout)
+			config.WSPublicURL = silentListener(t)
+			c := NewClient(config)
+
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			err := c.Connect(ctx)
+			elapsed := time.Since(start)
+			if err == nil {
+				c.Close()
+				t.Fatal("Connect succeeded against a silent listener")
+			}
+			if elapsed < tt.want || elapsed > tt.want+2*time.Second {
+				t.Errorf("Connect returned after %v, want about %v", elapsed, tt.want)
+			}
+			if got := c.GetState(); got != StateDisconnected {
+				t.Errorf("state = %v, want %v", got, StateDisconnected)
+			}
+		})
+	}
+}
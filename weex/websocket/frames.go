@@ -0,0 +1,147 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultMaxArgsPerFrame is the default maximum number of channels sent in one subscribe/unsubscribe frame
+const DefaultMaxArgsPerFrame = 20
+
+// maxArgsPerFrame returns the configured per-frame arg limit or the default
+func (c *Client) maxArgsPerFrame() int {
+	if c.config.WSMaxArgsPerFrame > 0 {
+		return c.config.WSMaxArgsPerFrame
+	}
+	return DefaultMaxArgsPerFrame
+}
+
+// chunkArgs splits args into consecutive chunks of at most size elements
+func chunkArgs(args []string, size int) [][]string {
+	var chunks [][]string
+	for size > 0 && len(args) > size {
+		chunks = append(chunks, args[:size:size])
+		args = args[size:]
+	}
+	if len(args) > 0 {
+		chunks = append(chunks, args)
+	}
+	return chunks
+}
+
+// writeFrame marshals and queues a subscribe or unsubscribe frame
+func (c *Client) writeFrame(op string, args []string) error {
+	data, err := json.Marshal(SubscribeRequest{Op: op, Args: args})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", op, err)
+	}
+	if err := c.write(data); err != nil {
+		return fmt.Errorf("failed to send %s request: %w", op, err)
+	}
+	return nil
+}
+
+// SubscribeManyAwait subscribes to several channels and waits for each channel's ack
+//
+// Channels are sent in frames of at most Config.WSMaxArgsPerFrame args. Each
+// frame's acks are awaited, bounded by ctx and the longest AckTimeout of the
+// frame's channels, before the next frame is sent. Channels that are rejected
+// or not acked have their handlers removed, and a best-effort unsubscribe is
+// sent for the ones not acked; their errors are joined and returned.
+func (c *Client) SubscribeManyAwait(ctx context.Context, subs []Subscription) error {
+	if !c.IsConnected() {
+		return fmt.Errorf("not connected")
+	}
+
+	handlers := make(map[string]MessageHandler, len(subs))
+	channels := make([]string, len(subs))
+	for i, sub := range subs {
+		handlers[sub.Channel] = sub.Handler
+		channels[i] = sub.Channel
+	}
+
+	var errs []error
+	chunks := chunkArgs(channels, c.maxArgsPerFrame())
+	for i, chunk := range chunks {
+		if err := c.subscribeChunk(ctx, chunk, handlers); err != nil {
+			errs = append(errs, err)
+		}
+		if err := ctx.Err(); err != nil && i < len(chunks)-1 {
+			for _, rest := range chunks[i+1:] {
+				errs = append(errs, fmt.Errorf("subscribe %d channels not sent: %w", len(rest), err))
+			}
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// subscribeChunk sends one subscribe frame and waits for the ack of every channel in it
+func (c *Client) subscribeChunk(ctx context.Context, chunk []string, handlers map[string]MessageHandler) error {
+	var timeout time.Duration
+	for _, channel := range chunk {
+		c.subscriptions.Add(channel, handlers[channel])
+		timeout = max(timeout, c.AckTimeout(channel))
+	}
+
+	failed, err := c.sendChunk(ctx, chunk, timeout)
+	if err != nil {
+		for _, channel := range chunk {
+			c.subscriptions.Remove(channel)
+		}
+		return err
+	}
+
+	var errs []error
+	var unacked []string
+	for _, channel := range chunk {
+		if err, ok := failed[channel]; ok {
+			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				unacked = append(unacked, channel)
+			} else {
+				c.subscriptions.Remove(channel)
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(unacked) > 0 {
+		c.dropUnacked(unacked)
+	}
+	return errors.Join(errs...)
+}
+
+// sendChunk sends one subscribe frame and waits up to timeout for the ack of every channel in it
+// It returns the error of each channel that was rejected or not acked, or the
+// error sending the frame.
+func (c *Client) sendChunk(ctx context.Context, chunk []string, timeout time.Duration) (map[string]error, error) {
+	waiters := make(map[string]chan error, len(chunk))
+	for _, channel := range chunk {
+		waiters[channel] = c.acks.register(channel)
+	}
+	defer func() {
+		for channel, waiter := range waiters {
+			c.acks.remove(channel, waiter)
+		}
+	}()
+
+	if err := c.writeFrame("subscribe", chunk); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	failed := make(map[string]error)
+	for _, channel := range chunk {
+		acked, err := waitAck(ctx, waiters[channel])
+		if !acked {
+			failed[channel] = fmt.Errorf("subscribe ack for %s not received: %w", channel, ctx.Err())
+		} else if err != nil {
+			failed[channel] = fmt.Errorf("subscribe %s: %w", channel, err)
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,142 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestChunkArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		size     int
+		expected [][]string
+	}{
+		{"empty", nil, 2, nil},
+		{"smaller than size", []string{"a"}, 2, [][]string{{"a"}}},
+		{"exact multiple", []string{"a", "b", "c", "d"}, 2, [][]string{{"a", "b"}, {"c", "d"}}},
+		{"remainder", []string{"a", "b", "c"}, 2, [][]string{{"a", "b"}, {"c"}}},
+		{"no limit", []string{"a", "b", "c"}, 0, [][]string{{"a", "b", "c"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chunkArgs(tt.args, tt.size); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("chunkArgs() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWaitAck(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	rejected := errors.New("rejected")
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		result    *error
+		wantAcked bool
+		wantErr   error
+	}{
+		{"acked", context.Background(), new(error), true, nil},
+		{"rejected", context.Background(), &rejected, true, rejected},
+		{"acked before deadline wins over done ctx", canceled, new(error), true, nil},
+		{"no ack and done ctx", canceled, nil, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waiter := make(chan error, 1)
+			if tt.result != nil {
+				waiter <- *tt.result
+			}
+			// Repeat to rule out a lucky select choice
+			for i := 0; i < 100 && tt.result != nil; i++ {
+				acked, err := waitAck(tt.ctx, waiter)
+				if acked != tt.wantAcked || err != tt.wantErr {
+					t.Fatalf("waitAck() = %v, %v, want %v, %v", acked, err, tt.wantAcked, tt.wantErr)
+				}
+				waiter <- *tt.result
+			}
+			if tt.result == nil {
+				if acked, _ := waitAck(tt.ctx, waiter); acked {
+					t.Error("waitAck() acked without a result")
+				}
+			}
+		})
+	}
+}
+
+func TestSubscribeManyAwaitSharedDeadline(t *testing.T) {
+	// The server acks a and c but never b, so the shared deadline expires
+	// while b is awaited; a and c must still count as subscribed
+	server := newTestServer(t, func(req wstest.Frame) []string {
+		var out []string
+		for _, channel := range req.Args {
+			if channel != "ticker.b" {
+				out = append(out, wstest.Ack(channel))
+			}
+		}
+		return out
+	})
+	client := connectTestClient(t, server, func(config *weex.Config) {
+		config.WSAckTimeout = 100 * time.Millisecond
+	})
+
+	subs := []Subscription{
+		{Channel: "ticker.a", Handler: noopHandler},
+		{Channel: "ticker.b", Handler: noopHandler},
+		{Channel: "ticker.c", Handler: noopHandler},
+	}
+	err := client.SubscribeManyAwait(context.Background(), subs)
+	if err == nil {
+		t.Fatal("SubscribeManyAwait() expected error for ticker.b")
+	}
+
+	got := client.GetSubscriptions()
+	slices.Sort(got)
+	if want := []string{"ticker.a", "ticker.c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("subscriptions = %v, want %v (error: %v)", got, want, err)
+	}
+	if got, want := unsubscribedChannels(server, 1), []string{"ticker.b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unsubscribed = %v, want %v", got, want)
+	}
+}
+
+func TestSubscribeManyAwaitChunksFrames(t *testing.T) {
+	server := newTestServer(t, func(req wstest.Frame) []string {
+		out := make([]string, len(req.Args))
+		for i, channel := range req.Args {
+			out[i] = wstest.Ack(channel)
+		}
+		return out
+	})
+	client := connectTestClient(t, server, func(config *weex.Config) {
+		config.WSMaxArgsPerFrame = 2
+	})
+
+	subs := make([]Subscription, 5)
+	for i := range subs {
+		subs[i] = Subscription{Channel: "ticker." + string(rune('a'+i)), Handler: noopHandler}
+	}
+	if err := client.SubscribeManyAwait(context.Background(), subs); err != nil {
+		t.Fatalf("SubscribeManyAwait() error = %v", err)
+	}
+
+	var sizes []int
+	for _, frame := range server.Frames() {
+		sizes = append(sizes, len(frame.Args))
+	}
+	if want := []int{2, 2, 1}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("frame sizes = %v, want %v", sizes, want)
+	}
+}
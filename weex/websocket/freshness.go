@@ -0,0 +1,162 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ChannelFreshness reports how far behind the server a channel's data is
+type ChannelFreshness struct {
+	Channel      string
+	LastReceived time.Time     // Local time the last message arrived
+	LastDataTime time.Time     // Latest server timestamp carried by the channel's data
+	Lag          time.Duration // Server time now minus LastDataTime, as of the last check
+}
+
+// FreshnessProbe measures data lag per channel from the server timestamps in each message
+//
+// A feed can keep delivering messages while falling behind, which idle
+// detection does not catch. The probe compares the newest "timestamp" in each
+// channel's data against the current time (adjusted by SetClockOffset) and
+// reports channels whose lag exceeds maxLag. Channels whose data has no
+// timestamp (e.g. candlesticks) are not tracked.
+//
+// Example:
+//
+//	probe := websocket.NewFreshnessProbe(2*time.Second, 5*time.Second)
+//	probe.SetOnLag(func(f websocket.ChannelFreshness) { log.Printf("%s lagging by %v", f.Channel, f.Lag) })
+//	client.SetFreshnessProbe(probe)
+//	go probe.Run(ctx)
+type FreshnessProbe struct {
+	maxLag   time.Duration
+	interval time.Duration
+
+	mu          sync.Mutex
+	channels    map[string]*ChannelFreshness
+	clockOffset time.Duration
+	onLag       func(ChannelFreshness)
+}
+
+// NewFreshnessProbe creates a FreshnessProbe that checks every interval for lag above maxLag
+func NewFreshnessProbe(maxLag, interval time.Duration) *FreshnessProbe {
+	return &FreshnessProbe{
+		maxLag:   maxLag,
+		interval: interval,
+		channels: make(map[string]*ChannelFreshness),
+	}
+}
+
+// SetClockOffset sets the server clock minus the local clock (e.g. from the server time endpoint)
+func (p *FreshnessProbe) SetClockOffset(offset time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clockOffset = offset
+}
+
+// SetOnLag sets the callback called by Check for each channel lagging more than maxLag
+func (p *FreshnessProbe) SetOnLag(callback func(ChannelFreshness)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onLag = callback
+}
+
+// Observe records a data message received on channel at now
+func (p *FreshnessProbe) Observe(channel string, message []byte, now time.Time) {
+	var payload struct {
+		Data []struct {
+			Timestamp int64 `json:"timestamp"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(message, &payload); err != nil {
+		return
+	}
+	var latest int64
+	for _, item := range payload.Data {
+		if item.Timestamp > latest {
+			latest = item.Timestamp
+		}
+	}
+	if latest == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.channels[channel]
+	if !ok {
+		entry = &ChannelFreshness{Channel: channel}
+		p.channels[channel] = entry
+	}
+	entry.LastReceived = now
+	if dataTime := time.UnixMilli(latest); dataTime.After(entry.LastDataTime) {
+		entry.LastDataTime = dataTime
+	}
+	entry.Lag = now.Add(p.clockOffset).Sub(entry.LastDataTime)
+}
+
+// Forget stops tracking channel, e.g. after unsubscribing
+func (p *FreshnessProbe) Forget(channel string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.channels, channel)
+}
+
+// Check updates the lag of every channel as of now and returns the channels lagging more than maxLag
+// The SetOnLag callback is called for each of them.
+func (p *FreshnessProbe) Check(now time.Time) []ChannelFreshness {
+	p.mu.Lock()
+	var lagging []ChannelFreshness
+	for _, entry := range p.channels {
+		entry.Lag = now.Add(p.clockOffset).Sub(entry.LastDataTime)
+		if entry.Lag > p.maxLag {
+			lagging = append(lagging, *entry)
+		}
+	}
+	onLag := p.onLag
+	p.mu.Unlock()
+
+	sort.Slice(lagging, func(i, j int) bool { return lagging[i].Channel < lagging[j].Channel })
+	if onLag != nil {
+		for _, f := range lagging {
+			onLag(f)
+		}
+	}
+	return lagging
+}
+
+// Snapshot returns the freshness of every tracked channel, sorted by channel
+func (p *FreshnessProbe) Snapshot() []ChannelFreshness {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	snapshot := make([]ChannelFreshness, 0, len(p.channels))
+	for _, entry := range p.channels {
+		snapshot = append(snapshot, *entry)
+	}
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].Channel < snapshot[j].Channel })
+	return snapshot
+}
+
+// Run calls Check every interval until ctx is done
+func (p *FreshnessProbe) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			p.Check(now)
+		}
+	}
+}
+
+// SetFreshnessProbe feeds every channel data message to probe (nil to stop)
+func (c *Client) SetFreshnessProbe(probe *FreshnessProbe) {
+	c.freshness.Store(probe)
+}
@@ -0,0 +1,185 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+)
+
+func tickerFrame(channel string, timestamps ...int64) []byte {
+	data := ""
+	for i, ts := range timestamps {
+		if i > 0 {
+			data += ","
+		}
+		data += fmt.Sprintf(`{"last":"1","timestamp":%d}`, ts)
+	}
+	return []byte(fmt.Sprintf(`{"channel":%q,"data":[%s]}`, channel, data))
+}
+
+func TestFreshnessProbeCheck(t *testing.T) {
+	now := time.UnixMilli(1716604853000)
+	ms := func(d time.Duration) int64 { return now.Add(-d).UnixMilli() }
+
+	tests := []struct {
+		name     string
+		messages map[string][]byte
+		offset   time.Duration
+		wantLag  map[string]time.Duration
+		lagging  []string
+	}{
+		{"fresh data", map[string][]byte{"ticker.a": tickerFrame("ticker.a", ms(500*time.Millisecond))},
+			0, map[string]time.Duration{"ticker.a": 500 * time.Millisecond}, nil},
+		{"stale but present data", map[string][]byte{
+			"ticker.a": tickerFrame("ticker.a", ms(time.Second)),
+			"ticker.b": tickerFrame("ticker.b", ms(5*time.Second)),
+			"ticker.c": tickerFrame("ticker.c", ms(3*time.Second)),
+		}, 0, map[string]time.Duration{"ticker.a": time.Second, "ticker.b": 5 * time.Second, "ticker.c": 3 * time.Second}, []string{"ticker.b", "ticker.c"}},
+		{"newest timestamp in batch wins", map[string][]byte{"ticker.a": tickerFrame("ticker.a", ms(9*time.Second), ms(time.Second), ms(4*time.Second))},
+			0, map[string]time.Duration{"ticker.a": time.Second}, nil},
+		{"server clock ahead", map[string][]byte{"ticker.a": tickerFrame("ticker.a", ms(time.Second))},
+			2 * time.Second, map[string]time.Duration{"ticker.a": 3 * time.Second}, []string{"ticker.a"}},
+		{"server clock behind", map[string][]byte{"ticker.a": tickerFrame("ticker.a", ms(3*time.Second))},
+			-2 * time.Second, map[string]time.Duration{"ticker.a": time.Second}, nil},
+		{"no timestamp not tracked", map[string][]byte{
+			"kline.a":  []byte(`{"channel":"kline.a","data":[["1716604853000","1","2","0.5","1.5","10"]]}`),
+			"ticker.a": []byte(`{"channel":"ticker.a","data":[{"last":"1"}]}`),
+		}, 0, map[string]time.Duration{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := NewFreshnessProbe(2*time.Second, time.Second)
+			probe.SetClockOffset(tt.offset)
+			var called []string
+			probe.SetOnLag(func(f ChannelFreshness) { called = append(called, f.Channel) })
+
+			for channel, message := range tt.messages {
+				probe.Observe(channel, message, now.Add(-100*time.Millisecond))
+			}
+			lagging := probe.Check(now)
+
+			var got []string
+			for _, f := range lagging {
+				got = append(got, f.Channel)
+				if f.Lag <= 2*time.Second {
+					t.Errorf("%s reported lagging with Lag = %v", f.Channel, f.Lag)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.lagging) {
+				t.Errorf("Check() lagging = %v, want %v", got, tt.lagging)
+			}
+			if fmt.Sprint(called) != fmt.Sprint(tt.lagging) {
+				t.Errorf("OnLag called for %v, want %v", called, tt.lagging)
+			}
+
+			snapshot := probe.Snapshot()
+			if len(snapshot) != len(tt.wantLag) {
+				t.Fatalf("Snapshot() = %+v, want %d channels", snapshot, len(tt.wantLag))
+			}
+			for _, f := range snapshot {
+				if want, ok := tt.wantLag[f.Channel]; !ok || f.Lag != want {
+					t.Errorf("%s Lag = %v, want %v", f.Channel, f.Lag, want)
+				}
+				if !f.LastReceived.Equal(now.Add(-100 * time.Millisecond)) {
+					t.Errorf("%s LastReceived = %v, want %v", f.Channel, f.LastReceived, now.Add(-100*time.Millisecond))
+				}
+			}
+		})
+	}
+}
+
+func TestFreshnessProbeLagGrowsWithoutNewData(t *testing.T) {
+	now := time.UnixMilli(1716604853000)
+	probe := NewFreshnessProbe(2*time.Second, time.Second)
+	probe.Observe("ticker.a", tickerFrame("ticker.a", now.UnixMilli()), now)
+
+	// An older message arriving later does not move the data time backwards
+	probe.Observe("ticker.a", tickerFrame("ticker.a", now.Add(-10*time.Second).UnixMilli()), now.Add(time.Second))
+
+	tests := []struct {
+		at      time.Duration
+		lagging bool
+	}{
+		{time.Second, false},
+		{2 * time.Second, false},
+		{2*time.Second + time.Millisecond, true},
+		{time.Minute, true},
+	}
+	for _, tt := range tests {
+		lagging := probe.Check(now.Add(tt.at))
+		if got := len(lagging) == 1; got != tt.lagging {
+			t.Errorf("Check(+%v) lagging = %v, want %v", tt.at, lagging, tt.lagging)
+		}
+		if lag := probe.Snapshot()[0].Lag; lag != tt.at {
+			t.Errorf("Check(+%v) Lag = %v, want %v", tt.at, lag, tt.at)
+		}
+	}
+
+	probe.Forget("ticker.a")
+	if snapshot := probe.Snapshot(); len(snapshot) != 0 {
+		t.Errorf("Snapshot() after Forget = %+v, want empty", snapshot)
+	}
+}
+
+func TestFreshnessProbeRun(t *testing.T) {
+	probe := NewFreshnessProbe(time.Second, 10*time.Millisecond)
+	stale := time.Now().Add(-time.Minute).UnixMilli()
+	probe.Observe("ticker.a", tickerFrame("ticker.a", stale), time.Now())
+
+	fired := make(chan ChannelFreshness, 1)
+	probe.SetOnLag(func(f ChannelFreshness) {
+		select {
+		case fired <- f:
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- probe.Run(ctx) }()
+
+	select {
+	case f := <-fired:
+		if f.Channel != "ticker.a" || f.Lag < time.Minute {
+			t.Errorf("OnLag(%+v), want ticker.a lagging at least 1m", f)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnLag not called")
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestClientFeedsFreshnessProbe(t *testing.T) {
+	config := weex.NewDefaultConfig()
+	config.Logger = weex.NewNoOpLogger()
+	client := NewClient(config)
+	defer client.Close()
+
+	stale := time.Now().Add(-time.Minute).UnixMilli()
+	client.handleMessage(tickerFrame("ticker.a", stale))
+	probe := NewFreshnessProbe(time.Second, time.Second)
+	if snapshot := probe.Snapshot(); len(snapshot) != 0 {
+		t.Fatalf("Snapshot() = %+v before SetFreshnessProbe, want empty", snapshot)
+	}
+
+	client.SetFreshnessProbe(probe)
+	client.handleMessage(tickerFrame("ticker.a", stale))
+	client.handleMessage([]byte(`{"event":"subscribe","channel":"ticker.b"}`))
+	if lagging := probe.Check(time.Now()); len(lagging) != 1 || lagging[0].Channel != "ticker.a" {
+		t.Errorf("Check() = %+v, want ticker.a lagging", lagging)
+	}
+
+	client.SetFreshnessProbe(nil)
+	client.handleMessage(tickerFrame("ticker.c", stale))
+	if snapshot := probe.Snapshot(); len(snapshot) != 1 {
+		t.Errorf("Snapshot() = %+v after SetFreshnessProbe(nil), want only ticker.a", snapshot)
+	}
+}
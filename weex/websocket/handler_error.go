@@ -0,0 +1,92 @@
+package websocket
+
+import "fmt"
+
+// HandlerErrorPolicy is the client's reaction when a subscription handler returns an error
+type HandlerErrorPolicy int32
+
+const (
+	HandlerErrorContinue    HandlerErrorPolicy = iota // Log the error and keep delivering messages (default)
+	HandlerErrorUnsubscribe                           // Unsubscribe the channel whose handler failed
+	HandlerErrorReconnect                             // Drop the connection and reconnect, resubscribing all channels
+)
+
+// String returns the string representation of HandlerErrorPolicy
+func (p HandlerErrorPolicy) String() string {
+	switch p {
+	case HandlerErrorContinue:
+		return "CONTINUE"
+	case HandlerErrorUnsubscribe:
+		return "UNSUBSCRIBE"
+	case HandlerErrorReconnect:
+		return "RECONNECT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// HandlerError is reported through the onError callback when a handler error triggers the policy
+type HandlerError struct {
+	Channel string
+	Policy  HandlerErrorPolicy
+	Err     error
+}
+
+// Error implements the error interface
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("handler error for channel %s (%s): %v", e.Channel, e.Policy, e.Err)
+}
+
+// Unwrap returns the handler's error
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// SetHandlerErrorPolicy sets the reaction to a handler returning an error
+// With HandlerErrorUnsubscribe or HandlerErrorReconnect the error is also
+// reported as a *HandlerError through the onError callback.
+func (c *Client) SetHandlerErrorPolicy(policy HandlerErrorPolicy) {
+	c.handlerErrorPolicy.Store(int32(policy))
+}
+
+// HandlerErrorPolicy returns the current handler error policy
+func (c *Client) HandlerErrorPolicy() HandlerErrorPolicy {
+	return HandlerErrorPolicy(c.handlerErrorPolicy.Load())
+}
+
+// handleHandlerError applies the handler error policy to err returned by channel's handler
+// It runs on the read goroutine. The unsubscribe is sent asynchronously; the
+// reconnect drops the connection before returning so that no further frames
+// already read from it reach the handlers, as when the read pump exits.
+func (c *Client) handleHandlerError(channel string, err error) {
+	c.logger.Error("Handler error for channel %s: %v", channel, err)
+
+	policy := c.HandlerErrorPolicy()
+	if policy == HandlerErrorContinue {
+		return
+	}
+	handlerErr := &HandlerError{Channel: channel, Policy: policy, Err: err}
+	if c.onError != nil {
+		go c.onError(handlerErr)
+	}
+
+	switch policy {
+	case HandlerErrorUnsubscribe:
+		// Remove the handler now so no further messages reach it
+		c.subscriptions.Remove(channel)
+		go func() {
+			if err := c.Unsubscribe(channel); err != nil {
+				c.logger.Error("Failed to unsubscribe %s after handler error: %v", channel, err)
+			}
+		}()
+	case HandlerErrorReconnect:
+		c.mu.RLock()
+		conn, replaying := c.conn, c.replaying
+		c.mu.RUnlock()
+		if replaying || conn == nil {
+			c.logger.Warn("Ignoring reconnect policy for %s: no live connection", channel)
+			return
+		}
+		c.handleDisconnect(conn, handlerErr)
+	}
+}
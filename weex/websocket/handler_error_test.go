@@ -0,0 +1,159 @@
+package websocket
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestHandlerErrorPolicy(t *testing.T) {
+	errHandler := errors.New("bad message")
+
+	tests := []struct {
+		name          string
+		policy        HandlerErrorPolicy
+		wantCalls     int  // handler calls on the first connection
+		wantUnsub     bool // unsubscribe frame sent
+		wantReconnect bool
+		wantReported  bool // HandlerError passed to onError
+	}{
+		{"continue", HandlerErrorContinue, 3, false, false, false},
+		{"unsubscribe", HandlerErrorUnsubscribe, 1, true, false, true},
+		{"reconnect", HandlerErrorReconnect, 1, false, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every subscribe is acked and followed by three pushes
+			server := newTestServer(t, func(req wstest.Frame) []string {
+				var out []string
+				for _, channel := range req.Args {
+					out = append(out, wstest.Ack(channel))
+					for i := 0; i < 3; i++ {
+						out = append(out, `{"channel":"`+channel+`","data":[{"last":"1"}]}`)
+					}
+				}
+				return out
+			})
+
+			var reported []*HandlerError
+			var mu sync.Mutex
+			client := connectTestClient(t, server, nil)
+			client.reconnectDelay = 10 * time.Millisecond
+			client.SetOnError(func(err error) {
+				var handlerErr *HandlerError
+				if errors.As(err, &handlerErr) {
+					mu.Lock()
+					reported = append(reported, handlerErr)
+					mu.Unlock()
+				}
+			})
+			client.SetHandlerErrorPolicy(tt.policy)
+			if got := client.HandlerErrorPolicy(); got != tt.policy {
+				t.Fatalf("HandlerErrorPolicy() = %v, want %v", got, tt.policy)
+			}
+
+			// The handler fails on the first connection only, so a reconnect is not repeated
+			var calls atomic.Int32
+			if err := client.Subscribe("ticker.a", func([]byte) error {
+				if client.Generation() > 1 {
+					return nil
+				}
+				calls.Add(1)
+				return errHandler
+			}); err != nil {
+				t.Fatalf("Subscribe() error = %v", err)
+			}
+
+			deadline := time.Now().Add(5 * time.Second)
+			done := func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case tt.wantReconnect:
+					return client.Generation() == 2 && len(reported) > 0
+				case tt.wantUnsub:
+					return len(server.FramesWithOp("unsubscribe")) > 0 && len(reported) > 0
+				default:
+					return calls.Load() == 3
+				}
+			}
+			for !done() && time.Now().Before(deadline) {
+				time.Sleep(5 * time.Millisecond)
+			}
+			time.Sleep(50 * time.Millisecond)
+
+			if got := int(calls.Load()); got != tt.wantCalls {
+				t.Errorf("handler calls = %d, want %d", got, tt.wantCalls)
+			}
+			if got := len(server.FramesWithOp("unsubscribe")) > 0; got != tt.wantUnsub {
+				t.Errorf("unsubscribe sent = %v, want %v", got, tt.wantUnsub)
+			}
+			if got := server.Conns() > 1; got != tt.wantReconnect {
+				t.Errorf("reconnected = %v (conns %d), want %v", got, server.Conns(), tt.wantReconnect)
+			}
+			subscribed := len(client.GetSubscriptions()) == 1
+			if subscribed == tt.wantUnsub {
+				t.Errorf("subscriptions = %v after policy %v", client.GetSubscriptions(), tt.policy)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if (len(reported) > 0) != tt.wantReported {
+				t.Fatalf("reported %v, want reported = %v", reported, tt.wantReported)
+			}
+			if tt.wantReported {
+				got := reported[0]
+				if got.Channel != "ticker.a" || got.Policy != tt.policy || !errors.Is(got, errHandler) {
+					t.Errorf("HandlerError = %+v, want ticker.a, %v wrapping %v", got, tt.policy, errHandler)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerErrorReconnectDuringReplay(t *testing.T) {
+	config := weex.NewDefaultConfig()
+	config.Logger = weex.NewNoOpLogger()
+	client := NewClient(config)
+	defer client.Close()
+	if err := client.StartReplay(); err != nil {
+		t.Fatalf("StartReplay() error = %v", err)
+	}
+	client.SetHandlerErrorPolicy(HandlerErrorReconnect)
+
+	var calls int
+	if err := client.Subscribe("ticker.a", func([]byte) error {
+		calls++
+		return errors.New("bad message")
+	}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		client.ReplayMessage([]byte(`{"channel":"ticker.a","data":[{"last":"1"}]}`))
+	}
+	if calls != 2 || !client.IsConnected() {
+		t.Errorf("calls = %d, connected = %v, want replay to continue", calls, client.IsConnected())
+	}
+}
+
+func TestHandlerErrorPolicyString(t *testing.T) {
+	tests := []struct {
+		policy HandlerErrorPolicy
+		want   string
+	}{
+		{HandlerErrorContinue, "CONTINUE"},
+		{HandlerErrorUnsubscribe, "UNSUBSCRIBE"},
+		{HandlerErrorReconnect, "RECONNECT"},
+		{HandlerErrorPolicy(9), "UNKNOWN"},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
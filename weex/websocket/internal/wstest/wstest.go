@@ -0,0 +1,158 @@
+// Package wstest provides a local WebSocket server for testing the websocket packages
+package wstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Frame is an op frame sent by a client (login, subscribe or unsubscribe)
+type Frame struct {
+	Op   string   `json:"op"`
+	Args []string `json:"args"`
+}
+
+// Server is a local WebSocket server recording the frames clients send
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	frames []Frame
+	conns  int
+	open   []*websocket.Conn
+}
+
+// NewServer starts a server calling reply for every frame with an op
+// reply returns the raw messages to send back on the same connection; it may be nil.
+// The server is closed when the test ends.
+func NewServer(t testing.TB, reply func(frame Frame) []string) *Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	s := &Server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s.mu.Lock()
+		s.conns++
+		s.open = append(s.open, conn)
+		s.mu.Unlock()
+
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var frame Frame
+			if err := json.Unmarshal(message, &frame); err != nil || frame.Op == "" {
+				continue
+			}
+			s.mu.Lock()
+			s.frames = append(s.frames, frame)
+			s.mu.Unlock()
+			if reply == nil {
+				continue
+			}
+			for _, out := range reply(frame) {
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(out)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// WSURL returns the ws:// URL of the server
+func (s *Server) WSURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// Frames returns the frames received so far
+func (s *Server) Frames() []Frame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Frame(nil), s.frames...)
+}
+
+// FramesWithOp returns the frames with the given op received so far
+func (s *Server) FramesWithOp(op string) []Frame {
+	var frames []Frame
+	for _, frame := range s.Frames() {
+		if frame.Op == op {
+			frames = append(frames, frame)
+		}
+	}
+	return frames
+}
+
+// Conns returns the number of connections accepted so far
+func (s *Server) Conns() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+// DropConns closes every connection accepted so far, forcing clients to reconnect
+func (s *Server) DropConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.open {
+		conn.Close()
+	}
+	s.open = nil
+}
+
+// AckSubscribes returns a reply acking every channel of a subscribe frame
+// Each ack is followed by the messages returned by data for the channel; data may be nil.
+// Login frames are accepted; other frames get no reply.
+func AckSubscribes(data func(channel string) []string) func(frame Frame) []string {
+	return func(frame Frame) []string {
+		switch frame.Op {
+		case "login":
+			return []string{`{"event":"login","code":"0"}`}
+		case "subscribe":
+			var out []string
+			for _, channel := range frame.Args {
+				out = append(out, Ack(channel))
+				if data != nil {
+					out = append(out, data(channel)...)
+				}
+			}
+			return out
+		}
+		return nil
+	}
+}
+
+// Ack returns a subscribe ack for channel
+func Ack(channel string) string {
+	return `{"event":"subscribe","channel":"` + channel + `","code":"0"}`
+}
+
+// Error returns a subscribe error for channel
+func Error(channel, code, msg string) string {
+	return `{"event":"error","channel":"` + channel + `","code":"` + code + `","msg":"` + msg + `"}`
+}
+
+// WaitFor polls cond until it returns true or five seconds pass
+func WaitFor(t testing.TB, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,33 @@
+package websocket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// Normalize converts the fill into an exchange-neutral TradeRecord
+func (f *FillItem) Normalize() (types.TradeRecord, error) {
+	side, err := types.ParseOrderSide(f.Side)
+	if err != nil {
+		return types.TradeRecord{}, fmt.Errorf("fill %s: %w", f.FillId, err)
+	}
+	pnl := f.RealizedPnl
+	if pnl == "" {
+		pnl = "0"
+	}
+	return types.TradeRecord{
+		TradeId:     f.FillId,
+		OrderId:     f.OrderId,
+		Symbol:      f.Symbol,
+		Side:        side,
+		Quantity:    f.Size,
+		Price:       f.Price,
+		Fee:         f.Fee,
+		FeeCoin:     f.FeeCoin,
+		RealizedPnl: pnl,
+		Time:        types.Millis(f.Timestamp).Time(),
+		Liquidity:   types.Liquidity(strings.ToUpper(f.Liquidity)),
+	}, nil
+}
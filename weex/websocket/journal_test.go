@@ -0,0 +1,52 @@
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestFillItemNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		fill    string
+		want    types.TradeRecord
+		wantErr bool
+	}{
+		{"closing sell", `{"fillId":"7","orderId":"42","clientOid":"c1","symbol":"cmt_btcusdt","price":"65000.5","size":"0.02","side":"sell","liquidity":"maker","fee":"0.78","feeCoin":"USDT","realizedPnl":"12.5","timestamp":1716604853286}`,
+			types.TradeRecord{TradeId: "7", OrderId: "42", Symbol: "cmt_btcusdt", Side: types.OrderSideSell, Quantity: "0.02", Price: "65000.5",
+				Fee: "0.78", FeeCoin: "USDT", RealizedPnl: "12.5", Time: time.UnixMilli(1716604853286), Liquidity: types.LiquidityMaker}, false},
+		{"opening buy without pnl", `{"fillId":"8","orderId":"43","symbol":"cmt_ethusdt","price":"3333.3","size":"3","side":"BUY","liquidity":"taker","fee":"-0.5","feeCoin":"USDT","timestamp":1716604853286}`,
+			types.TradeRecord{TradeId: "8", OrderId: "43", Symbol: "cmt_ethusdt", Side: types.OrderSideBuy, Quantity: "3", Price: "3333.3",
+				Fee: "-0.5", FeeCoin: "USDT", RealizedPnl: "0", Time: time.UnixMilli(1716604853286), Liquidity: types.LiquidityTaker}, false},
+		{"liquidity not reported", `{"fillId":"9","side":"buy","price":"1","size":"1","timestamp":1716604853286}`,
+			types.TradeRecord{TradeId: "9", Side: types.OrderSideBuy, Quantity: "1", Price: "1", RealizedPnl: "0",
+				Time: time.UnixMilli(1716604853286), Liquidity: types.LiquidityUnknown}, false},
+		{"unknown side", `{"fillId":"10","side":"long"}`, types.TradeRecord{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fill FillItem
+			if err := json.Unmarshal([]byte(tt.fill), &fill); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			got, err := fill.Normalize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.Time.Equal(tt.want.Time) {
+				t.Errorf("Time = %v, want %v", got.Time, tt.want.Time)
+			}
+			got.Time = tt.want.Time
+			if got != tt.want {
+				t.Errorf("Normalize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package private
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestFillDedupAcrossReconnect(t *testing.T) {
+	tests := []struct {
+		name   string
+		enable bool
+		want   []string
+	}{
+		{"enabled", true, []string{"1", "2", "3", "4"}},
+		{"disabled", false, []string{"1", "2", "3", "2", "3", "3", "4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The feed replays the last fills on every (re)subscribe
+			var subscribes atomic.Int32
+			server := newTestServer(t, func(channel string) []string {
+				if subscribes.Add(1) == 1 {
+					return []string{`{"channel":"fill","data":[{"fillId":"1"},{"fillId":"2"},{"fillId":"3"}]}`}
+				}
+				return []string{
+					`{"channel":"fill","data":[{"fillId":"2"},{"fillId":"3"}]}`,
+					`{"channel":"fill","data":[{"fillId":"3"},{"fillId":"4"}]}`,
+				}
+			})
+			client := connectTestClient(t, server)
+			if tt.enable {
+				client.EnableFillDedup(100)
+			}
+
+			var mu sync.Mutex
+			var fills []string
+			get := func() []string {
+				mu.Lock()
+				defer mu.Unlock()
+				return append([]string(nil), fills...)
+			}
+			err := client.SubscribeFills(func(msg *websocket.FillData) error {
+				mu.Lock()
+				defer mu.Unlock()
+				for _, item := range msg.Data {
+					fills = append(fills, item.FillId)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("SubscribeFills() error = %v", err)
+			}
+			wstest.WaitFor(t, "initial fills", func() bool { return len(get()) >= 3 })
+
+			server.DropConns()
+			wstest.WaitFor(t, "replayed fills", func() bool { return len(get()) >= len(tt.want) })
+			if got := get(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fills = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package private
+
+import (
+	"sync"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+)
+
+// OrderTransition is a single state change observed for an order
+type OrderTransition struct {
+	State      types.OrderStatus // Order state after the update
+	FilledSize types.Decimal     // Cumulative filled size after the update
+	UpdateTime int64             // Exchange update time (milliseconds)
+}
+
+// Time returns the transition time
+func (t OrderTransition) Time() time.Time {
+	return time.UnixMilli(t.UpdateTime)
+}
+
+// OrderLifecycle is the accumulated history of an order from the orders channel
+type OrderLifecycle struct {
+	OrderId     string              // Order ID
+	ClientOid   string              // Client order ID
+	Symbol      string              // Symbol
+	CreateTime  int64               // Exchange create time (milliseconds)
+	Transitions []OrderTransition   // State changes in arrival order
+	FinalState  types.OrderStatus   // Last observed state
+	Completed   bool                // True once the order is filled or canceled
+	Last        websocket.OrderItem // Most recent update
+}
+
+// Duration returns the time from creation to the last transition
+func (l *OrderLifecycle) Duration() time.Duration {
+	if len(l.Transitions) == 0 || l.CreateTime == 0 {
+		return 0
+	}
+	last := l.Transitions[len(l.Transitions)-1].UpdateTime
+	return time.Duration(last-l.CreateTime) * time.Millisecond
+}
+
+// LifecycleCallback is called with the record of an order that reached a final state
+type LifecycleCallback func(lifecycle OrderLifecycle)
+
+// OrderLifecycleTracker reconstructs order lifecycles from orders channel updates
+//
+// Updates are accumulated per orderId. A transition is recorded whenever the
+// state or filled size changes, so partial fills appear as separate steps.
+// When an order is filled or canceled the completed record is passed to the
+// callback and dropped from the tracker.
+//
+// Example:
+//
+//	tracker := private.NewOrderLifecycleTracker(func(l private.OrderLifecycle) {
+//	    fmt.Printf("%s %s in %v\n", l.OrderId, l.FinalState, l.Duration())
+//	})
+//	client.SubscribeOrdersEach(tracker.Update)
+type OrderLifecycleTracker struct {
+	mu         sync.Mutex
+	orders     map[string]*OrderLifecycle
+	onComplete LifecycleCallback
+}
+
+// NewOrderLifecycleTracker creates a new OrderLifecycleTracker
+func NewOrderLifecycleTracker(onComplete LifecycleCallback) *OrderLifecycleTracker {
+	return &OrderLifecycleTracker{
+		orders:     make(map[string]*OrderLifecycle),
+		onComplete: onComplete,
+	}
+}
+
+// Update records an order update and emits the lifecycle if the order completed
+// It matches OrderItemCallback so it can be passed to SubscribeOrdersEach
+func (t *OrderLifecycleTracker) Update(item *websocket.OrderItem) error {
+	if item.OrderId == "" {
+		return nil
+	}
+
+	t.mu.Lock()
+	lifecycle, ok := t.orders[item.OrderId]
+	if !ok {
+		lifecycle = &OrderLifecycle{
+			OrderId:    item.OrderId,
+			ClientOid:  item.ClientOid,
+			Symbol:     item.Symbol,
+			CreateTime: item.CreateTime,
+		}
+		t.orders[item.OrderId] = lifecycle
+	}
+
+	state := item.StatusEnum()
+	if n := len(lifecycle.Transitions); n == 0 ||
+		lifecycle.Transitions[n-1].State != state ||
+		!sameSize(lifecycle.Transitions[n-1].FilledSize, item.FilledSize) {
+		lifecycle.Transitions = append(lifecycle.Transitions, OrderTransition{
+			State:      state,
+			FilledSize: item.FilledSize,
+			UpdateTime: item.UpdateTime,
+		})
+	}
+	lifecycle.FinalState = state
+	lifecycle.Last = *item
+	if lifecycle.CreateTime == 0 {
+		lifecycle.CreateTime = item.CreateTime
+	}
+
+	if state != types.OrderStatusFilled && state != types.OrderStatusCanceled {
+		t.mu.Unlock()
+		return nil
+	}
+
+	lifecycle.Completed = true
+	delete(t.orders, item.OrderId)
+	callback := t.onComplete
+	t.mu.Unlock()
+
+	if callback != nil {
+		callback(*lifecycle)
+	}
+	return nil
+}
+
+// sameSize reports whether a and b are the same quantity, e.g. "0.5" and "0.50"
+// Unparseable sizes are compared as strings
+func sameSize(a, b types.Decimal) bool {
+	if cmp, err := a.CmpErr(b); err == nil {
+		return cmp == 0
+	}
+	return a == b
+}
+
+// Get returns the in-progress lifecycle for orderId
+func (t *OrderLifecycleTracker) Get(orderId string) (OrderLifecycle, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lifecycle, ok := t.orders[orderId]
+	if !ok {
+		return OrderLifecycle{}, false
+	}
+	result := *lifecycle
+	result.Transitions = append([]OrderTransition(nil), lifecycle.Transitions...)
+	return result, true
+}
+
+// Active returns the number of orders that have not yet reached a final state
+func (t *OrderLifecycleTracker) Active() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.orders)
+}
+
+// Reset discards all in-progress lifecycles
+func (t *OrderLifecycleTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.orders = make(map[string]*OrderLifecycle)
+}
@@ -0,0 +1,120 @@
+package private
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+)
+
+func TestOrderLifecycleTracker(t *testing.T) {
+	order := func(state int, filled types.Decimal, updated int64) websocket.OrderItem {
+		return websocket.OrderItem{OrderId: "1", ClientOid: "c1", Symbol: "cmt_btcusdt", State: state, FilledSize: filled, CreateTime: 1000, UpdateTime: updated}
+	}
+	tests := []struct {
+		name      string
+		updates   []websocket.OrderItem
+		want      []OrderTransition
+		final     types.OrderStatus
+		completed bool
+	}{
+		{"partially filled then filled", []websocket.OrderItem{
+			order(0, "0", 1000),
+			order(1, "0.3", 1500),
+			order(1, "0.6", 1800),
+			order(2, "1", 2500),
+		}, []OrderTransition{
+			{types.OrderStatusPending, "0", 1000},
+			{types.OrderStatusPartial, "0.3", 1500},
+			{types.OrderStatusPartial, "0.6", 1800},
+			{types.OrderStatusFilled, "1", 2500},
+		}, types.OrderStatusFilled, true},
+		{"canceled after a partial fill", []websocket.OrderItem{
+			order(0, "0", 1000),
+			order(1, "0.3", 1200),
+			order(3, "0.3", 1300),
+			order(4, "0.3", 1400),
+		}, []OrderTransition{
+			{types.OrderStatusPending, "0", 1000},
+			{types.OrderStatusPartial, "0.3", 1200},
+			{types.OrderStatusCanceling, "0.3", 1300},
+			{types.OrderStatusCanceled, "0.3", 1400},
+		}, types.OrderStatusCanceled, true},
+		{"repeated updates collapse", []websocket.OrderItem{
+			order(0, "0", 1000),
+			order(0, "0.0", 1100),
+			order(1, "0.5", 1200),
+			order(1, "0.50", 1300),
+		}, []OrderTransition{
+			{types.OrderStatusPending, "0", 1000},
+			{types.OrderStatusPartial, "0.5", 1200},
+		}, types.OrderStatusPartial, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var completed []OrderLifecycle
+			tracker := NewOrderLifecycleTracker(func(l OrderLifecycle) { completed = append(completed, l) })
+			for i := range tt.updates {
+				tracker.Update(&tt.updates[i])
+			}
+
+			var lifecycle OrderLifecycle
+			if tt.completed {
+				if len(completed) != 1 {
+					t.Fatalf("completed records = %d, want 1", len(completed))
+				}
+				lifecycle = completed[0]
+				if _, ok := tracker.Get("1"); ok || tracker.Active() != 0 {
+					t.Error("completed order still tracked")
+				}
+			} else {
+				if len(completed) != 0 {
+					t.Fatalf("completed records = %d, want 0", len(completed))
+				}
+				var ok bool
+				if lifecycle, ok = tracker.Get("1"); !ok || tracker.Active() != 1 {
+					t.Fatal("in-progress order not tracked")
+				}
+			}
+
+			if !reflect.DeepEqual(lifecycle.Transitions, tt.want) {
+				t.Errorf("Transitions = %+v, want %+v", lifecycle.Transitions, tt.want)
+			}
+			if lifecycle.FinalState != tt.final || lifecycle.Completed != tt.completed {
+				t.Errorf("FinalState, Completed = %v, %v, want %v, %v", lifecycle.FinalState, lifecycle.Completed, tt.final, tt.completed)
+			}
+			if lifecycle.OrderId != "1" || lifecycle.ClientOid != "c1" || lifecycle.Symbol != "cmt_btcusdt" || lifecycle.CreateTime != 1000 {
+				t.Errorf("identity = %+v", lifecycle)
+			}
+			want := time.Duration(tt.want[len(tt.want)-1].UpdateTime-1000) * time.Millisecond
+			if got := lifecycle.Duration(); got != want {
+				t.Errorf("Duration() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestOrderLifecycleTrackerOrders(t *testing.T) {
+	var completed []string
+	tracker := NewOrderLifecycleTracker(func(l OrderLifecycle) { completed = append(completed, l.OrderId) })
+
+	for _, item := range []websocket.OrderItem{
+		{OrderId: "1", State: 0},
+		{OrderId: "2", State: 0},
+		{OrderId: "", State: 2},
+		{OrderId: "2", State: 2},
+		{OrderId: "3", State: 0},
+	} {
+		tracker.Update(&item)
+	}
+	if !reflect.DeepEqual(completed, []string{"2"}) || tracker.Active() != 2 {
+		t.Errorf("completed = %v, active = %d, want [2], 2", completed, tracker.Active())
+	}
+
+	tracker.Reset()
+	if tracker.Active() != 0 {
+		t.Errorf("Active() after Reset = %d, want 0", tracker.Active())
+	}
+}
@@ -22,9 +22,28 @@ type OrderCallback func(order *websocket.OrderData) error
 // FillCallback is called when fill/execution data is received
 type FillCallback func(fill *websocket.FillData) error
 
+// AccountItemCallback is called once per account balance item received
+type AccountItemCallback func(item *websocket.AccountItem) error
+
+// PositionItemCallback is called once per position item received
+type PositionItemCallback func(item *websocket.PositionItem) error
+
+// OrderItemCallback is called once per order item received
+type OrderItemCallback func(item *websocket.OrderItem) error
+
+// FillItemCallback is called once per fill item received
+type FillItemCallback func(item *websocket.FillItem) error
+
 // Client provides convenient methods for subscribing to private channels
 type Client struct {
 	ws *websocket.Client
+
+	// Gap detection for the order and fill feeds
+	orderTracker *SequenceTracker
+	fillTracker  *SequenceTracker
+
+	// De-duplication of fills across reconnects (nil = disabled)
+	fillDedup *websocket.IDCache
 }
 
 // NewClient creates a new private WebSocket client (requires authentication)
@@ -92,6 +111,15 @@ func (c *Client) SubscribeOrders(callback OrderCallback) error {
 		if err := json.Unmarshal(data, &order); err != nil {
 			return fmt.Errorf("failed to unmarshal order data: %w", err)
 		}
+		if c.orderTracker != nil {
+			var updateTime int64
+			for _, item := range order.Data {
+				if item.UpdateTime > updateTime {
+					updateTime = item.UpdateTime
+				}
+			}
+			c.orderTracker.Observe(c.ws.Generation(), order.Seq, updateTime)
+		}
 		return callback(&order)
 	}
 
@@ -110,12 +138,89 @@ func (c *Client) SubscribeFills(callback FillCallback) error {
 		if err := json.Unmarshal(data, &fill); err != nil {
 			return fmt.Errorf("failed to unmarshal fill data: %w", err)
 		}
+		if c.fillTracker != nil {
+			var timestamp int64
+			for _, item := range fill.Data {
+				if item.Timestamp > timestamp {
+					timestamp = item.Timestamp
+				}
+			}
+			c.fillTracker.Observe(c.ws.Generation(), fill.Seq, timestamp)
+		}
+		if c.fillDedup != nil {
+			fresh := fill.Data[:0:0]
+			for _, item := range fill.Data {
+				if !c.fillDedup.Seen(item.FillId) {
+					fresh = append(fresh, item)
+				}
+			}
+			if len(fresh) == 0 {
+				return nil
+			}
+			fill.Data = fresh
+		}
 		return callback(&fill)
 	}
 
 	return c.ws.Subscribe(channel, handler)
 }
 
+// SubscribeAccountEach subscribes like SubscribeAccount but invokes callback once per item
+//
+// Frames with an empty data slice are skipped; iteration stops at the first callback error
+func (c *Client) SubscribeAccountEach(callback AccountItemCallback) error {
+	return c.SubscribeAccount(func(msg *websocket.AccountData) error {
+		for i := range msg.Data {
+			if err := callback(&msg.Data[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SubscribePositionsEach subscribes like SubscribePositions but invokes callback once per item
+//
+// Frames with an empty data slice are skipped; iteration stops at the first callback error
+func (c *Client) SubscribePositionsEach(callback PositionItemCallback) error {
+	return c.SubscribePositions(func(msg *websocket.PositionData) error {
+		for i := range msg.Data {
+			if err := callback(&msg.Data[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SubscribeOrdersEach subscribes like SubscribeOrders but invokes callback once per item
+//
+// Frames with an empty data slice are skipped; iteration stops at the first callback error
+func (c *Client) SubscribeOrdersEach(callback OrderItemCallback) error {
+	return c.SubscribeOrders(func(msg *websocket.OrderData) error {
+		for i := range msg.Data {
+			if err := callback(&msg.Data[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SubscribeFillsEach subscribes like SubscribeFills but invokes callback once per item
+//
+// Frames with an empty data slice are skipped; iteration stops at the first callback error
+func (c *Client) SubscribeFillsEach(callback FillItemCallback) error {
+	return c.SubscribeFills(func(msg *websocket.FillData) error {
+		for i := range msg.Data {
+			if err := callback(&msg.Data[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // UnsubscribeAccount unsubscribes from account updates
 func (c *Client) UnsubscribeAccount() error {
 	return c.ws.Unsubscribe("account")
@@ -146,6 +251,35 @@ func (c *Client) GetState() websocket.ConnectionState {
 	return c.ws.GetState()
 }
 
+// EnableFillDedup suppresses fills whose fillId was already delivered
+//
+// The most recent size fill IDs are remembered across reconnects, so fills
+// replayed after a reconnect or resync reach the callback only once. Frames
+// left empty after filtering are dropped.
+func (c *Client) EnableFillDedup(size int) {
+	c.fillDedup = websocket.NewIDCache(size)
+}
+
+// SetOnResync enables gap detection on the order and fill feeds
+//
+// callback is invoked when a sequence gap, reconnect, or out-of-order update
+// indicates that local state should be reconciled via REST. Must be called
+// before SubscribeOrders/SubscribeFills.
+func (c *Client) SetOnResync(callback ResyncCallback) {
+	c.orderTracker = NewSequenceTracker("orders", callback)
+	c.fillTracker = NewSequenceTracker("fill", callback)
+}
+
+// IsAuthenticated returns true if the most recent login succeeded
+func (c *Client) IsAuthenticated() bool {
+	return c.ws.IsAuthenticated()
+}
+
+// SetOnAuth sets the callback for login results
+func (c *Client) SetOnAuth(callback func(status websocket.AuthStatus, err error)) {
+	c.ws.SetOnAuth(callback)
+}
+
 // SetOnConnect sets the callback for connection events
 func (c *Client) SetOnConnect(callback func()) {
 	c.ws.SetOnConnect(callback)
@@ -160,3 +294,33 @@ func (c *Client) SetOnDisconnect(callback func(error)) {
 func (c *Client) SetOnError(callback func(error)) {
 	c.ws.SetOnError(callback)
 }
+
+// SetOnUnhandled sets the callback for messages that cannot be routed to a subscription
+func (c *Client) SetOnUnhandled(callback func(message []byte)) {
+	c.ws.SetOnUnhandled(callback)
+}
+
+// Stats returns aggregate connection statistics
+func (c *Client) Stats() websocket.Stats {
+	return c.ws.Stats()
+}
+
+// SetHandlerErrorPolicy sets the reaction to a handler returning an error (see websocket.HandlerErrorPolicy)
+func (c *Client) SetHandlerErrorPolicy(policy websocket.HandlerErrorPolicy) {
+	c.ws.SetHandlerErrorPolicy(policy)
+}
+
+// SetRawTap sets a callback receiving every raw message read from the connection
+func (c *Client) SetRawTap(callback func(message []byte)) {
+	c.ws.SetRawTap(callback)
+}
+
+// StartReplay puts the disconnected client into offline replay mode (see websocket.Replayer)
+func (c *Client) StartReplay() error {
+	return c.ws.StartReplay()
+}
+
+// ReplayMessage routes message to the subscribed handlers as if it had been received
+func (c *Client) ReplayMessage(message []byte) {
+	c.ws.ReplayMessage(message)
+}
@@ -0,0 +1,108 @@
+package private
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+// itemRecorder collects an identifier of every item passed to a per-item callback
+type itemRecorder struct {
+	mu    sync.Mutex
+	items []string
+}
+
+func (r *itemRecorder) add(item string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items = append(r.items, item)
+	return nil
+}
+
+func (r *itemRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.items...)
+}
+
+func TestSubscribeEach(t *testing.T) {
+	tests := []struct {
+		name      string
+		channel   string
+		item      string // JSON of one item with its identifier set to %s
+		subscribe func(c *Client, r *itemRecorder) error
+	}{
+		{"account", "account", `{"coinName":"%s"}`, func(c *Client, r *itemRecorder) error {
+			return c.SubscribeAccountEach(func(item *websocket.AccountItem) error { return r.add(item.CoinName) })
+		}},
+		{"positions", "positions", `{"symbol":"%s"}`, func(c *Client, r *itemRecorder) error {
+			return c.SubscribePositionsEach(func(item *websocket.PositionItem) error { return r.add(item.Symbol) })
+		}},
+		{"orders", "orders", `{"orderId":"%s"}`, func(c *Client, r *itemRecorder) error {
+			return c.SubscribeOrdersEach(func(item *websocket.OrderItem) error { return r.add(item.OrderId) })
+		}},
+		{"fills", "fill", `{"fillId":"%s"}`, func(c *Client, r *itemRecorder) error {
+			return c.SubscribeFillsEach(func(item *websocket.FillItem) error { return r.add(item.FillId) })
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := func(id string) string { return strings.Replace(tt.item, "%s", id, 1) }
+			server := newTestServer(t, func(channel string) []string {
+				return []string{
+					`{"channel":"` + channel + `","data":[]}`,
+					`{"channel":"` + channel + `"}`,
+					`{"channel":"` + channel + `","data":[` + item("a") + `,` + item("b") + `,` + item("c") + `]}`,
+				}
+			})
+			client := connectTestClient(t, server)
+
+			var recorder itemRecorder
+			if err := tt.subscribe(client, &recorder); err != nil {
+				t.Fatalf("subscribe error = %v", err)
+			}
+			wstest.WaitFor(t, "subscribe frame", func() bool { return len(server.FramesWithOp("subscribe")) > 0 })
+			if got := server.FramesWithOp("subscribe")[0].Args; !reflect.DeepEqual(got, []string{tt.channel}) {
+				t.Fatalf("subscribed %v, want %s", got, tt.channel)
+			}
+
+			// Frames are handled in order, so the empty frames were skipped once the items arrive
+			wstest.WaitFor(t, "items", func() bool { return len(recorder.get()) >= 3 })
+			if got, want := recorder.get(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("items = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSubscribeEachStopsAtError(t *testing.T) {
+	server := newTestServer(t, func(channel string) []string {
+		return []string{
+			`{"channel":"orders","data":[{"orderId":"1"},{"orderId":"2"},{"orderId":"3"}]}`,
+			`{"channel":"orders","data":[{"orderId":"4"}]}`,
+		}
+	})
+	client := connectTestClient(t, server)
+
+	var recorder itemRecorder
+	err := client.SubscribeOrdersEach(func(item *websocket.OrderItem) error {
+		recorder.add(item.OrderId)
+		if item.OrderId == "2" {
+			return errors.New("stop")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SubscribeOrdersEach() error = %v", err)
+	}
+
+	wstest.WaitFor(t, "next frame", func() bool { return len(recorder.get()) >= 3 })
+	if got, want := recorder.get(), []string{"1", "2", "4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+}
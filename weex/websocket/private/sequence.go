@@ -0,0 +1,124 @@
+package private
+
+import (
+	"sync"
+	"time"
+)
+
+// ResyncReason describes why a resync of private state is needed
+type ResyncReason int
+
+const (
+	ResyncSequenceGap         ResyncReason = iota // Sequence number skipped one or more values
+	ResyncReconnect                               // Reconnected without sequence numbers; updates may have been missed
+	ResyncTimestampRegression                     // An update arrived older than one already seen
+)
+
+// String returns the string representation of ResyncReason
+func (r ResyncReason) String() string {
+	switch r {
+	case ResyncSequenceGap:
+		return "SEQUENCE_GAP"
+	case ResyncReconnect:
+		return "RECONNECT"
+	case ResyncTimestampRegression:
+		return "TIMESTAMP_REGRESSION"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ResyncEvent signals that local order/fill state should be reconciled via REST
+type ResyncEvent struct {
+	Channel  string       // Channel that detected the gap ("orders" or "fill")
+	Reason   ResyncReason // Why a resync is needed
+	Expected int64        // Expected sequence number or minimum timestamp
+	Received int64        // Received sequence number or timestamp
+	Time     time.Time    // Time the gap was detected
+}
+
+// ResyncCallback is called when a resync is needed
+type ResyncCallback func(event ResyncEvent)
+
+// SequenceTracker detects missed updates on a private feed
+//
+// When the feed carries sequence numbers, any value other than last+1 is a gap.
+// Without sequence numbers, update timestamps are used as a weaker signal: a
+// reconnect always requests a resync and a timestamp older than one already
+// seen indicates out-of-order delivery.
+type SequenceTracker struct {
+	mu         sync.Mutex
+	channel    string
+	lastSeq    int64
+	lastTime   int64
+	generation uint64
+	started    bool
+	onResync   ResyncCallback
+}
+
+// NewSequenceTracker creates a new SequenceTracker for a channel
+func NewSequenceTracker(channel string, onResync ResyncCallback) *SequenceTracker {
+	return &SequenceTracker{
+		channel:  channel,
+		onResync: onResync,
+	}
+}
+
+// Observe records a message and emits a ResyncEvent if a gap is detected
+//
+// Parameters:
+//   - generation: Connection generation the message arrived on (websocket.Client.Generation)
+//   - seq: Feed sequence number (0 if not provided)
+//   - updateTime: Latest update timestamp in the message (Unix milliseconds, 0 if unknown)
+//
+// Returns true if a resync was requested
+func (t *SequenceTracker) Observe(generation uint64, seq, updateTime int64) bool {
+	t.mu.Lock()
+
+	var event *ResyncEvent
+	reconnected := t.started && generation != t.generation
+
+	switch {
+	case !t.started:
+		// First message establishes the baseline
+	case seq > 0 && t.lastSeq > 0:
+		if seq != t.lastSeq+1 {
+			event = &ResyncEvent{Reason: ResyncSequenceGap, Expected: t.lastSeq + 1, Received: seq}
+		}
+	case reconnected:
+		event = &ResyncEvent{Reason: ResyncReconnect, Expected: t.lastTime, Received: updateTime}
+	case updateTime > 0 && updateTime < t.lastTime:
+		event = &ResyncEvent{Reason: ResyncTimestampRegression, Expected: t.lastTime, Received: updateTime}
+	}
+
+	t.started = true
+	t.generation = generation
+	if seq > 0 {
+		t.lastSeq = seq
+	}
+	if updateTime > t.lastTime {
+		t.lastTime = updateTime
+	}
+	callback := t.onResync
+	t.mu.Unlock()
+
+	if event == nil {
+		return false
+	}
+	event.Channel = t.channel
+	event.Time = time.Now()
+	if callback != nil {
+		callback(*event)
+	}
+	return true
+}
+
+// Reset clears the tracker state, e.g. after a REST resync has completed
+func (t *SequenceTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.lastSeq = 0
+	t.lastTime = 0
+	t.started = false
+}
@@ -0,0 +1,132 @@
+package private
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestSequenceTracker(t *testing.T) {
+	type msg struct {
+		generation uint64
+		seq        int64
+		updateTime int64
+	}
+	type resync struct {
+		Reason   ResyncReason
+		Expected int64
+		Received int64
+	}
+	tests := []struct {
+		name string
+		msgs []msg
+		want []resync
+	}{
+		{"contiguous sequence", []msg{{1, 1, 0}, {1, 2, 0}, {1, 3, 0}}, nil},
+		{"sequence gap", []msg{{1, 1, 0}, {1, 2, 0}, {1, 5, 0}, {1, 6, 0}},
+			[]resync{{ResyncSequenceGap, 3, 5}}},
+		{"contiguous across reconnect", []msg{{1, 1, 0}, {2, 2, 0}}, nil},
+		{"gap across reconnect", []msg{{1, 1, 0}, {1, 2, 0}, {2, 9, 0}},
+			[]resync{{ResyncSequenceGap, 3, 9}}},
+		{"repeated sequence", []msg{{1, 4, 0}, {1, 4, 0}},
+			[]resync{{ResyncSequenceGap, 5, 4}}},
+		{"timestamps in order", []msg{{1, 0, 100}, {1, 0, 200}, {1, 0, 200}}, nil},
+		{"timestamp regression", []msg{{1, 0, 100}, {1, 0, 300}, {1, 0, 200}},
+			[]resync{{ResyncTimestampRegression, 300, 200}}},
+		{"reconnect without sequence", []msg{{1, 0, 100}, {2, 0, 400}},
+			[]resync{{ResyncReconnect, 100, 400}}},
+		{"first message after start", []msg{{3, 7, 100}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []resync
+			tracker := NewSequenceTracker("orders", func(e ResyncEvent) {
+				if e.Channel != "orders" || e.Time.IsZero() {
+					t.Errorf("event = %+v, want channel orders with a time", e)
+				}
+				got = append(got, resync{e.Reason, e.Expected, e.Received})
+			})
+			requested := 0
+			for _, m := range tt.msgs {
+				if tracker.Observe(m.generation, m.seq, m.updateTime) {
+					requested++
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resyncs = %+v, want %+v", got, tt.want)
+			}
+			if requested != len(tt.want) {
+				t.Errorf("Observe returned true %d times, want %d", requested, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestSequenceTrackerReset(t *testing.T) {
+	calls := 0
+	tracker := NewSequenceTracker("fill", func(ResyncEvent) { calls++ })
+	tracker.Observe(1, 10, 0)
+	tracker.Reset()
+	if tracker.Observe(1, 50, 0) || calls != 0 {
+		t.Errorf("resync requested after Reset, want the next message to set the baseline")
+	}
+	if !tracker.Observe(1, 52, 0) || calls != 1 {
+		t.Errorf("gap after Reset not detected")
+	}
+}
+
+func TestSetOnResync(t *testing.T) {
+	tests := []struct {
+		name      string
+		frames    []string
+		subscribe func(c *Client) error
+		want      ResyncEvent
+	}{
+		{"orders", []string{
+			`{"channel":"orders","seq":1,"data":[{"orderId":"1"}]}`,
+			`{"channel":"orders","seq":2,"data":[{"orderId":"2"}]}`,
+			`{"channel":"orders","seq":4,"data":[{"orderId":"4"}]}`,
+		}, func(c *Client) error {
+			return c.SubscribeOrders(func(*websocket.OrderData) error { return nil })
+		}, ResyncEvent{Channel: "orders", Reason: ResyncSequenceGap, Expected: 3, Received: 4}},
+		{"fills", []string{
+			`{"channel":"fill","data":[{"fillId":"1","timestamp":2000}]}`,
+			`{"channel":"fill","data":[{"fillId":"2","timestamp":1000}]}`,
+		}, func(c *Client) error {
+			return c.SubscribeFills(func(*websocket.FillData) error { return nil })
+		}, ResyncEvent{Channel: "fill", Reason: ResyncTimestampRegression, Expected: 2000, Received: 1000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, func(string) []string { return tt.frames })
+			client := connectTestClient(t, server)
+
+			var mu sync.Mutex
+			var events []ResyncEvent
+			client.SetOnResync(func(e ResyncEvent) {
+				mu.Lock()
+				defer mu.Unlock()
+				e.Time = time.Time{}
+				events = append(events, e)
+			})
+			if err := tt.subscribe(client); err != nil {
+				t.Fatalf("subscribe error = %v", err)
+			}
+
+			wstest.WaitFor(t, "resync", func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(events) > 0
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if !reflect.DeepEqual(events, []ResyncEvent{tt.want}) {
+				t.Errorf("events = %+v, want %+v", events, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package private
+
+import (
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+// newTestServer starts a server that accepts every login, acks every subscribed
+// channel and then sends the frames returned by reply (which may be nil).
+func newTestServer(t *testing.T, reply func(channel string) []string) *wstest.Server {
+	t.Helper()
+	return wstest.NewServer(t, wstest.AckSubscribes(reply))
+}
+
+// connectTestClient connects an authenticated private client to s
+func connectTestClient(t *testing.T, s *wstest.Server) *Client {
+	t.Helper()
+	config := weex.NewDefaultConfig().
+		WithAPIKey("test-api-key").
+		WithSecretKey("test-secret-key").
+		WithPassphrase("test-passphrase")
+	config.WSPrivateURL = s.WSURL()
+	config.Logger = weex.NewNoOpLogger()
+	auth := weex.NewAuthenticator(config.APIKey, config.SecretKey, config.Passphrase)
+
+	client := NewClient(config, auth)
+	if err := client.Connect(t.Context()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
@@ -0,0 +1,67 @@
+package public
+
+import (
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestTradeDedupAcrossReconnect(t *testing.T) {
+	subscribeTrades := func(c *Client, callback TradesCallback) error {
+		return c.SubscribeTrades("cmt_btcusdt", callback)
+	}
+	subscribeMarket := func(c *Client, callback TradesCallback) error {
+		return c.SubscribeMarket("cmt_btcusdt", MarketSubscription{Trades: callback})
+	}
+	tests := []struct {
+		name      string
+		subscribe func(c *Client, callback TradesCallback) error
+		enable    bool
+		want      []string
+	}{
+		{"enabled", subscribeTrades, true, []string{"1", "2", "3", "4"}},
+		{"disabled", subscribeTrades, false, []string{"1", "2", "3", "2", "3", "3", "4"}},
+		{"enabled through SubscribeMarket", subscribeMarket, true, []string{"1", "2", "3", "4"}},
+		{"disabled through SubscribeMarket", subscribeMarket, false, []string{"1", "2", "3", "2", "3", "3", "4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The feed replays the last trades on every (re)subscribe
+			var subscribes atomic.Int32
+			server := newTestServer(t, func(channel string) []string {
+				if subscribes.Add(1) == 1 {
+					return []string{`{"channel":"` + channel + `","data":[{"tradeId":"1"},{"tradeId":"2"},{"tradeId":"3"}]}`}
+				}
+				return []string{
+					`{"channel":"` + channel + `","data":[{"tradeId":"2"},{"tradeId":"3"}]}`,
+					`{"channel":"` + channel + `","data":[{"tradeId":"3"},{"tradeId":"4"}]}`,
+				}
+			})
+			client := connectTestClient(t, server)
+			if tt.enable {
+				client.EnableTradeDedup(100)
+			}
+
+			var recorder itemRecorder
+			err := tt.subscribe(client, func(msg *websocket.TradesData) error {
+				for _, item := range msg.Data {
+					recorder.add(item.TradeId)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("subscribe error = %v", err)
+			}
+			wstest.WaitFor(t, "initial trades", func() bool { return len(recorder.get()) >= 3 })
+
+			server.DropConns()
+			wstest.WaitFor(t, "replayed trades", func() bool { return len(recorder.get()) >= len(tt.want) })
+			if got := recorder.get(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("trades = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
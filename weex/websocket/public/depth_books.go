@@ -0,0 +1,153 @@
+package public
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+)
+
+// DepthBooks maintains one order book per depth level for a single symbol
+//
+// Each level has its own subscription and its own book, so a tight view
+// (e.g. 5 levels) and a wide view (e.g. 50 levels) can be shown side by side.
+//
+// Example:
+//
+//	books := public.NewDepthBooks(client, "cmt_btcusdt", 5, 50)
+//	if err := books.Subscribe(); err != nil {
+//	    return err
+//	}
+//	...
+//	top, _ := books.Book(5)
+//	wide, _ := books.Book(50)
+type DepthBooks struct {
+	client *Client
+	symbol string
+	levels []int
+
+	mu      sync.RWMutex
+	books   map[int]websocket.DepthItem
+	updated map[int]time.Time
+
+	onUpdate func(level int, book *websocket.DepthItem)
+}
+
+// NewDepthBooks creates a new DepthBooks for symbol at the given depth levels
+func NewDepthBooks(client *Client, symbol string, levels ...int) *DepthBooks {
+	unique := make([]int, 0, len(levels))
+	seen := make(map[int]bool, len(levels))
+	for _, level := range levels {
+		if !seen[level] {
+			seen[level] = true
+			unique = append(unique, level)
+		}
+	}
+	sort.Ints(unique)
+
+	return &DepthBooks{
+		client:  client,
+		symbol:  symbol,
+		levels:  unique,
+		books:   make(map[int]websocket.DepthItem),
+		updated: make(map[int]time.Time),
+	}
+}
+
+// SetOnUpdate sets a callback invoked after the book for a level is updated
+func (b *DepthBooks) SetOnUpdate(callback func(level int, book *websocket.DepthItem)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onUpdate = callback
+}
+
+// Subscribe subscribes to every configured depth level
+// On failure, levels subscribed so far are unsubscribed again
+func (b *DepthBooks) Subscribe() error {
+	for i, level := range b.levels {
+		level := level
+		err := b.client.SubscribeDepthLevel(b.symbol, level, func(msg *websocket.DepthData) error {
+			for j := range msg.Data {
+				b.Update(level, &msg.Data[j])
+			}
+			return nil
+		})
+		if err != nil {
+			for _, subscribed := range b.levels[:i] {
+				_ = b.client.UnsubscribeDepthLevel(b.symbol, subscribed)
+			}
+			return fmt.Errorf("failed to subscribe depth level %d: %w", level, err)
+		}
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from every configured depth level and drops the books
+func (b *DepthBooks) Unsubscribe() error {
+	var firstErr error
+	for _, level := range b.levels {
+		if err := b.client.UnsubscribeDepthLevel(b.symbol, level); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	b.mu.Lock()
+	b.books = make(map[int]websocket.DepthItem)
+	b.updated = make(map[int]time.Time)
+	b.mu.Unlock()
+
+	return firstErr
+}
+
+// Update replaces the book for level with item
+// Items for another symbol, and items older than the stored book, are ignored
+func (b *DepthBooks) Update(level int, item *websocket.DepthItem) {
+	if item.Symbol != "" && item.Symbol != b.symbol {
+		return
+	}
+
+	b.mu.Lock()
+	if current, ok := b.books[level]; ok && item.Timestamp > 0 && item.Timestamp < current.Timestamp {
+		b.mu.Unlock()
+		return
+	}
+	b.books[level] = *item
+	b.updated[level] = time.Now()
+	callback := b.onUpdate
+	b.mu.Unlock()
+
+	if callback != nil {
+		callback(level, item)
+	}
+}
+
+// Book returns the latest book for level
+func (b *DepthBooks) Book(level int) (websocket.DepthItem, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	book, ok := b.books[level]
+	return book, ok
+}
+
+// LastUpdate returns when the book for level was last received (zero if never)
+func (b *DepthBooks) LastUpdate(level int) time.Time {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.updated[level]
+}
+
+// Levels returns the configured depth levels in ascending order
+func (b *DepthBooks) Levels() []int {
+	levels := make([]int, len(b.levels))
+	copy(levels, b.levels)
+	return levels
+}
+
+// Symbol returns the symbol the books are maintained for
+func (b *DepthBooks) Symbol() string {
+	return b.symbol
+}
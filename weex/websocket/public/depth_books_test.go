@@ -0,0 +1,111 @@
+package public
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestNewDepthBooksLevels(t *testing.T) {
+	tests := []struct {
+		levels []int
+		want   []int
+	}{
+		{[]int{50, 5}, []int{5, 50}},
+		{[]int{5, 50, 5}, []int{5, 50}},
+		{nil, []int{}},
+	}
+	for _, tt := range tests {
+		books := NewDepthBooks(nil, "cmt_btcusdt", tt.levels...)
+		if got := books.Levels(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Levels() for %v = %v, want %v", tt.levels, got, tt.want)
+		}
+	}
+}
+
+func TestDepthBooksUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		updates []websocket.DepthItem
+		want    int64 // Timestamp of the stored book
+		wantOk  bool
+	}{
+		{"newer replaces", []websocket.DepthItem{{Symbol: "cmt_btcusdt", Timestamp: 1}, {Symbol: "cmt_btcusdt", Timestamp: 2}}, 2, true},
+		{"older ignored", []websocket.DepthItem{{Symbol: "cmt_btcusdt", Timestamp: 2}, {Symbol: "cmt_btcusdt", Timestamp: 1}}, 2, true},
+		{"other symbol ignored", []websocket.DepthItem{{Symbol: "cmt_ethusdt", Timestamp: 1}}, 0, false},
+		{"missing symbol accepted", []websocket.DepthItem{{Timestamp: 3}}, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			books := NewDepthBooks(nil, "cmt_btcusdt", 5)
+			for i := range tt.updates {
+				books.Update(5, &tt.updates[i])
+			}
+			book, ok := books.Book(5)
+			if ok != tt.wantOk || book.Timestamp != tt.want {
+				t.Errorf("Book(5) = %d, %v, want %d, %v", book.Timestamp, ok, tt.want, tt.wantOk)
+			}
+			if got := !books.LastUpdate(5).IsZero(); got != tt.wantOk {
+				t.Errorf("LastUpdate set = %v, want %v", got, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestDepthBooksSubscribe(t *testing.T) {
+	// Each level's feed carries a distinct best bid
+	server := newTestServer(t, func(channel string) []string {
+		bid := map[string]string{"depth.cmt_btcusdt.5": "100", "depth.cmt_btcusdt.50": "99"}[channel]
+		return []string{`{"channel":"` + channel + `","data":[{"symbol":"cmt_btcusdt","bids":[{"price":"` + bid + `","quantity":"1"}],"timestamp":1}]}`}
+	})
+	client := connectTestClient(t, server)
+	books := NewDepthBooks(client, "cmt_btcusdt", 50, 5)
+
+	var mu sync.Mutex
+	updates := make(map[int]int)
+	books.SetOnUpdate(func(level int, _ *websocket.DepthItem) {
+		mu.Lock()
+		defer mu.Unlock()
+		updates[level]++
+	})
+
+	if err := books.Subscribe(); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	wstest.WaitFor(t, "both books", func() bool {
+		_, ok5 := books.Book(5)
+		_, ok50 := books.Book(50)
+		return ok5 && ok50
+	})
+
+	for level, want := range map[int]types.Decimal{5: "100", 50: "99"} {
+		book, _ := books.Book(level)
+		if len(book.Bids) != 1 || book.Bids[0].Price != want {
+			t.Errorf("Book(%d).Bids = %+v, want best bid %s", level, book.Bids, want)
+		}
+	}
+	mu.Lock()
+	if updates[5] != 1 || updates[50] != 1 {
+		t.Errorf("updates = %v, want one per level", updates)
+	}
+	mu.Unlock()
+
+	var channels []string
+	for _, frame := range server.Frames() {
+		channels = append(channels, frame.Args...)
+	}
+	if want := []string{"depth.cmt_btcusdt.5", "depth.cmt_btcusdt.50"}; !reflect.DeepEqual(channels, want) {
+		t.Errorf("subscribed %v, want %v", channels, want)
+	}
+
+	if err := books.Unsubscribe(); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if _, ok := books.Book(5); ok {
+		t.Error("book kept after Unsubscribe")
+	}
+}
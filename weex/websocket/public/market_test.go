@@ -0,0 +1,96 @@
+package public
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestSubscribeMarket(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     func(r *itemRecorder) MarketSubscription
+		channels []string
+	}{
+		{"all", func(r *itemRecorder) MarketSubscription {
+			return MarketSubscription{
+				Ticker:      func(msg *websocket.TickerData) error { return r.add("ticker:" + msg.Channel) },
+				Depth:       func(msg *websocket.DepthData) error { return r.add("depth:" + msg.Channel) },
+				Trades:      func(msg *websocket.TradesData) error { return r.add("trades:" + msg.Channel) },
+				Candlestick: func(msg *websocket.CandlestickData) error { return r.add("candlestick:" + msg.Channel) },
+			}
+		}, []string{"ticker.cmt_btcusdt", "depth.cmt_btcusdt", "trades.cmt_btcusdt", "candlestick.cmt_btcusdt.1m"}},
+		{"ticker and depth", func(r *itemRecorder) MarketSubscription {
+			return MarketSubscription{
+				Ticker: func(msg *websocket.TickerData) error { return r.add("ticker:" + msg.Channel) },
+				Depth:  func(msg *websocket.DepthData) error { return r.add("depth:" + msg.Channel) },
+			}
+		}, []string{"ticker.cmt_btcusdt", "depth.cmt_btcusdt"}},
+		{"candlestick intervals", func(r *itemRecorder) MarketSubscription {
+			return MarketSubscription{
+				Candlestick: func(msg *websocket.CandlestickData) error { return r.add("candlestick:" + msg.Channel) },
+				Intervals:   []string{"1m", "5m", "1h"},
+			}
+		}, []string{"candlestick.cmt_btcusdt.1m", "candlestick.cmt_btcusdt.5m", "candlestick.cmt_btcusdt.1h"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, func(channel string) []string {
+				return []string{`{"channel":"` + channel + `","data":[]}`}
+			})
+			client := connectTestClient(t, server)
+
+			var recorder itemRecorder
+			opts := tt.opts(&recorder)
+			if err := client.SubscribeMarket("cmt_btcusdt", opts); err != nil {
+				t.Fatalf("SubscribeMarket() error = %v", err)
+			}
+			wstest.WaitFor(t, "subscribe frame", func() bool { return len(server.Frames()) > 0 })
+			frames := server.Frames()
+			if len(frames) != 1 || frames[0].Op != "subscribe" || !reflect.DeepEqual(frames[0].Args, tt.channels) {
+				t.Fatalf("frames = %+v, want one subscribe frame for %v", frames, tt.channels)
+			}
+
+			// Each channel's frame reaches only the callback of its type
+			wstest.WaitFor(t, "routed frames", func() bool { return len(recorder.get()) >= len(tt.channels) })
+			var want []string
+			for _, channel := range tt.channels {
+				kind, _, _ := strings.Cut(channel, ".")
+				want = append(want, kind+":"+channel)
+			}
+			got := recorder.get()
+			slices.Sort(got)
+			slices.Sort(want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("routed = %v, want %v", got, want)
+			}
+
+			if err := client.UnsubscribeMarket("cmt_btcusdt", opts); err != nil {
+				t.Fatalf("UnsubscribeMarket() error = %v", err)
+			}
+			wstest.WaitFor(t, "unsubscribe frame", func() bool { return len(server.Frames()) > 1 })
+			if frame := server.Frames()[1]; frame.Op != "unsubscribe" || !reflect.DeepEqual(frame.Args, tt.channels) {
+				t.Errorf("unsubscribe frame = %+v, want %v", frame, tt.channels)
+			}
+		})
+	}
+}
+
+func TestSubscribeMarketNothingSelected(t *testing.T) {
+	server := newTestServer(t, nil)
+	client := connectTestClient(t, server)
+
+	if err := client.SubscribeMarket("cmt_btcusdt", MarketSubscription{Intervals: []string{"1m"}}); err == nil {
+		t.Error("SubscribeMarket() error = nil, want an error when no channel is selected")
+	}
+	if err := client.UnsubscribeMarket("cmt_btcusdt", MarketSubscription{}); err != nil {
+		t.Errorf("UnsubscribeMarket() error = %v, want nil", err)
+	}
+	if frames := server.Frames(); len(frames) != 0 {
+		t.Errorf("frames = %+v, want none", frames)
+	}
+}
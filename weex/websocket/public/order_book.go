@@ -0,0 +1,386 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+)
+
+// orderBookResyncTimeout bounds a REST snapshot fetch triggered by a gap
+const orderBookResyncTimeout = 10 * time.Second
+
+// DepthSnapshotFunc fetches a full order book snapshot for symbol via REST
+type DepthSnapshotFunc func(ctx context.Context, symbol string) (*market.Depth, error)
+
+// MarketDepthSnapshot returns a DepthSnapshotFunc backed by Market().GetDepth
+// limit must be 15 or 200 (0 uses the API default)
+func MarketDepthSnapshot(svc *market.Service, limit int) DepthSnapshotFunc {
+	return func(ctx context.Context, symbol string) (*market.Depth, error) {
+		return svc.GetDepth(ctx, &market.GetDepthRequest{Symbol: symbol, Limit: limit})
+	}
+}
+
+// bookLevel is a price level held by OrderBook
+type bookLevel struct {
+	price    *big.Rat
+	priceQty types.PriceQty
+}
+
+// bufferedDepth is a depth update received while a resync is in progress
+type bufferedDepth struct {
+	seq  int64
+	item websocket.DepthItem
+}
+
+// OrderBook maintains a local order book from depth updates
+//
+// Each level in an update replaces the stored quantity at that price; a zero
+// quantity removes the level. A gap in feed sequence numbers, or an update
+// older than one already applied, marks the book unsynced and triggers a REST
+// snapshot via the DepthSnapshotFunc. Updates received meanwhile are buffered
+// and replayed on top of the snapshot. Without a DepthSnapshotFunc the next
+// update after a gap is taken as the new full book.
+type OrderBook struct {
+	symbol   string
+	snapshot DepthSnapshotFunc
+
+	mu        sync.RWMutex
+	bids      map[string]bookLevel
+	asks      map[string]bookLevel
+	lastSeq   int64
+	lastTime  int64
+	synced    bool
+	resyncing bool
+	pending   []bufferedDepth
+
+	onUpdate func(book *OrderBook)
+	onError  func(err error)
+}
+
+// NewOrderBook creates an empty OrderBook for symbol
+// snapshot may be nil, see OrderBook.
+func NewOrderBook(symbol string, snapshot DepthSnapshotFunc) *OrderBook {
+	return &OrderBook{
+		symbol:   symbol,
+		snapshot: snapshot,
+		bids:     make(map[string]bookLevel),
+		asks:     make(map[string]bookLevel),
+	}
+}
+
+// SetOnUpdate sets a callback invoked after each applied update or resync
+func (b *OrderBook) SetOnUpdate(callback func(book *OrderBook)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onUpdate = callback
+}
+
+// SetOnError sets a callback invoked when a REST resync fails
+// The next update triggers another resync attempt.
+func (b *OrderBook) SetOnError(callback func(err error)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onError = callback
+}
+
+// Apply applies a depth update with its feed sequence number (0 if not provided)
+// Items for another symbol are ignored.
+func (b *OrderBook) Apply(seq int64, item *websocket.DepthItem) error {
+	if item.Symbol != "" && item.Symbol != b.symbol {
+		return nil
+	}
+
+	b.mu.Lock()
+	if b.synced && b.isGap(seq, item.Timestamp) {
+		b.synced = false
+	}
+
+	if !b.synced {
+		if b.snapshot == nil {
+			b.replace(item.Bids, item.Asks, item.Timestamp)
+			b.lastSeq = seq
+			b.synced = true
+			b.mu.Unlock()
+			b.notify()
+			return nil
+		}
+
+		b.pending = append(b.pending, bufferedDepth{seq: seq, item: *item})
+		start := !b.resyncing
+		b.resyncing = true
+		b.mu.Unlock()
+
+		if start {
+			go b.resync()
+		}
+		return nil
+	}
+
+	if err := b.apply(seq, item); err != nil {
+		b.synced = false
+		b.mu.Unlock()
+		return err
+	}
+	b.mu.Unlock()
+	b.notify()
+	return nil
+}
+
+// Invalidate marks the book unsynced, e.g. after a reconnect
+// The next update triggers a resync.
+func (b *OrderBook) Invalidate() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.synced = false
+}
+
+// Synced returns true if the book is consistent with the feed
+func (b *OrderBook) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// Snapshot returns up to depth levels per side (all levels if depth <= 0)
+// Bids are sorted by price descending and asks by price ascending.
+func (b *OrderBook) Snapshot(depth int) (bids, asks []types.PriceQty) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return sortedLevels(b.bids, depth, true), sortedLevels(b.asks, depth, false)
+}
+
+// BestBid returns the highest bid
+func (b *OrderBook) BestBid() (types.PriceQty, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return bestLevel(b.bids, true)
+}
+
+// BestAsk returns the lowest ask
+func (b *OrderBook) BestAsk() (types.PriceQty, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return bestLevel(b.asks, false)
+}
+
+// Spread returns best ask minus best bid; false if either side is empty
+func (b *OrderBook) Spread() (types.Decimal, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	bid, ok := bestLevelRat(b.bids, true)
+	if !ok {
+		return "", false
+	}
+	ask, ok := bestLevelRat(b.asks, false)
+	if !ok {
+		return "", false
+	}
+	return types.NewDecimalFromRat(new(big.Rat).Sub(ask, bid)), true
+}
+
+// LastUpdate returns the timestamp of the last applied update (ms)
+func (b *OrderBook) LastUpdate() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.lastTime
+}
+
+// Symbol returns the symbol the book is maintained for
+func (b *OrderBook) Symbol() string {
+	return b.symbol
+}
+
+// isGap reports whether an update does not follow the last applied one; the caller must hold mu
+func (b *OrderBook) isGap(seq, timestamp int64) bool {
+	if seq > 0 && b.lastSeq > 0 {
+		return seq != b.lastSeq+1
+	}
+	return timestamp > 0 && timestamp < b.lastTime
+}
+
+// apply applies a delta to the book; the caller must hold mu
+func (b *OrderBook) apply(seq int64, item *websocket.DepthItem) error {
+	if err := applyLevels(b.bids, item.Bids); err != nil {
+		return err
+	}
+	if err := applyLevels(b.asks, item.Asks); err != nil {
+		return err
+	}
+	if seq > 0 {
+		b.lastSeq = seq
+	}
+	if item.Timestamp > b.lastTime {
+		b.lastTime = item.Timestamp
+	}
+	return nil
+}
+
+// replace replaces the whole book; the caller must hold mu
+func (b *OrderBook) replace(bids, asks []types.PriceQty, timestamp int64) {
+	b.bids = make(map[string]bookLevel, len(bids))
+	b.asks = make(map[string]bookLevel, len(asks))
+	_ = applyLevels(b.bids, bids)
+	_ = applyLevels(b.asks, asks)
+	b.lastTime = timestamp
+}
+
+// resync loads a REST snapshot and replays updates buffered since the gap
+func (b *OrderBook) resync() {
+	ctx, cancel := context.WithTimeout(context.Background(), orderBookResyncTimeout)
+	defer cancel()
+
+	depth, err := b.snapshot(ctx, b.symbol)
+	var bids, asks []types.PriceQty
+	var timestamp int64
+	if err == nil {
+		bids, asks, timestamp, err = parseRESTDepth(depth)
+	}
+
+	b.mu.Lock()
+	if err == nil {
+		b.replace(bids, asks, timestamp)
+		b.lastSeq = 0
+		for _, update := range b.pending {
+			if update.item.Timestamp > 0 && update.item.Timestamp <= timestamp {
+				continue
+			}
+			if err = b.apply(update.seq, &update.item); err != nil {
+				break
+			}
+		}
+	}
+	b.pending = nil
+	b.resyncing = false
+	b.synced = err == nil
+	onError := b.onError
+	b.mu.Unlock()
+
+	if err != nil {
+		if onError != nil {
+			onError(fmt.Errorf("order book resync for %s: %w", b.symbol, err))
+		}
+		return
+	}
+	b.notify()
+}
+
+// notify invokes the update callback
+func (b *OrderBook) notify() {
+	b.mu.RLock()
+	callback := b.onUpdate
+	b.mu.RUnlock()
+
+	if callback != nil {
+		callback(b)
+	}
+}
+
+// applyLevels sets or removes levels keyed by normalized price
+func applyLevels(side map[string]bookLevel, levels []types.PriceQty) error {
+	for _, level := range levels {
+		price, err := level.Price.Rat()
+		if err != nil {
+			return err
+		}
+		qty, err := level.Quantity.Rat()
+		if err != nil {
+			return err
+		}
+		key := price.RatString()
+		if qty.Sign() == 0 {
+			delete(side, key)
+			continue
+		}
+		side[key] = bookLevel{price: price, priceQty: level}
+	}
+	return nil
+}
+
+// sortedLevels returns up to depth levels ordered from the best price
+func sortedLevels(side map[string]bookLevel, depth int, descending bool) []types.PriceQty {
+	levels := make([]bookLevel, 0, len(side))
+	for _, level := range side {
+		levels = append(levels, level)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		cmp := levels[i].price.Cmp(levels[j].price)
+		if descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	if depth > 0 && len(levels) > depth {
+		levels = levels[:depth]
+	}
+
+	result := make([]types.PriceQty, len(levels))
+	for i, level := range levels {
+		result[i] = level.priceQty
+	}
+	return result
+}
+
+// bestLevel returns the best level of a side
+func bestLevel(side map[string]bookLevel, highest bool) (types.PriceQty, bool) {
+	var best *bookLevel
+	for key := range side {
+		level := side[key]
+		if best == nil || (level.price.Cmp(best.price) > 0) == highest {
+			best = &level
+		}
+	}
+	if best == nil {
+		return types.PriceQty{}, false
+	}
+	return best.priceQty, true
+}
+
+// bestLevelRat returns the best price of a side
+func bestLevelRat(side map[string]bookLevel, highest bool) (*big.Rat, bool) {
+	level, ok := bestLevel(side, highest)
+	if !ok {
+		return nil, false
+	}
+	price, err := level.Price.Rat()
+	return price, err == nil
+}
+
+// parseRESTDepth converts a REST depth response into price levels
+func parseRESTDepth(depth *market.Depth) (bids, asks []types.PriceQty, timestamp int64, err error) {
+	if bids, err = parseRESTLevels(depth.Bids); err != nil {
+		return nil, nil, 0, err
+	}
+	if asks, err = parseRESTLevels(depth.Asks); err != nil {
+		return nil, nil, 0, err
+	}
+	if depth.Timestamp != "" {
+		if timestamp, err = strconv.ParseInt(depth.Timestamp, 10, 64); err != nil {
+			return nil, nil, 0, fmt.Errorf("invalid depth timestamp %q: %w", depth.Timestamp, err)
+		}
+	}
+	return bids, asks, timestamp, nil
+}
+
+// parseRESTLevels converts [price, quantity] pairs into PriceQty values
+func parseRESTLevels(levels [][]string) ([]types.PriceQty, error) {
+	result := make([]types.PriceQty, len(levels))
+	for i, level := range levels {
+		if len(level) < 2 {
+			return nil, fmt.Errorf("invalid depth level %v", level)
+		}
+		result[i] = types.PriceQty{Price: types.Decimal(level[0]), Quantity: types.Decimal(level[1])}
+	}
+	return result, nil
+}
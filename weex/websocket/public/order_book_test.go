@@ -0,0 +1,277 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+// levels builds price levels from price, quantity pairs
+func levels(pairs ...string) []types.PriceQty {
+	result := make([]types.PriceQty, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		result = append(result, types.PriceQty{Price: types.Decimal(pairs[i]), Quantity: types.Decimal(pairs[i+1])})
+	}
+	return result
+}
+
+func TestOrderBookApply(t *testing.T) {
+	snapshot := websocket.DepthItem{Symbol: "btc", Timestamp: 1,
+		Bids: levels("100", "1", "99.5", "2", "99", "3"),
+		Asks: levels("101", "1", "101.5", "2", "102", "3")}
+
+	tests := []struct {
+		name       string
+		deltas     []websocket.DepthItem
+		depth      int
+		wantBids   []types.PriceQty
+		wantAsks   []types.PriceQty
+		wantSpread types.Decimal
+	}{
+		{"snapshot only", nil, 0,
+			levels("100", "1", "99.5", "2", "99", "3"), levels("101", "1", "101.5", "2", "102", "3"), "1"},
+		{"depth limited", nil, 2,
+			levels("100", "1", "99.5", "2"), levels("101", "1", "101.5", "2"), "1"},
+		{"quantity replaced", []websocket.DepthItem{{Timestamp: 2, Bids: levels("100", "5")}}, 1,
+			levels("100", "5"), levels("101", "1"), "1"},
+		{"zero quantity removes level", []websocket.DepthItem{{Timestamp: 2, Bids: levels("100", "0"), Asks: levels("101", "0.000")}}, 1,
+			levels("99.5", "2"), levels("101.5", "2"), "2"},
+		{"equal prices in other notation", []websocket.DepthItem{{Timestamp: 2, Bids: levels("100.00", "0", "99.50", "4")}}, 2,
+			levels("99.50", "4", "99", "3"), levels("101", "1", "101.5", "2"), "1.5"},
+		{"new levels inserted in order", []websocket.DepthItem{{Timestamp: 2, Bids: levels("100.5", "1"), Asks: levels("100.75", "2")}}, 2,
+			levels("100.5", "1", "100", "1"), levels("100.75", "2", "101", "1"), "0.25"},
+		{"other symbol ignored", []websocket.DepthItem{{Symbol: "eth", Timestamp: 2, Bids: levels("100", "0")}}, 1,
+			levels("100", "1"), levels("101", "1"), "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := NewOrderBook("btc", nil)
+
+			// Without a snapshot function the first update is the full book
+			if err := book.Apply(0, &snapshot); err != nil {
+				t.Fatalf("Apply(snapshot) error = %v", err)
+			}
+			for i := range tt.deltas {
+				if err := book.Apply(0, &tt.deltas[i]); err != nil {
+					t.Fatalf("Apply(delta %d) error = %v", i, err)
+				}
+			}
+
+			bids, asks := book.Snapshot(tt.depth)
+			if !reflect.DeepEqual(bids, tt.wantBids) {
+				t.Errorf("bids = %v, want %v", bids, tt.wantBids)
+			}
+			if !reflect.DeepEqual(asks, tt.wantAsks) {
+				t.Errorf("asks = %v, want %v", asks, tt.wantAsks)
+			}
+			if bid, ok := book.BestBid(); !ok || bid != tt.wantBids[0] {
+				t.Errorf("BestBid() = %v, %v, want %v", bid, ok, tt.wantBids[0])
+			}
+			if ask, ok := book.BestAsk(); !ok || ask != tt.wantAsks[0] {
+				t.Errorf("BestAsk() = %v, %v, want %v", ask, ok, tt.wantAsks[0])
+			}
+			if spread, ok := book.Spread(); !ok || spread != tt.wantSpread {
+				t.Errorf("Spread() = %q, %v, want %q", spread, ok, tt.wantSpread)
+			}
+			if !book.Synced() {
+				t.Error("Synced() = false")
+			}
+		})
+	}
+}
+
+func TestOrderBookEmptySide(t *testing.T) {
+	book := NewOrderBook("btc", nil)
+	if _, ok := book.Spread(); ok {
+		t.Error("Spread() ok on an empty book")
+	}
+	book.Apply(0, &websocket.DepthItem{Timestamp: 1, Bids: levels("100", "1")})
+	if _, ok := book.BestAsk(); ok {
+		t.Error("BestAsk() ok without asks")
+	}
+	if _, ok := book.Spread(); ok {
+		t.Error("Spread() ok without asks")
+	}
+	if err := book.Apply(0, &websocket.DepthItem{Timestamp: 2, Bids: levels("abc", "1")}); err == nil {
+		t.Error("Apply() with an invalid price returned no error")
+	}
+	if book.Synced() {
+		t.Error("Synced() = true after a failed update")
+	}
+}
+
+// depthSource is a DepthSnapshotFunc serving canned REST snapshots
+type depthSource struct {
+	mu    sync.Mutex
+	depth market.Depth
+	err   error
+	calls int
+}
+
+func (s *depthSource) fetch(_ context.Context, symbol string) (*market.Depth, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	depth := s.depth
+	return &depth, nil
+}
+
+func (s *depthSource) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func TestOrderBookResync(t *testing.T) {
+	type update struct {
+		seq  int64
+		item websocket.DepthItem
+	}
+	tests := []struct {
+		name      string
+		updates   []update
+		wantCalls int
+		wantBids  []types.PriceQty
+		wantAsks  []types.PriceQty
+	}{
+		{"initial snapshot with stale update skipped", []update{
+			{1, websocket.DepthItem{Timestamp: 5, Bids: levels("100", "9")}},
+		}, 1, levels("100", "1"), levels("101", "1")},
+		{"newer update replayed on snapshot", []update{
+			{1, websocket.DepthItem{Timestamp: 20, Bids: levels("100", "9")}},
+		}, 1, levels("100", "9"), levels("101", "1")},
+		{"contiguous deltas applied", []update{
+			{1, websocket.DepthItem{Timestamp: 5}},
+			{2, websocket.DepthItem{Timestamp: 11, Asks: levels("101", "0")}},
+			{3, websocket.DepthItem{Timestamp: 12, Asks: levels("100.5", "4")}},
+		}, 1, levels("100", "1"), levels("100.5", "4")},
+		{"sequence gap resyncs", []update{
+			{1, websocket.DepthItem{Timestamp: 5}},
+			{2, websocket.DepthItem{Timestamp: 11, Bids: levels("100", "0")}},
+			{5, websocket.DepthItem{Timestamp: 12, Asks: levels("100.5", "4")}},
+		}, 2, levels("100", "1"), levels("100.5", "4", "101", "1")},
+		{"out of order timestamp resyncs", []update{
+			{0, websocket.DepthItem{Timestamp: 5}},
+			{0, websocket.DepthItem{Timestamp: 15, Bids: levels("100", "0")}},
+			{0, websocket.DepthItem{Timestamp: 14, Bids: levels("99", "2")}},
+		}, 2, levels("100", "1", "99", "2"), levels("101", "1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &depthSource{depth: market.Depth{
+				Bids: [][]string{{"100", "1"}}, Asks: [][]string{{"101", "1"}}, Timestamp: "10",
+			}}
+			book := NewOrderBook("btc", source.fetch)
+			var mu sync.Mutex
+			updates := 0
+			book.SetOnUpdate(func(*OrderBook) {
+				mu.Lock()
+				defer mu.Unlock()
+				updates++
+			})
+
+			for i, u := range tt.updates {
+				if err := book.Apply(u.seq, &u.item); err != nil {
+					t.Fatalf("Apply(%d) error = %v", i, err)
+				}
+				// Let each resync finish before the next update, as a live feed would
+				wstest.WaitFor(t, "resync", book.Synced)
+			}
+
+			if got := source.Calls(); got != tt.wantCalls {
+				t.Errorf("snapshot fetches = %d, want %d", got, tt.wantCalls)
+			}
+			bids, asks := book.Snapshot(0)
+			if !reflect.DeepEqual(bids, tt.wantBids) {
+				t.Errorf("bids = %v, want %v", bids, tt.wantBids)
+			}
+			if !reflect.DeepEqual(asks, tt.wantAsks) {
+				t.Errorf("asks = %v, want %v", asks, tt.wantAsks)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if updates == 0 {
+				t.Error("onUpdate never called")
+			}
+		})
+	}
+}
+
+func TestOrderBookResyncFailure(t *testing.T) {
+	source := &depthSource{err: errors.New("rest unavailable")}
+	book := NewOrderBook("btc", source.fetch)
+	errs := make(chan error, 1)
+	book.SetOnError(func(err error) { errs <- err })
+
+	book.Apply(1, &websocket.DepthItem{Timestamp: 1, Bids: levels("100", "1")})
+	if err := <-errs; err == nil || !errors.Is(err, source.err) {
+		t.Fatalf("onError = %v, want the snapshot error", err)
+	}
+	if book.Synced() {
+		t.Error("Synced() = true after a failed resync")
+	}
+
+	// The next update tries again
+	source.mu.Lock()
+	source.err = nil
+	source.depth = market.Depth{Bids: [][]string{{"99", "1"}}, Asks: [][]string{{"101", "1"}}, Timestamp: "1"}
+	source.mu.Unlock()
+	book.Apply(2, &websocket.DepthItem{Timestamp: 2, Asks: levels("100.5", "1")})
+	wstest.WaitFor(t, "resync", book.Synced)
+	if ask, _ := book.BestAsk(); ask.Price != "100.5" {
+		t.Errorf("BestAsk() = %v, want 100.5", ask)
+	}
+	if got := source.Calls(); got != 2 {
+		t.Errorf("snapshot fetches = %d, want 2", got)
+	}
+}
+
+func TestSubscribeOrderBook(t *testing.T) {
+	server := newTestServer(t, func(channel string) []string {
+		return []string{
+			`{"channel":"` + channel + `","data":[{"symbol":"cmt_btcusdt","timestamp":1,` +
+				`"bids":[{"price":"100","quantity":"1"},{"price":"99","quantity":"2"}],` +
+				`"asks":[{"price":"101","quantity":"1"}]}]}`,
+			`{"channel":"` + channel + `","data":[{"symbol":"cmt_btcusdt","timestamp":2,` +
+				`"bids":[{"price":"100","quantity":"0"}],"asks":[{"price":"100.5","quantity":"3"}]}]}`,
+		}
+	})
+	client := connectTestClient(t, server)
+
+	var mu sync.Mutex
+	updates := 0
+	book, err := client.SubscribeOrderBook("cmt_btcusdt", func(*OrderBook) {
+		mu.Lock()
+		defer mu.Unlock()
+		updates++
+	})
+	if err != nil {
+		t.Fatalf("SubscribeOrderBook() error = %v", err)
+	}
+	wstest.WaitFor(t, "both depth updates", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return updates == 2
+	})
+
+	bids, asks := book.Snapshot(0)
+	if want := levels("99", "2"); !reflect.DeepEqual(bids, want) {
+		t.Errorf("bids = %v, want %v", bids, want)
+	}
+	if want := levels("100.5", "3", "101", "1"); !reflect.DeepEqual(asks, want) {
+		t.Errorf("asks = %v, want %v", asks, want)
+	}
+	if spread, _ := book.Spread(); spread != "1.5" {
+		t.Errorf("Spread() = %q, want 1.5", spread)
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
 	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
 )
 
@@ -22,9 +24,27 @@ type CandlestickCallback func(kline *websocket.CandlestickData) error
 // TradesCallback is called when trade data is received
 type TradesCallback func(trades *websocket.TradesData) error
 
+// TickerItemCallback is called once per ticker item received
+type TickerItemCallback func(item *websocket.TickerItem) error
+
+// DepthItemCallback is called once per depth item received
+type DepthItemCallback func(item *websocket.DepthItem) error
+
+// CandlestickItemCallback is called once per candlestick item received
+type CandlestickItemCallback func(item *websocket.CandlestickItem) error
+
+// TradeItemCallback is called once per trade item received
+type TradeItemCallback func(item *websocket.TradeItem) error
+
 // Client provides convenient methods for subscribing to public channels
 type Client struct {
 	ws *websocket.Client
+
+	// De-duplication of trades across reconnects (nil = disabled)
+	tradeDedup *websocket.IDCache
+
+	// REST market service used to resync order books (nil = no REST resync)
+	market *market.Service
 }
 
 // NewClient creates a new public WebSocket client
@@ -51,15 +71,44 @@ func (c *Client) Close() error {
 func (c *Client) SubscribeTicker(symbol string, callback TickerCallback) error {
 	channel := fmt.Sprintf("ticker.%s", symbol)
 
-	handler := func(data []byte) error {
-		var ticker websocket.TickerData
-		if err := json.Unmarshal(data, &ticker); err != nil {
-			return fmt.Errorf("failed to unmarshal ticker data: %w", err)
+	return c.ws.Subscribe(channel, tickerHandler(callback))
+}
+
+// SubscribeAllTickers subscribes to the ticker of every listed contract with one callback
+//
+// The contract list is fetched through the market service set with
+// SetMarketService, and the channels are sent in frames of at most
+// Config.WSMaxArgsPerFrame on this connection. Returns the subscribed symbols,
+// sorted. Contracts listed later are not picked up; call again to add them.
+func (c *Client) SubscribeAllTickers(ctx context.Context, callback TickerCallback) ([]string, error) {
+	if c.market == nil {
+		return nil, fmt.Errorf("market service not set; call SetMarketService first")
+	}
+	contracts, err := c.market.GetContracts(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contracts: %w", err)
+	}
+
+	symbols := make([]string, 0, len(contracts))
+	for _, contract := range contracts {
+		if contract.Symbol != "" {
+			symbols = append(symbols, contract.Symbol)
 		}
-		return callback(&ticker)
+	}
+	sort.Strings(symbols)
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("no contracts to subscribe")
 	}
 
-	return c.ws.Subscribe(channel, handler)
+	handler := tickerHandler(callback)
+	subs := make([]websocket.Subscription, len(symbols))
+	for i, symbol := range symbols {
+		subs[i] = websocket.Subscription{Channel: fmt.Sprintf("ticker.%s", symbol), Handler: handler}
+	}
+	if err := c.ws.SubscribeMany(subs); err != nil {
+		return nil, err
+	}
+	return symbols, nil
 }
 
 // SubscribeDepth subscribes to order book depth updates for a symbol
@@ -69,15 +118,55 @@ func (c *Client) SubscribeTicker(symbol string, callback TickerCallback) error {
 func (c *Client) SubscribeDepth(symbol string, callback DepthCallback) error {
 	channel := fmt.Sprintf("depth.%s", symbol)
 
-	handler := func(data []byte) error {
-		var depth websocket.DepthData
-		if err := json.Unmarshal(data, &depth); err != nil {
-			return fmt.Errorf("failed to unmarshal depth data: %w", err)
+	return c.ws.Subscribe(channel, depthHandler(callback))
+}
+
+// SubscribeDepthLevel subscribes to order book depth limited to a number of levels
+//
+// Channel format: depth.{symbol}.{level}
+// Example: depth.cmt_btcusdt.5
+func (c *Client) SubscribeDepthLevel(symbol string, level int, callback DepthCallback) error {
+	channel := fmt.Sprintf("depth.%s.%d", symbol, level)
+
+	return c.ws.Subscribe(channel, depthHandler(callback))
+}
+
+// SetMarketService sets the REST market service used by SubscribeOrderBook to resync books
+// Example: client.SetMarketService(restClient.Market())
+func (c *Client) SetMarketService(svc *market.Service) {
+	c.market = svc
+}
+
+// SubscribeOrderBook subscribes to depth updates for symbol and maintains a local OrderBook
+//
+// onUpdate is called after each applied update. Gaps and reconnects resync the
+// book from Market().GetDepth if SetMarketService was called; otherwise the next
+// update after a gap is taken as the full book.
+func (c *Client) SubscribeOrderBook(symbol string, onUpdate func(book *OrderBook)) (*OrderBook, error) {
+	var snapshot DepthSnapshotFunc
+	if c.market != nil {
+		snapshot = MarketDepthSnapshot(c.market, 200)
+	}
+	book := NewOrderBook(symbol, snapshot)
+	book.SetOnUpdate(onUpdate)
+
+	generation := c.ws.Generation()
+	err := c.SubscribeDepth(symbol, func(msg *websocket.DepthData) error {
+		if current := c.ws.Generation(); current != generation {
+			generation = current
+			book.Invalidate()
 		}
-		return callback(&depth)
+		for i := range msg.Data {
+			if err := book.Apply(msg.Seq, &msg.Data[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return c.ws.Subscribe(channel, handler)
+	return book, nil
 }
 
 // SubscribeCandlestick subscribes to candlestick/kline updates
@@ -89,15 +178,7 @@ func (c *Client) SubscribeDepth(symbol string, callback DepthCallback) error {
 func (c *Client) SubscribeCandlestick(symbol, interval string, callback CandlestickCallback) error {
 	channel := fmt.Sprintf("candlestick.%s.%s", symbol, interval)
 
-	handler := func(data []byte) error {
-		var kline websocket.CandlestickData
-		if err := json.Unmarshal(data, &kline); err != nil {
-			return fmt.Errorf("failed to unmarshal candlestick data: %w", err)
-		}
-		return callback(&kline)
-	}
-
-	return c.ws.Subscribe(channel, handler)
+	return c.ws.Subscribe(channel, candlestickHandler(callback))
 }
 
 // SubscribeTrades subscribes to recent trades for a symbol
@@ -107,15 +188,163 @@ func (c *Client) SubscribeCandlestick(symbol, interval string, callback Candlest
 func (c *Client) SubscribeTrades(symbol string, callback TradesCallback) error {
 	channel := fmt.Sprintf("trades.%s", symbol)
 
-	handler := func(data []byte) error {
-		var trades websocket.TradesData
-		if err := json.Unmarshal(data, &trades); err != nil {
-			return fmt.Errorf("failed to unmarshal trades data: %w", err)
+	return c.ws.Subscribe(channel, tradesHandler(c.dedupTrades(callback)))
+}
+
+// SubscribeTickerEach subscribes like SubscribeTicker but invokes callback once per item
+//
+// Frames with an empty data slice are skipped; iteration stops at the first callback error
+func (c *Client) SubscribeTickerEach(symbol string, callback TickerItemCallback) error {
+	return c.SubscribeTicker(symbol, func(msg *websocket.TickerData) error {
+		for i := range msg.Data {
+			if err := callback(&msg.Data[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SubscribeDepthEach subscribes like SubscribeDepth but invokes callback once per item
+//
+// Frames with an empty data slice are skipped; iteration stops at the first callback error
+func (c *Client) SubscribeDepthEach(symbol string, callback DepthItemCallback) error {
+	return c.SubscribeDepth(symbol, func(msg *websocket.DepthData) error {
+		for i := range msg.Data {
+			if err := callback(&msg.Data[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SubscribeCandlestickEach subscribes like SubscribeCandlestick but invokes callback once per item
+//
+// Frames with an empty data slice are skipped; iteration stops at the first callback error
+func (c *Client) SubscribeCandlestickEach(symbol, interval string, callback CandlestickItemCallback) error {
+	return c.SubscribeCandlestick(symbol, interval, func(msg *websocket.CandlestickData) error {
+		for i := range msg.Data {
+			if err := callback(&msg.Data[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SubscribeTradesEach subscribes like SubscribeTrades but invokes callback once per item
+//
+// Frames with an empty data slice are skipped; iteration stops at the first callback error
+func (c *Client) SubscribeTradesEach(symbol string, callback TradeItemCallback) error {
+	return c.SubscribeTrades(symbol, func(msg *websocket.TradesData) error {
+		for i := range msg.Data {
+			if err := callback(&msg.Data[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MarketSubscription selects the market data channels for SubscribeMarket
+// Channels whose callback is nil are not subscribed
+type MarketSubscription struct {
+	Ticker      TickerCallback      // Ticker updates
+	Depth       DepthCallback       // Order book depth updates
+	Trades      TradesCallback      // Recent trades
+	Candlestick CandlestickCallback // Candlestick updates for each interval in Intervals
+	Intervals   []string            // Candlestick intervals (default: ["1m"])
+}
+
+// marketSubscriptions builds the channel subscriptions selected by opts
+// Trades are filtered by the client's trade dedup, if enabled.
+func (c *Client) marketSubscriptions(symbol string, opts *MarketSubscription) []websocket.Subscription {
+	var subs []websocket.Subscription
+	if opts.Ticker != nil {
+		subs = append(subs, websocket.Subscription{Channel: fmt.Sprintf("ticker.%s", symbol), Handler: tickerHandler(opts.Ticker)})
+	}
+	if opts.Depth != nil {
+		subs = append(subs, websocket.Subscription{Channel: fmt.Sprintf("depth.%s", symbol), Handler: depthHandler(opts.Depth)})
+	}
+	if opts.Trades != nil {
+		subs = append(subs, websocket.Subscription{Channel: fmt.Sprintf("trades.%s", symbol), Handler: tradesHandler(c.dedupTrades(opts.Trades))})
+	}
+	if opts.Candlestick != nil {
+		intervals := opts.Intervals
+		if len(intervals) == 0 {
+			intervals = []string{"1m"}
+		}
+		for _, interval := range intervals {
+			subs = append(subs, websocket.Subscription{
+				Channel: fmt.Sprintf("candlestick.%s.%s", symbol, interval),
+				Handler: candlestickHandler(opts.Candlestick),
+			})
 		}
-		return callback(&trades)
 	}
+	return subs
+}
 
-	return c.ws.Subscribe(channel, handler)
+// SubscribeMarket subscribes to the selected market data channels for a symbol
+// in a single subscribe frame
+//
+// Example:
+//
+//	err := client.SubscribeMarket("cmt_btcusdt", public.MarketSubscription{
+//	    Ticker: onTicker,
+//	    Depth:  onDepth,
+//	})
+func (c *Client) SubscribeMarket(symbol string, opts MarketSubscription) error {
+	subs := c.marketSubscriptions(symbol, &opts)
+	if len(subs) == 0 {
+		return fmt.Errorf("no market data channels selected for %s", symbol)
+	}
+	return c.ws.SubscribeMany(subs)
+}
+
+// UnsubscribeMarket unsubscribes from the market data channels selected by opts
+func (c *Client) UnsubscribeMarket(symbol string, opts MarketSubscription) error {
+	subs := c.marketSubscriptions(symbol, &opts)
+	if len(subs) == 0 {
+		return nil
+	}
+	channels := make([]string, len(subs))
+	for i, sub := range subs {
+		channels[i] = sub.Channel
+	}
+	return c.ws.UnsubscribeMany(channels)
+}
+
+// EnableTradeDedup suppresses trades whose tradeId was already delivered
+//
+// The most recent size trade IDs are remembered across reconnects, so trades
+// replayed by the feed after a reconnect reach the callback only once. Frames
+// left empty after filtering are dropped. Applies to SubscribeTrades,
+// SubscribeTradesEach and the Trades channel of SubscribeMarket; must be called
+// before subscribing.
+func (c *Client) EnableTradeDedup(size int) {
+	c.tradeDedup = websocket.NewIDCache(size)
+}
+
+// dedupTrades wraps callback to filter already delivered trades
+func (c *Client) dedupTrades(callback TradesCallback) TradesCallback {
+	cache := c.tradeDedup
+	if cache == nil {
+		return callback
+	}
+	return func(trades *websocket.TradesData) error {
+		fresh := trades.Data[:0:0]
+		for _, item := range trades.Data {
+			if !cache.Seen(item.TradeId) {
+				fresh = append(fresh, item)
+			}
+		}
+		if len(fresh) == 0 {
+			return nil
+		}
+		trades.Data = fresh
+		return callback(trades)
+	}
 }
 
 // Unsubscribe unsubscribes from a channel
@@ -135,6 +364,12 @@ func (c *Client) UnsubscribeDepth(symbol string) error {
 	return c.ws.Unsubscribe(channel)
 }
 
+// UnsubscribeDepthLevel unsubscribes from level-limited depth updates
+func (c *Client) UnsubscribeDepthLevel(symbol string, level int) error {
+	channel := fmt.Sprintf("depth.%s.%d", symbol, level)
+	return c.ws.Unsubscribe(channel)
+}
+
 // UnsubscribeCandlestick unsubscribes from candlestick updates
 func (c *Client) UnsubscribeCandlestick(symbol, interval string) error {
 	channel := fmt.Sprintf("candlestick.%s.%s", symbol, interval)
@@ -171,3 +406,82 @@ func (c *Client) SetOnDisconnect(callback func(error)) {
 func (c *Client) SetOnError(callback func(error)) {
 	c.ws.SetOnError(callback)
 }
+
+// SetOnUnhandled sets the callback for messages that cannot be routed to a subscription
+func (c *Client) SetOnUnhandled(callback func(message []byte)) {
+	c.ws.SetOnUnhandled(callback)
+}
+
+// Stats returns aggregate connection statistics
+func (c *Client) Stats() websocket.Stats {
+	return c.ws.Stats()
+}
+
+// SetHandlerErrorPolicy sets the reaction to a handler returning an error (see websocket.HandlerErrorPolicy)
+func (c *Client) SetHandlerErrorPolicy(policy websocket.HandlerErrorPolicy) {
+	c.ws.SetHandlerErrorPolicy(policy)
+}
+
+// SetRawTap sets a callback receiving every raw message read from the connection
+func (c *Client) SetRawTap(callback func(message []byte)) {
+	c.ws.SetRawTap(callback)
+}
+
+// StartReplay puts the disconnected client into offline replay mode (see websocket.Replayer)
+func (c *Client) StartReplay() error {
+	return c.ws.StartReplay()
+}
+
+// ReplayMessage routes message to the subscribed handlers as if it had been received
+func (c *Client) ReplayMessage(message []byte) {
+	c.ws.ReplayMessage(message)
+}
+
+// SetFreshnessProbe feeds every channel data message to probe (nil to stop)
+func (c *Client) SetFreshnessProbe(probe *websocket.FreshnessProbe) {
+	c.ws.SetFreshnessProbe(probe)
+}
+
+// tickerHandler decodes ticker frames and passes them to callback
+func tickerHandler(callback TickerCallback) websocket.MessageHandler {
+	return func(data []byte) error {
+		var ticker websocket.TickerData
+		if err := json.Unmarshal(data, &ticker); err != nil {
+			return fmt.Errorf("failed to unmarshal ticker data: %w", err)
+		}
+		return callback(&ticker)
+	}
+}
+
+// depthHandler decodes depth frames and passes them to callback
+func depthHandler(callback DepthCallback) websocket.MessageHandler {
+	return func(data []byte) error {
+		var depth websocket.DepthData
+		if err := json.Unmarshal(data, &depth); err != nil {
+			return fmt.Errorf("failed to unmarshal depth data: %w", err)
+		}
+		return callback(&depth)
+	}
+}
+
+// candlestickHandler decodes candlestick frames and passes them to callback
+func candlestickHandler(callback CandlestickCallback) websocket.MessageHandler {
+	return func(data []byte) error {
+		var kline websocket.CandlestickData
+		if err := json.Unmarshal(data, &kline); err != nil {
+			return fmt.Errorf("failed to unmarshal candlestick data: %w", err)
+		}
+		return callback(&kline)
+	}
+}
+
+// tradesHandler decodes trades frames and passes them to callback
+func tradesHandler(callback TradesCallback) websocket.MessageHandler {
+	return func(data []byte) error {
+		var trades websocket.TradesData
+		if err := json.Unmarshal(data, &trades); err != nil {
+			return fmt.Errorf("failed to unmarshal trades data: %w", err)
+		}
+		return callback(&trades)
+	}
+}
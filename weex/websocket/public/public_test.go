@@ -0,0 +1,80 @@
+package public
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+// itemRecorder collects the symbol of every item passed to a per-item callback
+type itemRecorder struct {
+	mu    sync.Mutex
+	items []string
+}
+
+func (r *itemRecorder) add(item string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items = append(r.items, item)
+	return nil
+}
+
+func (r *itemRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.items...)
+}
+
+func TestSubscribeEach(t *testing.T) {
+	tests := []struct {
+		name      string
+		channel   string
+		item      string // JSON of one item with symbol set to %s
+		subscribe func(c *Client, r *itemRecorder) error
+	}{
+		{"ticker", "ticker.cmt_btcusdt", `{"symbol":"%s","lastPrice":"100"}`, func(c *Client, r *itemRecorder) error {
+			return c.SubscribeTickerEach("cmt_btcusdt", func(item *websocket.TickerItem) error { return r.add(item.Symbol) })
+		}},
+		{"depth", "depth.cmt_btcusdt", `{"symbol":"%s","bids":[],"asks":[]}`, func(c *Client, r *itemRecorder) error {
+			return c.SubscribeDepthEach("cmt_btcusdt", func(item *websocket.DepthItem) error { return r.add(item.Symbol) })
+		}},
+		{"candlestick", "candlestick.cmt_btcusdt.1m", `{"symbol":"%s","interval":"1m"}`, func(c *Client, r *itemRecorder) error {
+			return c.SubscribeCandlestickEach("cmt_btcusdt", "1m", func(item *websocket.CandlestickItem) error { return r.add(item.Symbol) })
+		}},
+		{"trades", "trades.cmt_btcusdt", `{"symbol":"%s","tradeId":"1"}`, func(c *Client, r *itemRecorder) error {
+			return c.SubscribeTradesEach("cmt_btcusdt", func(item *websocket.TradeItem) error { return r.add(item.Symbol) })
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := func(symbol string) string { return strings.Replace(tt.item, "%s", symbol, 1) }
+			server := newTestServer(t, func(channel string) []string {
+				return []string{
+					`{"channel":"` + channel + `","data":[]}`,
+					`{"channel":"` + channel + `"}`,
+					`{"channel":"` + channel + `","data":[` + item("a") + `,` + item("b") + `,` + item("c") + `]}`,
+				}
+			})
+			client := connectTestClient(t, server)
+
+			var recorder itemRecorder
+			if err := tt.subscribe(client, &recorder); err != nil {
+				t.Fatalf("subscribe error = %v", err)
+			}
+			wstest.WaitFor(t, "subscribe frame", func() bool { return len(server.Frames()) > 0 })
+			if got := server.Frames()[0].Args; !reflect.DeepEqual(got, []string{tt.channel}) {
+				t.Fatalf("subscribed %v, want %s", got, tt.channel)
+			}
+
+			// Frames are handled in order, so the empty frames were skipped once the items arrive
+			wstest.WaitFor(t, "items", func() bool { return len(recorder.get()) >= 3 })
+			if got, want := recorder.get(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("items = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package public
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+// sessionRecorder collects the decoded ticker and trades data passed to callbacks
+type sessionRecorder struct {
+	mu      sync.Mutex
+	tickers []websocket.TickerData
+	trades  []websocket.TradesData
+}
+
+func (r *sessionRecorder) subscribe(t *testing.T, c *Client) {
+	t.Helper()
+	if err := c.SubscribeTicker("cmt_btcusdt", func(data *websocket.TickerData) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.tickers = append(r.tickers, *data)
+		return nil
+	}); err != nil {
+		t.Fatalf("SubscribeTicker() error = %v", err)
+	}
+	if err := c.SubscribeTrades("cmt_btcusdt", func(data *websocket.TradesData) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.trades = append(r.trades, *data)
+		return nil
+	}); err != nil {
+		t.Fatalf("SubscribeTrades() error = %v", err)
+	}
+}
+
+func (r *sessionRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.tickers) + len(r.trades)
+}
+
+func TestRecordAndReplaySession(t *testing.T) {
+	tests := []struct {
+		name    string
+		speed   float64
+		minTime time.Duration
+	}{
+		{"as fast as possible", 0, 0},
+		{"accelerated", 1000, 0},
+		{"original speed", 1, 80 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, func(channel string) []string {
+				switch channel {
+				case "ticker.cmt_btcusdt":
+					return []string{
+						`{"channel":"ticker.cmt_btcusdt","data":[{"symbol":"cmt_btcusdt","lastPrice":"65000.5","timestamp":1716604853000}]}`,
+						`{"channel":"ticker.cmt_btcusdt","data":[{"symbol":"cmt_btcusdt","lastPrice":"65001","timestamp":1716604854000}]}`,
+					}
+				case "trades.cmt_btcusdt":
+					return []string{`{"channel":"trades.cmt_btcusdt","data":[{"symbol":"cmt_btcusdt","tradeId":"1","price":"65000.5","size":"0.02","side":"buy"}]}`}
+				}
+				return nil
+			})
+
+			var session bytes.Buffer
+			recorder := websocket.NewRecorder(&session)
+			live := NewClient(testConfig(server))
+			live.SetRawTap(recorder.Record)
+			if err := live.Connect(t.Context()); err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+			var want sessionRecorder
+			want.subscribe(t, live)
+			wstest.WaitFor(t, "live data", func() bool { return want.count() == 3 })
+			live.Close()
+			if err := recorder.Err(); err != nil {
+				t.Fatalf("Recorder.Err() = %v", err)
+			}
+
+			// Spread the recorded frames 50ms apart to exercise the replay delays
+			var frames bytes.Buffer
+			respaced := websocket.NewRecorder(&frames)
+			replayer := websocket.NewReplayer(&session)
+			start := time.UnixMilli(1716604853000)
+			var n int
+			if _, err := replayer.Run(t.Context(), replayFunc(func(message []byte) {
+				respaced.RecordAt(message, start.Add(time.Duration(n)*50*time.Millisecond))
+				n++
+			})); err != nil {
+				t.Fatalf("reading recording: %v", err)
+			}
+
+			config := weex.NewDefaultConfig()
+			config.Logger = weex.NewNoOpLogger()
+			offline := NewClient(config)
+			defer offline.Close()
+			if err := offline.StartReplay(); err != nil {
+				t.Fatalf("StartReplay() error = %v", err)
+			}
+			var got sessionRecorder
+			got.subscribe(t, offline)
+
+			replayer = websocket.NewReplayer(&frames)
+			replayer.SetSpeed(tt.speed)
+			began := time.Now()
+			count, err := replayer.Run(context.Background(), offline)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if count != n {
+				t.Errorf("Run() = %d frames, want %d", count, n)
+			}
+			if elapsed := time.Since(began); elapsed < tt.minTime {
+				t.Errorf("replay took %v, want at least %v", elapsed, tt.minTime)
+			}
+
+			// Handlers run synchronously during replay, so everything was delivered
+			if !reflect.DeepEqual(got.tickers, want.tickers) {
+				t.Errorf("replayed tickers = %+v, want %+v", got.tickers, want.tickers)
+			}
+			if !reflect.DeepEqual(got.trades, want.trades) {
+				t.Errorf("replayed trades = %+v, want %+v", got.trades, want.trades)
+			}
+		})
+	}
+}
+
+// replayFunc adapts a function to websocket.ReplayTarget
+type replayFunc func(message []byte)
+
+func (f replayFunc) ReplayMessage(message []byte) { f(message) }
@@ -0,0 +1,290 @@
+package public
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+)
+
+// DefaultRollingStatsWindow is the window used by NewRollingStats when none is given
+const DefaultRollingStatsWindow = 24 * time.Hour
+
+// RollingStatsSnapshot is the aggregate of the trades currently inside the window
+type RollingStatsSnapshot struct {
+	Symbol   string        // Symbol the trades belong to
+	High     types.Decimal // Highest trade price
+	Low      types.Decimal // Lowest trade price
+	Volume   types.Decimal // Sum of trade sizes
+	Notional types.Decimal // Sum of price * size
+	VWAP     types.Decimal // Notional / Volume ("" if Volume is zero)
+	Count    int           // Number of trades
+	From     int64         // Timestamp of the oldest trade (ms)
+	To       int64         // Timestamp of the newest trade (ms)
+}
+
+// rollingTrade is a parsed trade held by RollingStats
+type rollingTrade struct {
+	timestamp int64
+	price     *big.Rat
+	size      *big.Rat
+	notional  *big.Rat
+}
+
+// rollingQueue is a FIFO of trades ordered by timestamp
+// Evicted trades are skipped by advancing head; the backing slice is compacted
+// once at least half of it is evicted, so appends and evictions are amortized O(1).
+type rollingQueue struct {
+	items []rollingTrade
+	head  int
+}
+
+// len returns the number of trades in the queue
+func (q *rollingQueue) len() int {
+	return len(q.items) - q.head
+}
+
+// at returns the ith trade from the front
+func (q *rollingQueue) at(i int) *rollingTrade {
+	return &q.items[q.head+i]
+}
+
+// back returns the newest trade; the queue must not be empty
+func (q *rollingQueue) back() *rollingTrade {
+	return &q.items[len(q.items)-1]
+}
+
+// push appends a trade at the back
+func (q *rollingQueue) push(t rollingTrade) {
+	q.items = append(q.items, t)
+}
+
+// popBack drops the newest trade
+func (q *rollingQueue) popBack() {
+	q.items[len(q.items)-1] = rollingTrade{}
+	q.items = q.items[:len(q.items)-1]
+}
+
+// popFront drops the oldest trade
+func (q *rollingQueue) popFront() {
+	q.items[q.head] = rollingTrade{}
+	q.head++
+	if q.head*2 >= len(q.items) {
+		n := copy(q.items, q.items[q.head:])
+		clear(q.items[n:])
+		q.items = q.items[:n]
+		q.head = 0
+	}
+}
+
+// insert adds a trade after every trade with a timestamp <= its own, shifting later trades
+func (q *rollingQueue) insert(t rollingTrade) {
+	trades := q.items[q.head:]
+	i := sort.Search(len(trades), func(i int) bool { return trades[i].timestamp > t.timestamp })
+	q.items = append(q.items, rollingTrade{})
+	trades = q.items[q.head:]
+	copy(trades[i+1:], trades[i:])
+	trades[i] = t
+}
+
+// reset drops all trades
+func (q *rollingQueue) reset() {
+	q.items = nil
+	q.head = 0
+}
+
+// extremeDeque is a monotonic deque tracking the highest (sign 1) or lowest
+// (sign -1) price of the trades in the window
+// It holds, in timestamp order, the trades not outdone by a later trade, so
+// the front is the extreme and each trade is pushed and popped at most once.
+type extremeDeque struct {
+	rollingQueue
+	sign int
+}
+
+// push adds the newest trade, dropping the trades it outdoes
+func (d *extremeDeque) push(t rollingTrade) {
+	for d.len() > 0 && d.back().price.Cmp(t.price)*d.sign <= 0 {
+		d.popBack()
+	}
+	d.rollingQueue.push(t)
+}
+
+// evict drops trades with timestamp <= cutoff
+func (d *extremeDeque) evict(cutoff int64) {
+	for d.len() > 0 && d.at(0).timestamp <= cutoff {
+		d.popFront()
+	}
+}
+
+// RollingStats computes high/low/volume/VWAP over a time window from the trades feed
+// Trades are evicted once they are older than the window relative to the newest
+// trade seen, or to the time passed to Evict. Use one RollingStats per symbol.
+//
+// Trades arriving in timestamp order are added in amortized constant time and
+// Snapshot does not scan the window; an out-of-order trade costs a pass over it.
+//
+// Example:
+//
+//	stats := public.NewRollingStats("cmt_btcusdt", 24*time.Hour)
+//	client.SubscribeTradesEach("cmt_btcusdt", stats.Update)
+//	...
+//	s := stats.Snapshot()
+//	fmt.Println(s.High, s.Low, s.Volume, s.VWAP)
+type RollingStats struct {
+	symbol string
+	window time.Duration
+
+	mu       sync.Mutex
+	trades   rollingQueue // Sorted by timestamp
+	highs    extremeDeque
+	lows     extremeDeque
+	volume   *big.Rat
+	notional *big.Rat
+}
+
+// NewRollingStats creates a RollingStats for symbol over window (DefaultRollingStatsWindow if <= 0)
+func NewRollingStats(symbol string, window time.Duration) *RollingStats {
+	if window <= 0 {
+		window = DefaultRollingStatsWindow
+	}
+	return &RollingStats{
+		symbol:   symbol,
+		window:   window,
+		highs:    extremeDeque{sign: 1},
+		lows:     extremeDeque{sign: -1},
+		volume:   new(big.Rat),
+		notional: new(big.Rat),
+	}
+}
+
+// Update adds a trade to the window and evicts trades that fell out of it
+// Trades for other symbols and trades already older than the window are ignored.
+// It matches TradeItemCallback so it can be passed to SubscribeTradesEach.
+func (s *RollingStats) Update(item *websocket.TradeItem) error {
+	if s.symbol != "" && item.Symbol != "" && item.Symbol != s.symbol {
+		return nil
+	}
+
+	price, err := item.Price.Rat()
+	if err != nil {
+		return err
+	}
+	size, err := item.Size.Rat()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	newest := item.Timestamp
+	if s.trades.len() > 0 && s.trades.back().timestamp > newest {
+		newest = s.trades.back().timestamp
+	}
+	if item.Timestamp <= s.cutoff(newest) {
+		return nil
+	}
+
+	trade := rollingTrade{
+		timestamp: item.Timestamp,
+		price:     price,
+		size:      size,
+		notional:  new(big.Rat).Mul(price, size),
+	}
+	if newest == trade.timestamp {
+		s.trades.push(trade)
+		s.highs.push(trade)
+		s.lows.push(trade)
+	} else {
+		s.trades.insert(trade)
+		s.rebuildExtremes()
+	}
+	s.volume.Add(s.volume, trade.size)
+	s.notional.Add(s.notional, trade.notional)
+
+	s.evict(s.cutoff(newest))
+	return nil
+}
+
+// Evict drops trades older than the window relative to now
+// Call it periodically if the feed can go quiet, so stale trades do not linger.
+func (s *RollingStats) Evict(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evict(s.cutoff(now.UnixMilli()))
+}
+
+// Snapshot returns the current aggregate over the window
+func (s *RollingStats) Snapshot() RollingStatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap := RollingStatsSnapshot{
+		Symbol:   s.symbol,
+		Volume:   types.NewDecimalFromRat(s.volume),
+		Notional: types.NewDecimalFromRat(s.notional),
+		Count:    s.trades.len(),
+	}
+	if s.trades.len() == 0 {
+		return snap
+	}
+
+	snap.High = types.NewDecimalFromRat(s.highs.at(0).price)
+	snap.Low = types.NewDecimalFromRat(s.lows.at(0).price)
+	snap.From = s.trades.at(0).timestamp
+	snap.To = s.trades.back().timestamp
+	if s.volume.Sign() != 0 {
+		snap.VWAP = types.NewDecimalFromRat(new(big.Rat).Quo(s.notional, s.volume))
+	}
+	return snap
+}
+
+// Window returns the aggregation window
+func (s *RollingStats) Window() time.Duration {
+	return s.window
+}
+
+// Reset drops all trades
+func (s *RollingStats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.trades.reset()
+	s.highs.reset()
+	s.lows.reset()
+	s.volume.SetInt64(0)
+	s.notional.SetInt64(0)
+}
+
+// cutoff returns the timestamp at or before which trades are outside the window ending at newest
+func (s *RollingStats) cutoff(newest int64) int64 {
+	return newest - s.window.Milliseconds()
+}
+
+// evict drops trades with timestamp <= cutoff; the caller must hold mu
+func (s *RollingStats) evict(cutoff int64) {
+	for s.trades.len() > 0 && s.trades.at(0).timestamp <= cutoff {
+		t := s.trades.at(0)
+		s.volume.Sub(s.volume, t.size)
+		s.notional.Sub(s.notional, t.notional)
+		s.trades.popFront()
+	}
+	s.highs.evict(cutoff)
+	s.lows.evict(cutoff)
+}
+
+// rebuildExtremes refills the high and low deques from the window after an out-of-order insert
+// The caller must hold mu.
+func (s *RollingStats) rebuildExtremes() {
+	s.highs.reset()
+	s.lows.reset()
+	for i := 0; i < s.trades.len(); i++ {
+		s.highs.push(*s.trades.at(i))
+		s.lows.push(*s.trades.at(i))
+	}
+}
@@ -0,0 +1,162 @@
+package public
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+)
+
+// minute is one minute in milliseconds, the unit of trade timestamps in these tests
+const minute = int64(time.Minute / time.Millisecond)
+
+func TestRollingStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		trades []websocket.TradeItem
+		want   RollingStatsSnapshot
+	}{
+		{"empty", nil, RollingStatsSnapshot{Symbol: "btc", Volume: "0", Notional: "0"}},
+		{"all inside window", []websocket.TradeItem{
+			{Price: "100", Size: "1", Timestamp: 0},
+			{Price: "110", Size: "2", Timestamp: 10 * minute},
+			{Price: "90", Size: "1", Timestamp: 20 * minute},
+		}, RollingStatsSnapshot{Symbol: "btc", High: "110", Low: "90", Volume: "4", Notional: "410", VWAP: "102.5", Count: 3, From: 0, To: 20 * minute}},
+		{"oldest evicted", []websocket.TradeItem{
+			{Price: "200", Size: "1", Timestamp: 0},
+			{Price: "100", Size: "1", Timestamp: 30 * minute},
+			{Price: "110", Size: "1", Timestamp: 61 * minute},
+		}, RollingStatsSnapshot{Symbol: "btc", High: "110", Low: "100", Volume: "2", Notional: "210", VWAP: "105", Count: 2, From: 30 * minute, To: 61 * minute}},
+		{"trade exactly one window old evicted", []websocket.TradeItem{
+			{Price: "200", Size: "1", Timestamp: 0},
+			{Price: "100", Size: "1", Timestamp: 60 * minute},
+		}, RollingStatsSnapshot{Symbol: "btc", High: "100", Low: "100", Volume: "1", Notional: "100", VWAP: "100", Count: 1, From: 60 * minute, To: 60 * minute}},
+		{"late trade outside window ignored", []websocket.TradeItem{
+			{Price: "100", Size: "1", Timestamp: 120 * minute},
+			{Price: "500", Size: "1", Timestamp: 30 * minute},
+		}, RollingStatsSnapshot{Symbol: "btc", High: "100", Low: "100", Volume: "1", Notional: "100", VWAP: "100", Count: 1, From: 120 * minute, To: 120 * minute}},
+		{"late trade inside window kept in order", []websocket.TradeItem{
+			{Price: "100", Size: "1", Timestamp: 30 * minute},
+			{Price: "120", Size: "1", Timestamp: 10 * minute},
+		}, RollingStatsSnapshot{Symbol: "btc", High: "120", Low: "100", Volume: "2", Notional: "220", VWAP: "110", Count: 2, From: 10 * minute, To: 30 * minute}},
+		{"other symbol ignored", []websocket.TradeItem{
+			{Symbol: "btc", Price: "100", Size: "1", Timestamp: 0},
+			{Symbol: "eth", Price: "5", Size: "10", Timestamp: minute},
+		}, RollingStatsSnapshot{Symbol: "btc", High: "100", Low: "100", Volume: "1", Notional: "100", VWAP: "100", Count: 1, From: 0, To: 0}},
+		{"exact decimals", []websocket.TradeItem{
+			{Price: "0.1", Size: "3", Timestamp: 0},
+			{Price: "0.2", Size: "3", Timestamp: minute},
+		}, RollingStatsSnapshot{Symbol: "btc", High: "0.2", Low: "0.1", Volume: "6", Notional: "0.9", VWAP: "0.15", Count: 2, From: 0, To: minute}},
+		{"non-terminating VWAP", []websocket.TradeItem{
+			{Price: "1", Size: "1", Timestamp: 0},
+			{Price: "2", Size: "2", Timestamp: minute},
+		}, RollingStatsSnapshot{Symbol: "btc", High: "2", Low: "1", Volume: "3", Notional: "5", VWAP: "1.666666666666666667", Count: 2, From: 0, To: minute}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := NewRollingStats("btc", time.Hour)
+			for i := range tt.trades {
+				if err := stats.Update(&tt.trades[i]); err != nil {
+					t.Fatalf("Update(%d) error = %v", i, err)
+				}
+			}
+			if got := stats.Snapshot(); got != tt.want {
+				t.Errorf("Snapshot() = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRollingStatsEvict(t *testing.T) {
+	stats := NewRollingStats("btc", time.Hour)
+	for _, trade := range []websocket.TradeItem{
+		{Price: "100", Size: "1", Timestamp: 0},
+		{Price: "110", Size: "1", Timestamp: 30 * minute},
+	} {
+		if err := stats.Update(&trade); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	// The feed went quiet: only the trade within an hour of now remains
+	stats.Evict(time.UnixMilli(80 * minute))
+	if got := stats.Snapshot(); got.Count != 1 || got.Volume != "1" || got.High != "110" {
+		t.Errorf("after Evict: %+v, want only the 110 trade", got)
+	}
+
+	stats.Evict(time.UnixMilli(200 * minute))
+	if got := stats.Snapshot(); got.Count != 0 || got.Volume != "0" || got.Notional != "0" || got.VWAP != "" {
+		t.Errorf("after evicting everything: %+v, want empty", got)
+	}
+
+	stats.Update(&websocket.TradeItem{Price: "1", Size: "1", Timestamp: 300 * minute})
+	stats.Reset()
+	if got := stats.Snapshot(); got.Count != 0 || got.Volume != "0" {
+		t.Errorf("after Reset: %+v, want empty", got)
+	}
+}
+
+func TestRollingStatsInvalidTrade(t *testing.T) {
+	stats := NewRollingStats("", 0)
+	if got := stats.Window(); got != DefaultRollingStatsWindow {
+		t.Errorf("Window() = %v, want %v", got, DefaultRollingStatsWindow)
+	}
+	if err := stats.Update(&websocket.TradeItem{Price: "abc", Size: "1", Timestamp: 1}); err == nil {
+		t.Error("Update() with an invalid price returned no error")
+	}
+	if err := stats.Update(&websocket.TradeItem{Price: "1", Size: "x", Timestamp: 1}); err == nil {
+		t.Error("Update() with an invalid size returned no error")
+	}
+	if got := stats.Snapshot(); got.Count != 0 {
+		t.Errorf("Count = %d, want invalid trades skipped", got.Count)
+	}
+}
+
+func TestRollingStatsMatchesScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	stats := NewRollingStats("btc", 10*time.Minute)
+	var window []websocket.TradeItem // Trades inside the window, kept by a full scan
+
+	newest := int64(0)
+	for i := 0; i < 2000; i++ {
+		ts := newest + rng.Int63n(minute)
+		if rng.Intn(10) == 0 {
+			ts = newest - rng.Int63n(5*minute) // Out of order
+		}
+		item := websocket.TradeItem{Price: types.Decimal(strconv.Itoa(100 + rng.Intn(50))), Size: "1", Timestamp: ts}
+		if err := stats.Update(&item); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		newest = max(newest, ts)
+		cutoff := newest - 10*minute
+		kept := window[:0]
+		for _, w := range append(window, item) {
+			if w.Timestamp > cutoff {
+				kept = append(kept, w)
+			}
+		}
+		window = kept
+
+		snap := stats.Snapshot()
+		if snap.Count != len(window) {
+			t.Fatalf("trade %d: Count = %d, want %d", i, snap.Count, len(window))
+		}
+		high, low := window[0].Price, window[0].Price
+		for _, w := range window[1:] {
+			if w.Price.Cmp(high) > 0 {
+				high = w.Price
+			}
+			if w.Price.Cmp(low) < 0 {
+				low = w.Price
+			}
+		}
+		if snap.High != high || snap.Low != low {
+			t.Fatalf("trade %d: High, Low = %s, %s, want %s, %s", i, snap.High, snap.Low, high, low)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package public
+
+import (
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+// newTestServer starts a server that acks every subscribed channel and then sends
+// the frames returned by reply (which may be nil) on the same connection.
+func newTestServer(t *testing.T, reply func(channel string) []string) *wstest.Server {
+	t.Helper()
+	return wstest.NewServer(t, wstest.AckSubscribes(reply))
+}
+
+// testConfig returns a quiet config pointing the public URL at s
+func testConfig(s *wstest.Server) *weex.Config {
+	config := weex.NewDefaultConfig()
+	config.WSPublicURL = s.WSURL()
+	config.Logger = weex.NewNoOpLogger()
+	return config
+}
+
+// connectTestClient connects a public client to s
+func connectTestClient(t *testing.T, s *wstest.Server) *Client {
+	t.Helper()
+	client := NewClient(testConfig(s))
+	if err := client.Connect(t.Context()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
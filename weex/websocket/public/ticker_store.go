@@ -0,0 +1,99 @@
+package public
+
+import (
+	"sync"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+)
+
+// TickerStore keeps the most recent ticker per symbol from the ticker channel
+//
+// Example:
+//
+//	store := public.NewTickerStore(client)
+//	store.Subscribe("cmt_btcusdt")
+//	...
+//	if ticker, ok := store.Get("cmt_btcusdt"); ok && !store.IsStale("cmt_btcusdt", 5*time.Second) {
+//	    fmt.Println(ticker.LastPrice)
+//	}
+type TickerStore struct {
+	client  *Client
+	mu      sync.RWMutex
+	tickers map[string]tickerEntry
+}
+
+// tickerEntry is a stored ticker with its local receive time
+type tickerEntry struct {
+	item    websocket.TickerItem
+	updated time.Time
+}
+
+// NewTickerStore creates a new TickerStore backed by client
+func NewTickerStore(client *Client) *TickerStore {
+	return &TickerStore{
+		client:  client,
+		tickers: make(map[string]tickerEntry),
+	}
+}
+
+// Subscribe subscribes to the ticker channel for symbol and keeps the store updated
+func (s *TickerStore) Subscribe(symbol string) error {
+	return s.client.SubscribeTickerEach(symbol, s.Update)
+}
+
+// Unsubscribe unsubscribes from the ticker channel for symbol and drops its state
+func (s *TickerStore) Unsubscribe(symbol string) error {
+	s.mu.Lock()
+	delete(s.tickers, symbol)
+	s.mu.Unlock()
+	return s.client.UnsubscribeTicker(symbol)
+}
+
+// Update stores item as the latest ticker for its symbol
+// Updates older than the stored ticker (by Timestamp) are ignored
+func (s *TickerStore) Update(item *websocket.TickerItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.tickers[item.Symbol]; ok && item.Timestamp > 0 && item.Timestamp < current.item.Timestamp {
+		return nil
+	}
+	s.tickers[item.Symbol] = tickerEntry{item: *item, updated: time.Now()}
+	return nil
+}
+
+// Get returns the latest ticker for symbol
+func (s *TickerStore) Get(symbol string) (websocket.TickerItem, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.tickers[symbol]
+	return entry.item, ok
+}
+
+// LastUpdate returns when the ticker for symbol was last received (zero if never)
+func (s *TickerStore) LastUpdate(symbol string) time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.tickers[symbol].updated
+}
+
+// IsStale returns true if no ticker for symbol was received within maxAge
+func (s *TickerStore) IsStale(symbol string, maxAge time.Duration) bool {
+	updated := s.LastUpdate(symbol)
+	return updated.IsZero() || time.Since(updated) > maxAge
+}
+
+// Symbols returns the symbols that currently have a stored ticker
+func (s *TickerStore) Symbols() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	symbols := make([]string, 0, len(s.tickers))
+	for symbol := range s.tickers {
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
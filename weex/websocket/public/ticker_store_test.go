@@ -0,0 +1,101 @@
+package public
+
+import (
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestTickerStoreUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		updates []websocket.TickerItem
+		want    map[string]types.Decimal // Latest LastPrice per symbol
+	}{
+		{"latest wins", []websocket.TickerItem{
+			{Symbol: "btc", LastPrice: "100", Timestamp: 1},
+			{Symbol: "btc", LastPrice: "101", Timestamp: 2},
+		}, map[string]types.Decimal{"btc": "101"}},
+		{"older update ignored", []websocket.TickerItem{
+			{Symbol: "btc", LastPrice: "101", Timestamp: 2},
+			{Symbol: "btc", LastPrice: "100", Timestamp: 1},
+		}, map[string]types.Decimal{"btc": "101"}},
+		{"missing timestamp accepted", []websocket.TickerItem{
+			{Symbol: "btc", LastPrice: "101", Timestamp: 2},
+			{Symbol: "btc", LastPrice: "102"},
+		}, map[string]types.Decimal{"btc": "102"}},
+		{"symbols independent", []websocket.TickerItem{
+			{Symbol: "btc", LastPrice: "100", Timestamp: 5},
+			{Symbol: "eth", LastPrice: "10", Timestamp: 1},
+		}, map[string]types.Decimal{"btc": "100", "eth": "10"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewTickerStore(nil)
+			for i := range tt.updates {
+				store.Update(&tt.updates[i])
+			}
+			for symbol, want := range tt.want {
+				got, ok := store.Get(symbol)
+				if !ok || got.LastPrice != want {
+					t.Errorf("Get(%s) = %s, %v, want %s", symbol, got.LastPrice, ok, want)
+				}
+			}
+			if symbols := store.Symbols(); len(symbols) != len(tt.want) {
+				t.Errorf("Symbols() = %v, want %d symbols", symbols, len(tt.want))
+			}
+			if _, ok := store.Get("unknown"); ok {
+				t.Error("Get(unknown) ok = true")
+			}
+		})
+	}
+}
+
+func TestTickerStoreStaleness(t *testing.T) {
+	store := NewTickerStore(nil)
+	if !store.IsStale("btc", time.Hour) || !store.LastUpdate("btc").IsZero() {
+		t.Error("never updated symbol not reported stale")
+	}
+
+	before := time.Now()
+	store.Update(&websocket.TickerItem{Symbol: "btc", LastPrice: "100"})
+	if updated := store.LastUpdate("btc"); updated.Before(before) {
+		t.Errorf("LastUpdate() = %v, want after %v", updated, before)
+	}
+	if store.IsStale("btc", time.Hour) {
+		t.Error("fresh ticker reported stale")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if !store.IsStale("btc", 10*time.Millisecond) {
+		t.Error("old ticker not reported stale")
+	}
+}
+
+func TestTickerStoreSubscribe(t *testing.T) {
+	server := newTestServer(t, func(channel string) []string {
+		return []string{
+			`{"channel":"` + channel + `","data":[{"symbol":"cmt_btcusdt","lastPrice":"100","timestamp":1}]}`,
+			`{"channel":"` + channel + `","data":[{"symbol":"cmt_btcusdt","lastPrice":"101.5","timestamp":2}]}`,
+		}
+	})
+	client := connectTestClient(t, server)
+	store := NewTickerStore(client)
+
+	if err := store.Subscribe("cmt_btcusdt"); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	wstest.WaitFor(t, "latest ticker", func() bool {
+		ticker, ok := store.Get("cmt_btcusdt")
+		return ok && ticker.LastPrice == "101.5"
+	})
+
+	if err := store.Unsubscribe("cmt_btcusdt"); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if _, ok := store.Get("cmt_btcusdt"); ok {
+		t.Error("ticker kept after Unsubscribe")
+	}
+}
@@ -0,0 +1,158 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/rest/market"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+// contractsMarket returns a market service whose contract list is body, or an HTTP 500 when body is empty
+func contractsMarket(t *testing.T, body string) *market.Service {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if body == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	config := weex.NewDefaultConfig().WithBaseURL(server.URL)
+	config.MaxRetries = 0
+	config.Logger = weex.NewNoOpLogger()
+	client, err := weex.NewPublicClient(config)
+	if err != nil {
+		t.Fatalf("NewPublicClient() error = %v", err)
+	}
+	return client.Market()
+}
+
+// contractList returns the JSON contract list of n symbols, listed in reverse order
+func contractList(n int) (string, []string) {
+	var items, symbols []string
+	for i := n - 1; i >= 0; i-- {
+		symbol := fmt.Sprintf("cmt_s%02dusdt", i)
+		items = append(items, fmt.Sprintf(`{"symbol":%q,"tick_size":"0.1"}`, symbol))
+		symbols = append(symbols, symbol)
+	}
+	slices.Sort(symbols)
+	return "[" + strings.Join(items, ",") + "]", symbols
+}
+
+func TestSubscribeAllTickers(t *testing.T) {
+	tests := []struct {
+		name        string
+		contracts   int
+		maxPerFrame int
+		wantFrames  []int // args per subscribe frame
+	}{
+		{"single frame", 3, 20, []int{3}},
+		{"exact frames", 40, 20, []int{20, 20}},
+		{"chunked", 45, 20, []int{20, 20, 5}},
+		{"small frames", 7, 3, []int{3, 3, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, symbols := contractList(tt.contracts)
+			server := newTestServer(t, func(channel string) []string {
+				symbol := strings.TrimPrefix(channel, "ticker.")
+				return []string{`{"channel":"` + channel + `","data":[{"symbol":"` + symbol + `","lastPrice":"1"}]}`}
+			})
+			config := testConfig(server)
+			config.WSMaxArgsPerFrame = tt.maxPerFrame
+			client := NewClient(config)
+			if err := client.Connect(t.Context()); err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+			defer client.Close()
+			client.SetMarketService(contractsMarket(t, body))
+
+			var mu sync.Mutex
+			received := make(map[string]int)
+			got, err := client.SubscribeAllTickers(context.Background(), func(data *websocket.TickerData) error {
+				mu.Lock()
+				defer mu.Unlock()
+				for _, item := range data.Data {
+					received[item.Symbol]++
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("SubscribeAllTickers() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, symbols) {
+				t.Errorf("SubscribeAllTickers() = %v, want %v", got, symbols)
+			}
+
+			var sizes []int
+			var channels []string
+			wstest.WaitFor(t, "subscribe frames", func() bool {
+				sizes, channels = nil, nil
+				for _, frame := range server.Frames() {
+					if frame.Op == "subscribe" {
+						sizes = append(sizes, len(frame.Args))
+						channels = append(channels, frame.Args...)
+					}
+				}
+				return len(channels) >= len(symbols)
+			})
+			if !reflect.DeepEqual(sizes, tt.wantFrames) {
+				t.Errorf("subscribe frame sizes = %v, want %v", sizes, tt.wantFrames)
+			}
+			slices.Sort(channels)
+			for i, symbol := range symbols {
+				if i >= len(channels) || channels[i] != "ticker."+symbol {
+					t.Fatalf("subscribed channels = %v, want one ticker channel per symbol %v", channels, symbols)
+				}
+			}
+			// The single callback receives the pushes of every symbol
+			wstest.WaitFor(t, "ticker pushes", func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(received) == len(symbols)
+			})
+		})
+	}
+}
+
+func TestSubscribeAllTickersErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		market func(t *testing.T) *market.Service
+	}{
+		{"no market service", func(*testing.T) *market.Service { return nil }},
+		{"contracts request fails", func(t *testing.T) *market.Service { return contractsMarket(t, "") }},
+		{"no contracts", func(t *testing.T) *market.Service { return contractsMarket(t, `[]`) }},
+		{"contracts without symbols", func(t *testing.T) *market.Service { return contractsMarket(t, `[{"tick_size":"0.1"}]`) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, nil)
+			client := connectTestClient(t, server)
+			if svc := tt.market(t); svc != nil {
+				client.SetMarketService(svc)
+			}
+
+			got, err := client.SubscribeAllTickers(context.Background(), func(*websocket.TickerData) error { return nil })
+			if err == nil || got != nil {
+				t.Errorf("SubscribeAllTickers() = %v, %v, want an error", got, err)
+			}
+			if frames := server.Frames(); len(frames) != 0 {
+				t.Errorf("frames sent = %+v, want none", frames)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package websocket
+
+import (
+	"io"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// readMessage reads the next message, extending the read deadline while data arrives
+//
+// A large message may arrive as many fragments over longer than pongWait. Each
+// read that makes progress pushes the deadline out by pongWait, so the deadline
+// only fires when the peer stalls, not while a legitimate large frame is still
+// streaming in. The deadline is also refreshed once the message is complete,
+// since any inbound data shows the connection is alive.
+func readMessage(conn *websocket.Conn, pongWait time.Duration) ([]byte, error) {
+	_, r, err := conn.NextReader()
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := io.ReadAll(&deadlineReader{r: r, conn: conn, wait: pongWait})
+	if err != nil {
+		return nil, err
+	}
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	return message, nil
+}
+
+// deadlineReader extends the connection read deadline as message data is read
+type deadlineReader struct {
+	r    io.Reader
+	conn *websocket.Conn
+	wait time.Duration
+}
+
+// Read implements io.Reader
+func (d *deadlineReader) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	if n > 0 {
+		d.conn.SetReadDeadline(time.Now().Add(d.wait))
+	}
+	return n, err
+}
@@ -0,0 +1,133 @@
+package websocket
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+)
+
+// fragmentServer sends message on every connection as 1 KiB fragments, sleeping
+// gap between fragments and stall halfway through. It returns the server and
+// a counter of accepted connections.
+func fragmentServer(t *testing.T, message []byte, gap, stall time.Duration) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var conns atomic.Int32
+	upgrader := websocket.Upgrader{WriteBufferSize: 1024}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conns.Add(1)
+
+		writer, err := conn.NextWriter(websocket.TextMessage)
+		if err != nil {
+			return
+		}
+		chunks := (len(message) + 1023) / 1024
+		for i := 0; i < chunks; i++ {
+			if _, err := writer.Write(message[i*1024 : min((i+1)*1024, len(message))]); err != nil {
+				return
+			}
+			time.Sleep(gap)
+			if i == chunks/2 {
+				time.Sleep(stall)
+			}
+		}
+		writer.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s, &conns
+}
+
+func TestReadMessageFragmented(t *testing.T) {
+	const pongWait = 100 * time.Millisecond
+
+	tests := []struct {
+		name    string
+		size    int
+		gap     time.Duration
+		stall   time.Duration
+		wantErr bool
+	}{
+		{"single frame", 512, 0, 0, false},
+		{"fragments within deadline", 10 * 1024, 0, 0, false},
+		{"fragments spanning several deadlines", 10 * 1024, 40 * time.Millisecond, 0, false},
+		{"stall mid-message", 10 * 1024, 0, 3 * pongWait, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := bytes.Repeat([]byte("x"), tt.size)
+			s, _ := fragmentServer(t, want, tt.gap, tt.stall)
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(s.URL, "http"), nil)
+			if err != nil {
+				t.Fatalf("Dial() error = %v", err)
+			}
+			defer conn.Close()
+
+			conn.SetReadDeadline(time.Now().Add(pongWait))
+			message, err := readMessage(conn, pongWait)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("readMessage() expected a timeout error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readMessage() error = %v", err)
+			}
+			if !bytes.Equal(message, want) {
+				t.Errorf("readMessage() returned %d bytes, want %d intact", len(message), len(want))
+			}
+		})
+	}
+}
+
+func TestLargeFragmentedMessageNoReconnect(t *testing.T) {
+	const pongWait = 100 * time.Millisecond
+	message := []byte(`{"channel":"depth.big","data":"` + strings.Repeat("x", 20*1024) + `"}`)
+	s, conns := fragmentServer(t, message, 20*time.Millisecond, 0) // ~400ms, four times pongWait
+
+	client := NewClient(&weex.Config{
+		WSPublicURL: "ws" + strings.TrimPrefix(s.URL, "http"),
+		Logger:      weex.NewNoOpLogger(),
+	})
+	client.pongWait = pongWait
+	got := make(chan []byte, 1)
+	client.SetOnUnhandled(func(message []byte) {
+		select {
+		case got <- message:
+		default:
+		}
+	})
+	if err := client.Connect(t.Context()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	select {
+	case received := <-got:
+		if !bytes.Equal(received, message) {
+			t.Errorf("received %d bytes, want %d intact", len(received), len(message))
+		}
+		if n := conns.Load(); n != 1 {
+			t.Errorf("connections = %d, want 1", n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("large message not received")
+	}
+}
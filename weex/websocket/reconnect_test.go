@@ -0,0 +1,73 @@
+package websocket
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestMessagesFlowAfterReconnect(t *testing.T) {
+	tests := []struct {
+		name  string
+		drops int
+	}{
+		{"one drop", 1},
+		{"repeated drops", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every subscribe is acked and followed by one data push per channel
+			server := newTestServer(t, func(req wstest.Frame) []string {
+				var out []string
+				for _, channel := range req.Args {
+					out = append(out, wstest.Ack(channel), `{"channel":"`+channel+`","data":[{"last":"1"}]}`)
+				}
+				return out
+			})
+
+			var mu sync.Mutex
+			received := make(map[string]int)
+			handler := func(channel string) MessageHandler {
+				return func([]byte) error {
+					mu.Lock()
+					defer mu.Unlock()
+					received[channel]++
+					return nil
+				}
+			}
+			count := func(channel string) int {
+				mu.Lock()
+				defer mu.Unlock()
+				return received[channel]
+			}
+			client := connectTestClient(t, server, nil)
+			client.reconnectDelay = 10 * time.Millisecond
+			if err := client.Subscribe("ticker.a", handler("ticker.a")); err != nil {
+				t.Fatalf("Subscribe() error = %v", err)
+			}
+			wstest.WaitFor(t, "first ticker.a push", func() bool { return count("ticker.a") == 1 })
+
+			for i := 1; i <= tt.drops; i++ {
+				server.DropConns()
+				wstest.WaitFor(t, fmt.Sprintf("reconnect %d", i), func() bool { return server.Conns() == i+1 && client.IsConnected() })
+
+				// Reads work again: the resubscribed channel receives its push
+				wstest.WaitFor(t, fmt.Sprintf("ticker.a push after reconnect %d", i), func() bool { return count("ticker.a") == i+1 })
+
+				// Writes work again: a new subscription reaches the server and is served
+				channel := fmt.Sprintf("ticker.new%d", i)
+				if err := client.Subscribe(channel, handler(channel)); err != nil {
+					t.Fatalf("Subscribe(%s) after reconnect %d error = %v", channel, i, err)
+				}
+				wstest.WaitFor(t, channel+" push", func() bool { return count(channel) >= 1 })
+			}
+
+			if got := client.Generation(); got != uint64(tt.drops+1) {
+				t.Errorf("Generation() = %d, want %d", got, tt.drops+1)
+			}
+		})
+	}
+}
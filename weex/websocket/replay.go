@@ -0,0 +1,156 @@
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+// RecordedFrame is one raw message of a recorded session, stored as a JSON line
+type RecordedFrame struct {
+	Time types.Millis `json:"time"` // Local receive time
+	Data string       `json:"data"` // Raw message as received
+}
+
+// Recorder writes raw messages to w as JSON lines of RecordedFrame
+//
+// Example:
+//
+//	recorder := websocket.NewRecorder(file)
+//	client.SetRawTap(recorder.Record)
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewRecorder creates a Recorder writing to w
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Record writes message with the current time
+// After the first write error further messages are dropped; see Err.
+func (r *Recorder) Record(message []byte) {
+	r.RecordAt(message, time.Now())
+}
+
+// RecordAt writes message with the given receive time
+func (r *Recorder) RecordAt(message []byte, received time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return
+	}
+	r.err = r.enc.Encode(RecordedFrame{Time: types.Millis(received.UnixMilli()), Data: string(message)})
+}
+
+// Err returns the first write error, if any
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// ReplayTarget receives replayed messages; implemented by the public and private clients
+type ReplayTarget interface {
+	ReplayMessage(message []byte)
+}
+
+// Replayer feeds a session recorded by Recorder back through a client's handlers
+//
+// Frames are dispatched one at a time on the calling goroutine, in recorded
+// order, so handler behavior is reproducible. The target client must be in
+// replay mode (StartReplay) with its subscriptions registered.
+type Replayer struct {
+	reader *bufio.Reader
+	speed  float64
+}
+
+// NewReplayer creates a Replayer reading a recorded session from r
+// Frames are replayed as fast as possible until SetSpeed is called.
+func NewReplayer(r io.Reader) *Replayer {
+	return &Replayer{reader: bufio.NewReader(r)}
+}
+
+// SetSpeed sets the replay speed relative to the recording
+// 1 keeps the original gaps between frames, 10 replays ten times faster and
+// 0 (the default) dispatches frames without waiting.
+func (r *Replayer) SetSpeed(speed float64) {
+	r.speed = speed
+}
+
+// Run replays the remaining frames into target until the end of the recording or ctx is done
+// Returns the number of frames replayed.
+func (r *Replayer) Run(ctx context.Context, target ReplayTarget) (int, error) {
+	var (
+		count int
+		prev  types.Millis
+	)
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var frame RecordedFrame
+			if jerr := json.Unmarshal(line, &frame); jerr != nil {
+				return count, fmt.Errorf("invalid recorded frame %d: %w", count+1, jerr)
+			}
+			if r.speed > 0 && count > 0 && frame.Time > prev {
+				delay := time.Duration(float64(frame.Time-prev) * float64(time.Millisecond) / r.speed)
+				select {
+				case <-ctx.Done():
+					return count, ctx.Err()
+				case <-time.After(delay):
+				}
+			} else if ctx.Err() != nil {
+				return count, ctx.Err()
+			}
+			target.ReplayMessage([]byte(frame.Data))
+			prev = frame.Time
+			count++
+		}
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to read recorded session: %w", err)
+		}
+	}
+}
+
+// SetRawTap sets a callback receiving every raw message read from the connection
+// The callback runs on the read goroutine before the message is routed, so it
+// must not block; Recorder.Record is suitable.
+func (c *Client) SetRawTap(callback func(message []byte)) {
+	c.onRaw = callback
+}
+
+// StartReplay puts a disconnected client into offline replay mode
+// The client reports itself connected so handlers can be subscribed as usual,
+// but nothing is sent or received over the network; outgoing frames are
+// discarded. Messages are delivered with ReplayMessage. Close ends replay mode.
+func (c *Client) StartReplay() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state != StateDisconnected {
+		return fmt.Errorf("replay requires a disconnected client")
+	}
+	c.done = make(chan struct{})
+	c.reconnect = make(chan struct{}, 1)
+	c.replaying = true
+	c.setState(StateConnected)
+	return nil
+}
+
+// ReplayMessage routes message to the subscribed handlers as if it had been received
+func (c *Client) ReplayMessage(message []byte) {
+	c.handleMessage(message)
+}
@@ -0,0 +1,123 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+)
+
+// replayCollector records the messages replayed into it
+type replayCollector struct {
+	messages []string
+}
+
+func (c *replayCollector) ReplayMessage(message []byte) {
+	c.messages = append(c.messages, string(message))
+}
+
+func TestReplayerRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		recording string
+		want      []string
+		wantErr   string
+	}{
+		{"empty recording", "", nil, ""},
+		{"frames in order", `{"time":1,"data":"a"}` + "\n" + `{"time":2,"data":"b"}` + "\n", []string{"a", "b"}, ""},
+		{"missing final newline", `{"time":1,"data":"a"}` + "\n" + `{"time":2,"data":"b"}`, []string{"a", "b"}, ""},
+		{"invalid frame", `{"time":1,"data":"a"}` + "\n" + `not json` + "\n" + `{"time":3,"data":"c"}`, []string{"a"}, "invalid recorded frame 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var target replayCollector
+			count, err := NewReplayer(strings.NewReader(tt.recording)).Run(context.Background(), &target)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Run() error = %v, want %q", err, tt.wantErr)
+			}
+			if count != len(tt.want) || strings.Join(target.messages, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Run() = %d, replayed %v, want %v", count, target.messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayerRunCancelled(t *testing.T) {
+	var recording bytes.Buffer
+	recorder := NewRecorder(&recording)
+	start := time.Now()
+	recorder.RecordAt([]byte("a"), start)
+	recorder.RecordAt([]byte("b"), start.Add(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	replayer := NewReplayer(&recording)
+	replayer.SetSpeed(1)
+
+	var target replayCollector
+	count, err := replayer.Run(ctx, &target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run() error = %v, want context.DeadlineExceeded", err)
+	}
+	if count != 1 {
+		t.Errorf("Run() = %d frames, want 1 before the hour-long gap", count)
+	}
+}
+
+func TestRecorderErr(t *testing.T) {
+	recorder := NewRecorder(failingWriter{})
+	recorder.RecordAt([]byte("a"), time.Now())
+	recorder.RecordAt([]byte("b"), time.Now())
+	if err := recorder.Err(); err == nil {
+		t.Error("Err() = nil, want the write error")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestStartReplay(t *testing.T) {
+	config := weex.NewDefaultConfig()
+	config.Logger = weex.NewNoOpLogger()
+	client := NewClient(config)
+
+	if err := client.StartReplay(); err != nil {
+		t.Fatalf("StartReplay() error = %v", err)
+	}
+	if !client.IsConnected() {
+		t.Error("IsConnected() = false in replay mode")
+	}
+	if err := client.StartReplay(); err == nil {
+		t.Error("StartReplay() on a replaying client error = nil")
+	}
+
+	// Subscribing works offline: the frame is discarded and the handler receives replayed data
+	var got []string
+	if err := client.Subscribe("ticker.a", func(message []byte) error {
+		got = append(got, string(message))
+		return nil
+	}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	client.ReplayMessage([]byte(`{"channel":"ticker.a","data":[{"last":"1"}]}`))
+	client.ReplayMessage([]byte(`{"channel":"ticker.b","data":[{"last":"2"}]}`))
+	if len(got) != 1 || !strings.Contains(got[0], `"last":"1"`) {
+		t.Errorf("handler received %v, want the ticker.a message", got)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if client.IsConnected() {
+		t.Error("IsConnected() = true after Close")
+	}
+	if err := client.StartReplay(); err != nil {
+		t.Errorf("StartReplay() after Close error = %v", err)
+	}
+	client.Close()
+}
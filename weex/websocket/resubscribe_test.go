@@ -0,0 +1,117 @@
+package websocket
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestResubscribeAfterReconnect(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxArgs      int
+		reject       string
+		wantSizes    []int
+		wantRejected []string
+	}{
+		{"chunked", 2, "", []int{2, 2, 1}, nil},
+		{"single frame", 20, "", []int{5}, nil},
+		{"rejected channel", 2, "ticker.c", []int{2, 2, 1}, []string{"ticker.c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var connected sync.Mutex
+			reconnected := false
+			server := newTestServer(t, func(req wstest.Frame) []string {
+				connected.Lock()
+				reject := reconnected
+				connected.Unlock()
+				out := make([]string, len(req.Args))
+				for i, channel := range req.Args {
+					out[i] = wstest.Ack(channel)
+					if reject && channel == tt.reject {
+						out[i] = wstest.Error(channel, "30001", "rejected")
+					}
+				}
+				return out
+			})
+
+			config := testConfig(server)
+			config.WSMaxArgsPerFrame = tt.maxArgs
+			config.WSResubscribeTimeout = time.Second
+			client := NewClient(config)
+			client.reconnectDelay = 10 * time.Millisecond
+			var errMu sync.Mutex
+			var rejected []string
+			client.SetOnError(func(err error) {
+				errMu.Lock()
+				defer errMu.Unlock()
+				for _, channel := range []string{"ticker.a", "ticker.b", "ticker.c", "ticker.d", "ticker.e"} {
+					if strings.Contains(err.Error(), channel) {
+						rejected = append(rejected, channel)
+					}
+				}
+			})
+			if err := client.Connect(t.Context()); err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+			t.Cleanup(func() { client.Close() })
+
+			channels := []string{"ticker.a", "ticker.b", "ticker.c", "ticker.d", "ticker.e"}
+			subs := make([]Subscription, len(channels))
+			for i, channel := range channels {
+				subs[i] = Subscription{Channel: channel, Handler: noopHandler}
+			}
+			if err := client.SubscribeManyAwait(context.Background(), subs); err != nil {
+				t.Fatalf("SubscribeManyAwait() error = %v", err)
+			}
+			initial := len(server.Frames())
+
+			connected.Lock()
+			reconnected = true
+			connected.Unlock()
+			server.DropConns()
+
+			deadline := time.Now().Add(5 * time.Second)
+			for len(server.Frames()) < initial+len(tt.wantSizes) && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			var sizes []int
+			var resent []string
+			for _, frame := range server.Frames()[initial:] {
+				sizes = append(sizes, len(frame.Args))
+				resent = append(resent, frame.Args...)
+			}
+			slices.Sort(resent)
+			if !reflect.DeepEqual(sizes, tt.wantSizes) {
+				t.Errorf("resubscribe frame sizes = %v, want %v", sizes, tt.wantSizes)
+			}
+			if !reflect.DeepEqual(resent, channels) {
+				t.Errorf("resubscribed channels = %v, want %v", resent, channels)
+			}
+
+			// onError runs asynchronously
+			for time.Now().Before(deadline) {
+				errMu.Lock()
+				n := len(rejected)
+				errMu.Unlock()
+				if n >= len(tt.wantRejected) {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			errMu.Lock()
+			defer errMu.Unlock()
+			if !reflect.DeepEqual(rejected, tt.wantRejected) {
+				t.Errorf("onError channels = %v, want %v", rejected, tt.wantRejected)
+			}
+		})
+	}
+}
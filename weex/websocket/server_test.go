@@ -0,0 +1,41 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+// newTestServer starts a server calling reply for every login, subscribe and unsubscribe frame
+// reply returns the raw messages to send back on the same connection.
+func newTestServer(t *testing.T, reply func(req wstest.Frame) []string) *wstest.Server {
+	t.Helper()
+	return wstest.NewServer(t, reply)
+}
+
+// testConfig returns a quiet config pointing the public URL at s
+func testConfig(s *wstest.Server) *weex.Config {
+	config := weex.NewDefaultConfig()
+	config.WSPublicURL = s.WSURL()
+	config.Logger = weex.NewNoOpLogger()
+	return config
+}
+
+// connectTestClient connects a public client to s
+func connectTestClient(t *testing.T, s *wstest.Server, configure func(*weex.Config)) *Client {
+	t.Helper()
+	config := testConfig(s)
+	if configure != nil {
+		configure(config)
+	}
+	client := NewClient(config)
+	if err := client.Connect(t.Context()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// noopHandler is a MessageHandler that accepts every message
+func noopHandler([]byte) error { return nil }
@@ -0,0 +1,99 @@
+package websocket
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats represents aggregate connection statistics for a WebSocket client
+type Stats struct {
+	Reconnects        int              // Number of successful reconnections after an unexpected disconnect
+	TotalDowntime     time.Duration    // Accumulated time spent disconnected before reconnecting
+	LongestGap        time.Duration    // Longest single disconnect-to-reconnect gap
+	DisconnectedSince time.Time        // Start of the current outage (zero if connected or closed by the user)
+	MessagesReceived  int64            // Total number of channel data messages received
+	MessagesByChannel map[string]int64 // Channel data messages received per channel
+}
+
+// statsTracker accumulates connection statistics across the connection lifecycle
+type statsTracker struct {
+	mu                sync.Mutex
+	reconnects        int
+	totalDowntime     time.Duration
+	longestGap        time.Duration
+	disconnectedAt    time.Time
+	messagesReceived  int64
+	messagesByChannel map[string]int64
+}
+
+// newStatsTracker creates a new stats tracker
+func newStatsTracker() *statsTracker {
+	return &statsTracker{
+		messagesByChannel: make(map[string]int64),
+	}
+}
+
+// recordConnected records a successful connection and closes any open outage
+func (st *statsTracker) recordConnected(now time.Time) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.disconnectedAt.IsZero() {
+		return
+	}
+
+	gap := now.Sub(st.disconnectedAt)
+	st.reconnects++
+	st.totalDowntime += gap
+	if gap > st.longestGap {
+		st.longestGap = gap
+	}
+	st.disconnectedAt = time.Time{}
+}
+
+// recordDisconnected records the start of an unexpected outage
+func (st *statsTracker) recordDisconnected(now time.Time) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.disconnectedAt.IsZero() {
+		st.disconnectedAt = now
+	}
+}
+
+// recordClosed records a user-initiated close, which does not count as downtime
+func (st *statsTracker) recordClosed() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.disconnectedAt = time.Time{}
+}
+
+// recordMessage records a data message received on a channel
+func (st *statsTracker) recordMessage(channel string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.messagesReceived++
+	st.messagesByChannel[channel]++
+}
+
+// snapshot returns a copy of the current statistics
+func (st *statsTracker) snapshot() Stats {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	byChannel := make(map[string]int64, len(st.messagesByChannel))
+	for channel, count := range st.messagesByChannel {
+		byChannel[channel] = count
+	}
+
+	return Stats{
+		Reconnects:        st.reconnects,
+		TotalDowntime:     st.totalDowntime,
+		LongestGap:        st.longestGap,
+		DisconnectedSince: st.disconnectedAt,
+		MessagesReceived:  st.messagesReceived,
+		MessagesByChannel: byChannel,
+	}
+}
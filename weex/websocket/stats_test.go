@@ -0,0 +1,112 @@
+package websocket
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStatsTracker(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	tests := []struct {
+		name   string
+		events func(st *statsTracker)
+		want   Stats
+	}{
+		{"first connect", func(st *statsTracker) {
+			st.recordConnected(at(0))
+		}, Stats{}},
+		{"one reconnect", func(st *statsTracker) {
+			st.recordConnected(at(0))
+			st.recordDisconnected(at(10))
+			st.recordConnected(at(13))
+		}, Stats{Reconnects: 1, TotalDowntime: 3 * time.Second, LongestGap: 3 * time.Second}},
+		{"several reconnects", func(st *statsTracker) {
+			st.recordConnected(at(0))
+			st.recordDisconnected(at(10))
+			st.recordConnected(at(12))
+			st.recordDisconnected(at(20))
+			st.recordConnected(at(25))
+			st.recordDisconnected(at(30))
+			st.recordConnected(at(31))
+		}, Stats{Reconnects: 3, TotalDowntime: 8 * time.Second, LongestGap: 5 * time.Second}},
+		{"repeated disconnect keeps outage start", func(st *statsTracker) {
+			st.recordDisconnected(at(10))
+			st.recordDisconnected(at(15))
+			st.recordConnected(at(20))
+		}, Stats{Reconnects: 1, TotalDowntime: 10 * time.Second, LongestGap: 10 * time.Second}},
+		{"ongoing outage", func(st *statsTracker) {
+			st.recordDisconnected(at(10))
+		}, Stats{DisconnectedSince: at(10)}},
+		{"close is not downtime", func(st *statsTracker) {
+			st.recordDisconnected(at(10))
+			st.recordClosed()
+			st.recordConnected(at(60))
+		}, Stats{}},
+		{"messages", func(st *statsTracker) {
+			st.recordMessage("ticker.cmt_btcusdt")
+			st.recordMessage("ticker.cmt_btcusdt")
+			st.recordMessage("depth.cmt_btcusdt.15")
+		}, Stats{MessagesReceived: 3, MessagesByChannel: map[string]int64{"ticker.cmt_btcusdt": 2, "depth.cmt_btcusdt.15": 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newStatsTracker()
+			tt.events(st)
+			got := st.snapshot()
+			if tt.want.MessagesByChannel == nil {
+				tt.want.MessagesByChannel = map[string]int64{}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("snapshot() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatsSnapshotIsCopy(t *testing.T) {
+	st := newStatsTracker()
+	st.recordMessage("ticker.cmt_btcusdt")
+	st.snapshot().MessagesByChannel["ticker.cmt_btcusdt"] = 100
+	if got := st.snapshot().MessagesByChannel["ticker.cmt_btcusdt"]; got != 1 {
+		t.Errorf("count = %d after modifying a snapshot, want 1", got)
+	}
+}
+
+func TestStatsAcrossReconnects(t *testing.T) {
+	const cycles = 3
+	server := newTestServer(t, nil)
+	config := testConfig(server)
+	client := NewClient(config)
+	client.reconnectDelay = 10 * time.Millisecond
+	if err := client.Connect(t.Context()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	client.handleMessage([]byte(`{"channel":"ticker.cmt_btcusdt","data":[]}`))
+	for i := 1; i <= cycles; i++ {
+		server.DropConns()
+		deadline := time.Now().Add(5 * time.Second)
+		for (server.Conns() <= i || client.Stats().Reconnects < i) && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		client.handleMessage([]byte(`{"channel":"ticker.cmt_btcusdt","data":[]}`))
+	}
+
+	stats := client.Stats()
+	if stats.Reconnects != cycles {
+		t.Fatalf("Reconnects = %d, want %d", stats.Reconnects, cycles)
+	}
+	if stats.TotalDowntime <= 0 || stats.LongestGap <= 0 || stats.LongestGap > stats.TotalDowntime {
+		t.Errorf("TotalDowntime = %v, LongestGap = %v, want 0 < LongestGap <= TotalDowntime", stats.TotalDowntime, stats.LongestGap)
+	}
+	if !stats.DisconnectedSince.IsZero() {
+		t.Errorf("DisconnectedSince = %v while connected, want zero", stats.DisconnectedSince)
+	}
+	if stats.MessagesReceived != cycles+1 || stats.MessagesByChannel["ticker.cmt_btcusdt"] != cycles+1 {
+		t.Errorf("messages = %d %v, want %d on ticker.cmt_btcusdt", stats.MessagesReceived, stats.MessagesByChannel, cycles+1)
+	}
+}
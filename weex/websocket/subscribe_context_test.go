@@ -0,0 +1,86 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+)
+
+func TestSubscribeContextCancelled(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func(c *Client, ctx context.Context) error
+		ctx     func() (context.Context, context.CancelFunc)
+		wantErr error
+	}{
+		{"subscribe cancelled", func(c *Client, ctx context.Context) error {
+			return c.SubscribeContext(ctx, "ticker.new", noopHandler)
+		}, func() (context.Context, context.CancelFunc) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(20*time.Millisecond, cancel)
+			return ctx, cancel
+		}, context.Canceled},
+		{"subscribe deadline", func(c *Client, ctx context.Context) error {
+			return c.SubscribeContext(ctx, "ticker.new", noopHandler)
+		}, func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 20*time.Millisecond)
+		}, context.DeadlineExceeded},
+		{"unsubscribe cancelled", func(c *Client, ctx context.Context) error {
+			return c.UnsubscribeContext(ctx, "ticker.old")
+		}, func() (context.Context, context.CancelFunc) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			return ctx, cancel
+		}, context.Canceled},
+		{"unsubscribe deadline", func(c *Client, ctx context.Context) error {
+			return c.UnsubscribeContext(ctx, "ticker.old")
+		}, func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 20*time.Millisecond)
+		}, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Without a connection nothing drains the queue; fill it so writes block
+			c := NewClient(&weex.Config{WSWriteQueueSize: 2, Logger: weex.NewNoOpLogger()})
+			c.writeWait = 10 * time.Second
+			c.state = StateConnected
+			c.subscriptions.Add("ticker.old", noopHandler)
+			for i := 0; i < 2; i++ {
+				if err := c.write([]byte("{}")); err != nil {
+					t.Fatalf("write %d error = %v", i, err)
+				}
+			}
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+			start := time.Now()
+			err := tt.op(c, ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("returned after %v, want soon after the context was done", elapsed)
+			}
+			if slices.Contains(c.GetSubscriptions(), "ticker.new") {
+				t.Error("cancelled subscribe left its handler registered")
+			}
+			if got := c.WriteQueueDepth(); got != 2 {
+				t.Errorf("WriteQueueDepth() = %d, want 2 (nothing queued)", got)
+			}
+		})
+	}
+}
+
+func TestSubscribeContextNotConnected(t *testing.T) {
+	c := NewClient(&weex.Config{Logger: weex.NewNoOpLogger()})
+	if err := c.SubscribeContext(context.Background(), "ticker.a", noopHandler); err == nil {
+		t.Error("SubscribeContext() on a disconnected client returned no error")
+	}
+	if err := c.UnsubscribeContext(context.Background(), "ticker.a"); err == nil {
+		t.Error("UnsubscribeContext() on a disconnected client returned no error")
+	}
+}
@@ -89,6 +89,7 @@ type TickerItem struct {
 // DepthData represents depth/orderbook channel data
 type DepthData struct {
 	Channel string      `json:"channel"`
+	Seq     int64       `json:"seq,omitempty"` // Feed sequence number (0 if not provided)
 	Data    []DepthItem `json:"data"`
 }
 
@@ -182,6 +183,7 @@ type PositionItem struct {
 // OrderData represents order update data
 type OrderData struct {
 	Channel string      `json:"channel"`
+	Seq     int64       `json:"seq,omitempty"` // Feed sequence number (0 if not provided)
 	Data    []OrderItem `json:"data"`
 }
 
@@ -196,7 +198,7 @@ type OrderItem struct {
 	Size         types.Decimal `json:"size"`
 	FilledSize   types.Decimal `json:"filledSize"`
 	AvgFillPrice types.Decimal `json:"avgFillPrice"`
-	State        int           `json:"state"`      // Order state
+	State        int           `json:"state"`      // Order state, same codes as types.OrderStatus (see StatusEnum)
 	OrderType    int           `json:"orderType"`  // Execution type (0=normal, 1=post-only, etc.)
 	MatchPrice   int           `json:"matchPrice"` // 0=limit, 1=market
 	MarginMode   int           `json:"marginMode"` // 1=shared, 3=isolated
@@ -208,9 +210,22 @@ type OrderItem struct {
 	UpdateTime   int64         `json:"updateTime"`
 }
 
+// StatusEnum returns the order state as a types.OrderStatus
+// The orders channel uses the same state codes as the REST order endpoints:
+// -1=not triggered, 0=pending, 1=partially filled, 2=filled, 3=canceling, 4=canceled.
+func (o *OrderItem) StatusEnum() types.OrderStatus {
+	return types.OrderStatus(o.State)
+}
+
+// ExecutionType returns the order's execution type (normal, post-only, FOK, IOC)
+func (o *OrderItem) ExecutionType() types.OrderExecutionType {
+	return types.OrderExecutionType(o.OrderType)
+}
+
 // FillData represents fill/execution data
 type FillData struct {
 	Channel string     `json:"channel"`
+	Seq     int64      `json:"seq,omitempty"` // Feed sequence number (0 if not provided)
 	Data    []FillItem `json:"data"`
 }
 
@@ -258,3 +273,29 @@ func (s ConnectionState) String() string {
 		return "Unknown"
 	}
 }
+
+// AuthStatus represents the outcome of the private channel login
+type AuthStatus int
+
+const (
+	AuthNone      AuthStatus = iota // No login attempted
+	AuthPending                     // Login sent, awaiting response
+	AuthSucceeded                   // Login accepted
+	AuthFailed                      // Login rejected
+)
+
+// String returns the string representation of the auth status
+func (s AuthStatus) String() string {
+	switch s {
+	case AuthNone:
+		return "None"
+	case AuthPending:
+		return "Pending"
+	case AuthSucceeded:
+		return "Succeeded"
+	case AuthFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,69 @@
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex/types"
+)
+
+func TestOrderItemExecutionType(t *testing.T) {
+	tests := []struct {
+		orderType string
+		want      types.OrderExecutionType
+	}{
+		{"0", types.OrderExecNormal},
+		{"1", types.OrderExecPostOnly},
+		{"2", types.OrderExecFillOrKill},
+		{"3", types.OrderExecImmediateOrCancel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.String(), func(t *testing.T) {
+			var item OrderItem
+			if err := json.Unmarshal([]byte(`{"orderId":"1","orderType":`+tt.orderType+`}`), &item); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got := item.ExecutionType(); got != tt.want {
+				t.Errorf("ExecutionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderItemStatusEnum(t *testing.T) {
+	tests := []struct {
+		state    string
+		rest     string // Status string of the same state on the REST order endpoints
+		want     types.OrderStatus
+		terminal bool
+	}{
+		{"-1", "untriggered", types.OrderStatusNotTriggered, false},
+		{"0", "open", types.OrderStatusPending, false},
+		{"1", "partial_filled", types.OrderStatusPartial, false},
+		{"2", "filled", types.OrderStatusFilled, true},
+		{"3", "canceling", types.OrderStatusCanceling, false},
+		{"4", "canceled", types.OrderStatusCanceled, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.String(), func(t *testing.T) {
+			var item OrderItem
+			if err := json.Unmarshal([]byte(`{"orderId":"1","state":`+tt.state+`}`), &item); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			got := item.StatusEnum()
+			if got != tt.want {
+				t.Errorf("StatusEnum() = %v, want %v", got, tt.want)
+			}
+			if got.IsTerminal() != tt.terminal {
+				t.Errorf("IsTerminal() = %v, want %v", got.IsTerminal(), tt.terminal)
+			}
+
+			// REST reports the same state by name or by the same number
+			for _, s := range []string{tt.rest, tt.state} {
+				if rest, err := types.ParseOrderStatus(s); err != nil || rest != got {
+					t.Errorf("ParseOrderStatus(%q) = %v, %v, want %v", s, rest, err, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+	"github.com/weex-api/openapi-contract-go-sdk/weex/websocket/internal/wstest"
+)
+
+func TestUnhandledMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    bool // Delivered to the unhandled callback
+	}{
+		{"unknown channel", `{"channel":"ticker.unknown","data":[]}`, true},
+		{"invalid JSON", `{"channel":`, true},
+		{"no event or channel", `{"foo":"bar"}`, true},
+		{"subscribed channel", `{"channel":"ticker.a","data":[]}`, false},
+		{"subscribe ack", wstest.Ack("ticker.a"), false},
+		{"pong", `{"event":"pong"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(&weex.Config{Logger: weex.NewNoOpLogger()})
+			c.subscriptions.Add("ticker.a", noopHandler)
+			got := make(chan []byte, 1)
+			c.SetOnUnhandled(func(message []byte) { got <- message })
+
+			c.handleMessage([]byte(tt.message))
+
+			select {
+			case message := <-got:
+				if !tt.want {
+					t.Errorf("unexpected unhandled message %s", message)
+				} else if string(message) != tt.message {
+					t.Errorf("unhandled message = %s, want %s", message, tt.message)
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tt.want {
+					t.Error("unhandled callback not called")
+				}
+			}
+		})
+	}
+}
+
+func TestUnhandledFromServer(t *testing.T) {
+	const unknown = `{"channel":"ticker.unmodelled","data":[{"last":"1"}]}`
+	server := newTestServer(t, func(req wstest.Frame) []string {
+		return []string{wstest.Ack(req.Args[0]), unknown}
+	})
+	client := connectTestClient(t, server, nil)
+	got := make(chan []byte, 1)
+	client.SetOnUnhandled(func(message []byte) { got <- message })
+
+	if err := client.Subscribe("ticker.a", noopHandler); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	select {
+	case message := <-got:
+		if string(message) != unknown {
+			t.Errorf("unhandled message = %s, want %s", message, unknown)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("unhandled callback not called")
+	}
+}
@@ -0,0 +1,82 @@
+package websocket
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+)
+
+// DefaultWriteQueueSize is the default capacity of the outbound write queue
+const DefaultWriteQueueSize = 256
+
+// writeQueueMonitor tracks outbound write queue depth and the high-watermark event
+type writeQueueMonitor struct {
+	peak  atomic.Int64 // Highest depth observed
+	above atomic.Bool  // Whether depth is currently at or above the watermark
+
+	mu        sync.RWMutex
+	watermark int                  // Depth that triggers the callback (0 = disabled)
+	onHigh    func(depth, cap int) // Called when depth reaches the watermark
+}
+
+// observe records depth and fires the watermark callback on an upward crossing
+// The event re-arms once depth drops below the watermark again
+func (m *writeQueueMonitor) observe(depth, capacity int) {
+	for {
+		peak := m.peak.Load()
+		if int64(depth) <= peak || m.peak.CompareAndSwap(peak, int64(depth)) {
+			break
+		}
+	}
+
+	m.mu.RLock()
+	watermark, callback := m.watermark, m.onHigh
+	m.mu.RUnlock()
+
+	if watermark <= 0 {
+		return
+	}
+	if depth < watermark {
+		m.above.Store(false)
+		return
+	}
+	if m.above.CompareAndSwap(false, true) && callback != nil {
+		go callback(depth, capacity)
+	}
+}
+
+// WriteQueueDepth returns the number of messages waiting to be written to the socket
+func (c *Client) WriteQueueDepth() int {
+	return len(c.writeChan)
+}
+
+// WriteQueueCapacity returns the capacity of the outbound write queue
+func (c *Client) WriteQueueCapacity() int {
+	return cap(c.writeChan)
+}
+
+// WriteQueuePeak returns the highest write queue depth observed since the client was created
+func (c *Client) WriteQueuePeak() int {
+	return int(c.writeQueue.peak.Load())
+}
+
+// SetOnWriteQueueHighWatermark sets a callback invoked when the write queue depth reaches watermark
+//
+// The callback fires once per crossing and re-arms when the depth falls back
+// below watermark. A watermark <= 0 disables the event.
+func (c *Client) SetOnWriteQueueHighWatermark(watermark int, callback func(depth, capacity int)) {
+	c.writeQueue.mu.Lock()
+	defer c.writeQueue.mu.Unlock()
+	c.writeQueue.watermark = watermark
+	c.writeQueue.onHigh = callback
+	c.writeQueue.above.Store(false)
+}
+
+// writeQueueSize returns the configured write queue capacity or the default
+func writeQueueSize(config *weex.Config) int {
+	if config.WSWriteQueueSize > 0 {
+		return config.WSWriteQueueSize
+	}
+	return DefaultWriteQueueSize
+}
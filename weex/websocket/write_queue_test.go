@@ -0,0 +1,108 @@
+package websocket
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weex-api/openapi-contract-go-sdk/weex"
+)
+
+func TestWriteQueueSize(t *testing.T) {
+	tests := []struct {
+		size int
+		want int
+	}{
+		{8, 8},
+		{0, DefaultWriteQueueSize},
+		{-1, DefaultWriteQueueSize},
+	}
+	for _, tt := range tests {
+		c := NewClient(&weex.Config{WSWriteQueueSize: tt.size})
+		if got := c.WriteQueueCapacity(); got != tt.want {
+			t.Errorf("WriteQueueCapacity() for %d = %d, want %d", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestWriteQueueMonitor(t *testing.T) {
+	tests := []struct {
+		name      string
+		watermark int
+		depths    []int
+		wantFires []int // Depths reported to the callback
+		wantPeak  int
+	}{
+		{"fires once per crossing", 3, []int{1, 2, 3, 4, 3, 4}, []int{3}, 4},
+		{"re-arms below watermark", 3, []int{3, 2, 4}, []int{3, 4}, 4},
+		{"never reached", 3, []int{1, 2, 1}, nil, 2},
+		{"disabled", 0, []int{1, 5}, nil, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(&weex.Config{})
+			var mu sync.Mutex
+			var fires []int
+			c.SetOnWriteQueueHighWatermark(tt.watermark, func(depth, capacity int) {
+				mu.Lock()
+				defer mu.Unlock()
+				fires = append(fires, depth)
+			})
+			for _, depth := range tt.depths {
+				c.writeQueue.observe(depth, 10)
+			}
+
+			// Callbacks run on their own goroutines
+			time.Sleep(20 * time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			slices.Sort(fires)
+			if !slices.Equal(fires, tt.wantFires) {
+				t.Errorf("watermark events = %v, want %v", fires, tt.wantFires)
+			}
+			if got := c.WriteQueuePeak(); got != tt.wantPeak {
+				t.Errorf("WriteQueuePeak() = %d, want %d", got, tt.wantPeak)
+			}
+		})
+	}
+}
+
+func TestWriteQueueFull(t *testing.T) {
+	// Without a connection nothing drains the queue
+	c := NewClient(&weex.Config{WSWriteQueueSize: 4, Logger: weex.NewNoOpLogger()})
+	c.writeWait = 20 * time.Millisecond
+	events := make(chan [2]int, 4)
+	c.SetOnWriteQueueHighWatermark(3, func(depth, capacity int) { events <- [2]int{depth, capacity} })
+
+	for i := 1; i <= 4; i++ {
+		if err := c.write([]byte("{}")); err != nil {
+			t.Fatalf("write %d error = %v", i, err)
+		}
+		if got := c.WriteQueueDepth(); got != i {
+			t.Errorf("WriteQueueDepth() after %d writes = %d", i, got)
+		}
+	}
+	select {
+	case got := <-events:
+		if got != [2]int{3, 4} {
+			t.Errorf("watermark event = %v, want depth 3 of 4", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("watermark event not fired")
+	}
+
+	err := c.write([]byte("{}"))
+	if err == nil || !strings.Contains(err.Error(), "write queue full (4/4)") {
+		t.Errorf("write to a full queue error = %v, want write queue full (4/4)", err)
+	}
+	if got := c.WriteQueuePeak(); got != 4 {
+		t.Errorf("WriteQueuePeak() = %d, want 4", got)
+	}
+	select {
+	case got := <-events:
+		t.Errorf("extra watermark event %v while above the watermark", got)
+	default:
+	}
+}